@@ -4,9 +4,14 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/DoPlan-dev/CLI/internal/commands"
+	"github.com/DoPlan-dev/CLI/internal/config"
 	"github.com/DoPlan-dev/CLI/internal/context"
 	"github.com/DoPlan-dev/CLI/internal/tui"
 	"github.com/DoPlan-dev/CLI/internal/wizard"
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +22,14 @@ var (
 )
 
 func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// newRootCommand builds the doplan command with its subcommands
+func newRootCommand() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:     "doplan",
 		Aliases: []string{".", "dash", "d"},
@@ -27,10 +40,15 @@ Combines Spec-Kit and BMAD-METHOD methodologies.`,
 		RunE:    executeRoot,
 	}
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
+	rootCmd.AddCommand(commands.NewCheckCommand())
+	rootCmd.AddCommand(commands.NewGitHubCommand())
+	rootCmd.AddCommand(commands.NewHooksCommand())
+	rootCmd.AddCommand(commands.NewMetricsCommand())
+	rootCmd.AddCommand(commands.NewReleaseCommand())
+	rootCmd.AddCommand(commands.NewStatsCommand())
+	rootCmd.AddCommand(commands.NewWebhookCommand())
+
+	return rootCmd
 }
 
 // executeRoot is the context-aware root command handler
@@ -45,6 +63,8 @@ func executeRoot(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detect project state: %w", err)
 	}
+	setupTheme(projectRoot)
+	setupKeymap(projectRoot)
 
 	switch state {
 	case context.StateEmptyFolder:
@@ -54,14 +74,35 @@ func executeRoot(cmd *cobra.Command, args []string) error {
 	case context.StateOldDoPlanStructure:
 		return launchMigrationWizard()
 	case context.StateNewDoPlanStructure:
-		return tui.Run() // Open dashboard
+		return tui.Run(commands.RefreshProgress) // Open dashboard
 	case context.StateInsideFeature:
 		return showFeatureView(detector)
 	case context.StateInsidePhase:
 		return showPhaseView(detector)
 	default:
 		// Fallback to dashboard
-		return tui.Run()
+		return tui.Run(commands.RefreshProgress)
+	}
+}
+
+// setupTheme applies the theme and color mode from the tui section of the
+// project's config, or the defaults when there is none. A bad setting is reported
+// and the default theme used instead.
+func setupTheme(projectRoot string) {
+	var tuiConfig models.TUIConfig
+	if cfg, err := config.NewManager(projectRoot).LoadConfig(); err == nil && cfg != nil {
+		tuiConfig = cfg.TUI
+	}
+	if err := theme.Setup(projectRoot, tuiConfig.Theme, tuiConfig.Colors); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// setupKeymap applies the user's and the project's keymap files. Conflicting or
+// invalid bindings are reported and a preset used instead.
+func setupKeymap(projectRoot string) {
+	if err := keymap.Setup(projectRoot); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 	}
 }
 
@@ -84,12 +125,12 @@ func launchMigrationWizard() error {
 func showFeatureView(detector *context.Detector) error {
 	// Feature view functionality - fallback to main dashboard for now
 	// TODO: Implement feature-specific view
-	return tui.Run()
+	return tui.Run(commands.RefreshProgress)
 }
 
 // showPhaseView shows the phase-specific view
 func showPhaseView(detector *context.Detector) error {
 	// Phase view functionality - fallback to main dashboard for now
 	// TODO: Implement phase-specific view
-	return tui.Run()
+	return tui.Run(commands.RefreshProgress)
 }
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMainPackage(t *testing.T) {
@@ -10,3 +14,21 @@ func TestMainPackage(t *testing.T) {
 	// This test ensures the package structure is valid
 	t.Log("Main package structure is valid")
 }
+
+func TestRootCommand_Subcommands(t *testing.T) {
+	for _, name := range []string{"github", "hooks", "release", "stats", "webhook"} {
+		var out bytes.Buffer
+		root := newRootCommand()
+		root.SetOut(&out)
+		root.SetErr(&out)
+		root.SetArgs([]string{name, "--help"})
+		require.NoError(t, root.Execute(), name)
+	}
+
+	var out bytes.Buffer
+	root := newRootCommand()
+	root.SetOut(&out)
+	root.SetArgs([]string{"stats", "--help"})
+	require.NoError(t, root.Execute())
+	assert.Contains(t, out.String(), "burndown")
+}
@@ -0,0 +1,127 @@
+package board
+
+import (
+	"sort"
+
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// Board columns, in the order features move through them
+const (
+	ColumnTodo       = "todo"
+	ColumnInProgress = "in-progress"
+	ColumnReview     = "review"
+	ColumnComplete   = "complete"
+	ColumnBlocked    = "blocked"
+)
+
+// Columns lists the board columns from left to right
+var Columns = []string{ColumnTodo, ColumnInProgress, ColumnReview, ColumnComplete, ColumnBlocked}
+
+// Card is a feature as shown on the board
+type Card struct {
+	Feature   models.Feature
+	CI        models.CIStatus
+	Assignees []string // Authors of commits on the feature branch, from the last GitHub sync
+}
+
+// Column holds the cards of one status
+type Column struct {
+	Name  string
+	Cards []Card
+}
+
+// Filter narrows the board to one phase and one assignee; empty fields match all
+type Filter struct {
+	Phase    string
+	Assignee string
+}
+
+// ColumnOf returns the column a feature belongs in. Flagged features are blocked
+// whatever their status, and a merged PR completes a feature.
+func ColumnOf(feature *models.Feature) string {
+	switch {
+	case len(feature.Flags) > 0 || feature.Status == ColumnBlocked:
+		return ColumnBlocked
+	case feature.Status == ColumnComplete || github.IsFeatureMerged(feature):
+		return ColumnComplete
+	case feature.Status == ColumnReview:
+		return ColumnReview
+	case feature.Status == ColumnInProgress:
+		return ColumnInProgress
+	}
+	return ColumnTodo
+}
+
+// Build sorts the features matching filter into columns, keeping state order
+// within a column. data may be nil before the first GitHub sync.
+func Build(state *models.State, data *models.GitHubData, filter Filter) []Column {
+	columns := make([]Column, len(Columns))
+	index := make(map[string]int, len(Columns))
+	for i, name := range Columns {
+		columns[i].Name = name
+		index[name] = i
+	}
+	if state == nil {
+		return columns
+	}
+
+	for _, feature := range state.Features {
+		if filter.Phase != "" && feature.Phase != filter.Phase {
+			continue
+		}
+		card := Card{
+			Feature:   feature,
+			CI:        github.BranchCIStatus(data, feature.Branch),
+			Assignees: branchAuthors(data, feature.Branch),
+		}
+		if filter.Assignee != "" && !contains(card.Assignees, filter.Assignee) {
+			continue
+		}
+		i := index[ColumnOf(&feature)]
+		columns[i].Cards = append(columns[i].Cards, card)
+	}
+	return columns
+}
+
+// Assignees lists everyone who committed to a feature branch, for the assignee filter
+func Assignees(state *models.State, data *models.GitHubData) []string {
+	if state == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var assignees []string
+	for _, feature := range state.Features {
+		for _, author := range branchAuthors(data, feature.Branch) {
+			if !seen[author] {
+				seen[author] = true
+				assignees = append(assignees, author)
+			}
+		}
+	}
+	sort.Strings(assignees)
+	return assignees
+}
+
+func branchAuthors(data *models.GitHubData, branch string) []string {
+	if data == nil || branch == "" {
+		return nil
+	}
+	var authors []string
+	for _, commit := range data.Commits {
+		if commit.Branch == branch && commit.Author != "" && !contains(authors, commit.Author) {
+			authors = append(authors, commit.Author)
+		}
+	}
+	return authors
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
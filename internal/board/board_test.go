@@ -0,0 +1,144 @@
+package board
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func columnNames(columns []Column) map[string][]string {
+	names := make(map[string][]string)
+	for _, column := range columns {
+		for _, card := range column.Cards {
+			names[column.Name] = append(names[column.Name], card.Feature.ID)
+		}
+	}
+	return names
+}
+
+func TestBuild(t *testing.T) {
+	state := &models.State{Features: []models.Feature{
+		{ID: "01", Phase: "01-phase", Status: "todo"},
+		{ID: "02", Phase: "01-phase", Status: "in-progress", Branch: "feature/02"},
+		{ID: "03", Phase: "01-phase", Status: "review", Branch: "feature/03"},
+		{ID: "04", Phase: "02-phase", Status: "in-progress", Flags: []models.FeatureFlag{{Source: "check:build"}}},
+		{ID: "05", Phase: "02-phase", Status: "in-progress", Branch: "feature/05", PR: &models.PullRequest{Status: "merged", Branch: "feature/05"}},
+		{ID: "06", Phase: "02-phase", Status: ""},
+	}}
+	data := &models.GitHubData{
+		Commits: []models.Commit{
+			{Author: "Ada", Branch: "feature/02"},
+			{Author: "Grace", Branch: "feature/03"},
+			{Author: "Ada", Branch: "feature/03"},
+		},
+		Checks: []models.CheckRun{{Name: "build", Status: "completed", Conclusion: "failure", Branch: "feature/02", HeadSHA: "abc"}},
+	}
+
+	columns := Build(state, data, Filter{})
+	require.Len(t, columns, len(Columns))
+	assert.Equal(t, map[string][]string{
+		ColumnTodo:       {"01", "06"},
+		ColumnInProgress: {"02"},
+		ColumnReview:     {"03"},
+		ColumnComplete:   {"05"},
+		ColumnBlocked:    {"04"},
+	}, columnNames(columns))
+
+	card := columns[1].Cards[0]
+	assert.Equal(t, "failure", card.CI.State)
+	assert.Equal(t, []string{"Ada"}, card.Assignees)
+
+	assert.Equal(t, map[string][]string{ColumnTodo: {"06"}, ColumnComplete: {"05"}, ColumnBlocked: {"04"}},
+		columnNames(Build(state, data, Filter{Phase: "02-phase"})))
+	assert.Equal(t, map[string][]string{ColumnReview: {"03"}},
+		columnNames(Build(state, data, Filter{Assignee: "Grace"})))
+	assert.Equal(t, []string{"Ada", "Grace"}, Assignees(state, data))
+
+	assert.Len(t, Build(nil, nil, Filter{}), len(Columns), "an empty board still has its columns")
+}
+
+func setupBoardProject(t *testing.T) string {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	cfg := config.NewConfig("cursor")
+	cfg.GitHub.BaseBranch = "master"
+	projectRoot := helpers.SetupInstalledProject(t, cfg, &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation", Features: []string{"01"}}},
+		Features: []models.Feature{{ID: "01", Phase: "01-phase", Name: "Login", Status: "todo", TaskPhases: []models.TaskPhase{
+			{Name: "Setup", Tasks: []models.Task{{Name: "Form"}, {Name: "Session"}}},
+		}}},
+	})
+
+	// Named after the feature so 'doplan progress' matches its tasks.md
+	require.NoError(t, os.MkdirAll(filepath.Join(projectRoot, "doplan", "01-phase", "01-login"), 0755))
+
+	repo, err := git.PlainInit(projectRoot, false)
+	require.NoError(t, err)
+	helpers.CommitFiles(t, repo, "Test", time.Now(), map[string]string{"README.md": "project\n"})
+	return projectRoot
+}
+
+func loadFeature(t *testing.T, projectRoot string) models.Feature {
+	t.Helper()
+	state, err := config.NewManager(projectRoot).LoadState()
+	require.NoError(t, err)
+	return state.Features[0]
+}
+
+func TestMove(t *testing.T) {
+	projectRoot := setupBoardProject(t)
+
+	warnings, err := Move(projectRoot, "01", ColumnInProgress, nil)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	feature := loadFeature(t, projectRoot)
+	assert.Equal(t, "in-progress", feature.Status)
+	assert.Equal(t, "feature/01-phase-01-login", feature.Branch, "starting a feature creates its branch")
+	assert.Equal(t, "master", feature.BaseBranch)
+
+	warnings, err = Move(projectRoot, "01", ColumnBlocked, nil)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	feature = loadFeature(t, projectRoot)
+	assert.Equal(t, ColumnBlocked, ColumnOf(&feature))
+	assert.Equal(t, "in-progress", feature.Status, "blocking keeps the status")
+
+	// GitHub integration is off, so the PR is only a warning
+	warnings, err = Move(projectRoot, "01", ColumnReview, nil)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "Could not request a review")
+	feature = loadFeature(t, projectRoot)
+	assert.Equal(t, "review", feature.Status)
+	assert.Empty(t, feature.Flags, "moving out of blocked clears the board's flag")
+
+	warnings, err = Move(projectRoot, "01", ColumnComplete, nil)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	feature = loadFeature(t, projectRoot)
+	assert.Equal(t, "complete", feature.Status)
+	assert.Equal(t, 100, feature.Progress)
+	content, err := os.ReadFile(filepath.Join(projectRoot, "doplan", "01-phase", "01-login", "tasks.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "- [x] Form\n- [x] Session\n", "completing ticks off the remaining tasks")
+
+	warnings, err = Move(projectRoot, "01", ColumnTodo, nil)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "stays in complete because its status follows the tasks")
+
+	_, err = Move(projectRoot, "01", "done", nil)
+	assert.Error(t, err)
+}
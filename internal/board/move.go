@@ -0,0 +1,107 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/tasks"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// FlagSource marks the flag that moving a card to blocked raises
+const FlagSource = "board"
+
+// Move moves a feature to a column and runs what the move implies, saving through
+// tasks.Editor as the task editor and 'doplan progress' do:
+//
+//   - in-progress, review and complete create the feature branch if it has none
+//   - review opens a pull request, or marks a draft PR ready for review, once the
+//     required checks pass
+//   - complete ticks off the remaining tasks, which creates the feature checkpoint
+//     and the auto-PR when they are enabled
+//   - blocked flags the feature; moving it out clears that flag
+//
+// Side effects that fail, such as a PR without the GitHub CLI, do not undo the
+// move and are returned as warnings. The checkpoint and auto-PR are left to refresh.
+func Move(projectRoot, featureID, column string, refresh tasks.RefreshFunc) ([]string, error) {
+	if !contains(Columns, column) {
+		return nil, fmt.Errorf("unknown column %q", column)
+	}
+	editor, err := tasks.Open(projectRoot, featureID, refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	now := time.Now()
+	prepare := func(state *models.State, feature *models.Feature) error {
+		if column == ColumnBlocked {
+			feature.SetFlag(models.FeatureFlag{Source: FlagSource, Reason: "Moved to blocked on the board", Since: now.Format(time.RFC3339)})
+			return nil
+		}
+		feature.ClearFlag(FlagSource, now)
+		if column != ColumnTodo && feature.Branch == "" {
+			if err := startBranch(projectRoot, state, feature); err != nil {
+				warnings = append(warnings, fmt.Sprintf("Could not create a branch for '%s': %v", feature.Name, err))
+			}
+		}
+		return nil
+	}
+
+	// Blocking keeps the status, so unblocking returns the card where it was
+	status := column
+	if column == ColumnBlocked {
+		status = ""
+	}
+	if err := editor.Transition(status, column == ColumnComplete, prepare); err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, editor.Warnings()...)
+
+	feature := editor.Feature()
+	if column == ColumnReview {
+		if err := github.NewAutoPRManager(projectRoot).RequestReview(feature); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Could not request a review for '%s': %v", feature.Name, err))
+		}
+	}
+
+	if actual := ColumnOf(feature); actual != column {
+		reason := "its status follows the tasks in tasks.md"
+		if len(feature.Flags) > 0 {
+			var reasons []string
+			for _, flag := range feature.Flags {
+				reasons = append(reasons, flag.Reason)
+			}
+			reason = "it is still flagged: " + strings.Join(reasons, "; ")
+		}
+		warnings = append(warnings, fmt.Sprintf("'%s' stays in %s because %s", feature.Name, actual, reason))
+	}
+	return warnings, nil
+}
+
+// startBranch creates the feature branch from the configured base branch, stacked
+// on the branch of the feature it depends on, as 'doplan github stack start' does
+func startBranch(projectRoot string, state *models.State, feature *models.Feature) error {
+	base := ""
+	if cfg, err := config.NewManager(projectRoot).LoadConfig(); err == nil && cfg != nil {
+		base = cfg.GitHub.BaseBranch
+	}
+	if base == "" {
+		analyzer, err := github.NewBranchAnalyzer(projectRoot)
+		if err != nil {
+			return err
+		}
+		if base, err = analyzer.DefaultBaseBranch(); err != nil {
+			return err
+		}
+	}
+
+	stackMgr, err := github.NewStackManager(projectRoot)
+	if err != nil {
+		return err
+	}
+	return stackMgr.StartFeature(state, feature, base)
+}
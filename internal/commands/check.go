@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/checkpoint"
+	"github.com/DoPlan-dev/CLI/internal/config"
+	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/DoPlan-dev/CLI/internal/validator"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func NewCheckCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Evaluate quality gates",
+		Long: `Evaluate the quality gates in the 'gates' section of the config against the current
+statistics and state, and exit non-zero when a gate with severity 'error' fails.
+
+Rules:
+  min-coverage             Overall coverage is at least value percent
+  no-overdue-features      No unfinished feature is more than value days past its target date
+  max-open-prs-per-phase   No phase has more than value open pull requests
+  phase-checkpoints        Every completed phase has a phase checkpoint
+  min-forecast-confidence  Every target date has at least a value percent chance of being met`,
+		RunE: runCheck,
+	}
+
+	cmd.Flags().String("format", validator.GateFormatText, "Output format: text, junit, sarif, markdown")
+	cmd.Flags().String("export", "", "Write the report to a file instead of stdout (text also writes markdown)")
+
+	return cmd
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	errLogger := doplanerror.NewLogger(projectRoot, doplanerror.LogLevelInfo)
+	errHandler := doplanerror.NewHandler(errLogger)
+
+	if !config.IsInstalled(projectRoot) {
+		configPath := filepath.Join(projectRoot, ".cursor", "config", "doplan-config.json")
+		return errHandler.Handle(doplanerror.ErrConfigNotFound(configPath))
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	exportPath, _ := cmd.Flags().GetString("export")
+	switch format {
+	case validator.GateFormatText, validator.GateFormatJUnit, validator.GateFormatSARIF, validator.GateFormatMarkdown:
+	default:
+		return errHandler.Handle(doplanerror.NewValidationError("VAL013", "Invalid check format").
+			WithDetails(fmt.Sprintf("unknown format %q", format)).
+			WithSuggestion("Use one of: text, junit, sarif, markdown"))
+	}
+
+	cfgMgr := config.NewManager(projectRoot)
+	cfg, err := cfgMgr.LoadConfig()
+	if err != nil {
+		return errHandler.Handle(err)
+	}
+	if issues := validator.ValidateGateRules(cfg.Gates); len(issues) > 0 {
+		return errHandler.Handle(doplanerror.NewValidationError("VAL014", "Invalid quality gates").
+			WithDetails(strings.Join(issues, "\n")))
+	}
+
+	input, err := loadGateInput(projectRoot, cfg)
+	if err != nil {
+		return errHandler.Handle(err)
+	}
+	report := validator.EvaluateGates(cfg.Gates, input)
+
+	var buf bytes.Buffer
+	switch format {
+	case validator.GateFormatJUnit:
+		err = validator.WriteGateJUnit(&buf, report)
+	case validator.GateFormatSARIF:
+		err = validator.WriteGateSARIF(&buf, report, cfg.Version)
+	case validator.GateFormatMarkdown:
+		err = validator.WriteGateMarkdown(&buf, report)
+	default:
+		printGateReport(cmd, report)
+		if exportPath != "" {
+			err = validator.WriteGateMarkdown(&buf, report)
+		}
+	}
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewIOError("IO007", "Failed to render quality gate report").WithCause(err))
+	}
+
+	if exportPath != "" {
+		if err := os.WriteFile(exportPath, buf.Bytes(), 0644); err != nil {
+			return errHandler.Handle(doplanerror.NewIOError("IO006", "Failed to write quality gate report").WithCause(err))
+		}
+	} else if buf.Len() > 0 {
+		cmd.OutOrStdout().Write(buf.Bytes())
+	}
+
+	if !report.Passed() {
+		return fmt.Errorf("quality gates failed: %d failing", report.Failures())
+	}
+	return nil
+}
+
+// loadGateInput collects statistics the same way as 'doplan stats', without saving a
+// history snapshot. Forecasts are only simulated when a gate needs them.
+func loadGateInput(projectRoot string, cfg *models.Config) (validator.GateInput, error) {
+	now := time.Now()
+	metrics, _, err := newMetricsLoader(projectRoot)()
+	if err != nil {
+		return validator.GateInput{}, err
+	}
+
+	state, err := config.NewManager(projectRoot).LoadState()
+	if err != nil {
+		return validator.GateInput{}, err
+	}
+
+	githubData, err := github.NewGitHubSync(projectRoot).LoadData()
+	if err != nil {
+		githubData = &github.GitHubData{}
+	}
+
+	for _, gate := range cfg.Gates {
+		if gate.Rule == validator.GateMinForecastConfidence {
+			metrics.Forecast = statistics.LoadForecasts(projectRoot, state, cfg.Stats.Forecast, now)
+			break
+		}
+	}
+
+	checkpoints, err := checkpoint.NewCheckpointManager(projectRoot).ListCheckpoints()
+	if err != nil {
+		return validator.GateInput{}, doplanerror.NewIOError("IO005", "Failed to list checkpoints").WithCause(err)
+	}
+
+	return validator.GateInput{
+		State:       state,
+		Metrics:     metrics,
+		GitHub:      githubData,
+		Checkpoints: checkpoints,
+		Now:         now,
+	}, nil
+}
+
+func printGateReport(cmd *cobra.Command, report *validator.GateReport) {
+	out := cmd.OutOrStdout()
+	if len(report.Results) == 0 {
+		color.New(color.FgYellow).Fprintln(out, "⚠️  No quality gates configured. Add rules to the 'gates' section of the config.")
+		return
+	}
+
+	color.New(color.FgBlue).Fprint(out, "🚦 Evaluating quality gates...\n\n")
+	for _, result := range report.Results {
+		switch {
+		case result.Passed:
+			color.New(color.FgGreen).Fprintf(out, "  ✅ %s", result.Rule)
+		case result.Failed():
+			color.New(color.FgRed).Fprintf(out, "  ❌ %s", result.Rule)
+		default:
+			color.New(color.FgYellow).Fprintf(out, "  ⚠️  %s", result.Rule)
+		}
+		fmt.Fprintf(out, " [%s] %s\n", result.Subject, result.Message)
+	}
+
+	if report.Passed() {
+		color.New(color.FgGreen).Fprintf(out, "\n✅ All quality gates passed (%d warnings)\n", report.Warnings())
+	} else {
+		color.New(color.FgRed).Fprintf(out, "\n❌ %d quality gate(s) failed, %d warnings\n", report.Failures(), report.Warnings())
+	}
+}
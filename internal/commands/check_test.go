@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCheckProject installs a project with gates, a complete phase without a
+// checkpoint and an overdue feature
+func setupCheckProject(t *testing.T, gates []models.GateRule) string {
+	t.Helper()
+	cfg := config.NewConfig("cursor")
+	cfg.Gates = gates
+	return helpers.SetupInstalledProject(t, cfg, &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation", Status: "complete"}},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Login", Status: "in-progress", TargetDate: "2020-01-01"},
+		},
+	})
+}
+
+func TestNewCheckCommand(t *testing.T) {
+	cmd := NewCheckCommand()
+	assert.Equal(t, "check", cmd.Use)
+	assert.Equal(t, "text", cmd.Flags().Lookup("format").DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("export"))
+}
+
+func TestRunCheck(t *testing.T) {
+	projectRoot := setupCheckProject(t, []models.GateRule{
+		{Rule: "no-overdue-features"},
+		{Rule: "phase-checkpoints", Severity: "warning"},
+	})
+
+	exportPath := filepath.Join(projectRoot, "gates.xml")
+	cmd := NewCheckCommand()
+	require.NoError(t, cmd.Flags().Set("format", "junit"))
+	require.NoError(t, cmd.Flags().Set("export", exportPath))
+	err := runCheck(cmd, nil)
+	require.Error(t, err, "a failing error gate exits non-zero")
+	assert.Contains(t, err.Error(), "quality gates failed")
+
+	content, err := os.ReadFile(exportPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `<testsuites name="doplan-gates" tests="2" failures="1">`)
+	assert.Contains(t, string(content), `name="01 Login"`)
+
+	// Warnings alone pass
+	setupCheckProject(t, []models.GateRule{{Rule: "phase-checkpoints", Severity: "warning"}})
+
+	var out bytes.Buffer
+	cmd = NewCheckCommand()
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.Flags().Set("format", "markdown"))
+	require.NoError(t, runCheck(cmd, nil))
+	assert.Contains(t, out.String(), "✅ **Passed** (1 checks, 1 warnings)")
+	assert.Contains(t, out.String(), "| ⚠️ | phase-checkpoints | 01-phase Foundation |")
+}
+
+func TestRunCheck_InvalidGates(t *testing.T) {
+	setupCheckProject(t, []models.GateRule{{Rule: "max-bugs"}})
+
+	assert.Error(t, runCheck(NewCheckCommand(), nil))
+
+	cmd := NewCheckCommand()
+	require.NoError(t, cmd.Flags().Set("format", "xml"))
+	assert.Error(t, runCheck(cmd, nil))
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/DoPlan-dev/CLI/internal/config"
 	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/validator"
 	"github.com/DoPlan-dev/CLI/pkg/models"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -292,5 +293,7 @@ func validateConfig(cfg *models.Config) []string {
 		issues = append(issues, "AutoPR requires GitHub to be enabled")
 	}
 
+	issues = append(issues, validator.ValidateGateRules(cfg.Gates)...)
+
 	return issues
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/DoPlan-dev/CLI/internal/config"
 	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
 	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +21,11 @@ func NewGitHubCommand() *cobra.Command {
 		RunE:  runGitHub,
 	}
 
+	cmd.Flags().Bool("refresh", false, "Discard the on-disk cache under .doplan/cache/github and fetch everything")
+
+	cmd.AddCommand(NewGitHubBranchesCommand())
+	cmd.AddCommand(NewGitHubStackCommand())
+
 	return cmd
 }
 
@@ -42,6 +48,13 @@ func runGitHub(cmd *cobra.Command, args []string) error {
 
 	color.Blue("Syncing GitHub data...\n")
 
+	if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+		cache := github.NewDiskCache(projectRoot)
+		if err := cache.Clear(); err != nil {
+			errHandler.PrintError(doplanerror.NewIOError("IO006", "Failed to clear GitHub cache").WithPath(cache.Dir()).WithCause(err))
+		}
+	}
+
 	// Sync GitHub data
 	githubSync := github.NewGitHubSync(projectRoot)
 	data, err := githubSync.Sync()
@@ -49,7 +62,15 @@ func runGitHub(cmd *cobra.Command, args []string) error {
 		return errHandler.Handle(doplanerror.NewGitHubError("GH002", "Failed to sync GitHub data").WithCause(err))
 	}
 
-	color.Green("✅ GitHub data synced successfully!\n\n")
+	if stale := staleSources(data); len(stale) > 0 {
+		color.Yellow("⚠️  GitHub data synced with cached fallbacks:\n")
+		for _, line := range stale {
+			color.Yellow("   %s\n", line)
+		}
+		fmt.Println()
+	} else {
+		color.Green("✅ GitHub data synced successfully!\n\n")
+	}
 
 	// Display summary
 	fmt.Printf("Branches: %d\n", len(data.Branches))
@@ -60,13 +81,50 @@ func runGitHub(cmd *cobra.Command, args []string) error {
 	cfgMgr := config.NewManager(projectRoot)
 	state, err := cfgMgr.LoadState()
 	if err == nil {
+		// Record merges so stacked children know their parent is gone
+		if github.UpdateFeaturePRs(state, data.PRs) > 0 {
+			if err := cfgMgr.SaveState(state); err != nil {
+				errHandler.PrintError(doplanerror.NewStateError("STA002", "Failed to save state").WithCause(err))
+			}
+		}
+
 		autoPRMgr := github.NewAutoPRManager(projectRoot)
 		if err := autoPRMgr.WatchFeatures(state); err != nil {
 			errHandler.PrintError(doplanerror.NewGitHubError("GH003", "Failed to check for auto-PR creation").WithCause(err))
 		}
 	}
 
+	if state != nil {
+		base := ""
+		if cfg, err := cfgMgr.LoadConfig(); err == nil && cfg != nil {
+			base = cfg.GitHub.BaseBranch
+		}
+		if base == "" {
+			base = "main"
+		}
+		if pending := github.PendingRestacks(state, base); len(pending) > 0 {
+			color.Yellow("\n⚠️  %d stacked branch(es) need restacking. Run 'doplan github stack sync'.\n", len(pending))
+		}
+	}
+
 	color.Cyan("\nRun 'doplan dashboard' to see the updated dashboard.")
 
 	return nil
 }
+
+// staleSources describes each source that was served from cache because its fetch failed
+func staleSources(data *models.GitHubData) []string {
+	var lines []string
+	for _, source := range github.SyncSources {
+		status, ok := data.Sources[source]
+		if !ok || !status.Stale {
+			continue
+		}
+		since := "never synced"
+		if status.LastSynced != "" {
+			since = "last synced " + status.LastSynced
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s (%s)", source, status.Error, since))
+	}
+	return lines
+}
@@ -0,0 +1,259 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func NewGitHubBranchesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "branches",
+		Short: "Show branch hygiene (merged, stale, orphan and divergent branches)",
+		Long:  "Compare every branch with the base branch and list the ones that need attention",
+		RunE:  runGitHubBranches,
+	}
+
+	cmd.PersistentFlags().String("base", "", "Base branch to compare against (default: github.baseBranch, main or master)")
+	cmd.PersistentFlags().Int("stale-days", 0, "Days without commits before a branch is stale (default: github.staleBranchDays or 30)")
+	cmd.Flags().Bool("all", false, "List all branches, not only those needing attention")
+
+	cmd.AddCommand(NewGitHubBranchesPruneCommand())
+	cmd.AddCommand(NewGitHubBranchesRebaseReportCommand())
+
+	return cmd
+}
+
+func NewGitHubBranchesPruneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete merged branches",
+		Long: `Delete branches already merged into the base branch, optionally including stale and orphan branches.
+A branch is merged when its pull request was merged or, for feature branches, when the base branch
+has its commits or its squashed changes. Stale or orphan branches that are not merged are skipped
+unless --force is given. The base branch and github.protectedBranches are never deleted.`,
+		RunE: runGitHubBranchesPrune,
+	}
+
+	cmd.Flags().Bool("stale", false, "Also delete stale branches")
+	cmd.Flags().Bool("orphan", false, "Also delete feature branches with no matching feature")
+	cmd.Flags().Bool("force", false, "Delete stale or orphan branches even when they are not merged")
+	cmd.Flags().Bool("remote", false, "Also delete the branches on origin")
+	cmd.Flags().Bool("dry-run", false, "Show what would be deleted without deleting")
+
+	return cmd
+}
+
+func NewGitHubBranchesRebaseReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebase-report",
+		Short: "Report branches that are behind the base branch",
+		Long:  "List branches behind the base branch with ahead/behind counts and predicted rebase conflicts",
+		RunE:  runGitHubBranchesRebaseReport,
+	}
+
+	cmd.Flags().StringP("format", "f", "table", "Output format: table, json")
+
+	return cmd
+}
+
+func runGitHubBranches(cmd *cobra.Command, args []string) error {
+	_, branches, _, err := analyzeBranches(cmd)
+	if err != nil {
+		return err
+	}
+
+	showAll, _ := cmd.Flags().GetBool("all")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "BRANCH\tSTATUS\tAHEAD\tBEHIND\tFEATURE\tPR\tLAST COMMIT")
+	shown := 0
+	for _, branch := range branches {
+		if !showAll && !github.NeedsAttention(branch) {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+			branch.Name, branch.Status, branch.AheadCount, branch.BehindCount,
+			valueOrDash(branch.Feature), prMarker(branch), lastCommitAge(branch))
+		shown++
+	}
+	w.Flush()
+
+	if shown == 0 {
+		color.Green("✅ All branches are in good shape.")
+	}
+
+	return nil
+}
+
+func runGitHubBranchesPrune(cmd *cobra.Command, args []string) error {
+	analyzer, branches, _, err := analyzeBranches(cmd)
+	if err != nil {
+		return err
+	}
+
+	opts := github.PruneOptions{}
+	opts.IncludeStale, _ = cmd.Flags().GetBool("stale")
+	opts.IncludeOrphan, _ = cmd.Flags().GetBool("orphan")
+	opts.Remote, _ = cmd.Flags().GetBool("remote")
+	opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+	opts.Force, _ = cmd.Flags().GetBool("force")
+
+	result, err := analyzer.PruneBranches(branches, opts)
+	for _, name := range result.Pruned {
+		if opts.DryRun {
+			fmt.Printf("Would delete %s\n", name)
+		} else {
+			fmt.Printf("Deleted %s\n", name)
+		}
+	}
+	for _, name := range result.Skipped {
+		color.Yellow("Skipped %s: not merged (use --force to delete it)\n", name)
+	}
+	if err != nil {
+		return doplanerror.NewGitHubError("GH005", "Failed to prune some branches").WithCause(err)
+	}
+
+	if len(result.Pruned) == 0 {
+		color.Green("✅ Nothing to prune.")
+	} else if !opts.DryRun {
+		color.Green("✅ Pruned %d branch(es)\n", len(result.Pruned))
+	}
+
+	return nil
+}
+
+func runGitHubBranchesRebaseReport(cmd *cobra.Command, args []string) error {
+	analyzer, branches, base, err := analyzeBranches(cmd)
+	if err != nil {
+		return err
+	}
+
+	report := analyzer.RebaseReport(branches, base)
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(report) == 0 {
+		color.Green("✅ All branches are up to date with %s.\n", base)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "BRANCH\tAHEAD\tBEHIND\tCONFLICTS\tCOMMAND")
+	for _, entry := range report {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", entry.Branch, entry.Ahead, entry.Behind, entry.Conflicts, entry.Command)
+	}
+	w.Flush()
+
+	return nil
+}
+
+// analyzeBranches runs the branch analyzer with options from flags, config and state
+func analyzeBranches(cmd *cobra.Command) (*github.BranchAnalyzer, []models.Branch, string, error) {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return nil, nil, "", doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	analyzer, err := github.NewBranchAnalyzer(projectRoot)
+	if err != nil {
+		return nil, nil, "", doplanerror.NewGitHubError("GH004", "Not a Git repository").WithPath(projectRoot).WithCause(err)
+	}
+
+	opts := github.BranchAnalysisOptions{}
+	cfgMgr := config.NewManager(projectRoot)
+	if config.IsInstalled(projectRoot) {
+		if cfg, err := cfgMgr.LoadConfig(); err == nil && cfg != nil {
+			opts.BaseBranch = cfg.GitHub.BaseBranch
+			opts.Protected = cfg.GitHub.ProtectedBranches
+			if cfg.GitHub.StaleBranchDays > 0 {
+				opts.StaleAfter = time.Duration(cfg.GitHub.StaleBranchDays) * 24 * time.Hour
+			}
+		}
+		if state, err := cfgMgr.LoadState(); err == nil && len(state.Features) > 0 {
+			opts.State = state
+		}
+	}
+
+	// Pull requests from the last sync tell merged branches apart, squashed or not
+	data, dataErr := github.NewGitHubSync(projectRoot).LoadData()
+	if dataErr == nil {
+		opts.PRs = data.PRs
+	}
+
+	if base, _ := cmd.Flags().GetString("base"); base != "" {
+		opts.BaseBranch = base
+	}
+	if days, _ := cmd.Flags().GetInt("stale-days"); days > 0 {
+		opts.StaleAfter = time.Duration(days) * 24 * time.Hour
+	}
+	if opts.BaseBranch == "" {
+		if opts.BaseBranch, err = analyzer.DefaultBaseBranch(); err != nil {
+			return nil, nil, "", doplanerror.NewGitHubError("GH005", "Failed to determine base branch").WithCause(err)
+		}
+	}
+
+	branches, err := analyzer.Analyze(opts)
+	if err != nil {
+		return nil, nil, "", doplanerror.NewGitHubError("GH005", "Failed to analyze branches").
+			WithPath(filepath.Join(projectRoot, ".git")).
+			WithCause(err).
+			WithSuggestion("Use --base to choose the branch features merge into")
+	}
+
+	// Mark branches with pull requests from the last sync
+	if dataErr == nil {
+		data.Branches = branches
+		github.LinkBranchPRs(data)
+	}
+
+	return analyzer, branches, opts.BaseBranch, nil
+}
+
+func prMarker(branch models.Branch) string {
+	if branch.HasPR {
+		return "yes"
+	}
+	return "-"
+}
+
+func valueOrDash(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
+
+func lastCommitAge(branch models.Branch) string {
+	if branch.LastCommit == nil {
+		return "-"
+	}
+	when, err := time.Parse(time.RFC3339, branch.LastCommit.Date)
+	if err != nil {
+		return branch.LastCommit.Date
+	}
+	days := int(time.Since(when).Hours() / 24)
+	if days == 0 {
+		return "today"
+	}
+	return fmt.Sprintf("%dd ago", days)
+}
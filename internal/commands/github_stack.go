@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func NewGitHubStackCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stack",
+		Short: "Show stacked branches for dependent features",
+		Long:  "List features whose branches build on the branch of a feature they depend on",
+		RunE:  runGitHubStack,
+	}
+
+	cmd.PersistentFlags().String("base", "", "Branch features merge into (default: github.baseBranch, main or master)")
+
+	cmd.AddCommand(NewGitHubStackStartCommand())
+	cmd.AddCommand(NewGitHubStackSyncCommand())
+
+	return cmd
+}
+
+func NewGitHubStackStartCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "start <feature-id>",
+		Short: "Create a feature branch on top of its parent's branch",
+		Long:  "Create the feature's branch from the branch of the first unmerged feature it depends on, or from the base branch",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGitHubStackStart,
+	}
+}
+
+func NewGitHubStackSyncCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Rebase and retarget stacked branches after a parent merges",
+		Long:  "Move children of merged features onto the next base, retarget their PRs and rebase branches stacked on them",
+		RunE:  runGitHubStackSync,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Show what would be restacked without changing branches")
+	cmd.Flags().Bool("push", false, "Force-push rebased branches to origin")
+	cmd.Flags().StringP("format", "f", "table", "Output format: table, json")
+
+	return cmd
+}
+
+func runGitHubStack(cmd *cobra.Command, args []string) error {
+	_, _, state, base, err := loadStackContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	stacks := github.Stacks(state, base)
+	if len(stacks) == 0 {
+		color.Yellow("No stacked features. Features with dependencies get stacked branches via 'doplan github stack start <feature-id>'.")
+		return nil
+	}
+
+	for i, stack := range stacks {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s\n", base)
+		for _, entry := range stack {
+			fmt.Println(formatStackEntry(entry))
+		}
+	}
+
+	if pending := github.PendingRestacks(state, base); len(pending) > 0 {
+		fmt.Println()
+		color.Yellow("⚠️  %d branch(es) need restacking. Run 'doplan github stack sync'.\n", len(pending))
+	}
+
+	return nil
+}
+
+func runGitHubStackStart(cmd *cobra.Command, args []string) error {
+	projectRoot, cfgMgr, state, base, err := loadStackContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	feature := findFeatureByID(state, args[0])
+	if feature == nil {
+		return doplanerror.NewValidationError("VAL011", fmt.Sprintf("Feature %s not found", args[0])).
+			WithSuggestion("Use a feature ID from .doplan/state.json")
+	}
+
+	stackMgr, err := github.NewStackManager(projectRoot)
+	if err != nil {
+		return doplanerror.NewGitHubError("GH004", "Not a Git repository").WithPath(projectRoot).WithCause(err)
+	}
+
+	if err := stackMgr.StartFeature(state, feature, base); err != nil {
+		return doplanerror.NewGitHubError("GH007", "Failed to create feature branch").WithCause(err)
+	}
+
+	if err := cfgMgr.SaveState(state); err != nil {
+		return doplanerror.NewStateError("STA002", "Failed to save state").WithCause(err)
+	}
+
+	if feature.BaseBranch != base {
+		color.Green("✅ Created %s stacked on %s\n", feature.Branch, feature.BaseBranch)
+	} else {
+		color.Green("✅ Created %s from %s\n", feature.Branch, feature.BaseBranch)
+	}
+
+	return nil
+}
+
+func runGitHubStackSync(cmd *cobra.Command, args []string) error {
+	projectRoot, cfgMgr, state, base, err := loadStackContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Pick up merges since the last sync
+	if data, err := github.NewGitHubSync(projectRoot).LoadData(); err == nil {
+		github.UpdateFeaturePRs(state, data.PRs)
+	}
+
+	stackMgr, err := github.NewStackManager(projectRoot)
+	if err != nil {
+		return doplanerror.NewGitHubError("GH004", "Not a Git repository").WithPath(projectRoot).WithCause(err)
+	}
+
+	opts := github.RestackOptions{DefaultBase: base}
+	opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+	opts.Push, _ = cmd.Flags().GetBool("push")
+
+	results, restackErr := stackMgr.Restack(state, opts)
+
+	if !opts.DryRun {
+		if err := cfgMgr.SaveState(state); err != nil {
+			return doplanerror.NewStateError("STA002", "Failed to save state").WithCause(err)
+		}
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printRestackResults(results, opts.DryRun)
+	}
+
+	if restackErr != nil {
+		return doplanerror.NewGitHubError("GH007", "Failed to restack branches").WithCause(restackErr)
+	}
+
+	return nil
+}
+
+// loadStackContext loads state and resolves the base branch from flags, config or the repository
+func loadStackContext(cmd *cobra.Command) (string, *config.Manager, *models.State, string, error) {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return "", nil, nil, "", doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	if !config.IsInstalled(projectRoot) {
+		configPath := filepath.Join(projectRoot, ".cursor", "config", "doplan-config.json")
+		return "", nil, nil, "", doplanerror.ErrConfigNotFound(configPath)
+	}
+
+	cfgMgr := config.NewManager(projectRoot)
+	state, err := cfgMgr.LoadState()
+	if err != nil {
+		return "", nil, nil, "", doplanerror.NewStateError("STA001", "Failed to load state").WithCause(err)
+	}
+
+	base, _ := cmd.Flags().GetString("base")
+	if base == "" {
+		if cfg, err := cfgMgr.LoadConfig(); err == nil && cfg != nil {
+			base = cfg.GitHub.BaseBranch
+		}
+	}
+	if base == "" {
+		analyzer, err := github.NewBranchAnalyzer(projectRoot)
+		if err != nil {
+			return "", nil, nil, "", doplanerror.NewGitHubError("GH004", "Not a Git repository").WithPath(projectRoot).WithCause(err)
+		}
+		if base, err = analyzer.DefaultBaseBranch(); err != nil {
+			return "", nil, nil, "", doplanerror.NewGitHubError("GH005", "Failed to determine base branch").WithCause(err)
+		}
+	}
+
+	return projectRoot, cfgMgr, state, base, nil
+}
+
+func findFeatureByID(state *models.State, id string) *models.Feature {
+	for i := range state.Features {
+		if state.Features[i].ID == id {
+			return &state.Features[i]
+		}
+	}
+	return nil
+}
+
+func formatStackEntry(entry github.StackEntry) string {
+	line := fmt.Sprintf("%s└─ %s (%s)", strings.Repeat("   ", entry.Depth), entry.Feature.Branch, entry.Feature.Name)
+	if entry.Feature.PR != nil && entry.Feature.PR.URL != "" {
+		line += fmt.Sprintf(" #%d [%s]", entry.Feature.PR.Number, entry.Feature.PR.Status)
+	}
+	if entry.Feature.BaseBranch != "" && entry.Feature.BaseBranch != entry.Base {
+		line += " ⚠ needs restack"
+	}
+	return line
+}
+
+func printRestackResults(results []github.RestackResult, dryRun bool) {
+	if len(results) == 0 {
+		color.Green("✅ All stacked branches are up to date.")
+		return
+	}
+
+	for _, result := range results {
+		switch {
+		case dryRun && result.OldBase == result.NewBase:
+			fmt.Printf("Would rebase %s onto the restacked %s\n", result.Branch, result.NewBase)
+		case dryRun:
+			fmt.Printf("Would move %s from %s onto %s\n", result.Branch, result.OldBase, result.NewBase)
+		case result.Error != "":
+			color.Red("❌ %s: %s\n", result.Branch, result.Error)
+		default:
+			msg := fmt.Sprintf("✅ Rebased %s onto %s", result.Branch, result.NewBase)
+			if result.Retargeted {
+				msg += " and retargeted its PR"
+			}
+			color.Green("%s\n", msg)
+		}
+	}
+}
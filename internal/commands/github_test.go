@@ -4,6 +4,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
 	"github.com/DoPlan-dev/CLI/test/helpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,3 +26,61 @@ func TestRunGitHub_NotInstalled(t *testing.T) {
 	err := cmd.Execute()
 	assert.NoError(t, err) // Should handle gracefully
 }
+
+func TestNewGitHubBranchesCommand(t *testing.T) {
+	cmd := NewGitHubBranchesCommand()
+	assert.Equal(t, "branches", cmd.Use)
+
+	names := []string{}
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"prune", "rebase-report"}, names)
+}
+
+func TestRunGitHubBranches_NotARepository(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	originalDir, _ := os.Getwd()
+	os.Chdir(projectRoot)
+	defer os.Chdir(originalDir)
+
+	cmd := NewGitHubBranchesCommand()
+	err := runGitHubBranches(cmd, nil)
+	assert.Error(t, err)
+}
+
+func TestNewGitHubStackCommand(t *testing.T) {
+	cmd := NewGitHubStackCommand()
+	assert.Equal(t, "stack", cmd.Use)
+
+	names := []string{}
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"start", "sync"}, names)
+}
+
+func TestRunGitHubStack_NotInstalled(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	originalDir, _ := os.Getwd()
+	os.Chdir(projectRoot)
+	defer os.Chdir(originalDir)
+
+	err := runGitHubStack(NewGitHubStackCommand(), nil)
+	assert.Error(t, err)
+}
+
+func TestFormatStackEntry(t *testing.T) {
+	entry := github.StackEntry{
+		Feature: &models.Feature{
+			Name:       "User Profile",
+			Branch:     "feature/profile",
+			BaseBranch: "feature/auth",
+			PR:         &models.PullRequest{Number: 4, URL: "https://github.com/test/repo/pull/4", Status: "open"},
+		},
+		Base:  "main",
+		Depth: 1,
+	}
+
+	assert.Equal(t, "   └─ feature/profile (User Profile) #4 [open] ⚠ needs restack", formatStackEntry(entry))
+}
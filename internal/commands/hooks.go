@@ -0,0 +1,259 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/tasks"
+	"github.com/DoPlan-dev/CLI/internal/utils"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/fatih/color"
+	"github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+)
+
+func NewHooksCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage DoPlan Git hooks",
+		Long:  "Install Git hooks that enforce conventional commits and complete tasks from commit trailers",
+	}
+
+	cmd.AddCommand(NewHooksInstallCommand())
+	cmd.AddCommand(NewHooksUninstallCommand())
+	cmd.AddCommand(NewHooksCommitMsgCommand())
+	cmd.AddCommand(NewHooksPostCommitCommand())
+
+	return cmd
+}
+
+func NewHooksInstallCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "install",
+		Short: "Install commit-msg and post-commit hooks",
+		Long:  "Install hooks that validate commit messages and tick tasks referenced by 'Completes:' trailers",
+		RunE:  runHooksInstall,
+	}
+}
+
+func NewHooksUninstallCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove DoPlan Git hooks",
+		Long:  "Remove DoPlan hooks and restore any hooks they replaced",
+		RunE:  runHooksUninstall,
+	}
+}
+
+func NewHooksCommitMsgCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:    "commit-msg <message-file>",
+		Short:  "Validate a commit message (called by the commit-msg hook)",
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
+		RunE:   runHooksCommitMsg,
+	}
+}
+
+func NewHooksPostCommitCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:    "post-commit",
+		Short:  "Complete tasks referenced by the last commit (called by the post-commit hook)",
+		Hidden: true,
+		RunE:   runHooksPostCommit,
+	}
+}
+
+func runHooksInstall(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	errLogger := doplanerror.NewLogger(projectRoot, doplanerror.LogLevelInfo)
+	errHandler := doplanerror.NewHandler(errLogger)
+
+	hookMgr, err := github.NewHookManager(projectRoot)
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewGitHubError("GH004", "Not a Git repository").WithCause(err).WithSuggestion("Run 'git init' first"))
+	}
+
+	installed, err := hookMgr.Install()
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewIOError("IO006", "Failed to install Git hooks").WithPath(hookMgr.HooksDir()).WithCause(err))
+	}
+
+	color.Green("✅ Installed Git hooks: %s\n", strings.Join(installed, ", "))
+	fmt.Println("Commit messages are now checked against the commit rules.")
+	fmt.Printf("Add '%s: 01-phase/01-Feature#2' to a commit to tick that task.\n", github.CompletesTrailer)
+
+	return nil
+}
+
+func runHooksUninstall(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	errLogger := doplanerror.NewLogger(projectRoot, doplanerror.LogLevelInfo)
+	errHandler := doplanerror.NewHandler(errLogger)
+
+	hookMgr, err := github.NewHookManager(projectRoot)
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewGitHubError("GH004", "Not a Git repository").WithCause(err))
+	}
+
+	removed, err := hookMgr.Uninstall()
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewIOError("IO006", "Failed to remove Git hooks").WithPath(hookMgr.HooksDir()).WithCause(err))
+	}
+
+	if len(removed) == 0 {
+		color.Yellow("No DoPlan hooks installed.")
+		return nil
+	}
+
+	color.Green("✅ Removed Git hooks: %s\n", strings.Join(removed, ", "))
+	return nil
+}
+
+func runHooksCommitMsg(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	message, err := os.ReadFile(args[0])
+	if err != nil {
+		return doplanerror.ErrFileNotFound(args[0]).WithCause(err)
+	}
+
+	var scopes []string
+	if config.IsInstalled(projectRoot) {
+		if state, err := config.NewManager(projectRoot).LoadState(); err == nil {
+			scopes = commitScopes(projectRoot, state)
+		}
+	}
+
+	if err := github.ValidateCommitMessage(string(message), scopes); err != nil {
+		errHandler := doplanerror.NewHandler(nil)
+		return errHandler.Handle(doplanerror.NewValidationError("VAL009", "Commit message rejected").
+			WithDetails(err.Error()).
+			WithSuggestion("Use '<type>(<scope>): <description>', e.g. 'feat(user-authentication): add login form'. See .cursor/rules/commit-rules.md"))
+	}
+
+	return nil
+}
+
+func runHooksPostCommit(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	if !config.IsInstalled(projectRoot) {
+		return nil
+	}
+
+	repo, err := git.PlainOpen(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+
+	refs := github.ParseTaskReferences(commit.Message)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	cfgMgr := config.NewManager(projectRoot)
+	state, err := cfgMgr.LoadState()
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	completed := completeTaskReferences(projectRoot, state, refs, commit.Hash.String())
+	if completed == 0 {
+		return nil
+	}
+
+	return refreshProgress(projectRoot, cfgMgr, state)
+}
+
+// completeTaskReferences ticks each referenced task in tasks.md and state, returning how many were completed
+func completeTaskReferences(projectRoot string, state *models.State, refs []github.TaskReference, commitHash string) int {
+	completed := 0
+	for _, ref := range refs {
+		tasksPath := filepath.Join(projectRoot, "doplan", ref.PhaseDir, ref.FeatureDir, "tasks.md")
+		phase, name, err := tasks.CompleteTask(tasksPath, ref.TaskNumber, commitHash)
+		if err != nil {
+			color.Yellow("⚠️  Could not complete task %s: %v\n", ref, err)
+			continue
+		}
+
+		if feature := github.FeatureForDirs(state, ref.PhaseDir, ref.FeatureDir); feature != nil {
+			markTaskCompleted(feature, phase, name, commitHash)
+		}
+
+		color.Green("✅ Completed task %s: %s\n", ref, name)
+		completed++
+	}
+	return completed
+}
+
+// markTaskCompleted marks the task completed in tasks.md as completed in the state.
+// Task numbers count every checklist item in tasks.md, so the task is found by its
+// name in its phase, then by its name alone.
+func markTaskCompleted(feature *models.Feature, phase, name, commitHash string) {
+	for _, samePhase := range []bool{true, false} {
+		for i := range feature.TaskPhases {
+			if samePhase && feature.TaskPhases[i].Name != phase {
+				continue
+			}
+			for j := range feature.TaskPhases[i].Tasks {
+				if task := &feature.TaskPhases[i].Tasks[j]; task.Name == name {
+					task.Completed = true
+					task.Commit = commitHash
+					return
+				}
+			}
+		}
+	}
+}
+
+// commitScopes lists the scopes accepted in commit messages: feature and phase identifiers
+func commitScopes(projectRoot string, state *models.State) []string {
+	var scopes []string
+	for _, feature := range state.Features {
+		scopes = append(scopes, feature.ID)
+		if slug := utils.Slugify(feature.Name); slug != "" {
+			scopes = append(scopes, slug)
+		}
+	}
+	for _, phase := range state.Phases {
+		scopes = append(scopes, phase.ID)
+	}
+
+	// Directory names under doplan/ are valid too
+	entries, _ := os.ReadDir(filepath.Join(projectRoot, "doplan"))
+	for _, entry := range entries {
+		if entry.IsDir() && github.DirNumber(entry.Name()) > 0 {
+			scopes = append(scopes, entry.Name())
+		}
+	}
+
+	return scopes
+}
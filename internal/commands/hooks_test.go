@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHooksCommand(t *testing.T) {
+	cmd := NewHooksCommand()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "hooks", cmd.Use)
+	assert.Len(t, cmd.Commands(), 4)
+}
+
+func hooksTestState() *models.State {
+	return &models.State{
+		Phases: []models.Phase{
+			{ID: "phase-1", Features: []string{"feature-a"}},
+			{ID: "phase-2", Features: []string{"feature-b", "feature-c"}},
+		},
+		Features: []models.Feature{
+			{ID: "feature-a", Name: "Setup"},
+			{ID: "feature-b", Name: "Payments"},
+			{
+				ID:   "feature-c",
+				Name: "User Auth",
+				TaskPhases: []models.TaskPhase{
+					{Name: "Setup", Tasks: []models.Task{{Name: "Schema"}, {Name: "Migrations"}}},
+					{Name: "Build", Tasks: []models.Task{{Name: "Login form"}}},
+				},
+			},
+		},
+	}
+}
+
+func TestCompleteTaskReferences(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	state := hooksTestState()
+
+	helpers.WriteTestFile(t, projectRoot, "doplan/02-phase/02-Feature/tasks.md",
+		[]byte("### Setup\n\n- [ ] Schema\n- [ ] Migrations\n\n### Build\n\n- [ ] Login form\n"))
+
+	refs := []github.TaskReference{
+		{PhaseDir: "02-phase", FeatureDir: "02-Feature", TaskNumber: 3},
+		{PhaseDir: "02-phase", FeatureDir: "09-Feature", TaskNumber: 1},
+	}
+	completed := completeTaskReferences(projectRoot, state, refs, "abcdef1234567890")
+	assert.Equal(t, 1, completed)
+
+	task := state.Features[2].TaskPhases[1].Tasks[0]
+	assert.True(t, task.Completed)
+	assert.Equal(t, "abcdef1234567890", task.Commit)
+
+	content, err := os.ReadFile(filepath.Join(projectRoot, "doplan", "02-phase", "02-Feature", "tasks.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "- [x] Login form (commit abcdef1)")
+}
+
+func TestCompleteTaskReferences_StrayChecklistItem(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	state := hooksTestState()
+
+	// The note's checkbox is task #1 in tasks.md but not a task in the state
+	helpers.WriteTestFile(t, projectRoot, "doplan/02-phase/02-Feature/tasks.md",
+		[]byte("## Notes\n\n- [ ] Ask about rate limits\n\n### Setup\n\n- [ ] Schema\n- [ ] Migrations\n\n### Build\n\n- [ ] Login form\n"))
+
+	refs := []github.TaskReference{{PhaseDir: "02-phase", FeatureDir: "02-Feature", TaskNumber: 2}}
+	require.Equal(t, 1, completeTaskReferences(projectRoot, state, refs, "abcdef1234567890"))
+
+	setup := state.Features[2].TaskPhases[0].Tasks
+	assert.True(t, setup[0].Completed, "task #2 in tasks.md is Schema")
+	assert.Equal(t, "abcdef1234567890", setup[0].Commit)
+	assert.False(t, setup[1].Completed)
+
+	content, err := os.ReadFile(filepath.Join(projectRoot, "doplan", "02-phase", "02-Feature", "tasks.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "- [ ] Ask about rate limits\n")
+	assert.Contains(t, string(content), "- [x] Schema (commit abcdef1)\n- [ ] Migrations\n")
+}
+
+func TestCommitScopes(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(projectRoot, "doplan", "01-phase"), 0755))
+
+	scopes := commitScopes(projectRoot, hooksTestState())
+	assert.Contains(t, scopes, "feature-c")
+	assert.Contains(t, scopes, "user-auth")
+	assert.Contains(t, scopes, "phase-2")
+	assert.Contains(t, scopes, "01-phase")
+	assert.NotContains(t, scopes, "contracts")
+}
+
+func TestRunHooksCommitMsg(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	originalDir, _ := os.Getwd()
+	os.Chdir(projectRoot)
+	defer os.Chdir(originalDir)
+
+	good := filepath.Join(projectRoot, "COMMIT_EDITMSG")
+	require.NoError(t, os.WriteFile(good, []byte("feat: add login\n# comment\n"), 0644))
+	assert.NoError(t, runHooksCommitMsg(nil, []string{good}))
+
+	bad := filepath.Join(projectRoot, "BAD_MSG")
+	require.NoError(t, os.WriteFile(bad, []byte("added login\n"), 0644))
+	assert.Error(t, runHooksCommitMsg(nil, []string{bad}))
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func NewMetricsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Export project statistics as metrics",
+		Long:  "Expose DoPlan statistics in the OpenMetrics format for Prometheus and compatible scrapers",
+	}
+
+	cmd.AddCommand(NewMetricsServeCommand())
+
+	return cmd
+}
+
+func NewMetricsServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve an OpenMetrics endpoint",
+		Long: `Serve progress, velocity, task, pull request, checkpoint and coverage metrics per phase
+and feature in the OpenMetrics text format. Statistics are recalculated on every scrape
+and are not added to the stats history. For a one-off file, e.g. for a textfile
+collector, use 'doplan stats --format openmetrics --export <path>'.`,
+		RunE: runMetricsServe,
+	}
+
+	cmd.Flags().String("addr", ":9477", "Address to listen on")
+	cmd.Flags().String("path", "/metrics", "URL path of the metrics endpoint")
+
+	return cmd
+}
+
+func runMetricsServe(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	if !config.IsInstalled(projectRoot) {
+		configPath := filepath.Join(projectRoot, ".cursor", "config", "doplan-config.json")
+		return doplanerror.ErrConfigNotFound(configPath)
+	}
+
+	addr, _ := cmd.Flags().GetString("addr")
+	path, _ := cmd.Flags().GetString("path")
+
+	mux := http.NewServeMux()
+	mux.Handle(path, statistics.NewOpenMetricsHandler(newMetricsLoader(projectRoot)))
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	color.Green("✅ Serving OpenMetrics on %s%s\n", addr, path)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return doplanerror.NewIOError("IO008", "Metrics server failed").WithCause(err)
+	}
+	return nil
+}
+
+// newMetricsLoader collects and calculates statistics the same way as 'doplan stats'
+// for each scrape, without saving a history snapshot
+func newMetricsLoader(projectRoot string) statistics.OpenMetricsLoadFunc {
+	return func() (*statistics.StatisticsMetrics, *statistics.StatisticsData, error) {
+		data, err := statistics.NewCollector(projectRoot).Collect()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cfgMgr := config.NewManager(projectRoot)
+		state, err := cfgMgr.LoadState()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load state: %w", err)
+		}
+		cfg, err := cfgMgr.LoadConfig()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load config: %w", err)
+		}
+
+		githubData, err := github.NewGitHubSync(projectRoot).LoadData()
+		if err != nil {
+			githubData = &github.GitHubData{}
+		}
+
+		now := time.Now()
+		projectStartDate := now
+		if cfg != nil && !cfg.InstalledAt.IsZero() {
+			projectStartDate = cfg.InstalledAt
+		}
+
+		metrics := statistics.NewCalculator(projectStartDate).Calculate(data, state, githubData)
+		metrics.Burndown = statistics.LoadBurnCharts(projectRoot, state, now)
+		return metrics, data, nil
+	}
+}
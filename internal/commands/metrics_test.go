@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMetricsCommand(t *testing.T) {
+	cmd := NewMetricsCommand()
+	assert.Equal(t, "metrics", cmd.Use)
+
+	serve, _, err := cmd.Find([]string{"serve"})
+	require.NoError(t, err)
+	assert.Equal(t, ":9477", serve.Flags().Lookup("addr").DefValue)
+	assert.Equal(t, "/metrics", serve.Flags().Lookup("path").DefValue)
+}
+
+func TestRunMetricsServe_NotInstalled(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+
+	t.Chdir(projectRoot)
+
+	err := runMetricsServe(NewMetricsServeCommand(), nil)
+	require.Error(t, err)
+}
+
+func TestMetricsLoader_Scrape(t *testing.T) {
+	projectRoot := helpers.SetupInstalledProject(t, nil, &models.State{
+		Phases:   []models.Phase{{ID: "01-phase", Name: "Foundation", Features: []string{"01"}}},
+		Features: []models.Feature{{ID: "01", Phase: "01-phase", Name: "Auth", Status: "complete", Progress: 100}},
+	})
+
+	handler := statistics.NewOpenMetricsHandler(newMetricsLoader(projectRoot))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, statistics.OpenMetricsContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "doplan_phase_progress_ratio{phase=\"01-phase\"} 1\n")
+	assert.Contains(t, rec.Body.String(), "doplan_features{status=\"complete\"} 1\n")
+
+	_, err := os.Stat(statistics.NewStorage(projectRoot).Dir())
+	assert.True(t, os.IsNotExist(err), "scrapes do not record history")
+}
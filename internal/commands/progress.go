@@ -4,13 +4,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/DoPlan-dev/CLI/internal/checkpoint"
 	"github.com/DoPlan-dev/CLI/internal/config"
 	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
 	"github.com/DoPlan-dev/CLI/internal/generators"
 	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/tasks"
 	"github.com/DoPlan-dev/CLI/pkg/models"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -54,15 +55,49 @@ func runProgress(cmd *cobra.Command, args []string) error {
 		return errHandler.Handle(doplanerror.ErrStateNotFound(statePath).WithCause(err))
 	}
 
+	if err := refreshProgress(projectRoot, cfgMgr, state); err != nil {
+		return err
+	}
+
+	color.Green("✅ Progress updated and dashboard regenerated!\n")
+	color.Cyan("Run 'doplan dashboard' to view the updated dashboard.")
+
+	return nil
+}
+
+// refreshProgress runs RefreshProgress and prints its warnings
+func refreshProgress(projectRoot string, cfgMgr *config.Manager, state *models.State) error {
+	warnings, err := RefreshProgress(projectRoot, cfgMgr, state)
+	for _, warning := range warnings {
+		color.Yellow("⚠️  %s\n", warning)
+	}
+	return err
+}
+
+// RefreshProgress recomputes progress from tasks.md files, saves state, regenerates
+// the dashboard and triggers checkpoint and auto-PR automation. Failures of the
+// automation do not fail the refresh and are returned as warnings. It is the
+// tasks.RefreshFunc the TUI's task editor and board save through.
+func RefreshProgress(projectRoot string, cfgMgr *config.Manager, state *models.State) ([]string, error) {
+	var warnings []string
+
+	// Features first seen now start their lead time today
+	now := time.Now()
+	for i := range state.Features {
+		if state.Features[i].CreatedAt == "" {
+			state.Features[i].CreatedAt = now.Format(time.RFC3339)
+		}
+	}
+
 	// Scan feature directories and update progress
 	doplanDir := filepath.Join(projectRoot, "doplan")
-	if err := updateProgressFromTasks(doplanDir, state); err != nil {
-		color.Yellow("Warning: Failed to update progress from tasks: %v", err)
+	if err := tasks.UpdateProgress(doplanDir, state); err != nil {
+		warnings = append(warnings, fmt.Sprintf("Failed to update progress from tasks: %v", err))
 	}
 
 	// Save updated state
 	if err := cfgMgr.SaveState(state); err != nil {
-		return fmt.Errorf("failed to save state: %w", err)
+		return warnings, fmt.Errorf("failed to save state: %w", err)
 	}
 
 	// Sync GitHub data
@@ -75,7 +110,7 @@ func runProgress(cmd *cobra.Command, args []string) error {
 	// Regenerate dashboard
 	dashboardGen := generators.NewDashboardGenerator(projectRoot, state, githubData)
 	if err := dashboardGen.Generate(); err != nil {
-		return fmt.Errorf("failed to regenerate dashboard: %w", err)
+		return warnings, fmt.Errorf("failed to regenerate dashboard: %w", err)
 	}
 
 	// Auto-create checkpoints for completed features/phases
@@ -86,7 +121,7 @@ func runProgress(cmd *cobra.Command, args []string) error {
 			// Check if checkpoint already exists
 			if feature.CheckpointID == "" {
 				if err := checkpointMgr.AutoCreateFeatureCheckpoint(feature); err != nil {
-					color.Yellow("⚠️  Failed to create checkpoint for feature '%s': %v\n", feature.Name, err)
+					warnings = append(warnings, fmt.Sprintf("Failed to create checkpoint for feature '%s': %v", feature.Name, err))
 				}
 			}
 		}
@@ -95,69 +130,8 @@ func runProgress(cmd *cobra.Command, args []string) error {
 	// Check for auto-PR creation
 	autoPRMgr := github.NewAutoPRManager(projectRoot)
 	if err := autoPRMgr.WatchFeatures(state); err != nil {
-		color.Yellow("⚠️  Failed to check for auto-PR creation: %v\n", err)
+		warnings = append(warnings, fmt.Sprintf("Failed to check for auto-PR creation: %v", err))
 	}
 
-	color.Green("✅ Progress updated and dashboard regenerated!\n")
-	color.Cyan("Run 'doplan dashboard' to view the updated dashboard.")
-
-	return nil
-}
-
-func updateProgressFromTasks(doplanDir string, state *models.State) error {
-	// Walk through phase directories
-	return filepath.Walk(doplanDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Check if this is a tasks.md file
-		if info.Name() == "tasks.md" {
-			// Read tasks.md and count completed tasks
-			content, err := os.ReadFile(path)
-			if err != nil {
-				return err
-			}
-
-			// Count completed tasks (lines with [x])
-			lines := strings.Split(string(content), "\n")
-			totalTasks := 0
-			completedTasks := 0
-
-			for _, line := range lines {
-				if strings.Contains(line, "- [") {
-					totalTasks++
-					if strings.Contains(line, "- [x]") || strings.Contains(line, "- [X]") {
-						completedTasks++
-					}
-				}
-			}
-
-			// Calculate progress percentage
-			progress := 0
-			if totalTasks > 0 {
-				progress = (completedTasks * 100) / totalTasks
-			}
-
-			// Find the feature and update its progress
-			featureDir := filepath.Dir(path)
-			featureName := filepath.Base(featureDir)
-
-			// Try to match feature by directory name
-			for i := range state.Features {
-				if strings.Contains(strings.ToLower(state.Features[i].Name), strings.ToLower(featureName)) ||
-					strings.Contains(strings.ToLower(featureName), strings.ToLower(state.Features[i].Name)) {
-					state.Features[i].Progress = progress
-					if progress == 100 {
-						state.Features[i].Status = "complete"
-					} else if progress > 0 {
-						state.Features[i].Status = "in-progress"
-					}
-					break
-				}
-			}
-		}
-
-		return nil
-	})
+	return warnings, nil
 }
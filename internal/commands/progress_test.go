@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/internal/tasks"
 	"github.com/DoPlan-dev/CLI/pkg/models"
 	"github.com/DoPlan-dev/CLI/test/helpers"
 	"github.com/stretchr/testify/assert"
@@ -100,7 +101,7 @@ func TestUpdateProgressFromTasks(t *testing.T) {
 		},
 	}
 
-	err = updateProgressFromTasks(filepath.Join(projectRoot, "doplan"), state)
+	err = tasks.UpdateProgress(filepath.Join(projectRoot, "doplan"), state)
 	require.NoError(t, err)
 
 	// Progress should be calculated (2 out of 3 tasks = 66%)
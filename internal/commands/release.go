@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/release"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func NewReleaseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Release tooling",
+		Long:  "Generate release notes and changelog entries from completed features and commits",
+	}
+
+	cmd.AddCommand(NewReleaseNotesCommand())
+
+	return cmd
+}
+
+func NewReleaseNotesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notes",
+		Short: "Generate release notes",
+		Long:  "Combine completed features with conventional commits grouped by type. The Markdown layout comes from doplan/templates/release-notes-template.md",
+		RunE:  runReleaseNotes,
+	}
+
+	cmd.Flags().String("phase", "", "Phase ID or number to release (e.g. '02')")
+	cmd.Flags().String("since", "", "Include commits after this tag or revision")
+	cmd.Flags().String("version", "", "Version for the heading and tag (e.g. 'v1.2.0')")
+	cmd.Flags().StringP("format", "f", "markdown", "Output format: markdown, json")
+	cmd.Flags().StringP("output", "o", "", "Write notes to a file instead of stdout")
+	cmd.Flags().Bool("changelog", false, "Prepend the notes to CHANGELOG.md")
+	cmd.Flags().Bool("tag", false, "Create an annotated Git tag named after --version")
+
+	return cmd
+}
+
+func runReleaseNotes(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	errLogger := doplanerror.NewLogger(projectRoot, doplanerror.LogLevelInfo)
+	errHandler := doplanerror.NewHandler(errLogger)
+
+	if !config.IsInstalled(projectRoot) {
+		configPath := filepath.Join(projectRoot, ".cursor", "config", "doplan-config.json")
+		errHandler.PrintError(doplanerror.ErrConfigNotFound(configPath))
+		return nil
+	}
+
+	opts := release.Options{}
+	opts.Phase, _ = cmd.Flags().GetString("phase")
+	opts.Since, _ = cmd.Flags().GetString("since")
+	opts.Version, _ = cmd.Flags().GetString("version")
+	format, _ := cmd.Flags().GetString("format")
+	outputPath, _ := cmd.Flags().GetString("output")
+	updateChangelog, _ := cmd.Flags().GetBool("changelog")
+	createTag, _ := cmd.Flags().GetBool("tag")
+
+	if opts.Phase == "" && opts.Since == "" {
+		return errHandler.Handle(doplanerror.NewValidationError("VAL010", "Nothing to release").
+			WithSuggestion("Pass --phase <id> or --since <tag>"))
+	}
+	if createTag && opts.Version == "" {
+		return errHandler.Handle(doplanerror.NewValidationError("VAL010", "--tag requires --version"))
+	}
+	if format != "markdown" && format != "json" {
+		return errHandler.Handle(doplanerror.NewValidationError("VAL010", "Invalid format").WithDetails(format))
+	}
+
+	state, err := config.NewManager(projectRoot).LoadState()
+	if err != nil {
+		statePath := filepath.Join(projectRoot, ".doplan", "state.json")
+		return errHandler.Handle(doplanerror.ErrStateNotFound(statePath).WithCause(err))
+	}
+
+	gen, err := release.NewGenerator(projectRoot)
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewGitHubError("GH004", "Not a Git repository").WithCause(err))
+	}
+
+	notes, err := gen.Build(state, opts)
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewValidationError("VAL010", "Failed to build release notes").WithCause(err).WithDetails(err.Error()))
+	}
+
+	markdown, err := gen.RenderMarkdown(notes)
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewIOError("IO007", "Failed to render release notes").
+			WithPath(filepath.Join(projectRoot, "doplan", "templates", release.NotesTemplate)).
+			WithCause(err).
+			WithDetails(err.Error()))
+	}
+
+	output := markdown
+	if format == "json" {
+		if output, err = release.RenderJSON(notes); err != nil {
+			return errHandler.Handle(err)
+		}
+	}
+
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+			return errHandler.Handle(doplanerror.NewIOError("IO006", "Failed to write release notes").WithPath(outputPath).WithCause(err))
+		}
+		color.Green("✅ Release notes written to %s\n", outputPath)
+	} else {
+		fmt.Print(output)
+	}
+
+	if updateChangelog {
+		changelogPath := filepath.Join(projectRoot, "CHANGELOG.md")
+		if err := release.PrependChangelog(changelogPath, markdown); err != nil {
+			return errHandler.Handle(doplanerror.NewIOError("IO006", "Failed to update changelog").WithPath(changelogPath).WithCause(err))
+		}
+		color.Green("✅ Updated CHANGELOG.md\n")
+	}
+
+	if createTag {
+		if err := gen.CreateTag(opts.Version, markdown); err != nil {
+			return errHandler.Handle(doplanerror.NewGitHubError("GH006", "Failed to create tag").WithCause(err).WithDetails(err.Error()))
+		}
+		color.Green("✅ Created tag %s\n", opts.Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReleaseCommand(t *testing.T) {
+	cmd := NewReleaseCommand()
+	assert.Equal(t, "release", cmd.Use)
+	require.Len(t, cmd.Commands(), 1)
+	assert.Equal(t, "notes", cmd.Commands()[0].Name())
+}
+
+func TestRunReleaseNotes_NotInstalled(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	originalDir, _ := os.Getwd()
+	os.Chdir(projectRoot)
+	defer os.Chdir(originalDir)
+
+	cmd := NewReleaseNotesCommand()
+	assert.NoError(t, runReleaseNotes(cmd, nil))
+}
+
+func TestRunReleaseNotes_RequiresRange(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	installer := NewInstaller(projectRoot, "cursor")
+	require.NoError(t, installer.generateConfig())
+
+	originalDir, _ := os.Getwd()
+	os.Chdir(projectRoot)
+	defer os.Chdir(originalDir)
+
+	cmd := NewReleaseNotesCommand()
+	assert.Error(t, runReleaseNotes(cmd, nil))
+
+	cmd = NewReleaseNotesCommand()
+	require.NoError(t, cmd.Flags().Set("since", "v1.0.0"))
+	require.NoError(t, cmd.Flags().Set("tag", "true"))
+	assert.Error(t, runReleaseNotes(cmd, nil), "--tag without --version")
+}
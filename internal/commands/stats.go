@@ -21,12 +21,16 @@ func NewStatsCommand() *cobra.Command {
 		RunE:  runStats,
 	}
 
-	cmd.Flags().StringP("format", "f", "table", "Output format: table, json, html, markdown")
+	cmd.Flags().StringP("format", "f", "table", "Output format: table, json, html, markdown, openmetrics")
 	cmd.Flags().String("export", "", "Export to file path")
 	cmd.Flags().String("since", "", "Show stats since date/duration (e.g., '7d', '2025-01-01')")
 	cmd.Flags().String("range", "", "Show stats for date range (e.g., '2025-01-01:2025-01-15')")
-	cmd.Flags().String("metrics", "all", "Show specific metrics: velocity, completion, time, quality, all")
+	cmd.Flags().String("metrics", "all", "Show specific metrics: velocity, completion, time, quality, testing, tests, burndown, flow, hotspots, estimates, all")
 	cmd.Flags().Bool("trends", false, "Include trend analysis")
+	cmd.Flags().Bool("forecast", false, "Include Monte Carlo completion forecasts (P50/P85/P95)")
+	cmd.Flags().StringSlice("test-results", nil, "Ingest go test -json or JUnit XML reports ('-' reads stdin, e.g. go test -json ./... | doplan stats --test-results -)")
+
+	cmd.AddCommand(NewStatsBackfillCommand())
 
 	return cmd
 }
@@ -48,8 +52,11 @@ func runStats(cmd *cobra.Command, args []string) error {
 	errLogger := doplanerror.NewLogger(projectRoot, doplanerror.LogLevelInfo)
 	errHandler := doplanerror.NewHandler(errLogger)
 
-	// Collect statistics
-	color.Blue("Collecting statistics...\n")
+	// Collect statistics; OpenMetrics output stays parseable without the progress line
+	format, _ := cmd.Flags().GetString("format")
+	if format != "openmetrics" {
+		color.Blue("Collecting statistics...\n")
+	}
 
 	collector := statistics.NewCollector(projectRoot)
 	data, err := collector.Collect()
@@ -138,6 +145,24 @@ func runStats(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Burndown series, flow, hotspot and estimate metrics are rebuilt on every run rather than stored in the history
+	metrics.Burndown = statistics.LoadBurnCharts(projectRoot, state, time.Now())
+	metrics.Flow = statistics.LoadFlowMetrics(projectRoot, state, githubData, time.Now())
+	metrics.Hotspots = statistics.LoadHotspots(projectRoot, state, githubData, cfg, time.Now())
+	metrics.Estimates = statistics.CalculateEstimateMetrics(state, metrics.Flow, time.Now())
+	estimates := metrics.Estimates
+
+	// Test reports go into their own history, summarized on every run
+	testReports, _ := cmd.Flags().GetStringSlice("test-results")
+	if _, err := statistics.IngestTestReports(projectRoot, cfg.Stats.Tests.Paths, testReports, cmd.InOrStdin(), time.Now()); err != nil {
+		return errHandler.Handle(doplanerror.NewValidationError("VAL012", "Failed to ingest test results").WithDetails(err.Error()))
+	}
+	featureNames := make(map[string]string, len(state.Features))
+	for _, feature := range state.Features {
+		featureNames[feature.ID] = feature.Name
+	}
+	metrics.Tests = statistics.LoadTestResultMetrics(projectRoot, cfg.Stats.Coverage.Features, featureNames)
+
 	// Filter metrics if requested
 	if metricsFlag != "all" {
 		metrics = filterMetrics(metrics, metricsFlag)
@@ -146,14 +171,36 @@ func runStats(cmd *cobra.Command, args []string) error {
 	// Save to storage for historical tracking (only if not using historical data)
 	if sinceFlag == "" && rangeFlag == "" {
 		storage := statistics.NewStorage(projectRoot)
-		if err := storage.Save(metrics, data); err != nil {
+
+		// Coverage is compared with the previous snapshot before this run becomes the latest
+		if latest, err := storage.GetLatest(); err == nil && latest != nil && latest.Metrics != nil {
+			statistics.CompareCoverage(metrics.Testing, latest.Metrics.Testing)
+		}
+
+		snapshot := *metrics
+		snapshot.Burndown = nil
+		snapshot.Flow = nil
+		snapshot.Hotspots = nil
+		snapshot.Estimates = nil
+		snapshot.Tests = nil
+		if snapshot.Testing != nil {
+			testing := *snapshot.Testing
+			testing.PackageDiff = nil
+			snapshot.Testing = &testing
+		}
+		if err := storage.Save(&snapshot, data); err != nil {
 			// Log but don't fail the command
 			errHandler.PrintError(err)
 		}
 	}
 
-	// Get format and export options
-	format, _ := cmd.Flags().GetString("format")
+	// Forecasts are simulated fresh each run and never stored
+	if showForecast, _ := cmd.Flags().GetBool("forecast"); showForecast {
+		metrics.Forecast = statistics.LoadForecasts(projectRoot, state, cfg.Stats.Forecast, time.Now())
+		metrics.Forecast.Calibrate(estimates)
+	}
+
+	// Get export option
 	exportPath, _ := cmd.Flags().GetString("export")
 
 	// Report statistics
@@ -166,6 +213,8 @@ func runStats(cmd *cobra.Command, args []string) error {
 		return reporter.ReportHTML(metrics, exportPath)
 	case "markdown":
 		return reporter.ReportMarkdown(metrics, exportPath)
+	case "openmetrics":
+		return reporter.ReportOpenMetrics(metrics, data, exportPath)
 	case "table":
 		fallthrough
 	default:
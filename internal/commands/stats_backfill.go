@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/fatih/color"
+	"github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+)
+
+func NewStatsBackfillCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Rebuild statistics history from git",
+		Long: `Replay the git history of the state, tasks.md and progress.json files and record one
+statistics snapshot per day in which they changed, so burndowns and trends cover the
+time before 'doplan stats' was first run. Days that already have a snapshot are kept,
+so running it again only fills gaps. GitHub, checkpoint and coverage figures cannot be
+recovered from the files and are left empty in backfilled snapshots.`,
+		RunE: runStatsBackfill,
+	}
+
+	cmd.Flags().String("since", "", "Only replay history since date/duration (e.g., '90d', '2025-01-01')")
+	cmd.Flags().Bool("dry-run", false, "Show the snapshots that would be recorded without writing them")
+
+	return cmd
+}
+
+func runStatsBackfill(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	errLogger := doplanerror.NewLogger(projectRoot, doplanerror.LogLevelInfo)
+	errHandler := doplanerror.NewHandler(errLogger)
+
+	if !config.IsInstalled(projectRoot) {
+		configPath := filepath.Join(projectRoot, ".cursor", "config", "doplan-config.json")
+		return errHandler.Handle(doplanerror.ErrConfigNotFound(configPath))
+	}
+
+	var since time.Time
+	if sinceFlag, _ := cmd.Flags().GetString("since"); sinceFlag != "" {
+		since, err = parseTimeInput(sinceFlag)
+		if err != nil {
+			return errHandler.Handle(doplanerror.NewValidationError("VAL002", "Invalid 'since' time format").WithDetails(err.Error()))
+		}
+	}
+
+	cfg, err := config.NewManager(projectRoot).LoadConfig()
+	if err != nil {
+		return errHandler.Handle(err)
+	}
+
+	out := cmd.OutOrStdout()
+	color.New(color.FgBlue).Fprintln(out, "Replaying git history of DoPlan files...")
+	snapshots, err := statistics.Backfill(projectRoot, since, cfg.InstalledAt)
+	if err != nil {
+		if errors.Is(err, git.ErrRepositoryNotExists) {
+			return errHandler.Handle(doplanerror.NewGitHubError("GH004", "Not a Git repository").WithPath(projectRoot).WithCause(err))
+		}
+		return errHandler.Handle(doplanerror.NewIOError("IO005", "Failed to read git history").WithCause(err))
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		storage := statistics.NewStorage(projectRoot)
+		snapshots, err = storage.Import(snapshots, time.Now())
+		if err != nil {
+			return errHandler.Handle(doplanerror.NewIOError("IO006", "Failed to write statistics history").WithPath(storage.Dir()).WithCause(err))
+		}
+	}
+
+	for _, snapshot := range snapshots {
+		state := snapshot.Data.State
+		tasks := snapshot.Data.Tasks
+		fmt.Fprintf(out, "  %s  %d/%d features complete, %d/%d tasks done\n",
+			snapshot.Timestamp.Format("2006-01-02"), state.CompletedFeatures, state.TotalFeatures, tasks.CompletedTasks, tasks.TotalTasks)
+	}
+
+	switch {
+	case len(snapshots) == 0:
+		color.New(color.FgYellow).Fprintln(out, "⚠️  No new days to backfill")
+	case dryRun:
+		color.New(color.FgYellow).Fprintf(out, "\n%d day(s) would be backfilled (dry run)\n", len(snapshots))
+	default:
+		color.New(color.FgGreen).Fprintf(out, "\n✅ Backfilled %d day(s) of statistics history\n", len(snapshots))
+	}
+	return nil
+}
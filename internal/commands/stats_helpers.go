@@ -87,7 +87,7 @@ func parseRangeInput(input string) (time.Time, time.Time, error) {
 }
 
 // filterMetrics filters metrics based on the filter string
-// filter can be: "velocity", "completion", "time", "quality", or comma-separated
+// filter can be: "velocity", "completion", "time", "quality", "testing", "tests", "burndown", "flow", "hotspots", "estimates", or comma-separated
 func filterMetrics(metrics *statistics.StatisticsMetrics, filter string) *statistics.StatisticsMetrics {
 	if filter == "all" {
 		return metrics
@@ -109,6 +109,18 @@ func filterMetrics(metrics *statistics.StatisticsMetrics, filter string) *statis
 			filtered.Time = metrics.Time
 		case "quality":
 			filtered.Quality = metrics.Quality
+		case "testing":
+			filtered.Testing = metrics.Testing
+		case "tests":
+			filtered.Tests = metrics.Tests
+		case "burndown":
+			filtered.Burndown = metrics.Burndown
+		case "flow":
+			filtered.Flow = metrics.Flow
+		case "hotspots":
+			filtered.Hotspots = metrics.Hotspots
+		case "estimates":
+			filtered.Estimates = metrics.Estimates
 		}
 	}
 
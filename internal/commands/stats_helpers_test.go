@@ -131,6 +131,7 @@ func TestFilterMetrics(t *testing.T) {
 		Quality: &statistics.QualityMetrics{
 			PRMergeRate: 80.0,
 		},
+		Flow: &statistics.FlowMetrics{},
 	}
 
 	tests := []struct {
@@ -157,8 +158,11 @@ func TestFilterMetrics(t *testing.T) {
 			assert.Equal(t, tt.hasComp, filtered.Completion != nil)
 			assert.Equal(t, tt.hasTime, filtered.Time != nil)
 			assert.Equal(t, tt.hasQual, filtered.Quality != nil)
+			assert.Equal(t, tt.filter == "all", filtered.Flow != nil)
 		})
 	}
+
+	assert.NotNil(t, filterMetrics(metrics, "flow").Flow)
 }
 
 func TestAggregateHistoricalMetrics(t *testing.T) {
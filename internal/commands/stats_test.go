@@ -1,8 +1,11 @@
 package commands
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,10 +13,28 @@ import (
 	"github.com/DoPlan-dev/CLI/internal/statistics"
 	"github.com/DoPlan-dev/CLI/pkg/models"
 	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/go-git/go-git/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// exportStats runs stats with the given flags and returns the report it exported in format
+func exportStats(t *testing.T, projectRoot, format string, flags map[string]string) string {
+	t.Helper()
+	exportPath := filepath.Join(projectRoot, "stats."+format)
+	cmd := NewStatsCommand()
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	require.NoError(t, cmd.Flags().Set("format", format))
+	require.NoError(t, cmd.Flags().Set("export", exportPath))
+	require.NoError(t, runStats(cmd, []string{}))
+
+	content, err := os.ReadFile(exportPath)
+	require.NoError(t, err)
+	return string(content)
+}
+
 func TestNewStatsCommand(t *testing.T) {
 	cmd := NewStatsCommand()
 	assert.NotNil(t, cmd)
@@ -293,6 +314,150 @@ func TestRunStats_TrendsFlag(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRunStats_ForecastFlag(t *testing.T) {
+	cfg := config.NewConfig("cursor")
+	cfg.InstalledAt = time.Now().Add(-30 * 24 * time.Hour)
+	projectRoot := helpers.SetupInstalledProject(t, cfg, &models.State{
+		Phases: []models.Phase{{ID: "phase-1", Name: "Foundation", Features: []string{"feature-1"}}},
+		Features: []models.Feature{
+			{ID: "feature-1", Phase: "phase-1", Name: "Auth", TaskPhases: []models.TaskPhase{helpers.TaskPhase("Build", true, false)}},
+		},
+	})
+
+	content := exportStats(t, projectRoot, "markdown", map[string]string{"forecast": "true"})
+	assert.Contains(t, content, "## Forecast")
+	assert.Contains(t, content, "| Foundation | 1 tasks | not enough history yet |")
+	assert.Contains(t, content, "## Burndown")
+
+	// Forecasts and burndown series are rebuilt on each run, not stored in history
+	latest, err := statistics.NewStorage(projectRoot).GetLatest()
+	require.NoError(t, err)
+	assert.Nil(t, latest.Metrics.Forecast)
+	assert.Nil(t, latest.Metrics.Burndown)
+}
+
+func TestRunStats_FlowMetrics(t *testing.T) {
+	started := time.Now().Add(-20 * 24 * time.Hour).Format(time.RFC3339)
+	projectRoot := helpers.SetupInstalledProject(t, nil, &models.State{
+		Features: []models.Feature{
+			{ID: "feature-1", Name: "Auth", Status: "in-progress", StatusHistory: []models.StatusChange{{Status: "in-progress", At: started}}},
+		},
+	})
+
+	content := exportStats(t, projectRoot, "markdown", map[string]string{"metrics": "flow"})
+	assert.Contains(t, content, "## Flow")
+	assert.Contains(t, content, "in progress for over 14 days", "a stalled feature is listed as an outlier")
+	assert.NotContains(t, content, "## Velocity Metrics")
+
+	latest, err := statistics.NewStorage(projectRoot).GetLatest()
+	require.NoError(t, err)
+	assert.Nil(t, latest.Metrics.Flow, "flow metrics are not stored in history")
+}
+
+func TestRunStats_Estimates(t *testing.T) {
+	day := func(daysAgo int) string {
+		return time.Now().Add(-time.Duration(daysAgo) * 24 * time.Hour).Format(time.RFC3339)
+	}
+	var features []models.Feature
+	for i, name := range []string{"Auth", "Billing", "Search"} {
+		features = append(features, models.Feature{ID: fmt.Sprintf("0%d", i+1), Phase: "01-phase", Name: name, Status: "complete", Duration: "2 days",
+			StatusHistory: []models.StatusChange{{Status: "in-progress", At: day(30 - i*5)}, {Status: "complete", At: day(26 - i*5)}}})
+	}
+	features = append(features, models.Feature{ID: "04", Phase: "01-phase", Name: "Export", Status: "in-progress", Duration: "1 week",
+		TargetDate: time.Now().Format("2006-01-02"), StatusHistory: []models.StatusChange{{Status: "in-progress", At: day(3)}}})
+	projectRoot := helpers.SetupInstalledProject(t, nil, &models.State{Phases: []models.Phase{{ID: "01-phase", Name: "Foundation"}}, Features: features})
+
+	content := exportStats(t, projectRoot, "markdown", map[string]string{"metrics": "estimates"})
+	assert.Contains(t, content, "## Estimates")
+	assert.Contains(t, content, "Work takes 2.0× the estimate")
+	assert.Contains(t, content, "### Suggested Target Dates")
+	assert.Contains(t, content, "| Export | "+time.Now().Format("2006-01-02")+" | ")
+	assert.NotContains(t, content, "## Flow")
+
+	latest, err := statistics.NewStorage(projectRoot).GetLatest()
+	require.NoError(t, err)
+	assert.Nil(t, latest.Metrics.Estimates, "estimates are not stored in history")
+}
+
+func TestRunStats_Hotspots(t *testing.T) {
+	projectRoot := helpers.SetupInstalledProject(t, nil, nil)
+
+	repo, err := git.PlainInit(projectRoot, false)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		helpers.CommitFiles(t, repo, "Dana", time.Now().Add(-time.Duration(3-i)*time.Hour), map[string]string{"main.go": strings.Repeat("x\n", i+1)})
+	}
+
+	content := exportStats(t, projectRoot, "html", map[string]string{"metrics": "hotspots"})
+	assert.Contains(t, content, "<h2>Hotspots</h2>")
+	assert.Contains(t, content, "<tr><td>main.go</td><td>3</td><td>+3/-0</td><td>1</td><td>Dana (100%)</td><td>-</td></tr>")
+	assert.NotContains(t, content, "Velocity")
+
+	latest, err := statistics.NewStorage(projectRoot).GetLatest()
+	require.NoError(t, err)
+	assert.Nil(t, latest.Metrics.Hotspots, "hotspots are not stored in history")
+}
+
+func TestRunStats_OpenMetricsFormat(t *testing.T) {
+	projectRoot := helpers.SetupInstalledProject(t, nil, &models.State{
+		Phases:   []models.Phase{{ID: "01-phase", Name: "Foundation", Features: []string{"01"}}},
+		Features: []models.Feature{{ID: "01", Phase: "01-phase", Name: "Auth", Status: "in-progress", Progress: 50}},
+	})
+
+	content := exportStats(t, projectRoot, "openmetrics", nil)
+	assert.Contains(t, content, "doplan_feature_progress_ratio{feature=\"01\"} 0.5\n")
+	assert.Contains(t, content, "doplan_features{status=\"in-progress\"} 1\n")
+	assert.True(t, strings.HasSuffix(content, "# EOF\n"))
+}
+
+func TestRunStats_CoverageDiff(t *testing.T) {
+	projectRoot := helpers.SetupInstalledProject(t, nil, nil)
+
+	lcovPath := filepath.Join(projectRoot, "coverage", "lcov.info")
+	require.NoError(t, os.MkdirAll(filepath.Dir(lcovPath), 0755))
+	require.NoError(t, os.WriteFile(lcovPath, []byte("SF:src/auth/login.ts\nDA:1,1\nDA:2,0\nend_of_record\n"), 0644))
+	require.NoError(t, runStats(NewStatsCommand(), []string{}))
+
+	require.NoError(t, os.WriteFile(lcovPath, []byte("SF:src/auth/login.ts\nDA:1,1\nDA:2,1\nend_of_record\n"), 0644))
+	content := exportStats(t, projectRoot, "markdown", nil)
+	assert.Contains(t, content, "- **Overall:** +50.0 pts (improving)")
+	assert.Contains(t, content, "| src/auth | 50.0% | 100.0% | +50.0 pts |")
+
+	latest, err := statistics.NewStorage(projectRoot).GetLatest()
+	require.NoError(t, err)
+	require.NotNil(t, latest.Metrics.Testing)
+	assert.Equal(t, "improving", latest.Metrics.Testing.Trend)
+	assert.Nil(t, latest.Metrics.Testing.PackageDiff, "package diffs are not stored in history")
+}
+
+func TestRunStats_TestResults(t *testing.T) {
+	cfg := config.NewConfig("cursor")
+	cfg.Stats.Coverage.Features = []models.CoverageMapping{{Feature: "01", Paths: []string{"com/example/auth/**"}}}
+	projectRoot := helpers.SetupInstalledProject(t, cfg, &models.State{Features: []models.Feature{{ID: "01", Name: "Authentication"}}})
+
+	junit := `<testsuite name="auth">
+	<testcase classname="com.example.auth.LoginTest" name="acceptsPassword" time="0.5"/>
+	<testcase classname="com.example.auth.LoginTest" name="locksAccount" time="2">
+		<failure message="expected lock"/>
+	</testcase>
+</testsuite>`
+	require.NoError(t, os.WriteFile(filepath.Join(projectRoot, "report.xml"), []byte(junit), 0644))
+
+	content := exportStats(t, projectRoot, "html", map[string]string{"test-results": "report.xml"})
+	assert.Contains(t, content, "<h2>Test Results</h2>")
+	assert.Contains(t, content, "<td>com.example.auth.LoginTest locksAccount</td><td>Authentication</td>")
+
+	latest, err := statistics.NewStorage(projectRoot).GetLatest()
+	require.NoError(t, err)
+	assert.Nil(t, latest.Metrics.Tests, "test results have their own history")
+
+	// Reports that are not test results are rejected
+	require.NoError(t, os.WriteFile(filepath.Join(projectRoot, "notes.xml"), []byte("<notes/>"), 0644))
+	cmd := NewStatsCommand()
+	require.NoError(t, cmd.Flags().Set("test-results", "notes.xml"))
+	assert.Error(t, runStats(cmd, []string{}))
+}
+
 func TestRunStats_DateRange(t *testing.T) {
 	projectRoot := helpers.SetupTestProject(t)
 
@@ -393,3 +558,41 @@ func TestRunStats_AllFormats(t *testing.T) {
 		assert.NoError(t, err, "Should handle format: %s", format)
 	}
 }
+
+func TestRunStatsBackfill(t *testing.T) {
+	projectRoot := helpers.SetupInstalledProject(t, nil, nil)
+
+	repo, err := git.PlainInit(projectRoot, false)
+	require.NoError(t, err)
+	for i, status := range []string{"in-progress", "complete"} {
+		state, err := json.Marshal(&models.State{Features: []models.Feature{{ID: "01", Name: "Login", Status: status}}})
+		require.NoError(t, err)
+		helpers.CommitFiles(t, repo, "Dana", time.Now().AddDate(0, 0, i-3), map[string]string{".doplan/state.json": string(state)})
+	}
+
+	var out strings.Builder
+	cmd := NewStatsBackfillCommand()
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+	require.NoError(t, runStatsBackfill(cmd, nil))
+	assert.Contains(t, out.String(), "0/1 features complete")
+	assert.Contains(t, out.String(), "2 day(s) would be backfilled")
+	_, err = statistics.NewStorage(projectRoot).GetLatest()
+	assert.Error(t, err, "a dry run writes nothing")
+
+	out.Reset()
+	cmd = NewStatsBackfillCommand()
+	cmd.SetOut(&out)
+	require.NoError(t, runStatsBackfill(cmd, nil))
+	assert.Contains(t, out.String(), "Backfilled 2 day(s)")
+	history, err := statistics.NewStorage(projectRoot).LoadAll()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[1].Data.State.CompletedFeatures)
+
+	out.Reset()
+	cmd = NewStatsBackfillCommand()
+	cmd.SetOut(&out)
+	require.NoError(t, runStatsBackfill(cmd, nil))
+	assert.Contains(t, out.String(), "No new days to backfill")
+}
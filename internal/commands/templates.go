@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"text/tabwriter"
@@ -11,6 +10,7 @@ import (
 	"github.com/DoPlan-dev/CLI/internal/config"
 	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
 	"github.com/DoPlan-dev/CLI/internal/template"
+	"github.com/DoPlan-dev/CLI/internal/utils"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -212,26 +212,15 @@ func runTemplatesEdit(cmd *cobra.Command, args []string) error {
 		return errHandler.Handle(doplanerror.ErrFileNotFound(templatePath).WithCause(err))
 	}
 
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		// Try common editors
-		editors := []string{"nano", "vim", "vi", "code", "subl"}
-		for _, e := range editors {
-			if _, err := exec.LookPath(e); err == nil {
-				editor = e
-				break
-			}
-		}
-		if editor == "" {
-			return errHandler.Handle(doplanerror.NewIOError("IO001", "No editor found").WithSuggestion("Set EDITOR environment variable or install a text editor"))
-		}
+	cmdExec, err := utils.EditorCommand(templatePath)
+	if err != nil {
+		return errHandler.Handle(doplanerror.NewIOError("IO001", "No editor found").WithSuggestion("Set EDITOR environment variable or install a text editor"))
 	}
 
-	color.Cyan("Opening template in %s...\n", editor)
+	color.Cyan("Opening template in %s...\n", cmdExec.Args[0])
 	color.Yellow("Template path: %s\n", templatePath)
 
 	// Open editor
-	cmdExec := exec.Command(editor, templatePath)
 	cmdExec.Stdin = os.Stdin
 	cmdExec.Stdout = os.Stdout
 	cmdExec.Stderr = os.Stderr
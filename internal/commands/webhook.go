@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// WebhookSecretEnv holds the webhook secret when --secret is not given
+const WebhookSecretEnv = "DOPLAN_WEBHOOK_SECRET"
+
+func NewWebhookCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Receive GitHub webhooks",
+		Long:  "Run a local receiver that keeps DoPlan state current from GitHub repository events",
+	}
+
+	cmd.AddCommand(NewWebhookServeCommand())
+
+	return cmd
+}
+
+func NewWebhookServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a GitHub webhook endpoint",
+		Long: `Accept signed pull_request, push, check_run and issues deliveries and update state:
+a merged PR completes its feature, pushes are recorded as activity, failed checks flag
+the feature and issues labelled with a feature ID are tracked against it.
+Progress and the dashboard are refreshed the same way as 'doplan progress'.`,
+		RunE: runWebhookServe,
+	}
+
+	cmd.Flags().IntP("port", "p", 8787, "Port to listen on")
+	cmd.Flags().String("path", "/webhook", "URL path of the webhook endpoint")
+	cmd.Flags().String("secret", "", "Webhook secret used to verify signatures (default: $"+WebhookSecretEnv+")")
+
+	return cmd
+}
+
+func runWebhookServe(cmd *cobra.Command, args []string) error {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return doplanerror.NewIOError("IO001", "Failed to get current directory").WithCause(err)
+	}
+
+	if !config.IsInstalled(projectRoot) {
+		configPath := filepath.Join(projectRoot, ".cursor", "config", "doplan-config.json")
+		return doplanerror.ErrConfigNotFound(configPath)
+	}
+
+	port, _ := cmd.Flags().GetInt("port")
+	path, _ := cmd.Flags().GetString("path")
+	secret, _ := cmd.Flags().GetString("secret")
+	if secret == "" {
+		secret = os.Getenv(WebhookSecretEnv)
+	}
+	if secret == "" {
+		return doplanerror.NewConfigError("CFG003", "Webhook secret not configured").
+			WithSuggestion(fmt.Sprintf("Pass --secret or set %s to the secret configured on the GitHub webhook", WebhookSecretEnv))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, github.NewWebhookHandler(secret, newWebhookApplier(projectRoot)))
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	color.Green("✅ Listening for GitHub webhooks on http://localhost:%d%s\n", port, path)
+	fmt.Println("Subscribe the webhook to: pull requests, pushes, check runs and issues (content type application/json).")
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return doplanerror.NewGitHubError("GH008", "Webhook server failed").WithCause(err)
+	}
+	return nil
+}
+
+// newWebhookApplier applies each event to freshly loaded state and GitHub data, then
+// saves them and refreshes progress through the same path as 'doplan progress'
+func newWebhookApplier(projectRoot string) github.WebhookApplyFunc {
+	return func(event string, body []byte) (*github.WebhookResult, error) {
+		cfgMgr := config.NewManager(projectRoot)
+		state, err := cfgMgr.LoadState()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state: %w", err)
+		}
+
+		githubSync := github.NewGitHubSync(projectRoot)
+		githubData, err := githubSync.LoadData()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load GitHub data: %w", err)
+		}
+
+		result, err := github.ApplyWebhookEvent(state, githubData, event, body, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("%s %s: %s\n", time.Now().Format("15:04:05"), event, result.Summary)
+
+		if result.DataChanged {
+			if err := githubSync.SaveData(githubData); err != nil {
+				return nil, fmt.Errorf("failed to save GitHub data: %w", err)
+			}
+		}
+		if result.Changed() {
+			if err := refreshProgress(projectRoot, cfgMgr, state); err != nil {
+				return nil, err
+			}
+		}
+
+		return result, nil
+	}
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhookCommand(t *testing.T) {
+	cmd := NewWebhookCommand()
+	assert.Equal(t, "webhook", cmd.Use)
+
+	serve, _, err := cmd.Find([]string{"serve"})
+	require.NoError(t, err)
+	assert.NotNil(t, serve.Flags().Lookup("port"))
+	assert.NotNil(t, serve.Flags().Lookup("secret"))
+}
+
+func TestRunWebhookServe_RequiresSecret(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	require.NoError(t, NewInstaller(projectRoot, "cursor").generateConfig())
+	t.Setenv(WebhookSecretEnv, "")
+
+	originalDir, _ := os.Getwd()
+	os.Chdir(projectRoot)
+	defer os.Chdir(originalDir)
+
+	err := runWebhookServe(NewWebhookServeCommand(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Webhook secret not configured")
+}
+
+func TestWebhookApplier_PullRequestMerged(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	require.NoError(t, NewInstaller(projectRoot, "cursor").generateConfig())
+
+	state := &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation", Features: []string{"01"}}},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "User Authentication", Status: "in-progress", Progress: 50, CheckpointID: "existing"},
+		},
+	}
+	require.NoError(t, config.NewManager(projectRoot).SaveState(state))
+
+	body, err := os.ReadFile(filepath.Join("..", "github", "testdata", "webhooks", "pull_request_merged.json"))
+	require.NoError(t, err)
+
+	result, err := newWebhookApplier(projectRoot)(github.EventPullRequest, body)
+	require.NoError(t, err)
+	assert.True(t, result.StateChanged)
+
+	saved, err := config.NewManager(projectRoot).LoadState()
+	require.NoError(t, err)
+	assert.Equal(t, "complete", saved.Features[0].Status)
+	assert.Equal(t, 100, saved.Features[0].Progress)
+	require.NotNil(t, saved.Features[0].PR)
+	assert.Equal(t, "merged", saved.Features[0].PR.Status)
+
+	data, err := github.NewGitHubSync(projectRoot).LoadData()
+	require.NoError(t, err)
+	require.Len(t, data.PRs, 1)
+
+	// Progress was refreshed, so the dashboard reflects the merge
+	dashboard, err := os.ReadFile(filepath.Join(projectRoot, "doplan", "dashboard.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(dashboard), "**User Authentication**: 100% - complete")
+}
@@ -131,9 +131,13 @@ func (m *Manager) loadConfigYAML(path string) (*models.Config, error) {
 		InstalledAt: time.Now(), // TODO: Load from config if available
 		Version:     viper.GetString("project.version"),
 		GitHub: models.GitHubConfig{
-			Enabled:    viper.GetBool("github.enabled"),
-			AutoBranch: viper.GetBool("github.autoBranch"),
-			AutoPR:     viper.GetBool("github.autoPR"),
+			Enabled:           viper.GetBool("github.enabled"),
+			AutoBranch:        viper.GetBool("github.autoBranch"),
+			AutoPR:            viper.GetBool("github.autoPR"),
+			BaseBranch:        viper.GetString("github.baseBranch"),
+			StaleBranchDays:   viper.GetInt("github.staleBranchDays"),
+			RequiredChecks:    viper.GetStringSlice("github.requiredChecks"),
+			ProtectedBranches: viper.GetStringSlice("github.protectedBranches"),
 		},
 		Checkpoint: models.CheckpointConfig{
 			AutoFeature:  true, // Defaults
@@ -145,6 +149,30 @@ func (m *Manager) loadConfigYAML(path string) (*models.Config, error) {
 			PRDGenerated:  false,
 			PlanGenerated: false,
 		},
+		TUI: models.TUIConfig{
+			Theme:  viper.GetString("tui.theme"),
+			Colors: viper.GetString("tui.colors"),
+		},
+	}
+
+	// Nested PR settings map directly onto the model
+	if err := viper.UnmarshalKey("github.pr", &cfg.GitHub.PR); err != nil {
+		return nil, fmt.Errorf("failed to read github.pr config: %w", err)
+	}
+	if err := viper.UnmarshalKey("stats.forecast", &cfg.Stats.Forecast); err != nil {
+		return nil, fmt.Errorf("failed to read stats.forecast config: %w", err)
+	}
+	if err := viper.UnmarshalKey("stats.coverage", &cfg.Stats.Coverage); err != nil {
+		return nil, fmt.Errorf("failed to read stats.coverage config: %w", err)
+	}
+	if err := viper.UnmarshalKey("stats.tests", &cfg.Stats.Tests); err != nil {
+		return nil, fmt.Errorf("failed to read stats.tests config: %w", err)
+	}
+	if err := viper.UnmarshalKey("stats.hotspots", &cfg.Stats.Hotspots); err != nil {
+		return nil, fmt.Errorf("failed to read stats.hotspots config: %w", err)
+	}
+	if err := viper.UnmarshalKey("gates", &cfg.Gates); err != nil {
+		return nil, fmt.Errorf("failed to read gates config: %w", err)
 	}
 
 	return cfg, nil
@@ -168,11 +196,32 @@ func (m *Manager) SaveConfigV2(cfg *models.Config) error {
 			"ide":     cfg.IDE,
 		},
 		"github": map[string]interface{}{
-			"repository": "", // TODO: Get from config
-			"enabled":    cfg.GitHub.Enabled,
-			"autoBranch": cfg.GitHub.AutoBranch,
-			"autoPR":     cfg.GitHub.AutoPR,
+			"repository":        "", // TODO: Get from config
+			"enabled":           cfg.GitHub.Enabled,
+			"autoBranch":        cfg.GitHub.AutoBranch,
+			"autoPR":            cfg.GitHub.AutoPR,
+			"baseBranch":        cfg.GitHub.BaseBranch,
+			"staleBranchDays":   cfg.GitHub.StaleBranchDays,
+			"requiredChecks":    cfg.GitHub.RequiredChecks,
+			"protectedBranches": cfg.GitHub.ProtectedBranches,
+			"pr":                prConfigYAML(cfg.GitHub.PR),
+		},
+		"stats": map[string]interface{}{
+			"forecast": map[string]interface{}{
+				"confidence":  cfg.Stats.Forecast.Confidence,
+				"simulations": cfg.Stats.Forecast.Simulations,
+				"windowDays":  cfg.Stats.Forecast.WindowDays,
+			},
+			"coverage": coverageConfigYAML(cfg.Stats.Coverage),
+			"tests": map[string]interface{}{
+				"paths": cfg.Stats.Tests.Paths,
+			},
+			"hotspots": map[string]interface{}{
+				"windowDays": cfg.Stats.Hotspots.WindowDays,
+				"exclude":    cfg.Stats.Hotspots.Exclude,
+			},
 		},
+		"gates": gatesConfigYAML(cfg.Gates),
 		"design": map[string]interface{}{
 			"hasPreferences": false,
 			"tokensPath":     "doplan/design/design-tokens.json",
@@ -185,10 +234,7 @@ func (m *Manager) SaveConfigV2(cfg *models.Config) error {
 			"configured": []string{},
 			"required":   []string{},
 		},
-		"tui": map[string]interface{}{
-			"theme":      "default",
-			"animations": true,
-		},
+		"tui": tuiConfigYAML(cfg.TUI),
 	}
 
 	data, err := yaml.Marshal(yamlConfig)
@@ -288,3 +334,71 @@ func IsInstalled(projectRoot string) bool {
 	_, err := os.Stat(oldConfigPath)
 	return err == nil
 }
+
+// coverageConfigYAML maps coverage settings to the camelCase keys used in config.yaml
+func coverageConfigYAML(coverage models.CoverageConfig) map[string]interface{} {
+	features := make([]map[string]interface{}, 0, len(coverage.Features))
+	for _, mapping := range coverage.Features {
+		features = append(features, map[string]interface{}{
+			"feature": mapping.Feature,
+			"paths":   mapping.Paths,
+		})
+	}
+
+	return map[string]interface{}{
+		"paths":    coverage.Paths,
+		"features": features,
+	}
+}
+
+// gatesConfigYAML maps quality gates to the keys used in config.yaml
+func gatesConfigYAML(gates []models.GateRule) []map[string]interface{} {
+	rules := make([]map[string]interface{}, 0, len(gates))
+	for _, gate := range gates {
+		rules = append(rules, map[string]interface{}{
+			"rule":     gate.Rule,
+			"value":    gate.Value,
+			"severity": gate.Severity,
+		})
+	}
+	return rules
+}
+
+// tuiConfigYAML maps terminal UI settings to config.yaml, writing the defaults
+// for unset values so they are easy to find and change
+func tuiConfigYAML(tui models.TUIConfig) map[string]interface{} {
+	theme, colors := tui.Theme, tui.Colors
+	if theme == "" {
+		theme = "default"
+	}
+	if colors == "" {
+		colors = "auto"
+	}
+	return map[string]interface{}{
+		"theme":      theme,
+		"colors":     colors,
+		"animations": true,
+	}
+}
+
+// prConfigYAML maps PR settings to the camelCase keys used in config.yaml
+func prConfigYAML(pr models.PRConfig) map[string]interface{} {
+	rules := make([]map[string]interface{}, 0, len(pr.Rules))
+	for _, rule := range pr.Rules {
+		rules = append(rules, map[string]interface{}{
+			"phase":     rule.Phase,
+			"feature":   rule.Feature,
+			"reviewers": rule.Reviewers,
+			"labels":    rule.Labels,
+		})
+	}
+
+	return map[string]interface{}{
+		"titleTemplate": pr.TitleTemplate,
+		"bodyTemplate":  pr.BodyTemplate,
+		"draft":         pr.Draft,
+		"reviewers":     pr.Reviewers,
+		"labels":        pr.Labels,
+		"rules":         rules,
+	}
+}
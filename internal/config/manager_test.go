@@ -115,3 +115,106 @@ func TestNewConfig_DifferentIDEs(t *testing.T) {
 		assert.Equal(t, "1.0.0", cfg.Version)
 	}
 }
+
+func TestManager_SaveConfigV2_PRSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := NewConfig("cursor")
+	cfg.GitHub.PR = models.PRConfig{
+		BodyTemplate: "team-pr.md",
+		Draft:        true,
+		Reviewers:    []string{"lead"},
+		Rules: []models.PRRule{
+			{Phase: "phase-1", Feature: "auth-*", Reviewers: []string{"security"}, Labels: []string{"auth"}},
+		},
+	}
+	require.NoError(t, NewManager(tmpDir).SaveConfigV2(cfg))
+
+	loaded, err := NewManager(tmpDir).LoadConfig()
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, "team-pr.md", loaded.GitHub.PR.BodyTemplate)
+	assert.True(t, loaded.GitHub.PR.Draft)
+	assert.Equal(t, []string{"lead"}, loaded.GitHub.PR.Reviewers)
+	require.Len(t, loaded.GitHub.PR.Rules, 1)
+	assert.Equal(t, "auth-*", loaded.GitHub.PR.Rules[0].Feature)
+	assert.Equal(t, []string{"security"}, loaded.GitHub.PR.Rules[0].Reviewers)
+}
+
+func TestManager_SaveConfigV2_RequiredChecks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := NewConfig("cursor")
+	cfg.GitHub.RequiredChecks = []string{"build", "test"}
+	require.NoError(t, NewManager(tmpDir).SaveConfigV2(cfg))
+
+	loaded, err := NewManager(tmpDir).LoadConfig()
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, []string{"build", "test"}, loaded.GitHub.RequiredChecks)
+}
+
+func TestManager_SaveConfigV2_ForecastSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := NewConfig("cursor")
+	cfg.Stats.Forecast = models.ForecastConfig{Confidence: 90, Simulations: 500, WindowDays: 14}
+	require.NoError(t, NewManager(tmpDir).SaveConfigV2(cfg))
+
+	loaded, err := NewManager(tmpDir).LoadConfig()
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, cfg.Stats.Forecast, loaded.Stats.Forecast)
+}
+
+func TestManager_SaveConfigV2_StatsSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := NewConfig("cursor")
+	cfg.Stats.Coverage = models.CoverageConfig{
+		Paths:    []string{"web/coverage/lcov.info", "services/**/jacoco.xml"},
+		Features: []models.CoverageMapping{{Feature: "01", Paths: []string{"web/src/auth/**", "internal/auth/**"}}},
+	}
+	cfg.Stats.Tests.Paths = []string{"build/test-results/**/*.xml"}
+	cfg.Stats.Hotspots = models.HotspotsConfig{WindowDays: 30, Exclude: []string{"generated/**"}}
+	require.NoError(t, NewManager(tmpDir).SaveConfigV2(cfg))
+
+	loaded, err := NewManager(tmpDir).LoadConfig()
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, cfg.Stats.Coverage, loaded.Stats.Coverage)
+	assert.Equal(t, cfg.Stats.Tests, loaded.Stats.Tests)
+	assert.Equal(t, cfg.Stats.Hotspots, loaded.Stats.Hotspots)
+}
+
+func TestManager_SaveConfigV2_Gates(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := NewConfig("cursor")
+	cfg.Gates = []models.GateRule{
+		{Rule: "min-coverage", Value: 80},
+		{Rule: "max-open-prs-per-phase", Value: 3, Severity: "warning"},
+	}
+	require.NoError(t, NewManager(tmpDir).SaveConfigV2(cfg))
+
+	loaded, err := NewManager(tmpDir).LoadConfig()
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, cfg.Gates, loaded.Gates)
+}
+
+func TestManager_SaveConfigV2_TUISettings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := NewConfig("cursor")
+	require.NoError(t, NewManager(tmpDir).SaveConfigV2(cfg))
+	loaded, err := NewManager(tmpDir).LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, models.TUIConfig{Theme: "default", Colors: "auto"}, loaded.TUI, "defaults are written out")
+
+	cfg.TUI = models.TUIConfig{Theme: "high-contrast", Colors: "16"}
+	require.NoError(t, NewManager(tmpDir).SaveConfigV2(cfg))
+	loaded, err = NewManager(tmpDir).LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.TUI, loaded.TUI)
+}
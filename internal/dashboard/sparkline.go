@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -112,11 +113,11 @@ func ColorCodeSparkline(sparkline, trendText string) string {
 
 	switch trendText {
 	case "increasing":
-		style = style.Foreground(lipgloss.Color("#10b981")) // Green
+		style = style.Foreground(theme.Success())
 	case "decreasing":
-		style = style.Foreground(lipgloss.Color("#ef4444")) // Red
+		style = style.Foreground(theme.Error())
 	default:
-		style = style.Foreground(lipgloss.Color("#f59e0b")) // Amber
+		style = style.Foreground(theme.Warning())
 	}
 
 	return style.Render(sparkline)
@@ -3,6 +3,7 @@ package generators
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/DoPlan-dev/CLI/internal/config"
 	"github.com/DoPlan-dev/CLI/internal/dashboard"
 	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/statistics"
 	"github.com/DoPlan-dev/CLI/pkg/models"
 )
 
@@ -96,6 +98,8 @@ func (g *DashboardGenerator) buildDashboardJSON() *models.DashboardJSON {
 		progressData = make(map[string]*dashboard.ProgressData)
 	}
 
+	forecasts := g.loadForecasts()
+
 	// Build phases
 	phases := []models.PhaseJSON{}
 	for _, phase := range g.state.Phases {
@@ -158,7 +162,11 @@ func (g *DashboardGenerator) buildDashboardJSON() *models.DashboardJSON {
 					Status:       feature.Status,
 					Progress:     feature.Progress,
 					Branch:       feature.Branch,
+					BaseBranch:   feature.BaseBranch,
+					Dependencies: feature.Dependencies,
 					PR:           pr,
+					Flags:        feature.Flags,
+					CI:           g.ciStatus(feature.Branch),
 					Commits:      commits,
 					LastActivity: lastActivity,
 					Tasks:        tasks,
@@ -177,7 +185,7 @@ func (g *DashboardGenerator) buildDashboardJSON() *models.DashboardJSON {
 			switch feature.Status {
 			case "complete":
 				completed++
-			case "in-progress":
+			case "in-progress", "review":
 				inProgress++
 			default:
 				todo++
@@ -207,6 +215,7 @@ func (g *DashboardGenerator) buildDashboardJSON() *models.DashboardJSON {
 				TotalTasks:     totalTasks,
 				CompletedTasks: completedTasks,
 			},
+			Forecast: phaseForecastJSON(forecasts, phase.ID),
 		})
 	}
 
@@ -239,6 +248,8 @@ func (g *DashboardGenerator) buildDashboardJSON() *models.DashboardJSON {
 		Commits:      len(g.githubData.Commits),
 		Contributors: []string{},
 		LastCommit:   "",
+		SyncedAt:     g.githubData.SyncedAt,
+		Sources:      g.githubData.Sources,
 	}
 	if len(g.githubData.Commits) > 0 {
 		githubJSON.LastCommit = g.githubData.Commits[0].Date
@@ -281,6 +292,11 @@ func (g *DashboardGenerator) buildDashboardJSON() *models.DashboardJSON {
 		})
 	}
 
+	velocity := g.calculateVelocity()
+	if forecasts != nil {
+		velocity.Forecast = forecastJSON(forecasts.Project)
+	}
+
 	return &models.DashboardJSON{
 		Version:   "1.0",
 		Generated: time.Now().Format(time.RFC3339),
@@ -312,7 +328,7 @@ func (g *DashboardGenerator) buildDashboardJSON() *models.DashboardJSON {
 			Optional:   0,
 			Completion: 0,
 		},
-		Velocity: g.calculateVelocity(),
+		Velocity: velocity,
 	}
 }
 
@@ -377,15 +393,29 @@ func (g *DashboardGenerator) generateMarkdown() string {
 				feature := g.findFeature(featureID)
 				if feature != nil {
 					sb.WriteString(fmt.Sprintf("- **%s**: %d%% - %s\n", feature.Name, feature.Progress, feature.Status))
+					for _, flag := range feature.Flags {
+						sb.WriteString(fmt.Sprintf("  - ⚠ %s\n", flag.Reason))
+					}
 				}
 			}
 			sb.WriteString("\n")
 		}
 	}
 
+	// Timeline from phase and feature dates
+	if timeline := statistics.TimelineMermaid(g.loadTimeline()); timeline != "" {
+		sb.WriteString("## Timeline\n\n")
+		sb.WriteString("```mermaid\n")
+		sb.WriteString(timeline)
+		sb.WriteString("```\n\n")
+	}
+
 	// GitHub Activity
 	sb.WriteString("## GitHub Activity\n\n")
-	if g.githubData != nil && (len(g.githubData.Branches) > 0 || len(g.githubData.Commits) > 0 || len(g.githubData.PRs) > 0) {
+	for _, line := range g.syncNotes() {
+		sb.WriteString(fmt.Sprintf("_%s_\n\n", line))
+	}
+	if g.githubData != nil && (len(g.githubData.Branches) > 0 || len(g.githubData.Commits) > 0 || len(g.githubData.PRs) > 0 || len(g.openIssues()) > 0) {
 		// Active Branches
 		if len(g.githubData.Branches) > 0 {
 			sb.WriteString("### Active Branches\n\n")
@@ -432,6 +462,17 @@ func (g *DashboardGenerator) generateMarkdown() string {
 			}
 			sb.WriteString("\n")
 		}
+
+		// Open Issues
+		if issues := g.openIssues(); len(issues) > 0 {
+			sb.WriteString("### Open Issues\n\n")
+			sb.WriteString("| # | Title | Feature |\n")
+			sb.WriteString("|---|-------|---------|\n")
+			for _, issue := range issues {
+				sb.WriteString(fmt.Sprintf("| [#%d](%s) | %s | %s |\n", issue.Number, issue.URL, issue.Title, issue.Feature))
+			}
+			sb.WriteString("\n")
+		}
 	} else {
 		sb.WriteString("_No GitHub activity yet._\n\n")
 	}
@@ -556,6 +597,11 @@ func (g *DashboardGenerator) generateHTML() string {
         .status.active { background: #4caf50; color: white; }
         .status.complete { background: #2196f3; color: white; }
         .status.in-progress { background: #ff9800; color: white; }
+        .burn-chart, .timeline-chart { max-width: 100%%; height: auto; }
+        .ci { font-size: 12px; font-weight: 600; margin-left: 6px; }
+        .ci.success { color: #2e7d32; }
+        .ci.failure { color: #c62828; }
+        .ci.pending { color: #ef6c00; }
         .next-actions {
             list-style: none;
         }
@@ -592,6 +638,16 @@ func (g *DashboardGenerator) generateHTML() string {
             %s
         </div>
         
+        <div class="section">
+            <h2>Timeline</h2>
+            %s
+        </div>
+        
+        <div class="section">
+            <h2>Burndown</h2>
+            %s
+        </div>
+        
         <div class="section">
             <h2>GitHub Activity</h2>
             %s
@@ -610,6 +666,8 @@ func (g *DashboardGenerator) generateHTML() string {
 		overallProgress,
 		overallProgress,
 		g.generatePhaseHTML(),
+		g.generateTimelineHTML(),
+		g.generateBurndownHTML(),
 		g.generateGitHubHTML(),
 		g.generateNextActionsHTML(),
 	)
@@ -728,6 +786,55 @@ func (g *DashboardGenerator) calculateVelocity() models.VelocityJSON {
 	return velocity
 }
 
+// loadForecasts simulates completion of the project and its phases with the
+// project's forecast settings, calibrated by how features overran their estimates
+func (g *DashboardGenerator) loadForecasts() *statistics.Forecasts {
+	var forecastConfig models.ForecastConfig
+	if cfg, err := config.NewManager(g.projectRoot).LoadConfig(); err == nil && cfg != nil {
+		forecastConfig = cfg.Stats.Forecast
+	}
+	now := time.Now()
+	forecasts := statistics.LoadForecasts(g.projectRoot, g.state, forecastConfig, now)
+	forecasts.Calibrate(statistics.LoadEstimateMetrics(g.projectRoot, g.state, g.githubData, now))
+	return forecasts
+}
+
+// phaseForecastJSON returns the dashboard forecast of a phase, if it has one
+func phaseForecastJSON(forecasts *statistics.Forecasts, phaseID string) *models.ForecastJSON {
+	if forecasts == nil {
+		return nil
+	}
+	for _, forecast := range forecasts.Phases {
+		if forecast.ID == phaseID {
+			return forecastJSON(forecast)
+		}
+	}
+	return nil
+}
+
+// forecastJSON converts a forecast for dashboard JSON
+func forecastJSON(forecast *statistics.Forecast) *models.ForecastJSON {
+	if forecast == nil {
+		return nil
+	}
+
+	out := &models.ForecastJSON{
+		OnTargetChance: forecast.OnTargetChance,
+		AtRisk:         forecast.AtRisk,
+		Note:           forecast.Note,
+	}
+	if forecast.HasDates() {
+		out.P50 = forecast.P50.Format("2006-01-02")
+		out.P85 = forecast.P85.Format("2006-01-02")
+		out.P95 = forecast.P95.Format("2006-01-02")
+	}
+	if !forecast.CalibratedDate.IsZero() {
+		out.Calibration = forecast.Calibration
+		out.CalibratedDate = forecast.CalibratedDate.Format("2006-01-02")
+	}
+	return out
+}
+
 // UpdateDashboard regenerates dashboard.json and related files
 // This is a convenience function that loads state and GitHub data, then generates the dashboard
 func UpdateDashboard(projectRoot string) error {
@@ -845,8 +952,8 @@ func (g *DashboardGenerator) generatePhaseHTML() string {
 			feature := g.findFeature(featureID)
 			if feature != nil {
 				sb.WriteString(fmt.Sprintf(`<div class="feature">
-                    <strong>%s</strong> - %d%% - <span class="status %s">%s</span>
-                </div>`, feature.Name, feature.Progress, strings.ToLower(feature.Status), feature.Status))
+                    <strong>%s</strong> - %d%% - <span class="status %s">%s</span>%s
+                </div>`, feature.Name, feature.Progress, strings.ToLower(feature.Status), feature.Status, g.ciBadgeHTML(feature.Branch)))
 			}
 		}
 
@@ -856,12 +963,45 @@ func (g *DashboardGenerator) generatePhaseHTML() string {
 	return sb.String()
 }
 
-func (g *DashboardGenerator) generateGitHubHTML() string {
-	if g.githubData == nil || (len(g.githubData.Branches) == 0 && len(g.githubData.Commits) == 0 && len(g.githubData.PRs) == 0) {
-		return "<p><em>No GitHub activity yet.</em></p>"
+// generateTimelineHTML draws the phase and feature dates as a gantt chart
+func (g *DashboardGenerator) generateTimelineHTML() string {
+	svg := statistics.TimelineSVG(g.loadTimeline(), 960)
+	if svg == "" {
+		return "<p><em>No phase or feature dates to chart yet.</em></p>"
+	}
+	return svg
+}
+
+// loadTimeline places phases and features by their dates and flow timings
+func (g *DashboardGenerator) loadTimeline() *statistics.Timeline {
+	return statistics.LoadTimeline(g.projectRoot, g.state, g.githubData, time.Now())
+}
+
+// generateBurndownHTML charts the project and each phase from the statistics history
+func (g *DashboardGenerator) generateBurndownHTML() string {
+	charts := statistics.LoadBurnCharts(g.projectRoot, g.state, time.Now())
+	if charts == nil {
+		return "<p><em>No features to chart yet.</em></p>"
 	}
 
 	var sb strings.Builder
+	sb.WriteString(statistics.BurnChartSVG(charts.Project, 720, 260))
+	for _, series := range charts.Phases {
+		sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", html.EscapeString(series.Name)))
+		sb.WriteString(statistics.BurnChartSVG(series, 720, 220))
+	}
+	return sb.String()
+}
+
+func (g *DashboardGenerator) generateGitHubHTML() string {
+	var sb strings.Builder
+	for _, line := range g.syncNotes() {
+		sb.WriteString(fmt.Sprintf("<p><em>%s</em></p>", html.EscapeString(line)))
+	}
+
+	if g.githubData == nil || (len(g.githubData.Branches) == 0 && len(g.githubData.Commits) == 0 && len(g.githubData.PRs) == 0 && len(g.githubData.Workflows) == 0 && len(g.openIssues()) == 0) {
+		return sb.String() + "<p><em>No GitHub activity yet.</em></p>"
+	}
 
 	// Branches
 	if len(g.githubData.Branches) > 0 {
@@ -887,9 +1027,105 @@ func (g *DashboardGenerator) generateGitHubHTML() string {
 		sb.WriteString("</table>")
 	}
 
+	// CI runs
+	if len(g.githubData.Workflows) > 0 {
+		sb.WriteString("<h3>Recent CI Runs</h3><table><tr><th>Workflow</th><th>Branch</th><th>Run</th><th>Result</th><th>Updated</th></tr>")
+		maxRuns := 10
+		if len(g.githubData.Workflows) < maxRuns {
+			maxRuns = len(g.githubData.Workflows)
+		}
+		for _, run := range g.githubData.Workflows[:maxRuns] {
+			result := run.Conclusion
+			if run.Status != "completed" {
+				result = run.Status
+			}
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td><a href=\"%s\">#%d</a></td><td><span class=\"ci %s\">%s</span></td><td>%s</td></tr>",
+				html.EscapeString(run.Name), html.EscapeString(run.Branch), run.URL, run.RunNumber, ciClass(run.Status, run.Conclusion), result, run.UpdatedAt))
+		}
+		sb.WriteString("</table>")
+	}
+
+	// Issues
+	if issues := g.openIssues(); len(issues) > 0 {
+		sb.WriteString("<h3>Open Issues</h3><table><tr><th>#</th><th>Title</th><th>Feature</th></tr>")
+		for _, issue := range issues {
+			sb.WriteString(fmt.Sprintf("<tr><td><a href=\"%s\">#%d</a></td><td>%s</td><td>%s</td></tr>",
+				issue.URL, issue.Number, html.EscapeString(issue.Title), issue.Feature))
+		}
+		sb.WriteString("</table>")
+	}
+
 	return sb.String()
 }
 
+// ciStatus summarizes CI on a feature branch, or nil when no runs were recorded
+func (g *DashboardGenerator) ciStatus(branch string) *models.CIStatus {
+	status := github.BranchCIStatus(g.githubData, branch)
+	if status.State == github.CIStateNone {
+		return nil
+	}
+	return &status
+}
+
+// ciBadgeHTML renders a feature's CI state next to its status
+func (g *DashboardGenerator) ciBadgeHTML(branch string) string {
+	status := g.ciStatus(branch)
+	if status == nil {
+		return ""
+	}
+
+	switch status.State {
+	case github.CIStateFailure:
+		return fmt.Sprintf(` <span class="ci failure" title="%s">✗ CI failing</span>`, html.EscapeString(strings.Join(status.Failing, ", ")))
+	case github.CIStatePending:
+		return ` <span class="ci pending">● CI running</span>`
+	default:
+		return ` <span class="ci success">✓ CI passing</span>`
+	}
+}
+
+func ciClass(status, conclusion string) string {
+	switch {
+	case status != "completed":
+		return github.CIStatePending
+	case github.IsFailedConclusion(conclusion):
+		return github.CIStateFailure
+	default:
+		return github.CIStateSuccess
+	}
+}
+
+// openIssues returns the open issues received through webhooks
+func (g *DashboardGenerator) openIssues() []models.Issue {
+	if g.githubData == nil {
+		return nil
+	}
+	var issues []models.Issue
+	for _, issue := range g.githubData.Issues {
+		if issue.State == "open" {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// syncNotes describes when GitHub data was last synced and which sources are served from cache
+func (g *DashboardGenerator) syncNotes() []string {
+	if g.githubData == nil || g.githubData.SyncedAt == "" {
+		return nil
+	}
+
+	now := time.Now()
+	notes := []string{fmt.Sprintf("Last synced: %s", github.FormatSyncAge(g.githubData.SyncedAt, now))}
+	for _, source := range github.SyncSources {
+		if status, ok := g.githubData.Sources[source]; ok && status.Stale {
+			notes = append(notes, fmt.Sprintf("⚠ %s served from cache (last synced %s): %s",
+				source, github.FormatSyncAge(status.LastSynced, now), status.Error))
+		}
+	}
+	return notes
+}
+
 func (g *DashboardGenerator) generateNextActionsHTML() string {
 	actions := g.getNextActions()
 	var sb strings.Builder
@@ -1,10 +1,13 @@
 package generators
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/DoPlan-dev/CLI/internal/statistics"
 	"github.com/DoPlan-dev/CLI/pkg/models"
 	"github.com/DoPlan-dev/CLI/test/helpers"
 	"github.com/stretchr/testify/assert"
@@ -173,6 +176,51 @@ func TestDashboardGenerator_generateHTML(t *testing.T) {
 	assert.Contains(t, content, "<html")
 	assert.Contains(t, content, "Phase 1")
 	assert.Contains(t, content, "Test PR")
+	assert.Contains(t, content, "No features to chart yet.")
+}
+
+func TestDashboardGenerator_generateHTML_Burndown(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	state := &models.State{
+		Phases: []models.Phase{
+			{ID: "phase-1", Name: "Phase 1", Features: []string{"feature-1"}, TargetDate: time.Now().AddDate(0, 0, 14).Format("2006-01-02")},
+		},
+		Features: []models.Feature{
+			{ID: "feature-1", Phase: "phase-1", Name: "Feature 1", TaskPhases: []models.TaskPhase{helpers.TaskPhase("Build", true, false)}},
+		},
+	}
+
+	content := NewDashboardGenerator(projectRoot, state, &models.GitHubData{}).generateHTML()
+
+	assert.Contains(t, content, "<h2>Burndown</h2>")
+	assert.Contains(t, content, `aria-label="Burndown for Project"`)
+	assert.Contains(t, content, `aria-label="Burndown for Phase 1"`)
+	assert.Contains(t, content, `class="target"`)
+}
+
+func TestDashboardGenerator_Timeline(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	start := time.Now().AddDate(0, 0, -3)
+	state := &models.State{
+		Phases: []models.Phase{{ID: "phase-1", Name: "Phase 1", Features: []string{"feature-1"}}},
+		Features: []models.Feature{
+			{ID: "feature-1", Phase: "phase-1", Name: "Feature 1", StartDate: start.Format("2006-01-02"), Duration: "2 weeks"},
+		},
+	}
+	gen := NewDashboardGenerator(projectRoot, state, &models.GitHubData{})
+
+	markdown := gen.generateMarkdown()
+	assert.Contains(t, markdown, "## Timeline\n\n```mermaid\ngantt\n")
+	assert.Contains(t, markdown, fmt.Sprintf("    Feature 1 :%s, %s\n", start.Format("2006-01-02"), start.AddDate(0, 0, 14).Format("2006-01-02")))
+
+	html := gen.generateHTML()
+	assert.Contains(t, html, "<h2>Timeline</h2>")
+	assert.Contains(t, html, `<svg class="timeline-chart"`)
+
+	// Without dates there is nothing to chart
+	state.Features[0].StartDate, state.Features[0].Duration = "", ""
+	assert.NotContains(t, gen.generateMarkdown(), "## Timeline")
+	assert.Contains(t, gen.generateHTML(), "No phase or feature dates to chart yet.")
 }
 
 func TestDashboardGenerator_generateMarkdown_WithGitHubData(t *testing.T) {
@@ -197,3 +245,73 @@ func TestDashboardGenerator_generateMarkdown_WithGitHubData(t *testing.T) {
 	assert.Contains(t, content, "Test commit")
 	assert.Contains(t, content, "Test PR")
 }
+
+func TestDashboardGenerator_generateHTML_CIStatus(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	state := &models.State{
+		Phases: []models.Phase{{ID: "phase-1", Name: "Phase 1", Status: "in-progress", Features: []string{"auth"}}},
+		Features: []models.Feature{
+			{ID: "auth", Phase: "phase-1", Name: "Auth", Status: "in-progress", Branch: "feature/auth"},
+		},
+	}
+	githubData := &models.GitHubData{
+		Checks: []models.CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "failure", Branch: "feature/auth", HeadSHA: "abc"},
+		},
+		Workflows: []models.WorkflowRun{
+			{ID: 9, Name: "CI", Status: "completed", Conclusion: "failure", Branch: "feature/auth", RunNumber: 4, URL: "https://github.com/test/repo/actions/runs/9"},
+		},
+	}
+
+	gen := NewDashboardGenerator(projectRoot, state, githubData)
+	content := gen.generateHTML()
+	assert.Contains(t, content, `<span class="ci failure" title="build, CI">✗ CI failing</span>`)
+	assert.Contains(t, content, "Recent CI Runs")
+
+	dashboard := gen.buildDashboardJSON()
+	require.Len(t, dashboard.Phases, 1)
+	require.Len(t, dashboard.Phases[0].Features, 1)
+	require.NotNil(t, dashboard.Phases[0].Features[0].CI)
+	assert.Equal(t, "failure", dashboard.Phases[0].Features[0].CI.State)
+}
+
+func TestDashboardGenerator_buildDashboardJSON_Forecast(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	state := &models.State{
+		Phases: []models.Phase{
+			{ID: "phase-1", Name: "Phase 1", Features: []string{"feature-1"}},
+			{ID: "phase-2", Name: "Phase 2"},
+		},
+		Features: []models.Feature{
+			{ID: "feature-1", Phase: "phase-1", Name: "Feature 1", TaskPhases: []models.TaskPhase{helpers.TaskPhase("Build", false)}},
+		},
+	}
+
+	dashboardJSON := NewDashboardGenerator(projectRoot, state, &models.GitHubData{}).buildDashboardJSON()
+
+	require.NotNil(t, dashboardJSON.Velocity.Forecast)
+	assert.Equal(t, "not enough history yet", dashboardJSON.Velocity.Forecast.Note)
+	require.NotNil(t, dashboardJSON.Phases[0].Forecast)
+	assert.False(t, dashboardJSON.Phases[0].Forecast.AtRisk)
+	assert.Nil(t, dashboardJSON.Phases[1].Forecast, "phases without features are not forecast")
+}
+
+func TestForecastJSON_Calibrated(t *testing.T) {
+	forecast := &statistics.Forecast{
+		P50:            time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		P85:            time.Date(2026, 4, 8, 0, 0, 0, 0, time.UTC),
+		P95:            time.Date(2026, 4, 12, 0, 0, 0, 0, time.UTC),
+		OnTargetChance: 70,
+		Calibration:    1.4,
+		CalibratedDate: time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	out := forecastJSON(forecast)
+	assert.Equal(t, "2026-04-01", out.P50)
+	assert.Equal(t, 1.4, out.Calibration)
+	assert.Equal(t, "2026-04-20", out.CalibratedDate)
+
+	forecast.CalibratedDate = time.Time{}
+	assert.Empty(t, forecastJSON(forecast).CalibratedDate, "uncalibrated forecasts leave the calibration out")
+	assert.Zero(t, forecastJSON(forecast).Calibration)
+}
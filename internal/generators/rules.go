@@ -536,6 +536,23 @@ refactor: simplify authentication logic
 test: add unit tests for auth service
 ` + codeBlock + `
 
+### Scopes
+
+Use the feature (kebab-case name or ID) or phase the commit belongs to as the scope:
+` + backtick + `feat(user-authentication): add login form` + backtick + `
+
+### Completing Tasks From Commits
+
+Add a ` + backtick + `Completes:` + backtick + ` trailer to tick a task in tasks.md (tasks are numbered from 1 in file order):
+
+` + codeBlock + `
+feat(user-authentication): add login form
+
+Completes: 02-phase/03-Feature#4
+` + codeBlock + `
+
+Run ` + backtick + `doplan hooks install` + backtick + ` to enforce these rules and process trailers automatically.
+
 ## Automatic Commit Workflow
 
 ### During Feature Development
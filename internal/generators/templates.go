@@ -3,6 +3,9 @@ package generators
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/release"
 )
 
 // TemplatesGenerator generates template files
@@ -26,9 +29,12 @@ func (g *TemplatesGenerator) Generate() error {
 	}
 
 	templates := map[string]string{
-		"plan-template.md":   getPlanTemplate(),
-		"design-template.md": getDesignTemplate(),
-		"tasks-template.md":  getTasksTemplate(),
+		"plan-template.md":     getPlanTemplate(),
+		"design-template.md":   getDesignTemplate(),
+		"tasks-template.md":    getTasksTemplate(),
+		release.NotesTemplate:  release.DefaultNotesTemplate,
+		github.PRTitleTemplate: github.DefaultPRTitleTemplate,
+		github.PRBodyTemplate:  github.DefaultPRBodyTemplate,
 	}
 
 	for filename, content := range templates {
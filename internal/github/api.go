@@ -0,0 +1,192 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/go-git/go-git/v5"
+)
+
+// DefaultAPIURL is the GitHub REST API endpoint
+const DefaultAPIURL = "https://api.github.com"
+
+// APIClient calls the GitHub REST API with conditional requests
+type APIClient struct {
+	baseURL string
+	token   string
+	repo    string // owner/name
+	client  *http.Client
+}
+
+// ConditionalResult carries the validators returned by a conditional request
+type ConditionalResult struct {
+	ETag         string
+	LastModified string
+	NotModified  bool // 304: the cached copy is still current
+}
+
+// NewAPIClient creates a client for the project's repository, authenticating with
+// GH_TOKEN, GITHUB_TOKEN or the GitHub CLI's stored token
+func NewAPIClient(projectRoot string) (*APIClient, error) {
+	repo := repositorySlug(projectRoot)
+	if repo == "" {
+		return nil, fmt.Errorf("GitHub repository not configured")
+	}
+
+	token := apiToken()
+	if token == "" {
+		return nil, fmt.Errorf("no GitHub token: set GH_TOKEN or run 'gh auth login'")
+	}
+
+	return &APIClient{
+		baseURL: DefaultAPIURL,
+		token:   token,
+		repo:    repo,
+		client:  &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+// ListPullRequests lists the repository's most recently updated pull requests.
+// When etag or lastModified match the server's copy, NotModified is set and no PRs are returned.
+func (c *APIClient) ListPullRequests(etag, lastModified string) ([]models.PullRequest, *ConditionalResult, error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls?state=all&sort=updated&direction=desc&per_page=100", c.baseURL, c.repo)
+
+	var payload []pullRequestPayload
+	result, err := c.getConditional(url, etag, lastModified, &payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prs := make([]models.PullRequest, 0, len(payload))
+	for _, p := range payload {
+		prs = append(prs, models.PullRequest{
+			Number:    p.Number,
+			Title:     p.Title,
+			URL:       p.HTMLURL,
+			Status:    p.status(),
+			Branch:    p.Head.Ref,
+			Draft:     p.Draft,
+			CreatedAt: p.CreatedAt,
+			MergedAt:  p.mergedAt(),
+		})
+	}
+	return prs, result, nil
+}
+
+func (c *APIClient) getConditional(url, etag, lastModified string, v interface{}) (*ConditionalResult, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GitHub API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result := &ConditionalResult{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		result.NotModified = true
+		if result.ETag == "" {
+			result.ETag = etag
+		}
+		if result.LastModified == "" {
+			result.LastModified = lastModified
+		}
+		return result, nil
+	case resp.StatusCode >= 300:
+		return nil, fmt.Errorf("GitHub API returned %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub API response: %w", err)
+	}
+	return result, nil
+}
+
+// pullRequestPayload is the subset of the REST pull request object DoPlan reads
+type pullRequestPayload struct {
+	Number    int     `json:"number"`
+	Title     string  `json:"title"`
+	HTMLURL   string  `json:"html_url"`
+	State     string  `json:"state"`
+	Draft     bool    `json:"draft"`
+	CreatedAt string  `json:"created_at"`
+	MergedAt  *string `json:"merged_at"`
+	Head      struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+// status returns the state in the same form as 'gh pr list' (OPEN, CLOSED, MERGED)
+func (p pullRequestPayload) status() string {
+	if p.MergedAt != nil && *p.MergedAt != "" {
+		return "MERGED"
+	}
+	return strings.ToUpper(p.State)
+}
+
+// mergedAt returns the merge time, or "" for unmerged pull requests
+func (p pullRequestPayload) mergedAt() string {
+	if p.MergedAt == nil {
+		return ""
+	}
+	return *p.MergedAt
+}
+
+// repositorySlug returns owner/name from config.yaml, falling back to the origin remote
+func repositorySlug(projectRoot string) string {
+	if repo := extractUserRepo(getRepositoryFromConfig(projectRoot)); repo != "" {
+		return strings.TrimSuffix(repo, ".git")
+	}
+
+	repo, err := git.PlainOpen(projectRoot)
+	if err != nil {
+		return ""
+	}
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(extractUserRepo(remote.Config().URLs[0]), ".git")
+}
+
+func apiToken() string {
+	for _, name := range []string{"GH_TOKEN", "GITHUB_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+	output, err := exec.Command("gh", "auth", "token").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
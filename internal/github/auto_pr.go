@@ -28,28 +28,60 @@ func NewAutoPRManager(repoPath string) *AutoPRManager {
 	}
 }
 
-// CheckAndCreatePR checks if feature is complete and creates PR if needed
+// CheckAndCreatePR checks if feature is complete and creates PR if needed.
+// With draft PRs enabled, in-progress features get a draft PR that is marked
+// ready for review once the feature completes and its required checks pass.
 func (aprm *AutoPRManager) CheckAndCreatePR(feature *models.Feature) error {
-	if !aprm.config.GitHub.Enabled || !aprm.config.GitHub.AutoPR {
+	if aprm.config == nil || !aprm.config.GitHub.Enabled || !aprm.config.GitHub.AutoPR {
 		return nil // AutoPR disabled
 	}
 
-	if feature.PR != nil && feature.PR.URL != "" {
-		return nil // PR already exists
-	}
-
 	// Check if feature is complete
 	isComplete, err := aprm.isFeatureComplete(feature)
 	if err != nil {
 		return fmt.Errorf("failed to check feature completion: %w", err)
 	}
 
-	if !isComplete {
-		return nil // Feature not complete yet
+	if feature.PR != nil && feature.PR.URL != "" {
+		if feature.PR.Draft && isComplete {
+			if failing := aprm.failingRequiredChecks(feature); len(failing) > 0 {
+				color.Yellow("⚠️  Keeping PR for feature '%s' in draft: required checks failing: %s\n", feature.Name, strings.Join(failing, ", "))
+				return nil
+			}
+			return aprm.markPRReady(feature)
+		}
+		return nil // PR already exists
 	}
 
-	// Create PR
-	return aprm.createPRForFeature(feature)
+	if isComplete {
+		return aprm.createPRForFeature(feature)
+	}
+
+	if aprm.config.GitHub.PR.Draft && feature.Status == "in-progress" && feature.Branch != "" {
+		return aprm.createPR(feature, true)
+	}
+
+	return nil // Feature not complete yet
+}
+
+// RequestReview opens a pull request for a feature moved to review, or marks its
+// draft PR ready for review. Unlike CheckAndCreatePR it does not wait for the
+// feature to complete, but it still needs GitHub integration enabled and the
+// required checks passing.
+func (aprm *AutoPRManager) RequestReview(feature *models.Feature) error {
+	if aprm.config == nil || !aprm.config.GitHub.Enabled {
+		return fmt.Errorf("GitHub integration is disabled")
+	}
+	if feature.PR != nil && feature.PR.URL != "" && !feature.PR.Draft {
+		return nil // PR already open for review
+	}
+	if failing := aprm.failingRequiredChecks(feature); len(failing) > 0 {
+		return fmt.Errorf("required checks failing: %s", strings.Join(failing, ", "))
+	}
+	if feature.PR != nil && feature.PR.URL != "" {
+		return aprm.markPRReady(feature)
+	}
+	return aprm.createPR(feature, false)
 }
 
 func (aprm *AutoPRManager) isFeatureComplete(feature *models.Feature) (bool, error) {
@@ -64,7 +96,8 @@ func (aprm *AutoPRManager) isFeatureComplete(feature *models.Feature) (bool, err
 	}
 
 	// Check tasks.md file
-	featureDir := filepath.Join(aprm.repoPath, "doplan", feature.Phase, feature.ID)
+	state, _ := config.NewManager(aprm.repoPath).LoadState()
+	featureDir := filepath.Join(aprm.repoPath, filepath.FromSlash(FeatureDir(aprm.repoPath, state, feature)))
 	tasksPath := filepath.Join(featureDir, "tasks.md")
 
 	if _, err := os.Stat(tasksPath); os.IsNotExist(err) {
@@ -92,61 +125,127 @@ func (aprm *AutoPRManager) isFeatureComplete(feature *models.Feature) (bool, err
 	return totalTasks > 0 && completedTasks == totalTasks, nil
 }
 
+// createPRForFeature opens the PR for a complete feature. While required checks
+// fail it is opened as a draft, which CheckAndCreatePR marks ready once they pass.
 func (aprm *AutoPRManager) createPRForFeature(feature *models.Feature) error {
+	if failing := aprm.failingRequiredChecks(feature); len(failing) > 0 {
+		color.Yellow("⚠️  Opening PR for feature '%s' as a draft: required checks failing: %s\n", feature.Name, strings.Join(failing, ", "))
+		return aprm.createPR(feature, true)
+	}
+	return aprm.createPR(feature, false)
+}
+
+func (aprm *AutoPRManager) createPR(feature *models.Feature, draft bool) error {
 	if feature.Branch == "" {
 		return fmt.Errorf("feature branch not set")
 	}
 
-	prManager := NewPRManager(aprm.repoPath)
-
-	// Generate PR title
-	title := fmt.Sprintf("Feature: %s", feature.Name)
+	cfgMgr := config.NewManager(aprm.repoPath)
+	state, _ := cfgMgr.LoadState()
 
-	// Generate PR body
-	planPath := fmt.Sprintf("doplan/%s/%s/plan.md", feature.Phase, feature.ID)
-	designPath := fmt.Sprintf("doplan/%s/%s/design.md", feature.Phase, feature.ID)
-	tasksPath := fmt.Sprintf("doplan/%s/%s/tasks.md", feature.Phase, feature.ID)
+	// Render title and body from doplan/templates
+	content, err := RenderPR(aprm.repoPath, aprm.config, state, feature)
+	if err != nil {
+		return fmt.Errorf("failed to render PR: %w", err)
+	}
 
-	body := GeneratePRBody(feature.Name, planPath, designPath, tasksPath)
+	prManager := NewPRManager(aprm.repoPath)
 
-	// Create PR
-	prURL, err := prManager.CreatePullRequest(
-		feature.Branch,
-		title,
-		body,
-		"main", // base branch
-	)
+	// Stacked features target their parent's branch
+	prURL, err := prManager.CreatePullRequestWithOptions(PullRequestOptions{
+		Head:      feature.Branch,
+		Base:      StackBase(state, feature, aprm.baseBranch()),
+		Title:     content.Title,
+		Body:      content.Body,
+		Draft:     draft,
+		Reviewers: content.Reviewers,
+		Labels:    content.Labels,
+	})
 
 	if err != nil {
 		return fmt.Errorf("failed to create PR: %w", err)
 	}
 
-	color.Green("✅ Created PR for feature '%s': %s\n", feature.Name, prURL)
+	if draft {
+		color.Green("✅ Created draft PR for feature '%s': %s\n", feature.Name, prURL)
+	} else {
+		color.Green("✅ Created PR for feature '%s': %s\n", feature.Name, prURL)
+	}
 
 	// Update feature with PR info
 	feature.PR = &models.PullRequest{
 		URL:    prURL,
-		Title:  title,
+		Title:  content.Title,
 		Status: "open",
+		Branch: feature.Branch,
+		Draft:  draft,
 	}
 
-	// Save updated state
+	aprm.saveFeaturePR(feature)
+
+	return nil
+}
+
+// failingRequiredChecks returns the required checks failing on the feature branch,
+// as last recorded by 'doplan github' or the webhook receiver
+func (aprm *AutoPRManager) failingRequiredChecks(feature *models.Feature) []string {
+	data, err := NewGitHubSync(aprm.repoPath).LoadData()
+	if err != nil {
+		return nil
+	}
+	return FailingRequiredChecks(BranchCIStatus(data, feature.Branch), aprm.config.GitHub.RequiredChecks)
+}
+
+// markPRReady refreshes a draft PR's body with the final task list and marks it ready for review
+func (aprm *AutoPRManager) markPRReady(feature *models.Feature) error {
+	prManager := NewPRManager(aprm.repoPath)
+
 	cfgMgr := config.NewManager(aprm.repoPath)
-	state, err := cfgMgr.LoadState()
-	if err == nil {
-		// Update feature in state
-		for i := range state.Features {
-			if state.Features[i].ID == feature.ID {
-				state.Features[i].PR = feature.PR
-				cfgMgr.SaveState(state)
-				break
-			}
+	state, _ := cfgMgr.LoadState()
+
+	if content, err := RenderPR(aprm.repoPath, aprm.config, state, feature); err == nil {
+		if err := prManager.UpdateBody(feature.PR.URL, content.Body); err != nil {
+			color.Yellow("⚠️  Could not update PR body for feature '%s': %v\n", feature.Name, err)
 		}
 	}
 
+	if err := prManager.MarkReady(feature.PR.URL); err != nil {
+		return err
+	}
+
+	color.Green("✅ Marked PR ready for review for feature '%s': %s\n", feature.Name, feature.PR.URL)
+
+	feature.PR.Draft = false
+	aprm.saveFeaturePR(feature)
+
 	return nil
 }
 
+// saveFeaturePR stores the feature's PR info in state
+func (aprm *AutoPRManager) saveFeaturePR(feature *models.Feature) {
+	cfgMgr := config.NewManager(aprm.repoPath)
+	state, err := cfgMgr.LoadState()
+	if err != nil {
+		return
+	}
+
+	// Update feature in state
+	for i := range state.Features {
+		if state.Features[i].ID == feature.ID {
+			state.Features[i].PR = feature.PR
+			cfgMgr.SaveState(state)
+			break
+		}
+	}
+}
+
+func (aprm *AutoPRManager) baseBranch() string {
+	if aprm.config != nil && aprm.config.GitHub.BaseBranch != "" {
+		return aprm.config.GitHub.BaseBranch
+	}
+	return "main"
+}
+
 // WatchFeatures monitors features and creates PRs when complete
 func (aprm *AutoPRManager) WatchFeatures(state *models.State) error {
 	created := 0
@@ -350,3 +350,192 @@ func TestAutoPRManager_WatchFeatures_PartialFailures(t *testing.T) {
 	// Should not error - partial failures are handled gracefully
 	assert.NoError(t, err)
 }
+
+func TestAutoPRManager_CheckAndCreatePR_DraftForInProgress(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+
+	cfgMgr := config.NewManager(projectRoot)
+	cfg := config.NewConfig("cursor")
+	cfg.GitHub.Enabled = true
+	cfg.GitHub.AutoPR = true
+	cfg.GitHub.PR.Draft = true
+	require.NoError(t, cfgMgr.SaveConfig(cfg))
+
+	mgr := NewAutoPRManager(projectRoot)
+	feature := &models.Feature{
+		ID:       "feat-1",
+		Name:     "Test Feature",
+		Progress: 40,
+		Status:   "in-progress",
+		Branch:   "feature/test-branch",
+	}
+
+	// A draft PR is attempted; it fails because GitHub CLI is not available
+	err := mgr.CheckAndCreatePR(feature)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create PR")
+	assert.Nil(t, feature.PR)
+}
+
+func TestAutoPRManager_CheckAndCreatePR_DraftBecomesReady(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+
+	cfgMgr := config.NewManager(projectRoot)
+	cfg := config.NewConfig("cursor")
+	cfg.GitHub.Enabled = true
+	cfg.GitHub.AutoPR = true
+	cfg.GitHub.PR.Draft = true
+	require.NoError(t, cfgMgr.SaveConfig(cfg))
+
+	featureDir := filepath.Join(projectRoot, "doplan", "phase-1", "feat-1")
+	require.NoError(t, os.MkdirAll(featureDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(featureDir, "tasks.md"), []byte("- [x] Task 1\n"), 0644))
+
+	mgr := NewAutoPRManager(projectRoot)
+	feature := &models.Feature{
+		ID:       "feat-1",
+		Phase:    "phase-1",
+		Name:     "Test Feature",
+		Progress: 100,
+		Status:   "complete",
+		Branch:   "feature/test-branch",
+		PR:       &models.PullRequest{URL: "https://github.com/test/repo/pull/1", Draft: true},
+	}
+
+	// Marking ready fails without GitHub CLI; the PR stays a draft
+	err := mgr.CheckAndCreatePR(feature)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to mark PR ready")
+	assert.True(t, feature.PR.Draft)
+}
+
+func TestAutoPRManager_CheckAndCreatePR_DraftBlockedByFailingChecks(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+
+	cfgMgr := config.NewManager(projectRoot)
+	cfg := config.NewConfig("cursor")
+	cfg.GitHub.Enabled = true
+	cfg.GitHub.AutoPR = true
+	cfg.GitHub.PR.Draft = true
+	cfg.GitHub.RequiredChecks = []string{"build"}
+	require.NoError(t, cfgMgr.SaveConfig(cfg))
+
+	featureDir := filepath.Join(projectRoot, "doplan", "phase-1", "feat-1")
+	require.NoError(t, os.MkdirAll(featureDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(featureDir, "tasks.md"), []byte("- [x] Task 1\n"), 0644))
+
+	require.NoError(t, NewGitHubSync(projectRoot).SaveData(&models.GitHubData{
+		Checks: []models.CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "failure", Branch: "feature/test-branch", HeadSHA: "abc"},
+			{Name: "lint", Status: "completed", Conclusion: "success", Branch: "feature/test-branch", HeadSHA: "abc"},
+		},
+	}))
+
+	mgr := NewAutoPRManager(projectRoot)
+	feature := &models.Feature{
+		ID:       "feat-1",
+		Phase:    "phase-1",
+		Name:     "Test Feature",
+		Progress: 100,
+		Status:   "complete",
+		Branch:   "feature/test-branch",
+		PR:       &models.PullRequest{URL: "https://github.com/test/repo/pull/1", Draft: true},
+	}
+
+	// The failing required check stops the manager before it calls the GitHub CLI
+	err := mgr.CheckAndCreatePR(feature)
+	assert.NoError(t, err)
+	assert.True(t, feature.PR.Draft)
+}
+
+func TestAutoPRManager_CheckAndCreatePR_CompleteWithFailingChecks(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+
+	// A stand-in GitHub CLI records its arguments
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	gh := "#!/bin/sh\necho \"$@\" > " + argsFile + "\necho https://github.com/test/repo/pull/1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "gh"), []byte(gh), 0755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	cfgMgr := config.NewManager(projectRoot)
+	cfg := config.NewConfig("cursor")
+	cfg.GitHub.Enabled = true
+	cfg.GitHub.AutoPR = true
+	cfg.GitHub.RequiredChecks = []string{"build"}
+	require.NoError(t, cfgMgr.SaveConfig(cfg))
+
+	featureDir := filepath.Join(projectRoot, "doplan", "phase-1", "feat-1")
+	require.NoError(t, os.MkdirAll(featureDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(featureDir, "tasks.md"), []byte("- [x] Task 1\n"), 0644))
+
+	require.NoError(t, NewGitHubSync(projectRoot).SaveData(&models.GitHubData{
+		Checks: []models.CheckRun{{Name: "build", Status: "completed", Conclusion: "failure", Branch: "feature/test-branch", HeadSHA: "abc"}},
+	}))
+
+	feature := &models.Feature{
+		ID:       "feat-1",
+		Phase:    "phase-1",
+		Name:     "Test Feature",
+		Progress: 100,
+		Status:   "complete",
+		Branch:   "feature/test-branch",
+	}
+
+	// Drafts are off, but the complete feature's PR waits for its checks as a draft
+	require.NoError(t, NewAutoPRManager(projectRoot).CheckAndCreatePR(feature))
+	require.NotNil(t, feature.PR)
+	assert.True(t, feature.PR.Draft)
+	args, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(args), "--draft")
+}
+
+func TestAutoPRManager_RequestReview(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+
+	cfgMgr := config.NewManager(projectRoot)
+	cfg := config.NewConfig("cursor")
+	require.NoError(t, cfgMgr.SaveConfig(cfg))
+
+	feature := &models.Feature{ID: "feat-1", Name: "Test Feature", Status: "review", Branch: "feature/test-branch"}
+	err := NewAutoPRManager(projectRoot).RequestReview(feature)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disabled")
+
+	cfg.GitHub.Enabled = true
+	require.NoError(t, cfgMgr.SaveConfig(cfg))
+	mgr := NewAutoPRManager(projectRoot)
+
+	// An open PR is left alone; creating or readying one needs the GitHub CLI
+	feature.PR = &models.PullRequest{URL: "https://github.com/test/repo/pull/1"}
+	assert.NoError(t, mgr.RequestReview(feature))
+
+	feature.PR.Draft = true
+	err = mgr.RequestReview(feature)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to mark PR ready")
+
+	feature.PR = nil
+	err = mgr.RequestReview(feature)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create PR")
+	assert.Nil(t, feature.PR)
+
+	// Failing required checks stop the review before the GitHub CLI is called
+	cfg.GitHub.RequiredChecks = []string{"build"}
+	require.NoError(t, cfgMgr.SaveConfig(cfg))
+	require.NoError(t, NewGitHubSync(projectRoot).SaveData(&models.GitHubData{
+		Checks: []models.CheckRun{{Name: "build", Status: "completed", Conclusion: "failure", Branch: "feature/test-branch", HeadSHA: "abc"}},
+	}))
+	mgr = NewAutoPRManager(projectRoot)
+
+	err = mgr.RequestReview(feature)
+	assert.EqualError(t, err, "required checks failing: build")
+	assert.Nil(t, feature.PR)
+
+	feature.PR = &models.PullRequest{URL: "https://github.com/test/repo/pull/1", Draft: true}
+	err = mgr.RequestReview(feature)
+	assert.EqualError(t, err, "required checks failing: build")
+	assert.True(t, feature.PR.Draft)
+}
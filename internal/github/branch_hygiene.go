@@ -0,0 +1,650 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// Branch statuses reported by the branch analyzer
+const (
+	BranchStatusActive   = "active"
+	BranchStatusBehind   = "behind"
+	BranchStatusDiverged = "diverged"
+	BranchStatusStale    = "stale"
+	BranchStatusMerged   = "merged"
+	BranchStatusOrphan   = "orphan"
+	BranchStatusBase     = "base"
+)
+
+// How Analyze found a branch merged, recorded in models.Branch.MergedBy
+const (
+	MergedByPR       = "pr"       // Its pull request was merged
+	MergedBySquash   = "squash"   // Its changes were squashed into the base branch
+	MergedByAncestry = "ancestry" // Its tip is in the base branch's history
+)
+
+// DefaultStaleBranchDays is used when the config does not set github.staleBranchDays
+const DefaultStaleBranchDays = 30
+
+// DefaultProtectedBranches is used when the config does not set github.protectedBranches
+var DefaultProtectedBranches = []string{"main", "master", "develop", "release/*"}
+
+// BranchAnalyzer computes branch hygiene information using go-git
+type BranchAnalyzer struct {
+	repoPath string
+	repo     *git.Repository
+	now      func() time.Time
+
+	mergeTreeSupported *bool // Cached result of hasMergeTreeWriteTree
+}
+
+// BranchAnalysisOptions controls how branches are classified
+type BranchAnalysisOptions struct {
+	BaseBranch string               // Defaults to main, then master, then HEAD
+	StaleAfter time.Duration        // Defaults to DefaultStaleBranchDays
+	State      *models.State        // Used for orphan detection; nil disables it
+	PRs        []models.PullRequest // Merged PRs mark their branches merged
+	Protected  []string             // Branch name globs; defaults to DefaultProtectedBranches
+}
+
+// NewBranchAnalyzer creates a new branch analyzer
+func NewBranchAnalyzer(repoPath string) (*BranchAnalyzer, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	return &BranchAnalyzer{
+		repoPath: repoPath,
+		repo:     repo,
+		now:      time.Now,
+	}, nil
+}
+
+// DefaultBaseBranch returns main or master if present, otherwise the current branch
+func (ba *BranchAnalyzer) DefaultBaseBranch() (string, error) {
+	for _, name := range []string{"main", "master"} {
+		if _, err := ba.resolveBranch(name); err == nil {
+			return name, nil
+		}
+	}
+
+	head, err := ba.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	return head.Name().Short(), nil
+}
+
+// Analyze returns every local branch (plus remote-only origin branches) with
+// ahead/behind counts against the base branch and a hygiene status.
+//
+// A branch is merged when its pull request in opts.PRs was merged. Without one,
+// only feature branches count as merged, when their tip is in the base branch's
+// history or their changes were squashed into it; a branch with no commits of its
+// own is just as likely to be new. The base and protected branches never are.
+func (ba *BranchAnalyzer) Analyze(opts BranchAnalysisOptions) ([]models.Branch, error) {
+	if opts.BaseBranch == "" {
+		base, err := ba.DefaultBaseBranch()
+		if err != nil {
+			return nil, err
+		}
+		opts.BaseBranch = base
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = DefaultStaleBranchDays * 24 * time.Hour
+	}
+	if len(opts.Protected) == 0 {
+		opts.Protected = DefaultProtectedBranches
+	}
+
+	baseHash, err := ba.resolveBranch(opts.BaseBranch)
+	if err != nil {
+		return nil, fmt.Errorf("base branch %s not found: %w", opts.BaseBranch, err)
+	}
+	baseCommit, err := ba.repo.CommitObject(baseHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base branch %s: %w", opts.BaseBranch, err)
+	}
+	baseAncestors, err := ba.ancestors(baseHash)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := ba.branchRefs()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	branches := make([]models.Branch, 0, len(names))
+	for _, name := range names {
+		hash := refs[name]
+		commit, err := ba.repo.CommitObject(hash)
+		if err != nil {
+			continue
+		}
+
+		branch := models.Branch{
+			Name: name,
+			LastCommit: &models.Commit{
+				Hash:    hash.String(),
+				Message: firstLine(commit.Message),
+				Author:  commit.Author.Name,
+				Date:    commit.Committer.When.Format(time.RFC3339),
+				Branch:  name,
+			},
+		}
+
+		if name == opts.BaseBranch {
+			branch.Status = BranchStatusBase
+			branch.CommitCount = len(baseAncestors)
+			branches = append(branches, branch)
+			continue
+		}
+
+		if err := ba.countAheadBehind(commit, baseAncestors, &branch); err != nil {
+			return nil, err
+		}
+
+		branch.Protected = isProtectedBranch(name, opts.Protected)
+		isFeatureBranch := strings.HasPrefix(name, featureBranchPrefix)
+
+		var feature *models.Feature
+		if opts.State != nil {
+			if feature = FeatureForBranch(opts.State, name); feature != nil {
+				branch.Feature = feature.ID
+			} else if isFeatureBranch && !branch.Protected {
+				branch.Orphan = true
+			}
+		}
+
+		switch {
+		case branch.Protected:
+			// Never merged, so never pruned
+		case hasMergedPR(opts.PRs, name):
+			branch.MergedBy = MergedByPR
+		case !isFeatureBranch:
+			// Without a PR, other branches with nothing of their own may be long-lived
+		case branch.AheadCount == 0:
+			// Unless its feature has only just started and has no commits yet
+			if feature == nil || feature.Status == "complete" {
+				branch.MergedBy = MergedByAncestry
+			}
+		case ba.isSquashMerged(baseCommit, commit):
+			branch.MergedBy = MergedBySquash
+		}
+		branch.Merged = branch.MergedBy != ""
+		branch.Stale = ba.now().Sub(commit.Committer.When) > opts.StaleAfter
+		branch.Status = branchStatus(branch)
+
+		branches = append(branches, branch)
+	}
+
+	return branches, nil
+}
+
+// NeedsAttention reports whether a branch should be cleaned up or updated
+func NeedsAttention(branch models.Branch) bool {
+	switch branch.Status {
+	case BranchStatusMerged, BranchStatusStale, BranchStatusOrphan, BranchStatusDiverged, BranchStatusBehind:
+		return true
+	}
+	return false
+}
+
+// FeatureForBranch finds the feature that owns a branch, by its recorded branch or generated name
+func FeatureForBranch(state *models.State, branchName string) *models.Feature {
+	for i := range state.Features {
+		feature := &state.Features[i]
+		if feature.Branch != "" && feature.Branch == branchName {
+			return feature
+		}
+	}
+
+	for i := range state.Features {
+		feature := &state.Features[i]
+		if GenerateBranchName(feature.Phase, feature.ID, feature.Name) == branchName {
+			return feature
+		}
+	}
+
+	return nil
+}
+
+// PruneOptions controls which branches PruneBranches deletes
+type PruneOptions struct {
+	IncludeStale  bool
+	IncludeOrphan bool
+	Force         bool // Also delete stale or orphan branches that are not merged
+	Remote        bool // Also delete the branch on origin
+	DryRun        bool
+}
+
+// PruneResult lists what PruneBranches did with each candidate
+type PruneResult struct {
+	Pruned  []string // Deleted, or would be deleted on a dry run
+	Skipped []string // Stale or orphan branches kept because they are not merged
+}
+
+// PruneCandidates returns the branches PruneBranches would delete
+func PruneCandidates(branches []models.Branch, currentBranch string, opts PruneOptions) []models.Branch {
+	var candidates []models.Branch
+	for _, branch := range branches {
+		if branch.Name == currentBranch || branch.Status == BranchStatusBase || branch.Protected {
+			continue
+		}
+		if branch.Merged || (opts.IncludeStale && branch.Stale) || (opts.IncludeOrphan && branch.Orphan) {
+			candidates = append(candidates, branch)
+		}
+	}
+	return candidates
+}
+
+// PruneBranches deletes merged branches, and stale or orphan ones when requested.
+// Stale or orphan branches that are not merged are skipped unless opts.Force is set.
+// A branch that fails to delete does not stop the others; the failures are joined
+// into the returned error.
+func (ba *BranchAnalyzer) PruneBranches(branches []models.Branch, opts PruneOptions) (PruneResult, error) {
+	currentBranch := ""
+	if head, err := ba.repo.Head(); err == nil {
+		currentBranch = head.Name().Short()
+	}
+
+	var result PruneResult
+	var errs []error
+	for _, branch := range PruneCandidates(branches, currentBranch, opts) {
+		_, localErr := ba.repo.Reference(plumbing.NewBranchReferenceName(branch.Name), false)
+		_, remoteErr := ba.repo.Reference(plumbing.NewRemoteReferenceName("origin", branch.Name), false)
+		hasLocal := localErr == nil
+		hasRemote := opts.Remote && remoteErr == nil
+		if !hasLocal && !hasRemote {
+			continue
+		}
+
+		if !branch.Merged && !opts.Force {
+			result.Skipped = append(result.Skipped, branch.Name)
+			continue
+		}
+
+		if opts.DryRun {
+			result.Pruned = append(result.Pruned, branch.Name)
+			continue
+		}
+
+		if hasLocal {
+			// 'git branch -d' refuses branches it cannot see merged into HEAD, which
+			// squashed branches never are, so only force the delete when the merge
+			// is known from its pull request or its squashed changes, or when asked to
+			deleteFlag := "-d"
+			if opts.Force || branch.MergedBy == MergedByPR || branch.MergedBy == MergedBySquash {
+				deleteFlag = "-D"
+			}
+			if err := ba.git("branch", deleteFlag, branch.Name); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		if hasRemote {
+			if err := ba.git("push", "origin", "--delete", branch.Name); err != nil {
+				errs = append(errs, err)
+				if !hasLocal {
+					continue
+				}
+			}
+		}
+
+		result.Pruned = append(result.Pruned, branch.Name)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// RebaseReportEntry describes how far a branch has drifted from the base branch
+type RebaseReportEntry struct {
+	Branch    string `json:"branch"`
+	Feature   string `json:"feature,omitempty"`
+	Ahead     int    `json:"ahead"`
+	Behind    int    `json:"behind"`
+	Conflicts string `json:"conflicts"` // "none", "likely" or "unknown"
+	Command   string `json:"command"`
+}
+
+// RebaseReport lists branches that are behind the base branch, most behind first
+func (ba *BranchAnalyzer) RebaseReport(branches []models.Branch, baseBranch string) []RebaseReportEntry {
+	var entries []RebaseReportEntry
+	for _, branch := range branches {
+		if branch.Status == BranchStatusBase || branch.Merged || branch.BehindCount == 0 {
+			continue
+		}
+		entries = append(entries, RebaseReportEntry{
+			Branch:    branch.Name,
+			Feature:   branch.Feature,
+			Ahead:     branch.AheadCount,
+			Behind:    branch.BehindCount,
+			Conflicts: ba.predictConflicts(baseBranch, branch.Name),
+			Command:   fmt.Sprintf("git checkout %s && git rebase %s", branch.Name, baseBranch),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Behind > entries[j].Behind
+	})
+
+	return entries
+}
+
+// predictConflicts uses 'git merge-tree --write-tree' to check for conflicts without
+// touching the worktree. Git before 2.38 lacks it, so the prediction is "unknown" there.
+func (ba *BranchAnalyzer) predictConflicts(base, branch string) string {
+	if !ba.hasMergeTreeWriteTree() {
+		return "unknown"
+	}
+	cmd := exec.Command("git", "merge-tree", "--write-tree", base, branch)
+	cmd.Dir = ba.repoPath
+	err := cmd.Run()
+	if err == nil {
+		return "none"
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+		return "likely"
+	}
+	return "unknown"
+}
+
+// hasMergeTreeWriteTree reports whether the installed git supports 'merge-tree --write-tree'
+func (ba *BranchAnalyzer) hasMergeTreeWriteTree() bool {
+	if ba.mergeTreeSupported == nil {
+		version, err := ba.gitOutput("version")
+		supported := err == nil && gitVersionAtLeast(version, 2, 38)
+		ba.mergeTreeSupported = &supported
+	}
+	return *ba.mergeTreeSupported
+}
+
+// gitVersionAtLeast parses 'git version' output such as "git version 2.39.2 (Apple Git-143)"
+func gitVersionAtLeast(output string, major, minor int) bool {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(output), "git version"))
+	if len(fields) == 0 {
+		return false
+	}
+	parts := strings.SplitN(fields[0], ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
+
+// countAheadBehind fills the ahead, behind and commit counts and the creation time of a branch
+// whose tip is commit, by walking its history against the base branch's ancestors
+func (ba *BranchAnalyzer) countAheadBehind(commit *object.Commit, baseAncestors map[plumbing.Hash]bool, branch *models.Branch) error {
+	var created time.Time
+	shared := 0
+	err := object.NewCommitPreorderIter(commit, nil, nil).ForEach(func(c *object.Commit) error {
+		if baseAncestors[c.Hash] {
+			shared++
+			return nil
+		}
+		branch.AheadCount++
+		if created.IsZero() || c.Author.When.Before(created) {
+			created = c.Author.When
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk branch %s: %w", branch.Name, err)
+	}
+
+	if !created.IsZero() {
+		branch.CreatedAt = created.Format(time.RFC3339)
+	}
+	branch.BehindCount = len(baseAncestors) - shared
+	branch.CommitCount = branch.AheadCount + shared
+	return nil
+}
+
+// isSquashMerged reports whether the changes of a branch landed on the base branch
+// as a single commit: one made on the base branch since the merge base whose
+// changes match the branch's, the way 'git cherry' compares patch IDs
+func (ba *BranchAnalyzer) isSquashMerged(base, branch *object.Commit) bool {
+	mergeBases, err := branch.MergeBase(base)
+	if err != nil || len(mergeBases) == 0 {
+		return false
+	}
+	mergeBase := mergeBases[0]
+
+	want, err := changeID(mergeBase, branch)
+	if err != nil || want == "" {
+		return false
+	}
+
+	since, err := ba.ancestors(mergeBase.Hash)
+	if err != nil {
+		return false
+	}
+
+	found := false
+	err = object.NewCommitPreorderIter(base, since, nil).ForEach(func(c *object.Commit) error {
+		if c.NumParents() != 1 {
+			return nil
+		}
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil
+		}
+		if id, err := changeID(parent, c); err == nil && id == want {
+			found = true
+			return storer.ErrStop
+		}
+		return nil
+	})
+	return err == nil && found
+}
+
+// changeID hashes the lines added and removed between two commits, per file,
+// so the same change made on top of different commits gets the same ID
+func changeID(from, to *object.Commit) (string, error) {
+	patch, err := from.Patch(to)
+	if err != nil {
+		return "", err
+	}
+
+	files := make([]string, 0, len(patch.FilePatches()))
+	for _, filePatch := range patch.FilePatches() {
+		var b strings.Builder
+		fromFile, toFile := filePatch.Files()
+		if fromFile != nil {
+			b.WriteString("--- " + fromFile.Path() + "\n")
+		}
+		if toFile != nil {
+			b.WriteString("+++ " + toFile.Path() + "\n")
+		}
+		if filePatch.IsBinary() {
+			if toFile != nil {
+				b.WriteString(toFile.Hash().String())
+			}
+			files = append(files, b.String())
+			continue
+		}
+		for _, chunk := range filePatch.Chunks() {
+			switch chunk.Type() {
+			case diff.Add:
+				b.WriteString("+" + chunk.Content())
+			case diff.Delete:
+				b.WriteString("-" + chunk.Content())
+			}
+		}
+		files = append(files, b.String())
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+	sort.Strings(files)
+
+	sum := sha256.Sum256([]byte(strings.Join(files, "\x00")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// hasMergedPR reports whether a pull request from the branch was merged
+func hasMergedPR(prs []models.PullRequest, branch string) bool {
+	for _, pr := range prs {
+		if pr.Branch == branch && (pr.MergedAt != "" || strings.EqualFold(pr.Status, "merged")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isProtectedBranch matches a branch name against the protected branch globs
+func isProtectedBranch(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (ba *BranchAnalyzer) ancestors(hash plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	commit, err := ba.repo.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", hash, err)
+	}
+
+	seen := make(map[plumbing.Hash]bool)
+	err = object.NewCommitPreorderIter(commit, nil, nil).ForEach(func(c *object.Commit) error {
+		seen[c.Hash] = true
+		return nil
+	})
+	if err != nil && err != storer.ErrStop {
+		return nil, fmt.Errorf("failed to walk history: %w", err)
+	}
+	return seen, nil
+}
+
+// branchRefs maps branch names to their tips; local branches win over origin copies
+func (ba *BranchAnalyzer) branchRefs() (map[string]plumbing.Hash, error) {
+	refs := make(map[string]plumbing.Hash)
+
+	iter, err := ba.repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		name := ref.Name()
+		switch {
+		case name.IsBranch():
+			refs[name.Short()] = ref.Hash()
+		case name.IsRemote() && strings.HasPrefix(name.Short(), "origin/"):
+			short := strings.TrimPrefix(name.Short(), "origin/")
+			if short == "HEAD" {
+				return nil
+			}
+			if _, exists := refs[short]; !exists {
+				refs[short] = ref.Hash()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Local branches may be listed after their remote copies
+	branches, err := ba.repo.Branches()
+	if err == nil {
+		branches.ForEach(func(ref *plumbing.Reference) error {
+			refs[ref.Name().Short()] = ref.Hash()
+			return nil
+		})
+	}
+
+	return refs, nil
+}
+
+func (ba *BranchAnalyzer) resolveBranch(name string) (plumbing.Hash, error) {
+	if ref, err := ba.repo.Reference(plumbing.NewBranchReferenceName(name), true); err == nil {
+		return ref.Hash(), nil
+	}
+	ref, err := ba.repo.Reference(plumbing.NewRemoteReferenceName("origin", name), true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return ref.Hash(), nil
+}
+
+// gitOutput runs git and returns its trimmed output
+func (ba *BranchAnalyzer) gitOutput(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = ba.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (ba *BranchAnalyzer) git(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = ba.repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %s, output: %s", strings.Join(args, " "), err, string(output))
+	}
+	return nil
+}
+
+func branchStatus(branch models.Branch) string {
+	switch {
+	case branch.Merged:
+		return BranchStatusMerged
+	case branch.Orphan:
+		return BranchStatusOrphan
+	case branch.Stale:
+		return BranchStatusStale
+	case branch.AheadCount > 0 && branch.BehindCount > 0:
+		return BranchStatusDiverged
+	case branch.BehindCount > 0:
+		return BranchStatusBehind
+	default:
+		return BranchStatusActive
+	}
+}
+
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,371 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitFile writes a file and commits it at the given time
+func commitFile(t *testing.T, repo *git.Repository, root, name string, when time.Time) plumbing.Hash {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(name+when.String()), 0644))
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add(name)
+	require.NoError(t, err)
+
+	sig := &object.Signature{Name: "Test", Email: "test@example.com", When: when}
+	hash, err := wt.Commit("add "+name, &git.CommitOptions{Author: sig, Committer: sig})
+	require.NoError(t, err)
+	return hash
+}
+
+func checkout(t *testing.T, repo *git.Repository, branch string, create bool) {
+	t.Helper()
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, wt.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branch),
+		Create: create,
+	}))
+}
+
+func setupHygieneRepo(t *testing.T) (string, *git.Repository) {
+	projectRoot := helpers.CreateTempProject(t)
+	repo, err := git.PlainInit(projectRoot, false)
+	require.NoError(t, err)
+
+	now := time.Now()
+	commitFile(t, repo, projectRoot, "base.txt", now.Add(-100*24*time.Hour))
+	// go-git initialises HEAD to master; rename to main for the test
+	head, err := repo.Head()
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), head.Hash())))
+	checkout(t, repo, "main", false)
+	require.NoError(t, repo.Storer.RemoveReference(plumbing.NewBranchReferenceName("master")))
+
+	// merged: a feature branch fully contained in main
+	checkout(t, repo, "feature/merged-work", true)
+	checkout(t, repo, "main", false)
+
+	// not merged: other branches with no commits of their own, such as a protected one
+	checkout(t, repo, "spike", true)
+	checkout(t, repo, "main", false)
+	checkout(t, repo, "develop", true)
+	checkout(t, repo, "main", false)
+
+	// squash merged: main has one commit with both of the feature branch's changes
+	firstAt, secondAt := now.Add(-3*time.Hour), now.Add(-2*time.Hour)
+	checkout(t, repo, "feature/squashed", true)
+	commitFile(t, repo, projectRoot, "squash1.txt", firstAt)
+	commitFile(t, repo, projectRoot, "squash2.txt", secondAt)
+	checkout(t, repo, "main", false)
+	require.NoError(t, os.WriteFile(filepath.Join(projectRoot, "squash1.txt"), []byte("squash1.txt"+firstAt.String()), 0644))
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add("squash1.txt")
+	require.NoError(t, err)
+	commitFile(t, repo, projectRoot, "squash2.txt", secondAt)
+
+	// merged through a pull request, which ancestry cannot tell
+	checkout(t, repo, "hotfix/login", true)
+	commitFile(t, repo, projectRoot, "hotfix.txt", now.Add(-90*time.Minute))
+	checkout(t, repo, "main", false)
+
+	// feature branch with one commit, then main moves on -> diverged
+	checkout(t, repo, "feature/01-phase-auth-user-auth", true)
+	commitFile(t, repo, projectRoot, "auth.txt", now.Add(-time.Hour))
+	checkout(t, repo, "main", false)
+	commitFile(t, repo, projectRoot, "main2.txt", now.Add(-30*time.Minute))
+
+	// orphan feature branch that is also stale
+	checkout(t, repo, "feature/old-idea", true)
+	commitFile(t, repo, projectRoot, "old.txt", now.Add(-90*24*time.Hour))
+	checkout(t, repo, "main", false)
+
+	return projectRoot, repo
+}
+
+func findBranch(branches []models.Branch, name string) *models.Branch {
+	for i := range branches {
+		if branches[i].Name == name {
+			return &branches[i]
+		}
+	}
+	return nil
+}
+
+func TestBranchAnalyzer_Analyze(t *testing.T) {
+	projectRoot, _ := setupHygieneRepo(t)
+
+	analyzer, err := NewBranchAnalyzer(projectRoot)
+	require.NoError(t, err)
+
+	base, err := analyzer.DefaultBaseBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "main", base)
+
+	state := &models.State{
+		Features: []models.Feature{
+			{ID: "auth", Phase: "01-phase", Name: "User Auth", Status: "in-progress"},
+		},
+	}
+	branches, err := analyzer.Analyze(BranchAnalysisOptions{
+		State:      state,
+		StaleAfter: 30 * 24 * time.Hour,
+		PRs:        []models.PullRequest{{Number: 7, Branch: "hotfix/login", Status: "MERGED", MergedAt: "2025-01-01T00:00:00Z"}},
+	})
+	require.NoError(t, err)
+
+	main := findBranch(branches, "main")
+	require.NotNil(t, main)
+	assert.Equal(t, BranchStatusBase, main.Status)
+	assert.Equal(t, 3, main.CommitCount)
+
+	merged := findBranch(branches, "feature/merged-work")
+	require.NotNil(t, merged)
+	assert.True(t, merged.Merged)
+	assert.Equal(t, MergedByAncestry, merged.MergedBy)
+	assert.Equal(t, 0, merged.AheadCount)
+	assert.Equal(t, 2, merged.BehindCount)
+	assert.Equal(t, 1, merged.CommitCount)
+	assert.Equal(t, BranchStatusMerged, merged.Status)
+
+	spike := findBranch(branches, "spike")
+	require.NotNil(t, spike)
+	assert.False(t, spike.Merged, "only feature branches are merged for lack of commits of their own")
+	assert.Equal(t, BranchStatusStale, spike.Status)
+
+	develop := findBranch(branches, "develop")
+	require.NotNil(t, develop)
+	assert.True(t, develop.Protected)
+	assert.False(t, develop.Merged)
+
+	squashed := findBranch(branches, "feature/squashed")
+	require.NotNil(t, squashed)
+	assert.Equal(t, MergedBySquash, squashed.MergedBy)
+	assert.Equal(t, 2, squashed.AheadCount)
+
+	hotfix := findBranch(branches, "hotfix/login")
+	require.NotNil(t, hotfix)
+	assert.Equal(t, MergedByPR, hotfix.MergedBy)
+
+	feature := findBranch(branches, "feature/01-phase-auth-user-auth")
+	require.NotNil(t, feature)
+	assert.Equal(t, "auth", feature.Feature)
+	assert.Equal(t, 1, feature.AheadCount)
+	assert.Equal(t, 1, feature.BehindCount)
+	assert.Equal(t, BranchStatusDiverged, feature.Status)
+	assert.False(t, feature.Stale)
+	created, err := time.Parse(time.RFC3339, feature.CreatedAt)
+	require.NoError(t, err, "a branch is created with its first commit beyond the base")
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), created, time.Minute)
+	assert.Empty(t, merged.CreatedAt, "a branch with no commits of its own has no creation time")
+
+	orphan := findBranch(branches, "feature/old-idea")
+	require.NotNil(t, orphan)
+	assert.True(t, orphan.Orphan)
+	assert.True(t, orphan.Stale)
+	assert.Equal(t, BranchStatusOrphan, orphan.Status)
+	assert.True(t, NeedsAttention(*orphan))
+}
+
+func TestBranchAnalyzer_NewFeatureBranchIsNotMerged(t *testing.T) {
+	projectRoot, repo := setupHygieneRepo(t)
+	checkout(t, repo, "feature/01-phase-pay-payments", true)
+	checkout(t, repo, "main", false)
+
+	analyzer, err := NewBranchAnalyzer(projectRoot)
+	require.NoError(t, err)
+
+	state := &models.State{
+		Features: []models.Feature{{ID: "pay", Phase: "01-phase", Name: "Payments", Status: "in-progress"}},
+	}
+	branches, err := analyzer.Analyze(BranchAnalysisOptions{State: state})
+	require.NoError(t, err)
+
+	branch := findBranch(branches, "feature/01-phase-pay-payments")
+	require.NotNil(t, branch)
+	assert.False(t, branch.Merged)
+	assert.Equal(t, BranchStatusActive, branch.Status)
+}
+
+func TestBranchAnalyzer_PruneBranches(t *testing.T) {
+	projectRoot, repo := setupHygieneRepo(t)
+
+	analyzer, err := NewBranchAnalyzer(projectRoot)
+	require.NoError(t, err)
+	state := &models.State{
+		Features: []models.Feature{{ID: "auth", Phase: "01-phase", Name: "User Auth", Status: "in-progress"}},
+	}
+	branches, err := analyzer.Analyze(BranchAnalysisOptions{
+		State: state,
+		PRs:   []models.PullRequest{{Number: 7, Branch: "hotfix/login", Status: "MERGED", MergedAt: "2025-01-01T00:00:00Z"}},
+	})
+	require.NoError(t, err)
+
+	dryRun, err := analyzer.PruneBranches(branches, PruneOptions{DryRun: true, IncludeOrphan: true, IncludeStale: true, Force: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"feature/merged-work", "feature/squashed", "hotfix/login", "feature/old-idea", "spike"}, dryRun.Pruned,
+		"develop is protected even though it is stale")
+
+	_, err = repo.Reference(plumbing.NewBranchReferenceName("feature/merged-work"), false)
+	require.NoError(t, err, "dry run must not delete")
+
+	// Squashed and PR-merged branches are not in main's history, so they need a forced delete
+	result, err := analyzer.PruneBranches(branches, PruneOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feature/merged-work", "feature/squashed", "hotfix/login"}, result.Pruned)
+	assert.Empty(t, result.Skipped)
+
+	for _, name := range result.Pruned {
+		_, err = repo.Reference(plumbing.NewBranchReferenceName(name), false)
+		assert.Error(t, err, name)
+	}
+	for _, name := range []string{"feature/old-idea", "develop", "spike"} {
+		_, err = repo.Reference(plumbing.NewBranchReferenceName(name), false)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestBranchAnalyzer_PruneBranches_SkipsUnmergedStale(t *testing.T) {
+	projectRoot, repo := setupHygieneRepo(t)
+
+	analyzer, err := NewBranchAnalyzer(projectRoot)
+	require.NoError(t, err)
+	branches, err := analyzer.Analyze(BranchAnalysisOptions{})
+	require.NoError(t, err)
+
+	// feature/old-idea is stale with a commit main lacks; the merged branches still go
+	result, err := analyzer.PruneBranches(branches, PruneOptions{IncludeStale: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"feature/merged-work", "feature/squashed"}, result.Pruned)
+	assert.ElementsMatch(t, []string{"feature/old-idea", "spike"}, result.Skipped)
+	_, err = repo.Reference(plumbing.NewBranchReferenceName("feature/old-idea"), false)
+	require.NoError(t, err)
+
+	result, err = analyzer.PruneBranches(branches, PruneOptions{IncludeStale: true, Force: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"feature/old-idea", "spike"}, result.Pruned)
+	_, err = repo.Reference(plumbing.NewBranchReferenceName("feature/old-idea"), false)
+	assert.Error(t, err)
+}
+
+func TestBranchAnalyzer_PruneBranches_SafeDelete(t *testing.T) {
+	projectRoot, repo := setupHygieneRepo(t)
+
+	analyzer, err := NewBranchAnalyzer(projectRoot)
+	require.NoError(t, err)
+
+	// Without a merged PR, 'git branch -d' refuses a branch with commits HEAD lacks,
+	// which must not stop the other branches from being pruned
+	name := "feature/01-phase-auth-user-auth"
+	result, err := analyzer.PruneBranches([]models.Branch{
+		{Name: name, Merged: true, MergedBy: MergedByAncestry},
+		{Name: "feature/merged-work", Merged: true, MergedBy: MergedByAncestry},
+	}, PruneOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), name)
+	assert.Equal(t, []string{"feature/merged-work"}, result.Pruned)
+	_, err = repo.Reference(plumbing.NewBranchReferenceName(name), false)
+	require.NoError(t, err)
+
+	result, err = analyzer.PruneBranches([]models.Branch{{Name: name, Merged: true, MergedBy: MergedByPR}}, PruneOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{name}, result.Pruned)
+}
+
+func TestBranchAnalyzer_RebaseReport(t *testing.T) {
+	projectRoot, _ := setupHygieneRepo(t)
+
+	analyzer, err := NewBranchAnalyzer(projectRoot)
+	require.NoError(t, err)
+	branches, err := analyzer.Analyze(BranchAnalysisOptions{})
+	require.NoError(t, err)
+
+	report := analyzer.RebaseReport(branches, "main")
+	require.NotEmpty(t, report)
+	for _, entry := range report {
+		assert.NotEqual(t, "feature/merged-work", entry.Branch)
+		assert.Greater(t, entry.Behind, 0)
+		assert.Contains(t, entry.Command, "git rebase main")
+	}
+}
+
+func TestBranchAnalyzer_RebaseReport_OldGit(t *testing.T) {
+	projectRoot, _ := setupHygieneRepo(t)
+
+	analyzer, err := NewBranchAnalyzer(projectRoot)
+	require.NoError(t, err)
+	branches, err := analyzer.Analyze(BranchAnalysisOptions{})
+	require.NoError(t, err)
+
+	// Git before 2.38 cannot predict conflicts, but the rest of the report stands
+	unsupported := false
+	analyzer.mergeTreeSupported = &unsupported
+	report := analyzer.RebaseReport(branches, "main")
+	require.NotEmpty(t, report)
+	for _, entry := range report {
+		assert.Equal(t, "unknown", entry.Conflicts)
+		assert.Greater(t, entry.Behind, 0)
+	}
+}
+
+func TestGitVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"git version 2.38.0", true},
+		{"git version 2.39.2 (Apple Git-143)", true},
+		{"git version 3.0.0", true},
+		{"git version 2.37.1", false},
+		{"git version 1.8.3.1", false},
+		{"", false},
+		{"not git", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, gitVersionAtLeast(tt.output, 2, 38), tt.output)
+	}
+}
+
+func TestPruneCandidates_SkipsCurrentAndBase(t *testing.T) {
+	branches := []models.Branch{
+		{Name: "main", Status: BranchStatusBase, Merged: true},
+		{Name: "current", Merged: true},
+		{Name: "done", Merged: true},
+		{Name: "old", Stale: true},
+		{Name: "release/1.0", Stale: true, Protected: true},
+	}
+
+	candidates := PruneCandidates(branches, "current", PruneOptions{})
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "done", candidates[0].Name)
+
+	candidates = PruneCandidates(branches, "current", PruneOptions{IncludeStale: true})
+	assert.Len(t, candidates, 2)
+}
+
+func TestLinkBranchPRs(t *testing.T) {
+	data := &models.GitHubData{
+		Branches: []models.Branch{{Name: "feature/a"}, {Name: "feature/b"}},
+		PRs:      []models.PullRequest{{Number: 3, URL: "https://github.com/o/r/pull/3", Branch: "feature/b"}},
+	}
+	LinkBranchPRs(data)
+
+	assert.False(t, data.Branches[0].HasPR)
+	assert.True(t, data.Branches[1].HasPR)
+	assert.Equal(t, "https://github.com/o/r/pull/3", data.Branches[1].PRURL)
+}
@@ -28,6 +28,9 @@ func NewBranchManager(repoPath string) (*BranchManager, error) {
 	}, nil
 }
 
+// featureBranchPrefix starts the name of every generated feature branch
+const featureBranchPrefix = "feature/"
+
 // GenerateBranchName generates a branch name from phase and feature
 func GenerateBranchName(phaseID, featureID, featureName string) string {
 	// Convert to kebab-case
@@ -44,11 +47,17 @@ func GenerateBranchName(phaseID, featureID, featureName string) string {
 	}
 
 	cleanName := result.String()
-	return fmt.Sprintf("feature/%s-%s-%s", phaseID, featureID, cleanName)
+	return fmt.Sprintf("%s%s-%s-%s", featureBranchPrefix, phaseID, featureID, cleanName)
 }
 
 // CreateFeatureBranch creates a new feature branch
 func (bm *BranchManager) CreateFeatureBranch(branchName string) error {
+	return bm.CreateFeatureBranchFrom(branchName, "")
+}
+
+// CreateFeatureBranchFrom creates a new feature branch on top of baseBranch
+// (or the current HEAD when baseBranch is empty) and checks it out
+func (bm *BranchManager) CreateFeatureBranchFrom(branchName, baseBranch string) error {
 	// Check if branch already exists
 	branches, err := bm.repo.Branches()
 	if err != nil {
@@ -65,15 +74,28 @@ func (bm *BranchManager) CreateFeatureBranch(branchName string) error {
 		return err
 	}
 
-	// Get HEAD reference
-	headRef, err := bm.repo.Head()
-	if err != nil {
-		return fmt.Errorf("failed to get HEAD: %w", err)
+	// Start from the base branch, or HEAD
+	var start plumbing.Hash
+	if baseBranch != "" {
+		baseRef, err := bm.repo.Reference(plumbing.NewBranchReferenceName(baseBranch), true)
+		if err != nil {
+			baseRef, err = bm.repo.Reference(plumbing.NewRemoteReferenceName("origin", baseBranch), true)
+		}
+		if err != nil {
+			return fmt.Errorf("base branch %s not found", baseBranch)
+		}
+		start = baseRef.Hash()
+	} else {
+		headRef, err := bm.repo.Head()
+		if err != nil {
+			return fmt.Errorf("failed to get HEAD: %w", err)
+		}
+		start = headRef.Hash()
 	}
 
 	// Create new branch
 	branchRef := plumbing.NewBranchReferenceName(branchName)
-	ref := plumbing.NewHashReference(branchRef, headRef.Hash())
+	ref := plumbing.NewHashReference(branchRef, start)
 
 	if err := bm.repo.Storer.SetReference(ref); err != nil {
 		return fmt.Errorf("failed to create branch: %w", err)
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Data sources kept in the GitHub cache
+const (
+	SourceBranches = "branches"
+	SourceCommits  = "commits"
+	SourcePRs      = "prs"
+	SourceChecks   = "checks"
+)
+
+// SyncSources lists the data sources in the order they are reported
+var SyncSources = []string{SourceBranches, SourceCommits, SourcePRs, SourceChecks}
+
+// CacheEntry is one cached data source with the validators needed for conditional requests
+type CacheEntry struct {
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"lastModified,omitempty"`
+	LastHash     string          `json:"lastHash,omitempty"` // Newest commit fetched (commits only)
+	FetchedAt    time.Time       `json:"fetchedAt"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// DiskCache stores GitHub data sources under .doplan/cache/github so they survive between runs
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a cache in the project's .doplan/cache/github directory
+func NewDiskCache(projectRoot string) *DiskCache {
+	return &DiskCache{
+		dir: filepath.Join(projectRoot, ".doplan", "cache", "github"),
+	}
+}
+
+// Dir returns the cache directory
+func (c *DiskCache) Dir() string {
+	return c.dir
+}
+
+// Load reads a cached source into v. It returns nil when nothing is cached.
+func (c *DiskCache) Load(source string, v interface{}) (*CacheEntry, error) {
+	content, err := os.ReadFile(c.path(source))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s cache: %w", source, err)
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s cache: %w", source, err)
+	}
+	if v != nil && len(entry.Data) > 0 {
+		if err := json.Unmarshal(entry.Data, v); err != nil {
+			return nil, fmt.Errorf("failed to parse %s cache: %w", source, err)
+		}
+	}
+
+	return &entry, nil
+}
+
+// Save stores v as the cached data for a source
+func (c *DiskCache) Save(source string, entry *CacheEntry, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s cache: %w", source, err)
+	}
+	entry.Data = data
+
+	content, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s cache: %w", source, err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write then rename so a crash never leaves a truncated cache
+	tmpPath := c.path(source) + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s cache: %w", source, err)
+	}
+	return os.Rename(tmpPath, c.path(source))
+}
+
+// Clear removes every cached source
+func (c *DiskCache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+func (c *DiskCache) path(source string) string {
+	return filepath.Join(c.dir, source+".json")
+}
@@ -0,0 +1,169 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCache_SaveAndLoad(t *testing.T) {
+	cache := NewDiskCache(helpers.CreateTempProject(t))
+
+	var missing []models.Commit
+	entry, err := cache.Load(SourceCommits, &missing)
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	commits := []models.Commit{{Hash: "abc123", Message: "feat: add login"}}
+	require.NoError(t, cache.Save(SourceCommits, &CacheEntry{LastHash: "abc123", FetchedAt: time.Now()}, commits))
+
+	var loaded []models.Commit
+	entry, err = cache.Load(SourceCommits, &loaded)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "abc123", entry.LastHash)
+	assert.Equal(t, commits, loaded)
+
+	require.NoError(t, cache.Clear())
+	entry, err = cache.Load(SourceCommits, &loaded)
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+// fakePullsServer serves one PR and answers 304 when the client sends the current ETag
+func fakePullsServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		assert.Equal(t, "/repos/test/repo/pulls", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `[{"number":7,"title":"Add login","html_url":"https://github.com/test/repo/pull/7","state":"closed","draft":false,"merged_at":"2026-01-02T00:00:00Z","head":{"ref":"feature/login"}}]`)
+	}))
+}
+
+func TestAPIClient_ListPullRequests_Conditional(t *testing.T) {
+	requests := 0
+	server := fakePullsServer(t, &requests)
+	defer server.Close()
+
+	client := &APIClient{baseURL: server.URL, token: "secret", repo: "test/repo", client: server.Client()}
+
+	prs, result, err := client.ListPullRequests("", "")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, "MERGED", prs[0].Status)
+	assert.Equal(t, "feature/login", prs[0].Branch)
+	assert.Equal(t, `"v1"`, result.ETag)
+	assert.False(t, result.NotModified)
+
+	prs, result, err = client.ListPullRequests(`"v1"`, "")
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+	assert.True(t, result.NotModified)
+	assert.Equal(t, `"v1"`, result.ETag)
+	assert.Equal(t, 2, requests)
+}
+
+func TestGitHubSync_syncPRs_UsesCacheWhenNotModified(t *testing.T) {
+	requests := 0
+	server := fakePullsServer(t, &requests)
+	defer server.Close()
+
+	gs := NewGitHubSync(helpers.CreateTempProject(t))
+	gs.newAPIClient = func() (*APIClient, error) {
+		return &APIClient{baseURL: server.URL, token: "secret", repo: "test/repo", client: server.Client()}, nil
+	}
+
+	now := time.Now()
+	prs, status := gs.syncPRs(now)
+	require.Len(t, prs, 1)
+	assert.False(t, status.Stale)
+	assert.False(t, status.NotModified)
+
+	prs, status = gs.syncPRs(now.Add(time.Minute))
+	require.Len(t, prs, 1, "cached PRs are served on 304")
+	assert.True(t, status.NotModified)
+	assert.Equal(t, now.Add(time.Minute).Format(time.RFC3339), status.LastSynced)
+}
+
+func TestGitHubSync_syncPRs_StaleOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	projectRoot := helpers.CreateTempProject(t)
+	fetchedAt := time.Now().Add(-2 * time.Hour)
+	cached := []models.PullRequest{{Number: 3, Status: "OPEN"}}
+	require.NoError(t, NewDiskCache(projectRoot).Save(SourcePRs, &CacheEntry{ETag: `"old"`, FetchedAt: fetchedAt}, cached))
+
+	gs := NewGitHubSync(projectRoot)
+	gs.newAPIClient = func() (*APIClient, error) {
+		return &APIClient{baseURL: server.URL, repo: "test/repo", client: server.Client()}, nil
+	}
+
+	prs, status := gs.syncPRs(time.Now())
+	assert.Equal(t, cached, prs)
+	assert.True(t, status.Stale)
+	assert.Contains(t, status.Error, "502")
+	assert.Equal(t, fetchedAt.Format(time.RFC3339), status.LastSynced)
+}
+
+func TestGitHubSync_syncCommits_Incremental(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = projectRoot
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	git("init")
+	git("config", "user.name", "Test")
+	git("config", "user.email", "test@example.com")
+	git("commit", "--allow-empty", "-m", "first")
+
+	gs := NewGitHubSync(projectRoot)
+	commits, status := gs.syncCommits(time.Now())
+	require.Len(t, commits, 1)
+	assert.False(t, status.Stale)
+
+	// Pretend the cache holds an older commit the log no longer returns in a full read
+	var cached []models.Commit
+	entry, err := gs.diskCache.Load(SourceCommits, &cached)
+	require.NoError(t, err)
+	cached = append(cached, models.Commit{Hash: "0000000", Message: "cached only"})
+	require.NoError(t, gs.diskCache.Save(SourceCommits, entry, cached))
+
+	git("commit", "--allow-empty", "-m", "second | with pipe")
+	commits, _ = gs.syncCommits(time.Now())
+	require.Len(t, commits, 3, "only the new commit is read and prepended")
+	assert.Equal(t, "second | with pipe", commits[0].Message)
+	assert.Equal(t, "cached only", commits[2].Message)
+
+	entry, err = gs.diskCache.Load(SourceCommits, nil)
+	require.NoError(t, err)
+	assert.Equal(t, commits[0].Hash, entry.LastHash)
+}
+
+func TestFormatSyncAge(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "never", FormatSyncAge("", now))
+	assert.Equal(t, "just now", FormatSyncAge(now.Add(-10*time.Second).Format(time.RFC3339), now))
+	assert.Equal(t, "5m ago", FormatSyncAge(now.Add(-5*time.Minute).Format(time.RFC3339), now))
+	assert.Equal(t, "3h ago", FormatSyncAge(now.Add(-3*time.Hour).Format(time.RFC3339), now))
+	assert.Equal(t, "2d ago", FormatSyncAge(now.Add(-49*time.Hour).Format(time.RFC3339), now))
+}
@@ -0,0 +1,308 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+const (
+	// maxCheckHistory caps the check runs kept in GitHub data
+	maxCheckHistory = 500
+	// maxWorkflowHistory caps the workflow runs kept in GitHub data
+	maxWorkflowHistory = 200
+	// maxCheckedBranches caps how many branches one sync asks about
+	maxCheckedBranches = 30
+)
+
+// CI states reported by BranchCIStatus
+const (
+	CIStateSuccess = "success"
+	CIStateFailure = "failure"
+	CIStatePending = "pending"
+	CIStateNone    = "none"
+)
+
+// ciHistory is the cached form of the checks source
+type ciHistory struct {
+	Checks    []models.CheckRun    `json:"checks"`
+	Workflows []models.WorkflowRun `json:"workflows"`
+}
+
+// ListCheckRuns lists the check runs on the head commit of branch
+func (c *APIClient) ListCheckRuns(branch string) ([]models.CheckRun, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/commits/%s/check-runs?per_page=100", c.baseURL, c.repo, url.PathEscape(branch))
+
+	var payload struct {
+		CheckRuns []struct {
+			Name        string `json:"name"`
+			HeadSHA     string `json:"head_sha"`
+			Status      string `json:"status"`
+			Conclusion  string `json:"conclusion"`
+			HTMLURL     string `json:"html_url"`
+			CompletedAt string `json:"completed_at"`
+		} `json:"check_runs"`
+	}
+	if _, err := c.getConditional(endpoint, "", "", &payload); err != nil {
+		return nil, err
+	}
+
+	runs := make([]models.CheckRun, 0, len(payload.CheckRuns))
+	for _, r := range payload.CheckRuns {
+		runs = append(runs, models.CheckRun{
+			Name:        r.Name,
+			Status:      r.Status,
+			Conclusion:  r.Conclusion,
+			URL:         r.HTMLURL,
+			Branch:      branch,
+			HeadSHA:     r.HeadSHA,
+			CompletedAt: r.CompletedAt,
+		})
+	}
+	return runs, nil
+}
+
+// ListWorkflowRuns lists the most recent GitHub Actions runs on branch
+func (c *APIClient) ListWorkflowRuns(branch string) ([]models.WorkflowRun, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/actions/runs?branch=%s&per_page=20", c.baseURL, c.repo, url.QueryEscape(branch))
+
+	var payload struct {
+		WorkflowRuns []struct {
+			ID         int64  `json:"id"`
+			Name       string `json:"name"`
+			Event      string `json:"event"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			HTMLURL    string `json:"html_url"`
+			HeadBranch string `json:"head_branch"`
+			HeadSHA    string `json:"head_sha"`
+			RunNumber  int    `json:"run_number"`
+			UpdatedAt  string `json:"updated_at"`
+		} `json:"workflow_runs"`
+	}
+	if _, err := c.getConditional(endpoint, "", "", &payload); err != nil {
+		return nil, err
+	}
+
+	runs := make([]models.WorkflowRun, 0, len(payload.WorkflowRuns))
+	for _, r := range payload.WorkflowRuns {
+		runs = append(runs, models.WorkflowRun{
+			ID:         r.ID,
+			Name:       r.Name,
+			Event:      r.Event,
+			Status:     r.Status,
+			Conclusion: r.Conclusion,
+			URL:        r.HTMLURL,
+			Branch:     r.HeadBranch,
+			HeadSHA:    r.HeadSHA,
+			RunNumber:  r.RunNumber,
+			UpdatedAt:  r.UpdatedAt,
+		})
+	}
+	return runs, nil
+}
+
+// RecordCheckRuns merges check runs into the history, replacing earlier reports of
+// the same check on the same commit
+func RecordCheckRuns(data *models.GitHubData, runs []models.CheckRun) {
+	if len(runs) == 0 {
+		return
+	}
+
+	key := func(r models.CheckRun) string { return r.Branch + "\x00" + r.HeadSHA + "\x00" + r.Name }
+	incoming := make(map[string]bool, len(runs))
+	for _, run := range runs {
+		incoming[key(run)] = true
+	}
+
+	history := append([]models.CheckRun{}, runs...)
+	for _, run := range data.Checks {
+		if !incoming[key(run)] {
+			history = append(history, run)
+		}
+	}
+	if len(history) > maxCheckHistory {
+		history = history[:maxCheckHistory]
+	}
+	data.Checks = history
+}
+
+// RecordWorkflowRuns merges workflow runs into the history, newest first
+func RecordWorkflowRuns(data *models.GitHubData, runs []models.WorkflowRun) {
+	if len(runs) == 0 {
+		return
+	}
+
+	byID := make(map[int64]models.WorkflowRun, len(data.Workflows)+len(runs))
+	for _, run := range data.Workflows {
+		byID[run.ID] = run
+	}
+	for _, run := range runs {
+		byID[run.ID] = run
+	}
+
+	history := make([]models.WorkflowRun, 0, len(byID))
+	for _, run := range byID {
+		history = append(history, run)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].UpdatedAt != history[j].UpdatedAt {
+			return history[i].UpdatedAt > history[j].UpdatedAt
+		}
+		return history[i].ID > history[j].ID
+	})
+	if len(history) > maxWorkflowHistory {
+		history = history[:maxWorkflowHistory]
+	}
+	data.Workflows = history
+}
+
+// BranchCIStatus summarizes the latest run of each check on the newest commit of a branch
+func BranchCIStatus(data *models.GitHubData, branch string) models.CIStatus {
+	status := models.CIStatus{State: CIStateNone}
+	if data == nil || branch == "" {
+		return status
+	}
+
+	// History is newest first, so the first run seen names the current head
+	seen := make(map[string]bool)
+	for _, run := range data.Checks {
+		if run.Branch != branch {
+			continue
+		}
+		if status.HeadSHA == "" {
+			status.HeadSHA = run.HeadSHA
+		}
+		if run.HeadSHA != status.HeadSHA || seen[run.Name] {
+			continue
+		}
+		seen[run.Name] = true
+		status.Checks = append(status.Checks, run)
+	}
+
+	seenWorkflows := make(map[string]bool)
+	for _, run := range data.Workflows {
+		if run.Branch == branch && !seenWorkflows[run.Name] {
+			seenWorkflows[run.Name] = true
+			status.Workflows = append(status.Workflows, run)
+		}
+	}
+
+	pending := false
+	for _, run := range status.Checks {
+		switch {
+		case run.Status != "completed":
+			pending = true
+		case IsFailedConclusion(run.Conclusion):
+			status.Failing = append(status.Failing, run.Name)
+		}
+	}
+	for _, run := range status.Workflows {
+		if run.Status != "completed" {
+			pending = true
+		} else if IsFailedConclusion(run.Conclusion) && !seen[run.Name] {
+			status.Failing = append(status.Failing, run.Name)
+		}
+	}
+
+	switch {
+	case len(status.Failing) > 0:
+		status.State = CIStateFailure
+	case pending:
+		status.State = CIStatePending
+	case len(status.Checks) > 0 || len(status.Workflows) > 0:
+		status.State = CIStateSuccess
+	}
+	return status
+}
+
+// FailingRequiredChecks returns the failing checks that are required. With no
+// required checks configured every check is required.
+func FailingRequiredChecks(status models.CIStatus, required []string) []string {
+	if len(required) == 0 {
+		return status.Failing
+	}
+
+	var failing []string
+	for _, name := range status.Failing {
+		if containsFold(required, name) {
+			failing = append(failing, name)
+		}
+	}
+	return failing
+}
+
+// IsFailedConclusion reports whether a check or workflow conclusion counts as a failure
+func IsFailedConclusion(conclusion string) bool {
+	switch conclusion {
+	case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+		return true
+	}
+	return false
+}
+
+// syncChecks fetches check and workflow runs for feature branches and open PRs and
+// merges them into data's history
+func (gs *GitHubSync) syncChecks(now time.Time, data *models.GitHubData) models.SyncSource {
+	var cached ciHistory
+	entry, _ := gs.diskCache.Load(SourceChecks, &cached)
+	if len(data.Checks) == 0 && len(data.Workflows) == 0 {
+		data.Checks, data.Workflows = cached.Checks, cached.Workflows
+	}
+
+	branches := gs.checkedBranches(data.PRs)
+	if len(branches) == 0 {
+		return freshSource(now)
+	}
+
+	client, err := gs.newAPIClient()
+	if err != nil {
+		return staleSource(entry, err)
+	}
+
+	for _, branch := range branches {
+		checks, err := client.ListCheckRuns(branch)
+		if err != nil {
+			return staleSource(entry, err)
+		}
+		RecordCheckRuns(data, checks)
+
+		workflows, err := client.ListWorkflowRuns(branch)
+		if err != nil {
+			return staleSource(entry, err)
+		}
+		RecordWorkflowRuns(data, workflows)
+	}
+
+	gs.diskCache.Save(SourceChecks, &CacheEntry{FetchedAt: now}, ciHistory{Checks: data.Checks, Workflows: data.Workflows})
+	return freshSource(now)
+}
+
+// checkedBranches lists the unmerged feature branches and open PR heads whose CI is tracked
+func (gs *GitHubSync) checkedBranches(prs []models.PullRequest) []string {
+	var branches []string
+	seen := make(map[string]bool)
+	add := func(branch string) {
+		if branch != "" && !seen[branch] && len(branches) < maxCheckedBranches {
+			seen[branch] = true
+			branches = append(branches, branch)
+		}
+	}
+
+	if state, err := gs.loadState(); err == nil {
+		for i := range state.Features {
+			if !IsFeatureMerged(&state.Features[i]) {
+				add(state.Features[i].Branch)
+			}
+		}
+	}
+	for _, pr := range prs {
+		if pr.Status == "OPEN" || pr.Status == "open" {
+			add(pr.Branch)
+		}
+	}
+	return branches
+}
@@ -0,0 +1,120 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordCheckRuns(t *testing.T) {
+	data := &models.GitHubData{}
+
+	RecordCheckRuns(data, []models.CheckRun{{Name: "build", Status: "in_progress", Branch: "feature/a", HeadSHA: "aaa"}})
+	RecordCheckRuns(data, []models.CheckRun{{Name: "build", Status: "completed", Conclusion: "success", Branch: "feature/a", HeadSHA: "aaa"}})
+	require.Len(t, data.Checks, 1, "a later report of the same run replaces the earlier one")
+	assert.Equal(t, "success", data.Checks[0].Conclusion)
+
+	RecordCheckRuns(data, []models.CheckRun{{Name: "build", Status: "completed", Conclusion: "failure", Branch: "feature/a", HeadSHA: "bbb"}})
+	require.Len(t, data.Checks, 2, "runs on earlier commits are kept as history")
+	assert.Equal(t, "bbb", data.Checks[0].HeadSHA)
+}
+
+func TestRecordWorkflowRuns(t *testing.T) {
+	data := &models.GitHubData{}
+
+	RecordWorkflowRuns(data, []models.WorkflowRun{
+		{ID: 1, Name: "CI", Status: "completed", Conclusion: "failure", Branch: "feature/a", UpdatedAt: "2026-03-01T10:00:00Z"},
+	})
+	RecordWorkflowRuns(data, []models.WorkflowRun{
+		{ID: 2, Name: "CI", Status: "in_progress", Branch: "feature/a", UpdatedAt: "2026-03-01T11:00:00Z"},
+		{ID: 1, Name: "CI", Status: "completed", Conclusion: "failure", Branch: "feature/a", UpdatedAt: "2026-03-01T10:00:00Z"},
+	})
+
+	require.Len(t, data.Workflows, 2)
+	assert.Equal(t, int64(2), data.Workflows[0].ID)
+}
+
+func TestBranchCIStatus(t *testing.T) {
+	data := &models.GitHubData{
+		Checks: []models.CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "success", Branch: "feature/a", HeadSHA: "new"},
+			{Name: "lint", Status: "in_progress", Branch: "feature/a", HeadSHA: "new"},
+			{Name: "build", Status: "completed", Conclusion: "failure", Branch: "feature/a", HeadSHA: "old"},
+			{Name: "build", Status: "completed", Conclusion: "failure", Branch: "feature/b", HeadSHA: "bbb"},
+			{Name: "lint", Status: "completed", Conclusion: "skipped", Branch: "feature/b", HeadSHA: "bbb"},
+		},
+	}
+
+	// Runs on older commits no longer count
+	status := BranchCIStatus(data, "feature/a")
+	assert.Equal(t, CIStatePending, status.State)
+	assert.Equal(t, "new", status.HeadSHA)
+	assert.Len(t, status.Checks, 2)
+	assert.Empty(t, status.Failing)
+
+	status = BranchCIStatus(data, "feature/b")
+	assert.Equal(t, CIStateFailure, status.State)
+	assert.Equal(t, []string{"build"}, status.Failing)
+	assert.Equal(t, []string{"build"}, FailingRequiredChecks(status, nil))
+	assert.Equal(t, []string{"build"}, FailingRequiredChecks(status, []string{"Build"}))
+	assert.Empty(t, FailingRequiredChecks(status, []string{"lint"}))
+
+	data.Checks[1].Status = "completed"
+	data.Checks[1].Conclusion = "success"
+	assert.Equal(t, CIStateSuccess, BranchCIStatus(data, "feature/a").State)
+
+	assert.Equal(t, CIStateNone, BranchCIStatus(data, "feature/none").State)
+	assert.Equal(t, CIStateNone, BranchCIStatus(nil, "feature/a").State)
+}
+
+func TestGitHubSync_syncChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/test/repo/commits/feature%2Fauth/check-runs", "/repos/test/repo/commits/feature/auth/check-runs":
+			fmt.Fprint(w, `{"total_count":1,"check_runs":[{"name":"build","head_sha":"abc","status":"completed","conclusion":"failure","html_url":"https://github.com/test/repo/runs/1","completed_at":"2026-03-01T10:00:00Z"}]}`)
+		case "/repos/test/repo/actions/runs":
+			assert.Equal(t, "feature/auth", r.URL.Query().Get("branch"))
+			fmt.Fprint(w, `{"total_count":1,"workflow_runs":[{"id":9,"name":"CI","event":"push","status":"completed","conclusion":"failure","html_url":"https://github.com/test/repo/actions/runs/9","head_branch":"feature/auth","head_sha":"abc","run_number":4,"updated_at":"2026-03-01T10:00:00Z"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	projectRoot := helpers.CreateTempProject(t)
+	require.NoError(t, config.NewManager(projectRoot).SaveState(&models.State{
+		Features: []models.Feature{
+			{ID: "auth", Name: "Auth", Branch: "feature/auth"},
+			{ID: "done", Name: "Done", Branch: "feature/done", PR: &models.PullRequest{Status: "merged"}},
+		},
+	}))
+
+	gs := NewGitHubSync(projectRoot)
+	gs.newAPIClient = func() (*APIClient, error) {
+		return &APIClient{baseURL: server.URL, repo: "test/repo", client: server.Client()}, nil
+	}
+
+	data := &models.GitHubData{}
+	status := gs.syncChecks(time.Now(), data)
+	assert.False(t, status.Stale, status.Error)
+
+	require.Len(t, data.Checks, 1, "merged features are not checked")
+	assert.Equal(t, "feature/auth", data.Checks[0].Branch)
+	require.Len(t, data.Workflows, 1)
+	assert.Equal(t, CIStateFailure, BranchCIStatus(data, "feature/auth").State)
+
+	// When the API fails the history is kept and the source reported stale
+	server.Close()
+	data = &models.GitHubData{}
+	status = gs.syncChecks(time.Now(), data)
+	assert.True(t, status.Stale)
+	assert.Len(t, data.Checks, 1, "history comes from the cache")
+}
@@ -0,0 +1,228 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CommitTypes lists the conventional commit types accepted by DoPlan
+var CommitTypes = []string{
+	"feat",
+	"fix",
+	"docs",
+	"style",
+	"refactor",
+	"perf",
+	"test",
+	"build",
+	"ci",
+	"chore",
+	"revert",
+}
+
+// CompletesTrailer is the commit trailer that marks a task as completed
+const CompletesTrailer = "Completes"
+
+// headerPattern matches "<type>(<scope>)!: <description>". Whitespace around
+// the scope and colon is tolerated so FormatCommitMessage output also parses.
+var headerPattern = regexp.MustCompile(`^([a-zA-Z]+)\s*(?:\(\s*([^()]*?)\s*\))?\s*(!)?\s*:\s*(.*)$`)
+
+// taskRefPattern matches "<phase-dir>/<feature-dir>#<task-number>"
+var taskRefPattern = regexp.MustCompile(`^([^/\s#]+)/([^/\s#]+)#(\d+)$`)
+
+// ConventionalCommit represents a parsed conventional commit message
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+	Body        string
+	Trailers    map[string][]string
+}
+
+// TaskReference points at a single task inside a feature's tasks.md
+type TaskReference struct {
+	PhaseDir   string // e.g. "02-phase"
+	FeatureDir string // e.g. "03-auth"
+	TaskNumber int    // 1-based position of the checkbox in tasks.md
+}
+
+// String returns the trailer form of the reference
+func (r TaskReference) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.PhaseDir, r.FeatureDir, r.TaskNumber)
+}
+
+// ParseCommitMessage parses a commit message according to conventional commits
+func ParseCommitMessage(message string) (*ConventionalCommit, error) {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	header := strings.TrimSpace(lines[0])
+	if header == "" {
+		return nil, fmt.Errorf("commit message is empty")
+	}
+
+	matches := headerPattern.FindStringSubmatch(header)
+	if matches == nil {
+		return nil, fmt.Errorf("commit header %q does not follow '<type>(<scope>): <description>'", header)
+	}
+
+	commit := &ConventionalCommit{
+		Type:        strings.ToLower(matches[1]),
+		Scope:       matches[2],
+		Breaking:    matches[3] == "!",
+		Description: strings.TrimSpace(matches[4]),
+		Trailers:    parseTrailers(lines[1:]),
+	}
+
+	if len(lines) > 1 {
+		commit.Body = strings.TrimSpace(strings.Join(lines[1:], "\n"))
+	}
+	if len(commit.Trailers["BREAKING CHANGE"]) > 0 || len(commit.Trailers["BREAKING-CHANGE"]) > 0 {
+		commit.Breaking = true
+	}
+
+	return commit, nil
+}
+
+// ValidateCommitMessage checks a commit message against the commit rules.
+// When scopes is non-empty, a scope (if given) must be one of them.
+func ValidateCommitMessage(message string, scopes []string) error {
+	message = stripCommentLines(message)
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("commit message is empty")
+	}
+
+	// Let git-generated messages through untouched
+	header := strings.TrimSpace(strings.SplitN(strings.TrimSpace(message), "\n", 2)[0])
+	for _, prefix := range []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(header, prefix) {
+			return nil
+		}
+	}
+
+	commit, err := ParseCommitMessage(message)
+	if err != nil {
+		return err
+	}
+
+	if !isCommitType(commit.Type) {
+		return fmt.Errorf("unknown commit type %q (expected one of: %s)", commit.Type, strings.Join(CommitTypes, ", "))
+	}
+
+	if commit.Description == "" {
+		return fmt.Errorf("commit description is empty")
+	}
+
+	if commit.Scope != "" && len(scopes) > 0 && !containsFold(scopes, commit.Scope) {
+		return fmt.Errorf("unknown commit scope %q (expected a feature or phase: %s)", commit.Scope, strings.Join(scopes, ", "))
+	}
+
+	for _, value := range commit.Trailers[CompletesTrailer] {
+		if _, err := ParseTaskReference(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseTaskReference parses a "<phase-dir>/<feature-dir>#<n>" trailer value
+func ParseTaskReference(value string) (TaskReference, error) {
+	matches := taskRefPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return TaskReference{}, fmt.Errorf("invalid %s trailer %q (expected <phase>/<feature>#<task>)", CompletesTrailer, value)
+	}
+
+	number, err := strconv.Atoi(matches[3])
+	if err != nil || number < 1 {
+		return TaskReference{}, fmt.Errorf("invalid task number in %q", value)
+	}
+
+	return TaskReference{
+		PhaseDir:   matches[1],
+		FeatureDir: matches[2],
+		TaskNumber: number,
+	}, nil
+}
+
+// ParseTaskReferences returns every valid task reference in a commit message
+func ParseTaskReferences(message string) []TaskReference {
+	lines := strings.Split(stripCommentLines(message), "\n")
+	if len(lines) < 2 {
+		return nil
+	}
+
+	var refs []TaskReference
+	for _, value := range parseTrailers(lines[1:])[CompletesTrailer] {
+		// A single trailer may list several tasks separated by commas
+		for _, part := range strings.Split(value, ",") {
+			if ref, err := ParseTaskReference(part); err == nil {
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	return refs
+}
+
+// parseTrailers reads "Key: value" lines from the last paragraph of a message body
+func parseTrailers(lines []string) map[string][]string {
+	trailers := make(map[string][]string)
+
+	// Trailers live in the final paragraph
+	start := 0
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) == "" {
+			start = i + 1
+			break
+		}
+	}
+
+	for _, line := range lines[start:] {
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(value) == "" {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if key == "" || strings.ContainsAny(key, "\t") || (strings.Contains(key, " ") && key != "BREAKING CHANGE") {
+			continue
+		}
+		trailers[key] = append(trailers[key], strings.TrimSpace(value))
+	}
+
+	return trailers
+}
+
+func stripCommentLines(message string) string {
+	var kept []string
+	for _, line := range strings.Split(message, "\n") {
+		// Everything below the scissors line is the diff shown by 'git commit -v'
+		if strings.HasPrefix(line, "# ------------------------ >8 ------------------------") {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+func isCommitType(commitType string) bool {
+	for _, t := range CommitTypes {
+		if t == commitType {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
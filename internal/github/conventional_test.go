@@ -0,0 +1,81 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommitMessage(t *testing.T) {
+	commit, err := ParseCommitMessage("feat(auth)!: add login\n\nImplements the form.\n\nCompletes: 02-phase/03-auth#4\nRefs: #12")
+	require.NoError(t, err)
+
+	assert.Equal(t, "feat", commit.Type)
+	assert.Equal(t, "auth", commit.Scope)
+	assert.True(t, commit.Breaking)
+	assert.Equal(t, "add login", commit.Description)
+	assert.Equal(t, []string{"02-phase/03-auth#4"}, commit.Trailers["Completes"])
+	assert.Equal(t, []string{"#12"}, commit.Trailers["Refs"])
+}
+
+func TestParseCommitMessage_FormatCommitMessageOutput(t *testing.T) {
+	commit, err := ParseCommitMessage(FormatCommitMessage("fix", "api", "handle nil"))
+	require.NoError(t, err)
+	assert.Equal(t, "fix", commit.Type)
+	assert.Equal(t, "api", commit.Scope)
+	assert.Equal(t, "handle nil", commit.Description)
+}
+
+func TestValidateCommitMessage(t *testing.T) {
+	scopes := []string{"user-authentication", "01-phase"}
+
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{"valid without scope", "feat: add login", false},
+		{"valid with scope", "fix(user-authentication): handle nil", false},
+		{"scope is case insensitive", "fix(User-Authentication): handle nil", false},
+		{"unknown type", "feature: add login", true},
+		{"missing colon", "add login", true},
+		{"empty description", "feat(01-phase):", true},
+		{"unknown scope", "feat(payments): add stripe", true},
+		{"merge commit", "Merge branch 'main' into feature/x", false},
+		{"fixup commit", "fixup! feat: add login", false},
+		{"comments only", "# Please enter the commit message\n", true},
+		{"bad trailer", "feat: add login\n\nCompletes: 02-phase", true},
+		{"good trailer", "feat: add login\n\nCompletes: 01-phase/01-Feature#2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommitMessage(tt.message, scopes)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateCommitMessage_NoScopes(t *testing.T) {
+	assert.NoError(t, ValidateCommitMessage("feat(anything): add login", nil))
+}
+
+func TestParseTaskReferences(t *testing.T) {
+	message := "feat(auth): add login\n\nSome body text.\n\nCompletes: 02-phase/03-auth#4, 02-phase/03-auth#5\nCompletes: 01-phase/01-Feature#1\nCompletes: nonsense\n# a comment"
+
+	refs := ParseTaskReferences(message)
+	require.Len(t, refs, 3)
+	assert.Equal(t, TaskReference{PhaseDir: "02-phase", FeatureDir: "03-auth", TaskNumber: 4}, refs[0])
+	assert.Equal(t, 5, refs[1].TaskNumber)
+	assert.Equal(t, "01-phase/01-Feature#1", refs[2].String())
+}
+
+func TestParseTaskReferences_None(t *testing.T) {
+	assert.Empty(t, ParseTaskReferences("feat: add login"))
+	assert.Empty(t, ParseTaskReferences("feat: add login\n\nCompletes: 02-phase/03-auth#0"))
+}
@@ -0,0 +1,145 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// hookMarker identifies hook scripts written by DoPlan
+const hookMarker = "# doplan-managed-hook"
+
+// hookBackupSuffix is appended to pre-existing hooks that DoPlan replaces
+const hookBackupSuffix = ".doplan-backup"
+
+// ManagedHooks lists the Git hooks installed by DoPlan
+var ManagedHooks = []string{"commit-msg", "post-commit"}
+
+// HookManager installs and removes DoPlan Git hooks
+type HookManager struct {
+	repoPath string
+	repo     *git.Repository
+}
+
+// NewHookManager creates a new hook manager
+func NewHookManager(repoPath string) (*HookManager, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	return &HookManager{
+		repoPath: repoPath,
+		repo:     repo,
+	}, nil
+}
+
+// HooksDir returns the directory Git reads hooks from, honoring core.hooksPath
+func (hm *HookManager) HooksDir() string {
+	if cfg, err := hm.repo.Config(); err == nil {
+		if hooksPath := cfg.Raw.Section("core").Option("hooksPath"); hooksPath != "" {
+			if filepath.IsAbs(hooksPath) {
+				return hooksPath
+			}
+			return filepath.Join(hm.repoPath, hooksPath)
+		}
+	}
+	return filepath.Join(hm.repoPath, ".git", "hooks")
+}
+
+// Install writes the DoPlan hooks, backing up any existing hooks so they keep running
+func (hm *HookManager) Install() ([]string, error) {
+	hooksDir := hm.HooksDir()
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	var installed []string
+	for _, name := range ManagedHooks {
+		path := filepath.Join(hooksDir, name)
+
+		if content, err := os.ReadFile(path); err == nil && !strings.Contains(string(content), hookMarker) {
+			backupPath := path + hookBackupSuffix
+			if _, err := os.Stat(backupPath); err == nil {
+				return installed, fmt.Errorf("hook backup %s already exists", backupPath)
+			}
+			if err := os.Rename(path, backupPath); err != nil {
+				return installed, fmt.Errorf("failed to back up %s hook: %w", name, err)
+			}
+		}
+
+		if err := os.WriteFile(path, []byte(hookScript(name)), 0755); err != nil {
+			return installed, fmt.Errorf("failed to write %s hook: %w", name, err)
+		}
+		installed = append(installed, name)
+	}
+
+	return installed, nil
+}
+
+// Uninstall removes the DoPlan hooks and restores any hooks they replaced
+func (hm *HookManager) Uninstall() ([]string, error) {
+	hooksDir := hm.HooksDir()
+
+	var removed []string
+	for _, name := range ManagedHooks {
+		path := filepath.Join(hooksDir, name)
+
+		content, err := os.ReadFile(path)
+		if err != nil || !strings.Contains(string(content), hookMarker) {
+			continue // Not ours, leave it alone
+		}
+
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s hook: %w", name, err)
+		}
+
+		backupPath := path + hookBackupSuffix
+		if _, err := os.Stat(backupPath); err == nil {
+			if err := os.Rename(backupPath, path); err != nil {
+				return removed, fmt.Errorf("failed to restore %s hook: %w", name, err)
+			}
+		}
+		removed = append(removed, name)
+	}
+
+	return removed, nil
+}
+
+// IsInstalled reports whether all DoPlan hooks are present
+func (hm *HookManager) IsInstalled() bool {
+	for _, name := range ManagedHooks {
+		content, err := os.ReadFile(filepath.Join(hm.HooksDir(), name))
+		if err != nil || !strings.Contains(string(content), hookMarker) {
+			return false
+		}
+	}
+	return true
+}
+
+func hookScript(name string) string {
+	// post-commit must never fail the commit that already happened
+	run := fmt.Sprintf(`doplan hooks %s "$@"`, name)
+	if name == "post-commit" {
+		run += " || true"
+	}
+
+	return fmt.Sprintf(`#!/bin/sh
+%s
+# Installed by 'doplan hooks install'. Remove with 'doplan hooks uninstall'.
+
+backup="$0%s"
+if [ -x "$backup" ]; then
+	"$backup" "$@" || exit $?
+fi
+
+if ! command -v doplan >/dev/null 2>&1; then
+	exit 0
+fi
+
+%s
+`, hookMarker, hookBackupSuffix, run)
+}
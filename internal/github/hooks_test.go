@@ -0,0 +1,69 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookManager_InstallUninstall(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	_, err := git.PlainInit(projectRoot, false)
+	require.NoError(t, err)
+
+	hm, err := NewHookManager(projectRoot)
+	require.NoError(t, err)
+	assert.False(t, hm.IsInstalled())
+
+	// Pre-existing user hook must survive install/uninstall
+	hooksDir := hm.HooksDir()
+	require.NoError(t, os.MkdirAll(hooksDir, 0755))
+	userHook := "#!/bin/sh\necho user hook\n"
+	require.NoError(t, os.WriteFile(filepath.Join(hooksDir, "commit-msg"), []byte(userHook), 0755))
+
+	installed, err := hm.Install()
+	require.NoError(t, err)
+	assert.Equal(t, ManagedHooks, installed)
+	assert.True(t, hm.IsInstalled())
+
+	content, err := os.ReadFile(filepath.Join(hooksDir, "commit-msg"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), hookMarker)
+	assert.Contains(t, string(content), "doplan hooks commit-msg")
+	assert.FileExists(t, filepath.Join(hooksDir, "commit-msg"+hookBackupSuffix))
+
+	// Installing twice is idempotent
+	_, err = hm.Install()
+	require.NoError(t, err)
+
+	removed, err := hm.Uninstall()
+	require.NoError(t, err)
+	assert.Equal(t, ManagedHooks, removed)
+	assert.False(t, hm.IsInstalled())
+
+	content, err = os.ReadFile(filepath.Join(hooksDir, "commit-msg"))
+	require.NoError(t, err)
+	assert.Equal(t, userHook, string(content))
+	assert.NoFileExists(t, filepath.Join(hooksDir, "commit-msg"+hookBackupSuffix))
+	assert.NoFileExists(t, filepath.Join(hooksDir, "post-commit"))
+}
+
+func TestHookManager_HooksPath(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	repo, err := git.PlainInit(projectRoot, false)
+	require.NoError(t, err)
+
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	cfg.Raw.Section("core").SetOption("hooksPath", ".githooks")
+	require.NoError(t, repo.SetConfig(cfg))
+
+	hm, err := NewHookManager(projectRoot)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(projectRoot, ".githooks"), hm.HooksDir())
+}
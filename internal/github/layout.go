@@ -0,0 +1,82 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// FeatureDir returns a feature's directory relative to the project root. Features
+// listed in a phase use the "NN-phase/NN-Feature" numbering of the plan generator,
+// and a renamed "NN-..." directory under projectRoot is found by its number. Other
+// features live under their phase and feature IDs.
+func FeatureDir(projectRoot string, state *models.State, feature *models.Feature) string {
+	phaseNumber, featureNumber := featurePosition(state, feature.ID)
+	if phaseNumber == 0 {
+		return fmt.Sprintf("doplan/%s/%s", feature.Phase, feature.ID)
+	}
+
+	phaseDir := fmt.Sprintf("doplan/%02d-phase", phaseNumber)
+	if projectRoot != "" {
+		entries, _ := os.ReadDir(filepath.Join(projectRoot, filepath.FromSlash(phaseDir)))
+		for _, entry := range entries {
+			if entry.IsDir() && DirNumber(entry.Name()) == featureNumber {
+				return phaseDir + "/" + entry.Name()
+			}
+		}
+	}
+	return fmt.Sprintf("%s/%02d-Feature", phaseDir, featureNumber)
+}
+
+// FeatureForDirs resolves a feature from its "NN-phase" and "NN-..." directory
+// names, as task references in commit messages give them
+func FeatureForDirs(state *models.State, phaseDir, featureDir string) *models.Feature {
+	phaseNumber := DirNumber(phaseDir)
+	featureNumber := DirNumber(featureDir)
+	if phaseNumber < 1 || phaseNumber > len(state.Phases) {
+		return nil
+	}
+
+	phase := state.Phases[phaseNumber-1]
+	if featureNumber < 1 || featureNumber > len(phase.Features) {
+		return nil
+	}
+
+	featureID := phase.Features[featureNumber-1]
+	for i := range state.Features {
+		if state.Features[i].ID == featureID {
+			return &state.Features[i]
+		}
+	}
+	return nil
+}
+
+// DirNumber returns the number an "NN-..." directory name starts with, or 0
+func DirNumber(dir string) int {
+	prefix, _, _ := strings.Cut(dir, "-")
+	n, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// featurePosition returns the number of the phase listing a feature and of the
+// feature within it, counting from 1, or zeros when no phase lists it
+func featurePosition(state *models.State, featureID string) (int, int) {
+	if state == nil {
+		return 0, 0
+	}
+	for i, phase := range state.Phases {
+		for j, id := range phase.Features {
+			if id == featureID {
+				return i + 1, j + 1
+			}
+		}
+	}
+	return 0, 0
+}
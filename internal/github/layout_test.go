@@ -0,0 +1,38 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureDir(t *testing.T) {
+	state := prTestState()
+
+	assert.Equal(t, "doplan/01-phase/02-Feature", FeatureDir("", state, &state.Features[1]))
+	assert.Equal(t, "doplan/phase-9/orphan", FeatureDir("", state, &models.Feature{ID: "orphan", Phase: "phase-9"}))
+	assert.Equal(t, "doplan/phase-1/auth", FeatureDir("", nil, &state.Features[0]))
+
+	// A renamed feature directory is found by its number
+	projectRoot := helpers.CreateTempProject(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(projectRoot, "doplan", "01-phase", "02-profile"), 0755))
+	assert.Equal(t, "doplan/01-phase/02-profile", FeatureDir(projectRoot, state, &state.Features[1]))
+	assert.Equal(t, "doplan/01-phase/01-Feature", FeatureDir(projectRoot, state, &state.Features[0]))
+}
+
+func TestFeatureForDirs(t *testing.T) {
+	state := prTestState()
+
+	feature := FeatureForDirs(state, "01-phase", "02-profile")
+	require.NotNil(t, feature)
+	assert.Equal(t, "profile", feature.ID)
+
+	assert.Nil(t, FeatureForDirs(state, "02-phase", "01-Feature"))
+	assert.Nil(t, FeatureForDirs(state, "01-phase", "03-Feature"))
+	assert.Nil(t, FeatureForDirs(state, "phase", "01-Feature"))
+}
@@ -18,15 +18,47 @@ func NewPRManager(repoPath string) *PRManager {
 	}
 }
 
+// PullRequestOptions describes a pull request to open
+type PullRequestOptions struct {
+	Head      string
+	Base      string
+	Title     string
+	Body      string
+	Draft     bool
+	Reviewers []string
+	Labels    []string
+}
+
 // CreatePullRequest creates a pull request using GitHub CLI
 func (prm *PRManager) CreatePullRequest(branchName, title, body, baseBranch string) (string, error) {
+	return prm.CreatePullRequestWithOptions(PullRequestOptions{
+		Head:  branchName,
+		Base:  baseBranch,
+		Title: title,
+		Body:  body,
+	})
+}
+
+// CreatePullRequestWithOptions creates a pull request, optionally as a draft with reviewers and labels
+func (prm *PRManager) CreatePullRequestWithOptions(opts PullRequestOptions) (string, error) {
+	args := []string{"pr", "create",
+		"--title", opts.Title,
+		"--body", opts.Body,
+		"--base", opts.Base,
+		"--head", opts.Head,
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	for _, reviewer := range opts.Reviewers {
+		args = append(args, "--reviewer", reviewer)
+	}
+	for _, label := range opts.Labels {
+		args = append(args, "--label", label)
+	}
+
 	// Use GitHub CLI to create PR
-	cmd := exec.Command("gh", "pr", "create",
-		"--title", title,
-		"--body", body,
-		"--base", baseBranch,
-		"--head", branchName,
-	)
+	cmd := exec.Command("gh", args...)
 	cmd.Dir = prm.repoPath
 
 	output, err := cmd.CombinedOutput()
@@ -46,6 +78,39 @@ func (prm *PRManager) CreatePullRequest(branchName, title, body, baseBranch stri
 	return outputStr, nil
 }
 
+// MarkReady marks a draft pull request as ready for review
+func (prm *PRManager) MarkReady(prURL string) error {
+	cmd := exec.Command("gh", "pr", "ready", prURL)
+	cmd.Dir = prm.repoPath
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to mark PR ready: %s, output: %s", err, string(output))
+	}
+	return nil
+}
+
+// UpdateBody replaces the body of an existing pull request
+func (prm *PRManager) UpdateBody(prURL, body string) error {
+	cmd := exec.Command("gh", "pr", "edit", prURL, "--body", body)
+	cmd.Dir = prm.repoPath
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to update PR: %s, output: %s", err, string(output))
+	}
+	return nil
+}
+
+// Retarget changes the base branch of an existing pull request
+func (prm *PRManager) Retarget(prURL, baseBranch string) error {
+	cmd := exec.Command("gh", "pr", "edit", prURL, "--base", baseBranch)
+	cmd.Dir = prm.repoPath
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to retarget PR: %s, output: %s", err, string(output))
+	}
+	return nil
+}
+
 // GeneratePRBody generates PR body from feature information
 func GeneratePRBody(featureName, planPath, designPath, tasksPath string) string {
 	var body strings.Builder
@@ -0,0 +1,224 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/internal/checkpoint"
+	"github.com/DoPlan-dev/CLI/internal/template"
+	"github.com/DoPlan-dev/CLI/internal/utils"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+const (
+	// PRTitleTemplate is the template in doplan/templates that renders PR titles
+	PRTitleTemplate = "pr-title-template.md"
+	// PRBodyTemplate is the template in doplan/templates that renders PR bodies
+	PRBodyTemplate = "pr-body-template.md"
+)
+
+// DefaultPRTitleTemplate renders the PR title used before templates existed
+const DefaultPRTitleTemplate = `Feature: {{.Feature.Name}}`
+
+// DefaultPRBodyTemplate lays out a PR body with the feature's plan, tasks and checkpoint
+const DefaultPRBodyTemplate = `## Feature: {{.Feature.Name}}
+
+{{default .Feature.Description "This PR implements the feature as planned."}}
+{{if .Phase}}
+**Phase:** {{.Phase.Name}}
+{{end}}
+**Progress:** {{progressBar .Feature.Progress 20}}
+
+### Acceptance Criteria
+
+{{formatChecklist .Feature.Requirements}}
+
+### Objectives
+
+{{formatList .Feature.Objectives}}
+
+### Tasks
+
+{{taskChecklist .Feature.TaskPhases}}
+{{if .Dependencies}}
+### Dependencies
+{{range .Dependencies}}
+- **{{.Name}}** ({{.Status}}){{if .PR}} - {{.PR.URL}}{{end}}
+{{- end}}
+{{end}}
+### Planning Documents
+
+- [Plan]({{.Project.planPath}})
+- [Design]({{.Project.designPath}})
+- [Tasks]({{.Project.tasksPath}})
+{{if .Checkpoint}}
+### Checkpoint
+
+Latest checkpoint: ` + "`{{.Checkpoint.ID}}`" + ` - {{.Checkpoint.Name}} ({{.Checkpoint.CreatedAt.Format "2006-01-02 15:04"}})
+{{end}}`
+
+// PRContent is a rendered pull request ready to be opened
+type PRContent struct {
+	Title     string
+	Body      string
+	Reviewers []string
+	Labels    []string
+	Draft     bool
+}
+
+// RenderPR renders a feature's PR title and body through the project templates and
+// applies the reviewer and label rules from config
+func RenderPR(projectRoot string, cfg *models.Config, state *models.State, feature *models.Feature) (*PRContent, error) {
+	prCfg := models.PRConfig{}
+	if cfg != nil {
+		prCfg = cfg.GitHub.PR
+	}
+
+	titleName := prCfg.TitleTemplate
+	if titleName == "" {
+		titleName = PRTitleTemplate
+	}
+	bodyName := prCfg.BodyTemplate
+	if bodyName == "" {
+		bodyName = PRBodyTemplate
+	}
+
+	processor := template.NewProcessor(filepath.Join(projectRoot, "doplan", "templates"))
+	if err := loadPRTemplate(processor, projectRoot, titleName, DefaultPRTitleTemplate); err != nil {
+		return nil, err
+	}
+	if err := loadPRTemplate(processor, projectRoot, bodyName, DefaultPRBodyTemplate); err != nil {
+		return nil, err
+	}
+
+	data := BuildPRTemplateData(projectRoot, cfg, state, feature)
+
+	title, err := processor.ProcessTemplate(titleName, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render PR title: %w", err)
+	}
+	body, err := processor.ProcessTemplate(bodyName, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render PR body: %w", err)
+	}
+
+	reviewers, labels := PRReviewersAndLabels(prCfg, feature)
+
+	return &PRContent{
+		Title:     strings.TrimSpace(strings.SplitN(strings.TrimSpace(title), "\n", 2)[0]),
+		Body:      strings.TrimSpace(utils.CollapseBlankLines(body)) + "\n",
+		Reviewers: reviewers,
+		Labels:    labels,
+		Draft:     prCfg.Draft && feature.Status != "complete",
+	}, nil
+}
+
+// BuildPRTemplateData gathers the phase, dependencies, checkpoint and document links for a feature
+func BuildPRTemplateData(projectRoot string, cfg *models.Config, state *models.State, feature *models.Feature) template.TemplateData {
+	featureDir := FeatureDir(projectRoot, state, feature)
+
+	data := template.TemplateData{
+		Feature:      feature,
+		Config:       cfg,
+		Dependencies: []models.Feature{},
+		Project: map[string]interface{}{
+			"featureDir": featureDir,
+			"planPath":   featureDir + "/plan.md",
+			"designPath": featureDir + "/design.md",
+			"tasksPath":  featureDir + "/tasks.md",
+		},
+	}
+
+	if state != nil {
+		for i := range state.Phases {
+			if state.Phases[i].ID == feature.Phase {
+				data.Phase = &state.Phases[i]
+				break
+			}
+		}
+
+		for _, depID := range feature.Dependencies {
+			for _, f := range state.Features {
+				if f.ID == depID {
+					data.Dependencies = append(data.Dependencies, f)
+					break
+				}
+			}
+		}
+	}
+
+	if feature.CheckpointID != "" {
+		checkpoints, err := checkpoint.NewCheckpointManager(projectRoot).ListCheckpoints()
+		if err == nil {
+			for _, cp := range checkpoints {
+				if cp.ID == feature.CheckpointID {
+					data.Checkpoint = cp
+					break
+				}
+			}
+		}
+	}
+
+	return data
+}
+
+// PRReviewersAndLabels combines the global reviewers and labels with those of every
+// rule that matches the feature
+func PRReviewersAndLabels(prCfg models.PRConfig, feature *models.Feature) ([]string, []string) {
+	reviewers := append([]string{}, prCfg.Reviewers...)
+	labels := append([]string{}, prCfg.Labels...)
+
+	for _, rule := range prCfg.Rules {
+		if !ruleMatches(rule, feature) {
+			continue
+		}
+		reviewers = append(reviewers, rule.Reviewers...)
+		labels = append(labels, rule.Labels...)
+	}
+
+	return dedupe(reviewers), dedupe(labels)
+}
+
+func ruleMatches(rule models.PRRule, feature *models.Feature) bool {
+	if rule.Phase != "" && rule.Phase != feature.Phase {
+		return false
+	}
+	if rule.Feature == "" {
+		return true
+	}
+
+	for _, candidate := range []string{feature.ID, utils.Slugify(feature.Name)} {
+		if matched, err := path.Match(rule.Feature, candidate); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPRTemplate loads a project template, falling back to the built-in default
+func loadPRTemplate(processor *template.Processor, projectRoot, name, fallback string) error {
+	templatePath := filepath.Join(projectRoot, "doplan", "templates", name)
+	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
+		return processor.ParseTemplate(name, fallback)
+	}
+	if err := processor.LoadTemplate(name); err != nil {
+		return fmt.Errorf("failed to load %s: %w", name, err)
+	}
+	return nil
+}
+
+func dedupe(items []string) []string {
+	seen := make(map[string]bool)
+	result := []string{}
+	for _, item := range items {
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+	return result
+}
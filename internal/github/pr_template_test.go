@@ -0,0 +1,114 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func prTestState() *models.State {
+	return &models.State{
+		Phases: []models.Phase{
+			{ID: "phase-1", Name: "Foundation", Features: []string{"auth", "profile"}},
+		},
+		Features: []models.Feature{
+			{
+				ID:     "auth",
+				Phase:  "phase-1",
+				Name:   "User Authentication",
+				Status: "complete",
+				PR:     &models.PullRequest{Number: 3, URL: "https://github.com/test/repo/pull/3"},
+			},
+			{
+				ID:           "profile",
+				Phase:        "phase-1",
+				Name:         "User Profile",
+				Description:  "Profile page for signed-in users",
+				Status:       "in-progress",
+				Progress:     50,
+				Branch:       "feature/phase-1-profile-user-profile",
+				Requirements: []string{"Users can edit their name"},
+				Dependencies: []string{"auth"},
+				TaskPhases: []models.TaskPhase{
+					{Name: "Backend", Tasks: []models.Task{
+						{Name: "Add profile endpoint", Completed: true},
+						{Name: "Validate input"},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderPR_DefaultTemplates(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	state := prTestState()
+
+	content, err := RenderPR(projectRoot, &models.Config{}, state, &state.Features[1])
+	require.NoError(t, err)
+
+	assert.Equal(t, "Feature: User Profile", content.Title)
+	assert.Contains(t, content.Body, "Profile page for signed-in users")
+	assert.Contains(t, content.Body, "**Phase:** Foundation")
+	assert.Contains(t, content.Body, "- [ ] Users can edit their name")
+	assert.Contains(t, content.Body, "- [x] Add profile endpoint")
+	assert.Contains(t, content.Body, "- [ ] Validate input")
+	assert.Contains(t, content.Body, "**User Authentication** (complete) - https://github.com/test/repo/pull/3")
+	assert.Contains(t, content.Body, "[Design](doplan/01-phase/02-Feature/design.md)")
+	assert.NotContains(t, content.Body, "Checkpoint")
+	assert.False(t, content.Draft)
+}
+
+func TestRenderPR_ProjectTemplates(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	templatesDir := filepath.Join(projectRoot, "doplan", "templates")
+	require.NoError(t, os.MkdirAll(templatesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, PRTitleTemplate), []byte("feat({{.Feature.ID}}): {{.Feature.Name}}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "custom-body.md"), []byte("Closes {{.Feature.ID}}\n\n\n{{.Project.tasksPath}}\n"), 0644))
+
+	cfg := &models.Config{}
+	cfg.GitHub.PR.BodyTemplate = "custom-body.md"
+	cfg.GitHub.PR.Draft = true
+	state := prTestState()
+
+	content, err := RenderPR(projectRoot, cfg, state, &state.Features[1])
+	require.NoError(t, err)
+
+	assert.Equal(t, "feat(profile): User Profile", content.Title)
+	assert.Equal(t, "Closes profile\n\ndoplan/01-phase/02-Feature/tasks.md\n", content.Body)
+	assert.True(t, content.Draft)
+}
+
+func TestRenderPR_InvalidTemplate(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	templatesDir := filepath.Join(projectRoot, "doplan", "templates")
+	require.NoError(t, os.MkdirAll(templatesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, PRBodyTemplate), []byte("{{.Feature.Name"), 0644))
+
+	state := prTestState()
+	_, err := RenderPR(projectRoot, nil, state, &state.Features[1])
+	assert.Error(t, err)
+}
+
+func TestPRReviewersAndLabels(t *testing.T) {
+	prCfg := models.PRConfig{
+		Reviewers: []string{"lead"},
+		Labels:    []string{"doplan"},
+		Rules: []models.PRRule{
+			{Phase: "phase-1", Labels: []string{"foundation"}},
+			{Feature: "user-*", Reviewers: []string{"ux-team", "lead"}},
+			{Feature: "billing*", Reviewers: []string{"finance"}},
+			{Phase: "phase-2", Labels: []string{"later"}},
+		},
+	}
+	feature := &models.Feature{ID: "profile", Phase: "phase-1", Name: "User Profile"}
+
+	reviewers, labels := PRReviewersAndLabels(prCfg, feature)
+	assert.Equal(t, []string{"lead", "ux-team"}, reviewers)
+	assert.Equal(t, []string{"doplan", "foundation"}, labels)
+}
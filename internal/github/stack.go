@@ -0,0 +1,344 @@
+package github
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// maxStackDepth guards against dependency cycles when walking stacks
+const maxStackDepth = 32
+
+// StackEntry is one feature in a stack of dependent branches
+type StackEntry struct {
+	Feature *models.Feature
+	Base    string // Branch the feature builds on
+	Depth   int    // 0 for the bottom of the stack
+}
+
+// RestackOptions controls how children are moved after their parent merges
+type RestackOptions struct {
+	DefaultBase string // Branch features merge into
+	DryRun      bool
+	Push        bool // Force-push rebased branches to origin
+}
+
+// RestackResult describes what happened to one stacked branch
+type RestackResult struct {
+	Feature    string `json:"feature"`
+	Branch     string `json:"branch"`
+	OldBase    string `json:"oldBase"`
+	NewBase    string `json:"newBase"`
+	Rebased    bool   `json:"rebased"`
+	Retargeted bool   `json:"retargeted"`
+	Error      string `json:"error,omitempty"`
+}
+
+// StackManager creates stacked feature branches and restacks them when parents merge
+type StackManager struct {
+	repoPath string
+	repo     *git.Repository
+}
+
+// NewStackManager creates a new stack manager
+func NewStackManager(repoPath string) (*StackManager, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	return &StackManager{
+		repoPath: repoPath,
+		repo:     repo,
+	}, nil
+}
+
+// IsFeatureMerged reports whether the feature's pull request has been merged
+func IsFeatureMerged(feature *models.Feature) bool {
+	return feature.PR != nil && strings.EqualFold(feature.PR.Status, "merged")
+}
+
+// ParentFeature returns the dependency a feature stacks on: the first dependency
+// with a branch that has not merged yet
+func ParentFeature(state *models.State, feature *models.Feature) *models.Feature {
+	if state == nil {
+		return nil
+	}
+	for _, depID := range feature.Dependencies {
+		for i := range state.Features {
+			dep := &state.Features[i]
+			if dep.ID == depID && dep.ID != feature.ID && dep.Branch != "" && !IsFeatureMerged(dep) {
+				return dep
+			}
+		}
+	}
+	return nil
+}
+
+// StackBase returns the branch a feature's branch and PR should build on:
+// its parent's branch, or defaultBase once every parent has merged
+func StackBase(state *models.State, feature *models.Feature, defaultBase string) string {
+	if parent := ParentFeature(state, feature); parent != nil {
+		return parent.Branch
+	}
+	return defaultBase
+}
+
+// Stacks groups features with open branches into stacks, bottom first.
+// Only stacks of two or more features are returned.
+func Stacks(state *models.State, defaultBase string) [][]StackEntry {
+	if state == nil {
+		return nil
+	}
+
+	children := make(map[string][]*models.Feature)
+	var roots []*models.Feature
+	for i := range state.Features {
+		feature := &state.Features[i]
+		if feature.Branch == "" || IsFeatureMerged(feature) {
+			continue
+		}
+		if parent := ParentFeature(state, feature); parent != nil {
+			children[parent.ID] = append(children[parent.ID], feature)
+		} else {
+			roots = append(roots, feature)
+		}
+	}
+
+	var stacks [][]StackEntry
+	for _, root := range roots {
+		if len(children[root.ID]) == 0 {
+			continue
+		}
+
+		var stack []StackEntry
+		var walk func(feature *models.Feature, base string, depth int)
+		walk = func(feature *models.Feature, base string, depth int) {
+			if depth > maxStackDepth {
+				return
+			}
+			stack = append(stack, StackEntry{Feature: feature, Base: base, Depth: depth})
+			for _, child := range children[feature.ID] {
+				walk(child, feature.Branch, depth+1)
+			}
+		}
+		walk(root, defaultBase, 0)
+		stacks = append(stacks, stack)
+	}
+
+	return stacks
+}
+
+// PendingRestacks lists features whose branch was created on a base that has since changed,
+// e.g. because the parent feature merged
+func PendingRestacks(state *models.State, defaultBase string) []*models.Feature {
+	if state == nil {
+		return nil
+	}
+
+	var pending []*models.Feature
+	for i := range state.Features {
+		feature := &state.Features[i]
+		if feature.Branch == "" || feature.BaseBranch == "" || IsFeatureMerged(feature) {
+			continue
+		}
+		if feature.BaseBranch != StackBase(state, feature, defaultBase) {
+			pending = append(pending, feature)
+		}
+	}
+	return pending
+}
+
+// UpdateFeaturePRs copies pull request status from synced GitHub data onto features,
+// matching on the head branch. It returns the number of features updated.
+func UpdateFeaturePRs(state *models.State, prs []models.PullRequest) int {
+	updated := 0
+	for i := range state.Features {
+		feature := &state.Features[i]
+		if feature.Branch == "" {
+			continue
+		}
+
+		for _, pr := range prs {
+			if pr.Branch != feature.Branch {
+				continue
+			}
+			status := strings.ToLower(pr.Status)
+			if feature.PR != nil && feature.PR.URL == pr.URL && feature.PR.Status == status && feature.PR.Number == pr.Number &&
+				feature.PR.CreatedAt == pr.CreatedAt && feature.PR.MergedAt == pr.MergedAt {
+				break
+			}
+
+			draft := status == "open" && (pr.Draft || (feature.PR != nil && feature.PR.Draft))
+			feature.PR = &models.PullRequest{
+				Number:    pr.Number,
+				Title:     pr.Title,
+				URL:       pr.URL,
+				Status:    status,
+				Branch:    pr.Branch,
+				Draft:     draft,
+				CreatedAt: pr.CreatedAt,
+				MergedAt:  pr.MergedAt,
+			}
+			updated++
+			break
+		}
+	}
+	return updated
+}
+
+// StartFeature creates the feature's branch on top of its parent's branch (or defaultBase)
+// and records the branch and its base on the feature
+func (sm *StackManager) StartFeature(state *models.State, feature *models.Feature, defaultBase string) error {
+	if feature.Branch != "" {
+		return fmt.Errorf("feature %s already has branch %s", feature.ID, feature.Branch)
+	}
+
+	base := StackBase(state, feature, defaultBase)
+	branchName := GenerateBranchName(feature.Phase, feature.ID, feature.Name)
+
+	bm := &BranchManager{repoPath: sm.repoPath, repo: sm.repo}
+	if err := bm.CreateFeatureBranchFrom(branchName, base); err != nil {
+		return err
+	}
+
+	feature.Branch = branchName
+	feature.BaseBranch = base
+	return nil
+}
+
+// Restack moves every stacked branch onto its current base. Children of a merged
+// parent are rebased onto the parent's base and their PRs retargeted; branches
+// stacked on a rebased branch are rebased onto its new tip.
+func (sm *StackManager) Restack(state *models.State, opts RestackOptions) ([]RestackResult, error) {
+	currentBranch := ""
+	if head, err := sm.repo.Head(); err == nil && head.Name().IsBranch() {
+		currentBranch = head.Name().Short()
+	}
+
+	// Parents first so their children see the rewritten tips
+	var order []*models.Feature
+	for i := range state.Features {
+		feature := &state.Features[i]
+		if feature.Branch != "" && feature.BaseBranch != "" && !IsFeatureMerged(feature) {
+			order = append(order, feature)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return stackDepth(state, order[i]) < stackDepth(state, order[j])
+	})
+
+	prManager := NewPRManager(sm.repoPath)
+	rewritten := make(map[string]plumbing.Hash)
+	var results []RestackResult
+	rebasedAny := false
+
+	for _, feature := range order {
+		newBase := StackBase(state, feature, opts.DefaultBase)
+
+		// Rebase the commits after upstream onto newBase
+		var upstream string
+		retarget := false
+		if feature.BaseBranch != newBase {
+			upstream = feature.BaseBranch
+			if _, err := sm.resolveBranch(upstream); err != nil {
+				upstream = newBase
+			}
+			retarget = true
+		} else if oldTip, ok := rewritten[newBase]; ok {
+			upstream = oldTip.String()
+		} else {
+			continue
+		}
+
+		result := RestackResult{
+			Feature: feature.ID,
+			Branch:  feature.Branch,
+			OldBase: feature.BaseBranch,
+			NewBase: newBase,
+		}
+		if opts.DryRun {
+			rewritten[feature.Branch] = plumbing.ZeroHash
+			results = append(results, result)
+			continue
+		}
+
+		oldTip, err := sm.resolveBranch(feature.Branch)
+		if err != nil {
+			result.Error = fmt.Sprintf("branch %s not found", feature.Branch)
+			results = append(results, result)
+			continue
+		}
+
+		if err := sm.git("rebase", "--onto", newBase, upstream, feature.Branch); err != nil {
+			sm.git("rebase", "--abort")
+			result.Error = fmt.Sprintf("rebase failed, resolve manually with 'git rebase --onto %s %s %s'", newBase, upstream, feature.Branch)
+			results = append(results, result)
+			continue
+		}
+		rebasedAny = true
+		result.Rebased = true
+		rewritten[feature.Branch] = oldTip
+
+		if opts.Push {
+			if err := sm.git("push", "--force-with-lease", "origin", feature.Branch); err != nil {
+				result.Error = err.Error()
+			}
+		}
+
+		if retarget && feature.PR != nil && feature.PR.URL != "" && strings.EqualFold(feature.PR.Status, "open") {
+			if err := prManager.Retarget(feature.PR.URL, newBase); err != nil {
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+			result.Retargeted = true
+		}
+
+		feature.BaseBranch = newBase
+		results = append(results, result)
+	}
+
+	if rebasedAny && currentBranch != "" {
+		if err := sm.git("checkout", currentBranch); err != nil {
+			return results, fmt.Errorf("failed to return to %s: %w", currentBranch, err)
+		}
+	}
+
+	return results, nil
+}
+
+// stackDepth counts how many unmerged parents a feature is stacked on
+func stackDepth(state *models.State, feature *models.Feature) int {
+	depth := 0
+	for parent := ParentFeature(state, feature); parent != nil && depth < maxStackDepth; parent = ParentFeature(state, parent) {
+		depth++
+	}
+	return depth
+}
+
+func (sm *StackManager) resolveBranch(name string) (plumbing.Hash, error) {
+	if ref, err := sm.repo.Reference(plumbing.NewBranchReferenceName(name), true); err == nil {
+		return ref.Hash(), nil
+	}
+	ref, err := sm.repo.Reference(plumbing.NewRemoteReferenceName("origin", name), true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return ref.Hash(), nil
+}
+
+func (sm *StackManager) git(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = sm.repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %s, output: %s", strings.Join(args, " "), err, string(output))
+	}
+	return nil
+}
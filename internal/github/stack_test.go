@@ -0,0 +1,156 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stackTestState() *models.State {
+	return &models.State{
+		Features: []models.Feature{
+			{ID: "auth", Phase: "phase-1", Name: "User Auth", Branch: "feature/auth", BaseBranch: "main"},
+			{ID: "profile", Phase: "phase-1", Name: "User Profile", Branch: "feature/profile", BaseBranch: "feature/auth", Dependencies: []string{"auth"}},
+			{ID: "avatar", Phase: "phase-1", Name: "Avatar", Branch: "feature/avatar", BaseBranch: "feature/profile", Dependencies: []string{"profile"}},
+			{ID: "billing", Phase: "phase-2", Name: "Billing", Branch: "feature/billing", BaseBranch: "main"},
+		},
+	}
+}
+
+func TestStackBase(t *testing.T) {
+	state := stackTestState()
+
+	assert.Equal(t, "main", StackBase(state, &state.Features[0], "main"))
+	assert.Equal(t, "feature/auth", StackBase(state, &state.Features[1], "main"))
+
+	// Once the parent merges, children target the default base
+	state.Features[0].PR = &models.PullRequest{URL: "https://github.com/test/repo/pull/1", Status: "merged"}
+	assert.Equal(t, "main", StackBase(state, &state.Features[1], "main"))
+	assert.Nil(t, ParentFeature(state, &state.Features[1]))
+}
+
+func TestStacks(t *testing.T) {
+	state := stackTestState()
+
+	stacks := Stacks(state, "main")
+	require.Len(t, stacks, 1, "billing has no children and is not a stack")
+	require.Len(t, stacks[0], 3)
+	assert.Equal(t, "auth", stacks[0][0].Feature.ID)
+	assert.Equal(t, "main", stacks[0][0].Base)
+	assert.Equal(t, "avatar", stacks[0][2].Feature.ID)
+	assert.Equal(t, 2, stacks[0][2].Depth)
+}
+
+func TestStacks_DependencyCycle(t *testing.T) {
+	state := &models.State{
+		Features: []models.Feature{
+			{ID: "a", Branch: "feature/a", Dependencies: []string{"b"}},
+			{ID: "b", Branch: "feature/b", Dependencies: []string{"a"}},
+		},
+	}
+
+	// Neither feature is a root, so no stack is reported and nothing loops
+	assert.Empty(t, Stacks(state, "main"))
+	assert.Equal(t, maxStackDepth, stackDepth(state, &state.Features[0]))
+}
+
+func TestPendingRestacks(t *testing.T) {
+	state := stackTestState()
+	assert.Empty(t, PendingRestacks(state, "main"))
+
+	state.Features[0].PR = &models.PullRequest{Status: "merged"}
+	pending := PendingRestacks(state, "main")
+	require.Len(t, pending, 1)
+	assert.Equal(t, "profile", pending[0].ID)
+}
+
+func TestUpdateFeaturePRs(t *testing.T) {
+	state := stackTestState()
+	state.Features[1].PR = &models.PullRequest{URL: "https://github.com/test/repo/pull/2", Status: "open", Draft: true}
+
+	updated := UpdateFeaturePRs(state, []models.PullRequest{
+		{Number: 1, URL: "https://github.com/test/repo/pull/1", Status: "MERGED", Branch: "feature/auth"},
+		{Number: 2, URL: "https://github.com/test/repo/pull/2", Status: "OPEN", Branch: "feature/profile"},
+		{Number: 9, URL: "https://github.com/test/repo/pull/9", Status: "OPEN", Branch: "feature/unrelated"},
+	})
+
+	assert.Equal(t, 2, updated)
+	assert.True(t, IsFeatureMerged(&state.Features[0]))
+	assert.Equal(t, 2, state.Features[1].PR.Number)
+	assert.True(t, state.Features[1].PR.Draft)
+	assert.Nil(t, state.Features[3].PR)
+}
+
+func TestStackManager_StartAndRestack(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	projectRoot := helpers.CreateTempProject(t)
+	repo, err := git.PlainInit(projectRoot, false)
+	require.NoError(t, err)
+
+	now := time.Now()
+	commitFile(t, repo, projectRoot, "base.txt", now.Add(-time.Hour))
+	head, err := repo.Head()
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), head.Hash())))
+	checkout(t, repo, "main", false)
+
+	state := &models.State{
+		Features: []models.Feature{
+			{ID: "auth", Phase: "phase-1", Name: "User Auth"},
+			{ID: "profile", Phase: "phase-1", Name: "User Profile", Dependencies: []string{"auth"}},
+		},
+	}
+	auth, profile := &state.Features[0], &state.Features[1]
+
+	stackMgr, err := NewStackManager(projectRoot)
+	require.NoError(t, err)
+
+	require.NoError(t, stackMgr.StartFeature(state, auth, "main"))
+	assert.Equal(t, "main", auth.BaseBranch)
+	commitFile(t, repo, projectRoot, "auth.txt", now.Add(-50*time.Minute))
+
+	require.NoError(t, stackMgr.StartFeature(state, profile, "main"))
+	assert.Equal(t, auth.Branch, profile.BaseBranch)
+	profileCommit := commitFile(t, repo, projectRoot, "profile.txt", now.Add(-40*time.Minute))
+
+	assert.Error(t, stackMgr.StartFeature(state, profile, "main"), "branch already exists")
+
+	// Squash-merge the parent into main
+	checkout(t, repo, "main", false)
+	mainTip := commitFile(t, repo, projectRoot, "auth.txt", now.Add(-30*time.Minute))
+	auth.PR = &models.PullRequest{Number: 1, Status: "merged", Branch: auth.Branch}
+
+	dryRun, err := stackMgr.Restack(state, RestackOptions{DefaultBase: "main", DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, dryRun, 1)
+	assert.Equal(t, auth.Branch, profile.BaseBranch, "dry run leaves state alone")
+
+	results, err := stackMgr.Restack(state, RestackOptions{DefaultBase: "main"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Rebased)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, "main", profile.BaseBranch)
+
+	// Only the child's own commit was replayed onto main
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(profile.Branch), true)
+	require.NoError(t, err)
+	tip, err := repo.CommitObject(ref.Hash())
+	require.NoError(t, err)
+	assert.NotEqual(t, profileCommit, tip.Hash)
+	assert.Equal(t, []plumbing.Hash{mainTip}, tip.ParentHashes)
+
+	head, err = repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, "main", head.Name().Short())
+}
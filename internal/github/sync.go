@@ -2,6 +2,7 @@ package github
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -9,13 +10,21 @@ import (
 	"sync"
 	"time"
 
+	"github.com/DoPlan-dev/CLI/internal/config"
 	"github.com/DoPlan-dev/CLI/pkg/models"
 )
 
+// maxCachedCommits caps the commit history kept in the cache
+const maxCachedCommits = 100
+
 // GitHubSync syncs GitHub data
 type GitHubSync struct {
-	repoPath string
-	cache    *githubCache
+	repoPath  string
+	cache     *githubCache
+	diskCache *DiskCache
+
+	// newAPIClient is replaceable in tests
+	newAPIClient func() (*APIClient, error)
 }
 
 type githubCache struct {
@@ -32,10 +41,16 @@ func NewGitHubSync(repoPath string) *GitHubSync {
 		cache: &githubCache{
 			ttl: 5 * time.Minute,
 		},
+		diskCache: NewDiskCache(repoPath),
+		newAPIClient: func() (*APIClient, error) {
+			return NewAPIClient(repoPath)
+		},
 	}
 }
 
-// Sync fetches GitHub data and updates github-data.json (with caching and parallel fetching)
+// Sync fetches GitHub data and updates github-data.json (with caching and parallel fetching).
+// Each source falls back to the on-disk cache when its fetch fails; the failure is
+// recorded in data.Sources rather than returned.
 func (gs *GitHubSync) Sync() (*models.GitHubData, error) {
 	// Check cache first
 	gs.cache.mu.RLock()
@@ -46,51 +61,70 @@ func (gs *GitHubSync) Sync() (*models.GitHubData, error) {
 	}
 	gs.cache.mu.RUnlock()
 
+	now := time.Now()
 	data := &models.GitHubData{
 		Branches: []models.Branch{},
 		Commits:  []models.Commit{},
 		PRs:      []models.PullRequest{},
 		Pushes:   []models.Push{},
+		SyncedAt: now.Format(time.RFC3339),
+		Sources:  make(map[string]models.SyncSource),
+	}
+
+	// Pushes and issues only arrive through webhooks, so carry them over
+	if previous, err := gs.LoadData(); err == nil {
+		data.Pushes = nonNil(previous.Pushes)
+		data.Issues = previous.Issues
+		data.Checks = previous.Checks
+		data.Workflows = previous.Workflows
 	}
 
 	// Parallel fetching using goroutines
 	var wg sync.WaitGroup
+	var mu sync.Mutex
+	record := func(source string, status models.SyncSource) {
+		mu.Lock()
+		data.Sources[source] = status
+		mu.Unlock()
+	}
 
 	// Fetch branches
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		branches, err := gs.fetchBranches()
-		if err == nil {
-			data.Branches = branches
-		}
+		branches, status := gs.syncBranches(now)
+		data.Branches = branches
+		record(SourceBranches, status)
 	}()
 
 	// Fetch commits
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		commits, err := gs.fetchCommits()
-		if err == nil {
-			data.Commits = commits
-		}
+		commits, status := gs.syncCommits(now)
+		data.Commits = commits
+		record(SourceCommits, status)
 	}()
 
 	// Fetch PRs
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		prs, err := gs.fetchPRs()
-		if err == nil {
-			data.PRs = prs
-		}
+		prs, status := gs.syncPRs(now)
+		data.PRs = prs
+		record(SourcePRs, status)
 	}()
 
 	// Wait for all fetches to complete
 	wg.Wait()
 
+	// Checks are fetched per feature branch and open PR, so they need the PR list
+	data.Sources[SourceChecks] = gs.syncChecks(now, data)
+
+	LinkBranchPRs(data)
+
 	// Save to file
-	if err := gs.saveData(data); err != nil {
+	if err := gs.SaveData(data); err != nil {
 		return nil, err
 	}
 
@@ -103,7 +137,125 @@ func (gs *GitHubSync) Sync() (*models.GitHubData, error) {
 	return data, nil
 }
 
+// syncBranches analyzes local branches, serving the cached list if analysis fails
+func (gs *GitHubSync) syncBranches(now time.Time) ([]models.Branch, models.SyncSource) {
+	var cached []models.Branch
+	entry, _ := gs.diskCache.Load(SourceBranches, &cached)
+
+	branches, err := gs.fetchBranches()
+	if err != nil {
+		return nonNil(cached), staleSource(entry, err)
+	}
+
+	branches = nonNil(branches)
+	gs.diskCache.Save(SourceBranches, &CacheEntry{FetchedAt: now}, branches)
+	return branches, freshSource(now)
+}
+
+// syncCommits fetches only the commits made since the newest cached commit
+func (gs *GitHubSync) syncCommits(now time.Time) ([]models.Commit, models.SyncSource) {
+	var cached []models.Commit
+	entry, _ := gs.diskCache.Load(SourceCommits, &cached)
+
+	lastHash := ""
+	if entry != nil && len(cached) > 0 {
+		lastHash = entry.LastHash
+	}
+
+	commits, err := gs.fetchCommitsSince(lastHash, cached)
+	if err != nil {
+		return nonNil(cached), staleSource(entry, err)
+	}
+
+	commits = nonNil(commits)
+	newEntry := &CacheEntry{FetchedAt: now}
+	if len(commits) > 0 {
+		newEntry.LastHash = commits[0].Hash
+	}
+	gs.diskCache.Save(SourceCommits, newEntry, commits)
+	return commits, freshSource(now)
+}
+
+// syncPRs asks the GitHub API for pull requests changed since the cached ETag,
+// falling back to the GitHub CLI when no API token is available
+func (gs *GitHubSync) syncPRs(now time.Time) ([]models.PullRequest, models.SyncSource) {
+	var cached []models.PullRequest
+	entry, _ := gs.diskCache.Load(SourcePRs, &cached)
+
+	client, err := gs.newAPIClient()
+	if err != nil {
+		prs, cliErr := gs.fetchPRs()
+		if cliErr != nil {
+			return nonNil(cached), staleSource(entry, cliErr)
+		}
+		prs = nonNil(prs)
+		gs.diskCache.Save(SourcePRs, &CacheEntry{FetchedAt: now}, prs)
+		return prs, freshSource(now)
+	}
+
+	etag, lastModified := "", ""
+	if entry != nil {
+		etag, lastModified = entry.ETag, entry.LastModified
+	}
+
+	prs, result, err := client.ListPullRequests(etag, lastModified)
+	if err != nil {
+		return nonNil(cached), staleSource(entry, err)
+	}
+
+	if result.NotModified && entry != nil {
+		entry.FetchedAt = now
+		gs.diskCache.Save(SourcePRs, entry, nonNil(cached))
+		status := freshSource(now)
+		status.NotModified = true
+		return nonNil(cached), status
+	}
+
+	prs = nonNil(prs)
+	gs.diskCache.Save(SourcePRs, &CacheEntry{
+		ETag:         result.ETag,
+		LastModified: result.LastModified,
+		FetchedAt:    now,
+	}, prs)
+	return prs, freshSource(now)
+}
+
+func freshSource(now time.Time) models.SyncSource {
+	return models.SyncSource{LastSynced: now.Format(time.RFC3339)}
+}
+
+// staleSource reports a failed fetch, keeping the time of the last successful one
+func staleSource(entry *CacheEntry, err error) models.SyncSource {
+	status := models.SyncSource{Stale: true, Error: err.Error()}
+	if entry != nil && !entry.FetchedAt.IsZero() {
+		status.LastSynced = entry.FetchedAt.Format(time.RFC3339)
+	}
+	return status
+}
+
+// commandError prefers a command's own output over the bare exit status
+func commandError(err error, output []byte) string {
+	if msg := strings.TrimSpace(string(output)); msg != "" {
+		return msg
+	}
+	return err.Error()
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
 func (gs *GitHubSync) fetchBranches() ([]models.Branch, error) {
+	// Prefer full hygiene analysis; fall back to remote branch names only
+	if analyzer, err := NewBranchAnalyzer(gs.repoPath); err == nil {
+		if branches, err := analyzer.Analyze(gs.branchAnalysisOptions()); err == nil {
+			return branches, nil
+		}
+	}
+
 	cmd := exec.Command("git", "branch", "-r")
 	cmd.Dir = gs.repoPath
 
@@ -136,20 +288,72 @@ func (gs *GitHubSync) fetchBranches() ([]models.Branch, error) {
 	return branches, nil
 }
 
+func (gs *GitHubSync) loadState() (*models.State, error) {
+	return config.NewManager(gs.repoPath).LoadState()
+}
+
+// branchAnalysisOptions builds analysis options from the project config and state
+func (gs *GitHubSync) branchAnalysisOptions() BranchAnalysisOptions {
+	opts := BranchAnalysisOptions{}
+
+	cfgMgr := config.NewManager(gs.repoPath)
+	if cfg, err := cfgMgr.LoadConfig(); err == nil && cfg != nil {
+		opts.BaseBranch = cfg.GitHub.BaseBranch
+		opts.Protected = cfg.GitHub.ProtectedBranches
+		if cfg.GitHub.StaleBranchDays > 0 {
+			opts.StaleAfter = time.Duration(cfg.GitHub.StaleBranchDays) * 24 * time.Hour
+		}
+	}
+	if state, err := cfgMgr.LoadState(); err == nil && len(state.Features) > 0 {
+		opts.State = state
+	}
+	// PRs are fetched alongside the branches, so use the ones from the last sync
+	if data, err := gs.LoadData(); err == nil {
+		opts.PRs = data.PRs
+	}
+
+	return opts
+}
+
+// LinkBranchPRs marks branches that have a pull request in data.PRs
+func LinkBranchPRs(data *models.GitHubData) {
+	for i := range data.Branches {
+		for _, pr := range data.PRs {
+			if pr.Branch != "" && pr.Branch == data.Branches[i].Name {
+				data.Branches[i].HasPR = true
+				data.Branches[i].PRURL = pr.URL
+				break
+			}
+		}
+	}
+}
+
 func (gs *GitHubSync) fetchCommits() ([]models.Commit, error) {
-	cmd := exec.Command("git", "log", "--pretty=format:%H|%s|%an|%ad", "--date=iso", "-20")
+	return gs.fetchCommitsSince("", nil)
+}
+
+// fetchCommitsSince reads commits after lastHash and prepends them to cached.
+// Without a usable lastHash (first run, rewritten history) the recent history is read in full.
+func (gs *GitHubSync) fetchCommitsSince(lastHash string, cached []models.Commit) ([]models.Commit, error) {
+	args := []string{"log", "--pretty=format:%H%x1f%s%x1f%an%x1f%ad", "--date=iso", fmt.Sprintf("--max-count=%d", maxCachedCommits)}
+	incremental := lastHash != "" && gs.isAncestorOfHead(lastHash)
+	if incremental {
+		args = append(args, lastHash+"..HEAD")
+	}
+
+	cmd := exec.Command("git", args...)
 	cmd.Dir = gs.repoPath
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("git log failed: %s", commandError(err, output))
 	}
 
 	var commits []models.Commit
 	lines := strings.Split(string(output), "\n")
 
 	for _, line := range lines {
-		parts := strings.Split(line, "|")
+		parts := strings.Split(line, "\x1f")
 		if len(parts) < 4 {
 			continue
 		}
@@ -164,40 +368,64 @@ func (gs *GitHubSync) fetchCommits() ([]models.Commit, error) {
 		commits = append(commits, commit)
 	}
 
+	if incremental {
+		commits = append(commits, cached...)
+		if len(commits) > maxCachedCommits {
+			commits = commits[:maxCachedCommits]
+		}
+	}
+
 	return commits, nil
 }
 
+func (gs *GitHubSync) isAncestorOfHead(hash string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", hash, "HEAD")
+	cmd.Dir = gs.repoPath
+	return cmd.Run() == nil
+}
+
 func (gs *GitHubSync) fetchPRs() ([]models.PullRequest, error) {
 	// Try to use GitHub CLI
-	cmd := exec.Command("gh", "pr", "list", "--json", "number,title,url,state")
+	cmd := exec.Command("gh", "pr", "list", "--state", "all", "--json", "number,title,url,state,headRefName,isDraft,createdAt,mergedAt")
 	cmd.Dir = gs.repoPath
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// GitHub CLI not available or not authenticated
-		return []models.PullRequest{}, nil
+		return nil, fmt.Errorf("GitHub CLI unavailable: %s", commandError(err, output))
 	}
 
 	var prs []models.PullRequest
 
 	// Parse JSON output
 	var ghPRs []struct {
-		Number int    `json:"number"`
-		Title  string `json:"title"`
-		URL    string `json:"url"`
-		State  string `json:"state"`
+		Number      int    `json:"number"`
+		Title       string `json:"title"`
+		URL         string `json:"url"`
+		State       string `json:"state"`
+		HeadRefName string `json:"headRefName"`
+		IsDraft     bool   `json:"isDraft"`
+		CreatedAt   string `json:"createdAt"`
+		MergedAt    string `json:"mergedAt"`
 	}
 
 	if err := json.Unmarshal(output, &ghPRs); err != nil {
-		return []models.PullRequest{}, nil
+		return nil, fmt.Errorf("failed to parse gh output: %w", err)
 	}
 
 	for _, ghPR := range ghPRs {
 		pr := models.PullRequest{
-			Number: ghPR.Number,
-			Title:  ghPR.Title,
-			URL:    ghPR.URL,
-			Status: ghPR.State,
+			Number:    ghPR.Number,
+			Title:     ghPR.Title,
+			URL:       ghPR.URL,
+			Status:    ghPR.State,
+			Branch:    ghPR.HeadRefName,
+			Draft:     ghPR.IsDraft,
+			CreatedAt: ghPR.CreatedAt,
+		}
+		// gh reports unmerged pull requests with a zero merge time
+		if ghPR.State == "MERGED" {
+			pr.MergedAt = ghPR.MergedAt
 		}
 		prs = append(prs, pr)
 	}
@@ -205,7 +433,8 @@ func (gs *GitHubSync) fetchPRs() ([]models.PullRequest, error) {
 	return prs, nil
 }
 
-func (gs *GitHubSync) saveData(data *models.GitHubData) error {
+// SaveData writes GitHub data to github-data.json
+func (gs *GitHubSync) SaveData(data *models.GitHubData) error {
 	dataPath := filepath.Join(gs.repoPath, "doplan", "github-data.json")
 
 	// Ensure directory exists
@@ -245,3 +474,26 @@ func (gs *GitHubSync) LoadData() (*models.GitHubData, error) {
 
 	return &githubData, nil
 }
+
+// FormatSyncAge renders an RFC3339 sync time as a short "5m ago" style age
+func FormatSyncAge(syncedAt string, now time.Time) string {
+	if syncedAt == "" {
+		return "never"
+	}
+	when, err := time.Parse(time.RFC3339, syncedAt)
+	if err != nil {
+		return syncedAt
+	}
+
+	age := now.Sub(when)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
@@ -0,0 +1,477 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/utils"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// Webhook events DoPlan understands
+const (
+	EventPing        = "ping"
+	EventPullRequest = "pull_request"
+	EventPush        = "push"
+	EventCheckRun    = "check_run"
+	EventIssues      = "issues"
+)
+
+// Headers GitHub sends with every delivery
+const (
+	EventHeader     = "X-GitHub-Event"
+	SignatureHeader = "X-Hub-Signature-256"
+	DeliveryHeader  = "X-GitHub-Delivery"
+)
+
+const (
+	// maxWebhookBody matches GitHub's own payload cap
+	maxWebhookBody = 25 << 20
+	// maxPushes caps the push activity kept in GitHub data
+	maxPushes = 50
+)
+
+// WebhookResult describes what one event changed
+type WebhookResult struct {
+	Event        string   `json:"event"`
+	Action       string   `json:"action,omitempty"`
+	Features     []string `json:"features,omitempty"` // IDs of the features the event touched
+	Summary      string   `json:"summary"`
+	StateChanged bool     `json:"stateChanged"`
+	DataChanged  bool     `json:"dataChanged"` // GitHub activity data changed
+}
+
+// Changed reports whether the event requires saving and a progress refresh
+func (r *WebhookResult) Changed() bool {
+	return r.StateChanged || r.DataChanged
+}
+
+// WebhookApplyFunc applies a verified event; calls are serialized by the handler
+type WebhookApplyFunc func(event string, body []byte) (*WebhookResult, error)
+
+// WebhookHandler receives GitHub webhook deliveries, verifies their HMAC signature
+// and passes supported events to apply
+type WebhookHandler struct {
+	secret   []byte
+	apply    WebhookApplyFunc
+	errorLog *log.Logger // Errors stay server-side; senders only get a generic message
+	mu       sync.Mutex
+}
+
+// NewWebhookHandler creates a handler that only accepts deliveries signed with secret
+func NewWebhookHandler(secret string, apply WebhookApplyFunc) *WebhookHandler {
+	return &WebhookHandler{
+		secret:   []byte(secret),
+		apply:    apply,
+		errorLog: log.New(os.Stderr, "webhook: ", log.LstdFlags),
+	}
+}
+
+// VerifySignature checks an X-Hub-Signature-256 header ("sha256=<hex>") against body
+func VerifySignature(secret []byte, signature string, body []byte) bool {
+	if len(secret) == 0 || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// SignPayload returns the X-Hub-Signature-256 value GitHub would send for body
+func SignPayload(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxWebhookBody {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !VerifySignature(h.secret, r.Header.Get(SignatureHeader), body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event := r.Header.Get(EventHeader)
+	switch event {
+	case "":
+		http.Error(w, "missing "+EventHeader+" header", http.StatusBadRequest)
+		return
+	case EventPing:
+		writeWebhookResult(w, http.StatusOK, &WebhookResult{Event: event, Summary: "pong"})
+		return
+	case EventPullRequest, EventPush, EventCheckRun, EventIssues:
+	default:
+		writeWebhookResult(w, http.StatusAccepted, &WebhookResult{Event: event, Summary: "event ignored"})
+		return
+	}
+
+	h.mu.Lock()
+	result, err := h.apply(event, body)
+	h.mu.Unlock()
+	if err != nil {
+		h.errorLog.Printf("failed to apply %s delivery %s: %v", event, r.Header.Get(DeliveryHeader), err)
+		http.Error(w, "failed to apply event", http.StatusInternalServerError)
+		return
+	}
+
+	writeWebhookResult(w, http.StatusOK, result)
+}
+
+func writeWebhookResult(w http.ResponseWriter, status int, result *WebhookResult) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
+
+// webhookPayload is the subset of the event payloads DoPlan reads
+type webhookPayload struct {
+	Action      string              `json:"action"`
+	PullRequest *pullRequestPayload `json:"pull_request"`
+
+	// push
+	Ref     string `json:"ref"`
+	Deleted bool   `json:"deleted"`
+	Commits []struct {
+		ID        string `json:"id"`
+		Message   string `json:"message"`
+		Timestamp string `json:"timestamp"`
+		Author    struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commits"`
+
+	// check_run
+	CheckRun *struct {
+		Name        string `json:"name"`
+		HeadSHA     string `json:"head_sha"`
+		Status      string `json:"status"`
+		Conclusion  string `json:"conclusion"`
+		HTMLURL     string `json:"html_url"`
+		CompletedAt string `json:"completed_at"`
+		CheckSuite  struct {
+			HeadBranch string `json:"head_branch"`
+		} `json:"check_suite"`
+		PullRequests []struct {
+			Head struct {
+				Ref string `json:"ref"`
+			} `json:"head"`
+		} `json:"pull_requests"`
+	} `json:"check_run"`
+
+	// issues
+	Issue *struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		HTMLURL string `json:"html_url"`
+		State   string `json:"state"`
+		Labels  []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	} `json:"issue"`
+}
+
+// ApplyWebhookEvent translates a webhook payload into state and GitHub data updates:
+// a merged PR completes its feature, a push records activity, a failed check flags
+// the feature and an issue labelled with a feature is tracked against it
+func ApplyWebhookEvent(state *models.State, data *models.GitHubData, event string, body []byte, now time.Time) (*WebhookResult, error) {
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse %s payload: %w", event, err)
+	}
+
+	result := &WebhookResult{Event: event, Action: payload.Action}
+	switch event {
+	case EventPullRequest:
+		applyPullRequestEvent(state, data, &payload, result, now)
+	case EventPush:
+		applyPushEvent(state, data, &payload, result, now)
+	case EventCheckRun:
+		applyCheckRunEvent(state, data, &payload, result, now)
+	case EventIssues:
+		applyIssuesEvent(state, data, &payload, result)
+	default:
+		result.Summary = "event ignored"
+	}
+
+	if result.Summary == "" {
+		result.Summary = "no matching feature"
+	}
+	return result, nil
+}
+
+func applyPullRequestEvent(state *models.State, data *models.GitHubData, payload *webhookPayload, result *WebhookResult, now time.Time) {
+	if payload.PullRequest == nil {
+		return
+	}
+	p := payload.PullRequest
+	pr := models.PullRequest{
+		Number:    p.Number,
+		Title:     p.Title,
+		URL:       p.HTMLURL,
+		Status:    p.status(),
+		Branch:    p.Head.Ref,
+		Draft:     p.Draft,
+		CreatedAt: p.CreatedAt,
+		MergedAt:  p.mergedAt(),
+	}
+
+	// Keep the PR list in GitHub data current
+	replaced := false
+	for i := range data.PRs {
+		if data.PRs[i].Number == pr.Number {
+			data.PRs[i] = pr
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		data.PRs = append([]models.PullRequest{pr}, data.PRs...)
+	}
+	LinkBranchPRs(data)
+	result.DataChanged = true
+
+	feature := FeatureForBranch(state, pr.Branch)
+	if feature == nil {
+		result.Summary = fmt.Sprintf("PR #%d updated", pr.Number)
+		return
+	}
+	if feature.Branch == "" {
+		feature.Branch = pr.Branch
+	}
+
+	UpdateFeaturePRs(state, []models.PullRequest{pr})
+	result.StateChanged = true
+	result.Features = []string{feature.ID}
+
+	if IsFeatureMerged(feature) {
+		mergedAt, err := time.Parse(time.RFC3339, pr.MergedAt)
+		if err != nil {
+			mergedAt = now
+		}
+		feature.SetStatus("complete", mergedAt)
+		feature.Progress = 100
+		result.Summary = fmt.Sprintf("PR #%d merged, %s complete", pr.Number, feature.Name)
+		return
+	}
+	result.Summary = fmt.Sprintf("PR #%d for %s is %s", pr.Number, feature.Name, strings.ToLower(pr.Status))
+}
+
+func applyPushEvent(state *models.State, data *models.GitHubData, payload *webhookPayload, result *WebhookResult, now time.Time) {
+	if !strings.HasPrefix(payload.Ref, "refs/heads/") || payload.Deleted {
+		result.Summary = "push ignored"
+		return
+	}
+	branch := strings.TrimPrefix(payload.Ref, "refs/heads/")
+
+	data.Pushes = append([]models.Push{{
+		Branch:      branch,
+		Status:      "pushed",
+		CommitCount: len(payload.Commits),
+		Timestamp:   now.Format(time.RFC3339),
+	}}, data.Pushes...)
+	if len(data.Pushes) > maxPushes {
+		data.Pushes = data.Pushes[:maxPushes]
+	}
+
+	// Newest commits first, as 'git log' lists them
+	known := make(map[string]bool, len(data.Commits))
+	for _, commit := range data.Commits {
+		known[commit.Hash] = true
+	}
+	var commits []models.Commit
+	for i := len(payload.Commits) - 1; i >= 0; i-- {
+		c := payload.Commits[i]
+		if known[c.ID] {
+			continue
+		}
+		commits = append(commits, models.Commit{
+			Hash:    c.ID,
+			Message: strings.SplitN(c.Message, "\n", 2)[0],
+			Author:  c.Author.Name,
+			Date:    c.Timestamp,
+			Branch:  branch,
+		})
+	}
+	data.Commits = append(commits, data.Commits...)
+	if len(data.Commits) > maxCachedCommits {
+		data.Commits = data.Commits[:maxCachedCommits]
+	}
+	result.DataChanged = true
+	result.Summary = fmt.Sprintf("%d commit(s) pushed to %s", len(payload.Commits), branch)
+
+	feature := FeatureForBranch(state, branch)
+	if feature == nil {
+		return
+	}
+	result.Features = []string{feature.ID}
+	if feature.Status == "" || feature.Status == "todo" {
+		feature.SetStatus("in-progress", now)
+		result.StateChanged = true
+	}
+}
+
+func applyCheckRunEvent(state *models.State, data *models.GitHubData, payload *webhookPayload, result *WebhookResult, now time.Time) {
+	run := payload.CheckRun
+	if run == nil {
+		return
+	}
+
+	branches := []string{run.CheckSuite.HeadBranch}
+	for _, pr := range run.PullRequests {
+		branches = append(branches, pr.Head.Ref)
+	}
+
+	// Every report goes into the check history, including queued and in-progress runs
+	var history []models.CheckRun
+	for _, branch := range branches {
+		if branch != "" && !containsCheckBranch(history, branch) {
+			history = append(history, models.CheckRun{
+				Name:        run.Name,
+				Status:      run.Status,
+				Conclusion:  run.Conclusion,
+				URL:         run.HTMLURL,
+				Branch:      branch,
+				HeadSHA:     run.HeadSHA,
+				CompletedAt: run.CompletedAt,
+			})
+		}
+	}
+	if len(history) > 0 {
+		RecordCheckRuns(data, history)
+		result.DataChanged = true
+	}
+
+	if payload.Action != "completed" {
+		result.Summary = fmt.Sprintf("Check '%s' %s", run.Name, strings.ReplaceAll(run.Status, "_", " "))
+		return
+	}
+
+	source := "check:" + run.Name
+	for _, branch := range branches {
+		feature := FeatureForBranch(state, branch)
+		if feature == nil || containsString(result.Features, feature.ID) {
+			continue
+		}
+
+		if IsFailedConclusion(run.Conclusion) {
+			reason := fmt.Sprintf("Check '%s' %s", run.Name, strings.ReplaceAll(run.Conclusion, "_", " "))
+			if run.HTMLURL != "" {
+				reason += " (" + run.HTMLURL + ")"
+			}
+			if feature.SetFlag(models.FeatureFlag{Source: source, Reason: reason, Since: now.Format(time.RFC3339)}) {
+				result.StateChanged = true
+			}
+			result.Summary = fmt.Sprintf("%s flagged: %s", feature.Name, reason)
+		} else {
+			if feature.ClearFlag(source, now) {
+				result.StateChanged = true
+			}
+			result.Summary = fmt.Sprintf("Check '%s' %s for %s", run.Name, run.Conclusion, feature.Name)
+		}
+		result.Features = append(result.Features, feature.ID)
+	}
+}
+
+func applyIssuesEvent(state *models.State, data *models.GitHubData, payload *webhookPayload, result *WebhookResult) {
+	if payload.Issue == nil {
+		return
+	}
+	p := payload.Issue
+	issue := models.Issue{
+		Number: p.Number,
+		Title:  p.Title,
+		URL:    p.HTMLURL,
+		State:  strings.ToLower(p.State),
+	}
+
+	// Issues are linked to features by a label naming the feature ID or its kebab-case name
+	for _, label := range p.Labels {
+		for _, feature := range state.Features {
+			if strings.EqualFold(label.Name, feature.ID) || strings.EqualFold(label.Name, utils.Slugify(feature.Name)) {
+				issue.Feature = feature.ID
+				break
+			}
+		}
+		if issue.Feature != "" {
+			break
+		}
+	}
+
+	if payload.Action == "deleted" {
+		data.Issues = removeIssue(data.Issues, issue.Number)
+	} else {
+		replaced := false
+		for i := range data.Issues {
+			if data.Issues[i].Number == issue.Number {
+				data.Issues[i] = issue
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			data.Issues = append([]models.Issue{issue}, data.Issues...)
+		}
+	}
+	result.DataChanged = true
+	result.Summary = fmt.Sprintf("Issue #%d %s", issue.Number, payload.Action)
+	if issue.Feature != "" {
+		result.Features = []string{issue.Feature}
+	}
+}
+
+func removeIssue(issues []models.Issue, number int) []models.Issue {
+	for i := range issues {
+		if issues[i].Number == number {
+			return append(issues[:i], issues[i+1:]...)
+		}
+	}
+	return issues
+}
+
+func containsCheckBranch(runs []models.CheckRun, branch string) bool {
+	for _, run := range runs {
+		if run.Branch == branch {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
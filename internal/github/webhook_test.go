@@ -0,0 +1,216 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var webhookSecret = []byte("It's a Secret to Everybody")
+
+func loadWebhookPayload(t *testing.T, name string) []byte {
+	body, err := os.ReadFile(filepath.Join("testdata", "webhooks", name))
+	require.NoError(t, err)
+	return body
+}
+
+func webhookTestState() *models.State {
+	return &models.State{
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "User Authentication", Status: "todo"},
+			{ID: "02", Phase: "01-phase", Name: "User Profile", Status: "todo", Branch: "feature/profile"},
+		},
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte("Hello, World!")
+
+	// Example from GitHub's webhook validation docs
+	signature := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	assert.True(t, VerifySignature(webhookSecret, signature, body))
+	assert.Equal(t, signature, SignPayload(webhookSecret, body))
+
+	assert.False(t, VerifySignature(webhookSecret, signature, []byte("Hello, World?")))
+	assert.False(t, VerifySignature([]byte("wrong"), signature, body))
+	assert.False(t, VerifySignature(webhookSecret, "sha1=abc", body))
+	assert.False(t, VerifySignature(nil, signature, body), "an empty secret never verifies")
+}
+
+func TestApplyWebhookEvent_PullRequestMerged(t *testing.T) {
+	state := webhookTestState()
+	data := &models.GitHubData{}
+
+	result, err := ApplyWebhookEvent(state, data, EventPullRequest, loadWebhookPayload(t, "pull_request_merged.json"), time.Now())
+	require.NoError(t, err)
+
+	assert.True(t, result.StateChanged)
+	assert.True(t, result.DataChanged)
+	assert.Equal(t, []string{"01"}, result.Features)
+
+	feature := state.Features[0]
+	assert.Equal(t, "feature/01-phase-01-user-authentication", feature.Branch)
+	assert.Equal(t, "complete", feature.Status)
+	assert.Equal(t, 100, feature.Progress)
+	require.NotNil(t, feature.PR)
+	assert.Equal(t, 12, feature.PR.Number)
+	assert.True(t, IsFeatureMerged(&feature))
+
+	require.Len(t, data.PRs, 1)
+	assert.Equal(t, "MERGED", data.PRs[0].Status)
+	assert.Equal(t, "2026-02-27T16:40:00Z", feature.PR.CreatedAt)
+	assert.Equal(t, "2026-03-02T10:15:00Z", feature.PR.MergedAt)
+
+	// The feature completed when the PR merged, not when the event arrived
+	require.Len(t, feature.StatusHistory, 1)
+	assert.Equal(t, models.StatusChange{Status: "complete", At: "2026-03-02T10:15:00Z"}, feature.StatusHistory[0])
+}
+
+func TestApplyWebhookEvent_Push(t *testing.T) {
+	state := webhookTestState()
+	data := &models.GitHubData{
+		Commits: []models.Commit{{Hash: "2222222222222222222222222222222222222222", Message: "feat(auth): add login form"}},
+	}
+	now := time.Date(2026, 3, 1, 9, 31, 0, 0, time.UTC)
+
+	result, err := ApplyWebhookEvent(state, data, EventPush, loadWebhookPayload(t, "push.json"), now)
+	require.NoError(t, err)
+
+	assert.True(t, result.DataChanged)
+	assert.True(t, result.StateChanged)
+	assert.Equal(t, "in-progress", state.Features[0].Status)
+	assert.Equal(t, []models.StatusChange{{Status: "in-progress", At: now.Format(time.RFC3339)}}, state.Features[0].StatusHistory)
+
+	require.Len(t, data.Pushes, 1)
+	assert.Equal(t, "feature/01-phase-01-user-authentication", data.Pushes[0].Branch)
+	assert.Equal(t, 2, data.Pushes[0].CommitCount)
+	assert.Equal(t, now.Format(time.RFC3339), data.Pushes[0].Timestamp)
+
+	// Only the unseen commit is added, newest first, with its subject line
+	require.Len(t, data.Commits, 2)
+	assert.Equal(t, "test(auth): cover login errors", data.Commits[0].Message)
+	assert.Equal(t, "feature/01-phase-01-user-authentication", data.Commits[0].Branch)
+}
+
+func TestApplyWebhookEvent_CheckRun(t *testing.T) {
+	state := webhookTestState()
+	data := &models.GitHubData{}
+	failed := loadWebhookPayload(t, "check_run_failed.json")
+
+	result, err := ApplyWebhookEvent(state, data, EventCheckRun, failed, time.Now())
+	require.NoError(t, err)
+	assert.True(t, result.StateChanged)
+	assert.Equal(t, []string{"01"}, result.Features, "the suite branch and PR head name the same feature once")
+
+	require.Len(t, data.Checks, 1, "the run is recorded once for its branch")
+	assert.Equal(t, CIStateFailure, BranchCIStatus(data, "feature/01-phase-01-user-authentication").State)
+
+	require.Len(t, state.Features[0].Flags, 1)
+	assert.Equal(t, "check:build", state.Features[0].Flags[0].Source)
+	assert.Contains(t, state.Features[0].Flags[0].Reason, "Check 'build' failure")
+
+	// Redelivery leaves the feature alone
+	result, err = ApplyWebhookEvent(state, data, EventCheckRun, failed, time.Now())
+	require.NoError(t, err)
+	assert.False(t, result.StateChanged)
+
+	// A passing run of the same check clears the flag
+	passed := bytes.Replace(failed, []byte(`"conclusion": "failure"`), []byte(`"conclusion": "success"`), 1)
+	result, err = ApplyWebhookEvent(state, data, EventCheckRun, passed, time.Now())
+	require.NoError(t, err)
+	assert.True(t, result.StateChanged)
+	assert.Empty(t, state.Features[0].Flags)
+}
+
+func TestApplyWebhookEvent_Issues(t *testing.T) {
+	state := webhookTestState()
+	data := &models.GitHubData{}
+	opened := loadWebhookPayload(t, "issues_opened.json")
+
+	result, err := ApplyWebhookEvent(state, data, EventIssues, opened, time.Now())
+	require.NoError(t, err)
+	assert.True(t, result.DataChanged)
+	assert.False(t, result.StateChanged)
+	require.Len(t, data.Issues, 1)
+	assert.Equal(t, "01", data.Issues[0].Feature)
+	assert.Equal(t, "open", data.Issues[0].State)
+
+	closed := bytes.Replace(opened, []byte(`"state": "open"`), []byte(`"state": "closed"`), 1)
+	_, err = ApplyWebhookEvent(state, data, EventIssues, closed, time.Now())
+	require.NoError(t, err)
+	require.Len(t, data.Issues, 1)
+	assert.Equal(t, "closed", data.Issues[0].State)
+}
+
+func TestApplyWebhookEvent_InvalidPayload(t *testing.T) {
+	_, err := ApplyWebhookEvent(webhookTestState(), &models.GitHubData{}, EventPush, []byte("not json"), time.Now())
+	assert.Error(t, err)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var applied []string
+	handler := NewWebhookHandler(string(webhookSecret), func(event string, body []byte) (*WebhookResult, error) {
+		applied = append(applied, event)
+		return &WebhookResult{Event: event, Summary: "ok", DataChanged: true}, nil
+	})
+
+	deliver := func(method, event string, body []byte, signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/webhook", bytes.NewReader(body))
+		req.Header.Set(EventHeader, event)
+		if signature != "" {
+			req.Header.Set(SignatureHeader, signature)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	body := loadWebhookPayload(t, "push.json")
+	signature := SignPayload(webhookSecret, body)
+
+	rec := deliver(http.MethodPost, EventPush, body, signature)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var result WebhookResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, EventPush, result.Event)
+	assert.True(t, result.DataChanged)
+
+	assert.Equal(t, http.StatusUnauthorized, deliver(http.MethodPost, EventPush, body, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, deliver(http.MethodPost, EventPush, body, SignPayload([]byte("wrong"), body)).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, deliver(http.MethodGet, EventPush, nil, "").Code)
+	assert.Equal(t, http.StatusBadRequest, deliver(http.MethodPost, "", body, signature).Code)
+	assert.Equal(t, http.StatusOK, deliver(http.MethodPost, EventPing, body, signature).Code)
+	assert.Equal(t, http.StatusAccepted, deliver(http.MethodPost, "star", body, signature).Code)
+
+	assert.Equal(t, []string{EventPush}, applied, "only verified, supported events are applied")
+}
+
+func TestWebhookHandler_ApplyErrorStaysServerSide(t *testing.T) {
+	handler := NewWebhookHandler(string(webhookSecret), func(event string, body []byte) (*WebhookResult, error) {
+		return nil, errors.New("failed to load state: open /home/dev/project/.doplan/state.json: permission denied")
+	})
+	var logged bytes.Buffer
+	handler.errorLog = log.New(&logged, "", 0)
+
+	body := loadWebhookPayload(t, "push.json")
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set(EventHeader, EventPush)
+	req.Header.Set(SignatureHeader, SignPayload(webhookSecret, body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "/home/dev/project")
+	assert.Contains(t, logged.String(), "/home/dev/project/.doplan/state.json")
+}
@@ -0,0 +1,44 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/internal/board"
+	"github.com/DoPlan-dev/CLI/internal/checkpoint"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/tasks"
+)
+
+// Run runs an action item and returns what it did. Starting a feature moves it
+// to in-progress through board.Move, so it gets its branch as on the board;
+// problems that do not stop the move are reported in the message.
+func Run(projectRoot string, item Item, refresh tasks.RefreshFunc) (string, error) {
+	switch item.Action {
+	case ActionSyncGitHub:
+		data, err := github.NewGitHubSync(projectRoot).Sync()
+		if err != nil {
+			return "", fmt.Errorf("failed to sync GitHub data: %w", err)
+		}
+		return fmt.Sprintf("Synced %d branches, %d commits and %d pull requests", len(data.Branches), len(data.Commits), len(data.PRs)), nil
+
+	case ActionCheckpoint:
+		cp, err := checkpoint.NewCheckpointManager(projectRoot).CreateCheckpoint("manual", "Manual Checkpoint", "Created from the command palette")
+		if err != nil {
+			return "", fmt.Errorf("failed to create checkpoint: %w", err)
+		}
+		return "Created checkpoint " + cp.ID, nil
+
+	case ActionStartFeature:
+		warnings, err := board.Move(projectRoot, item.FeatureID, board.ColumnInProgress, refresh)
+		if err != nil {
+			return "", err
+		}
+		name := strings.TrimPrefix(item.Title, "Start feature: ")
+		if len(warnings) > 0 {
+			return fmt.Sprintf("Started %s: %s", name, strings.Join(warnings, "; ")), nil
+		}
+		return "Started " + name, nil
+	}
+	return "", fmt.Errorf("unknown action %q", item.Action)
+}
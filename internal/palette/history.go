@@ -0,0 +1,58 @@
+package palette
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/utils"
+)
+
+// maxHistory is how many picked items the history remembers
+const maxHistory = 200
+
+// History remembers when palette items were last picked, in .doplan/palette.json
+type History struct {
+	path string
+	Used map[string]time.Time `json:"used"`
+}
+
+// LoadHistory reads the palette history of a project. A missing or unreadable
+// file gives an empty history.
+func LoadHistory(projectRoot string) *History {
+	h := &History{path: filepath.Join(projectRoot, ".doplan", "palette.json")}
+	if data, err := os.ReadFile(h.path); err == nil {
+		_ = json.Unmarshal(data, h)
+	}
+	if h.Used == nil {
+		h.Used = make(map[string]time.Time)
+	}
+	return h
+}
+
+// LastUsed returns when an item was last picked, or the zero time
+func (h *History) LastUsed(key string) time.Time {
+	if h == nil {
+		return time.Time{}
+	}
+	return h.Used[key]
+}
+
+// Record marks an item as picked at now and saves the history, forgetting the
+// least recently picked items beyond maxHistory
+func (h *History) Record(key string, now time.Time) error {
+	h.Used[key] = now
+	if len(h.Used) > maxHistory {
+		keys := make([]string, 0, len(h.Used))
+		for k := range h.Used {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return h.Used[keys[i]].After(h.Used[keys[j]]) })
+		for _, k := range keys[maxHistory:] {
+			delete(h.Used, k)
+		}
+	}
+	return utils.WriteJSON(h.path, h)
+}
@@ -0,0 +1,257 @@
+package palette
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/tasks"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/sahilm/fuzzy"
+)
+
+// Item kinds, in the order they are listed before anything was used
+const (
+	KindAction  = "action"
+	KindPhase   = "phase"
+	KindFeature = "feature"
+	KindTask    = "task"
+	KindDoc     = "doc"
+)
+
+// Actions the palette runs through Run
+const (
+	ActionStartFeature = "start-feature"
+	ActionCheckpoint   = "checkpoint"
+	ActionSyncGitHub   = "sync-github"
+)
+
+// Item is one entry of the palette
+type Item struct {
+	Kind      string
+	Key       string // Identifies the item in the recency history, e.g. "feature:01"
+	Title     string
+	Detail    string
+	PhaseID   string
+	FeatureID string
+	Task      string // Task name, for tasks
+	Path      string // File opened in $EDITOR, if any
+	Action    string // For actions
+	Updated   time.Time
+}
+
+// Result is an item matching a query. Matched holds the byte offsets of the
+// matched characters in the item's title.
+type Result struct {
+	Item    Item
+	Matched []int
+}
+
+// Index lists the actions, phases, features, tasks and doplan/ documents of a
+// project. Tasks are read from each feature's tasks.md, or the state when the
+// feature has none. Features and phases are as recent as their last status change
+// or tasks.md edit, documents as their last edit.
+func Index(projectRoot string, state *models.State) []Item {
+	items := []Item{
+		{Kind: KindAction, Key: "action:" + ActionSyncGitHub, Title: "Sync GitHub", Detail: "Fetch branches, commits and pull requests", Action: ActionSyncGitHub},
+		{Kind: KindAction, Key: "action:" + ActionCheckpoint, Title: "Create checkpoint", Detail: "Archive the current state and docs", Action: ActionCheckpoint},
+	}
+	if state == nil {
+		return append(items, docItems(projectRoot)...)
+	}
+
+	phaseNames := make(map[string]string)
+	for _, phase := range state.Phases {
+		phaseNames[phase.ID] = phase.Name
+	}
+
+	var phaseItems, featureItems, taskItems []Item
+	phaseUpdated := make(map[string]time.Time)
+	for _, feature := range state.Features {
+		path := tasks.FeaturePath(projectRoot, state, &feature)
+		taskPhases := feature.TaskPhases
+		updated := statusChanged(feature)
+		if info, err := os.Stat(path); err == nil {
+			if info.ModTime().After(updated) {
+				updated = info.ModTime()
+			}
+			if file, err := tasks.Load(path); err == nil {
+				taskPhases = file.Phases()
+			}
+		} else {
+			path = ""
+		}
+		if updated.After(phaseUpdated[feature.Phase]) {
+			phaseUpdated[feature.Phase] = updated
+		}
+
+		if feature.Status == "" || feature.Status == "todo" {
+			items = append(items, Item{
+				Kind: KindAction, Key: "action:" + ActionStartFeature + ":" + feature.ID,
+				Title: "Start feature: " + feature.Name, Detail: "Create the branch and move it to in-progress",
+				PhaseID: feature.Phase, FeatureID: feature.ID, Action: ActionStartFeature, Updated: updated,
+			})
+		}
+		featureItems = append(featureItems, Item{
+			Kind: KindFeature, Key: "feature:" + feature.ID, Title: feature.Name,
+			Detail:  joinDetail(phaseNames[feature.Phase], feature.Status),
+			PhaseID: feature.Phase, FeatureID: feature.ID, Path: path, Updated: updated,
+		})
+		for _, group := range taskPhases {
+			for _, task := range group.Tasks {
+				detail := feature.Name
+				if group.Name != "" {
+					detail += " › " + group.Name
+				}
+				if task.Completed {
+					detail += " ✓"
+				}
+				taskItems = append(taskItems, Item{
+					Kind: KindTask, Key: "task:" + feature.ID + ":" + task.Name, Title: task.Name, Detail: detail,
+					PhaseID: feature.Phase, FeatureID: feature.ID, Task: task.Name, Path: path, Updated: updated,
+				})
+			}
+		}
+	}
+	for _, phase := range state.Phases {
+		phaseItems = append(phaseItems, Item{
+			Kind: KindPhase, Key: "phase:" + phase.ID, Title: phase.Name, Detail: joinDetail("Phase", phase.Status),
+			PhaseID: phase.ID, Updated: phaseUpdated[phase.ID],
+		})
+	}
+
+	items = append(items, phaseItems...)
+	items = append(items, featureItems...)
+	items = append(items, taskItems...)
+	return append(items, docItems(projectRoot)...)
+}
+
+// docItems lists the files under doplan/, skipping hidden ones
+func docItems(projectRoot string) []Item {
+	root := filepath.Join(projectRoot, "doplan")
+	var items []Item
+	_ = filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") && path != root {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(projectRoot, path)
+		rel = filepath.ToSlash(rel)
+		item := Item{Kind: KindDoc, Key: "doc:" + rel, Title: rel, Detail: "Open in $EDITOR", Path: path}
+		if info, err := entry.Info(); err == nil {
+			item.Updated = info.ModTime()
+		}
+		items = append(items, item)
+		return nil
+	})
+	return items
+}
+
+func statusChanged(feature models.Feature) time.Time {
+	var latest time.Time
+	for _, change := range feature.StatusHistory {
+		if at, err := time.Parse(time.RFC3339, change.At); err == nil && at.After(latest) {
+			latest = at
+		}
+	}
+	return latest
+}
+
+func joinDetail(parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, " · ")
+}
+
+// itemSource matches queries against an item's title and detail
+type itemSource []Item
+
+func (s itemSource) String(i int) string { return s[i].Title + " " + s[i].Detail }
+func (s itemSource) Len() int            { return len(s) }
+
+// Search returns the items matching query, best first. Fuzzy matches are ranked by
+// their score with a bonus for recent items, so of two similar matches the one
+// picked or changed lately comes first. An empty query lists everything by recency.
+func Search(items []Item, query string, history *History, now time.Time) []Result {
+	lastUsed := func(item Item) time.Time {
+		used := history.LastUsed(item.Key)
+		if item.Updated.After(used) {
+			return item.Updated
+		}
+		return used
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		results := make([]Result, len(items))
+		for i, item := range items {
+			results[i] = Result{Item: item}
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			return lastUsed(results[i].Item).After(lastUsed(results[j].Item))
+		})
+		return results
+	}
+
+	// fuzzy returns matches best first; the bonus can reorder close ones
+	matches := fuzzy.FindFrom(query, itemSource(items))
+	scores := make([]int, len(matches))
+	results := make([]Result, len(matches))
+	for i, match := range matches {
+		item := items[match.Index]
+		results[i].Item = item
+		for _, index := range match.MatchedIndexes {
+			if index < len(item.Title) {
+				results[i].Matched = append(results[i].Matched, index)
+			}
+		}
+		scores[i] = match.Score + recencyBonus(lastUsed(item), now)
+	}
+	sort.Stable(byScore{results, scores})
+	return results
+}
+
+// byScore sorts results by descending score
+type byScore struct {
+	results []Result
+	scores  []int
+}
+
+func (s byScore) Len() int           { return len(s.results) }
+func (s byScore) Less(i, j int) bool { return s.scores[i] > s.scores[j] }
+func (s byScore) Swap(i, j int) {
+	s.results[i], s.results[j] = s.results[j], s.results[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
+
+// recencyBonus favors items used or changed lately, fading over a month
+func recencyBonus(t, now time.Time) int {
+	if t.IsZero() {
+		return 0
+	}
+	switch age := now.Sub(t); {
+	case age < time.Hour:
+		return 30
+	case age < 24*time.Hour:
+		return 20
+	case age < 7*24*time.Hour:
+		return 10
+	case age < 30*24*time.Hour:
+		return 5
+	}
+	return 0
+}
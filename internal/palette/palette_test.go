@@ -0,0 +1,121 @@
+package palette
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var paletteNow = time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+
+func paletteTestProject(t *testing.T) (string, *models.State) {
+	t.Helper()
+	projectRoot := helpers.SetupTestProject(t)
+	state := &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation", Status: "in-progress", Features: []string{"01", "02"}}},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Login", Status: "in-progress", StatusHistory: []models.StatusChange{
+				{Status: "in-progress", At: paletteNow.Add(-48 * time.Hour).Format(time.RFC3339)},
+			}},
+			{ID: "02", Phase: "01-phase", Name: "Logout", Status: "todo", TaskPhases: []models.TaskPhase{
+				{Name: "Setup", Tasks: []models.Task{{Name: "Clear session", Completed: true}}},
+			}},
+		},
+	}
+	helpers.WriteTestFile(t, projectRoot, "doplan/01-phase/01-login/tasks.md", []byte("# Login\n\n## Setup\n- [ ] Add login form\n"))
+	helpers.WriteTestFile(t, projectRoot, "doplan/01-phase/01-login/plan.md", []byte("# Plan\n"))
+	helpers.WriteTestFile(t, projectRoot, "doplan/.hidden/notes.md", []byte("hidden"))
+
+	// Keep modification times out of the ranking
+	old := paletteNow.AddDate(-1, 0, 0)
+	for _, path := range []string{"doplan/01-phase/01-login/tasks.md", "doplan/01-phase/01-login/plan.md"} {
+		require.NoError(t, os.Chtimes(filepath.Join(projectRoot, path), old, old))
+	}
+	return projectRoot, state
+}
+
+func keys(results []Result) []string {
+	var keys []string
+	for _, result := range results {
+		keys = append(keys, result.Item.Key)
+	}
+	return keys
+}
+
+func TestIndex(t *testing.T) {
+	projectRoot, state := paletteTestProject(t)
+	items := Index(projectRoot, state)
+
+	var all []string
+	for _, item := range items {
+		all = append(all, item.Key)
+	}
+	assert.Equal(t, []string{
+		"action:sync-github", "action:checkpoint", "action:start-feature:02",
+		"phase:01-phase",
+		"feature:01", "feature:02",
+		"task:01:Add login form", "task:02:Clear session",
+		"doc:doplan/01-phase/01-login/plan.md", "doc:doplan/01-phase/01-login/tasks.md",
+	}, all, "tasks come from tasks.md when there is one, and hidden files are skipped")
+
+	login := items[4]
+	assert.Equal(t, "Foundation · in-progress", login.Detail)
+	assert.Equal(t, filepath.Join(projectRoot, "doplan", "01-phase", "01-login", "tasks.md"), login.Path)
+	assert.Equal(t, paletteNow.Add(-48*time.Hour), login.Updated)
+	assert.Equal(t, login.Updated, items[3].Updated, "a phase is as recent as its features")
+
+	assert.Equal(t, "Logout › Setup ✓", items[7].Detail)
+	assert.Empty(t, items[7].Path, "Logout has no tasks.md")
+}
+
+func TestSearch(t *testing.T) {
+	projectRoot, state := paletteTestProject(t)
+	items := Index(projectRoot, state)
+	history := LoadHistory(projectRoot)
+
+	results := Search(items, "logn", history, paletteNow)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "feature:01", results[0].Item.Key)
+	assert.Equal(t, []int{0, 1, 2, 4}, results[0].Matched)
+	assert.NotContains(t, keys(results), "action:checkpoint")
+
+	// Picking an item moves it ahead of a close match
+	require.Equal(t, "feature:02", Search(items, "logout", history, paletteNow)[0].Item.Key)
+	before := keys(Search(items, "log", history, paletteNow))
+	require.NoError(t, history.Record("action:start-feature:02", paletteNow.Add(-time.Minute)))
+	after := keys(Search(items, "log", history, paletteNow))
+	assert.Less(t, indexOf(after, "action:start-feature:02"), indexOf(before, "action:start-feature:02"))
+
+	// The history is saved and an empty query lists by recency
+	reloaded := LoadHistory(projectRoot)
+	assert.Equal(t, paletteNow.Add(-time.Minute), reloaded.LastUsed("action:start-feature:02"))
+	listed := keys(Search(items, "", reloaded, paletteNow))
+	assert.Equal(t, []string{"action:start-feature:02", "phase:01-phase", "feature:01"}, listed[:3])
+	assert.Len(t, listed, len(items))
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestHistory_Record(t *testing.T) {
+	history := LoadHistory(helpers.CreateTempProject(t))
+	for i := 0; i < maxHistory+5; i++ {
+		require.NoError(t, history.Record(fmt.Sprintf("feature:%d", i), paletteNow.Add(time.Duration(i)*time.Minute)))
+	}
+	assert.Len(t, history.Used, maxHistory)
+	assert.True(t, history.LastUsed("feature:0").IsZero(), "the oldest picks are forgotten")
+	assert.True(t, (*History)(nil).LastUsed("a").IsZero())
+}
@@ -0,0 +1,111 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// DefaultNotesTemplate lays out release notes in Keep a Changelog style
+const DefaultNotesTemplate = `## [{{default .Release.Version "Unreleased"}}] - {{.Release.Date}}
+{{if .Release.Phase}}
+Phase: {{.Release.Phase}}
+{{end}}
+{{if .Release.Breaking}}
+### ⚠ Breaking Changes
+{{range .Release.Breaking}}
+- {{if .Scope}}**{{.Scope}}:** {{end}}{{.Description}} ({{.ShortHash}})
+{{- end}}
+{{end}}
+{{if .Release.Features}}
+### Completed Features
+{{range .Release.Features}}
+- **{{.Name}}**{{if .Description}} - {{.Description}}{{end}}{{if .PRURL}} ([#{{.PRNumber}}]({{.PRURL}})){{end}}
+{{- end}}
+{{end}}
+{{range .Release.Sections}}
+### {{.Title}}
+{{range .Commits}}
+- {{if .Scope}}**{{.Scope}}:** {{end}}{{.Description}} ({{.ShortHash}})
+{{- end}}
+{{end}}
+{{if .Release.Contributors}}
+### Contributors
+
+{{join .Release.Contributors ", "}}
+{{end}}
+`
+
+// changelogHeader is written when CHANGELOG.md does not exist yet
+const changelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+`
+
+// PrependChangelog inserts a release entry above the newest release in CHANGELOG.md,
+// keeping the file header and any [Unreleased] section at the top
+func PrependChangelog(path, entry string) error {
+	entry = strings.TrimSpace(entry) + "\n\n"
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read changelog: %w", err)
+		}
+		return os.WriteFile(path, []byte(changelogHeader+entry), 0644)
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	insertAt := -1
+	offset := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "## ") && !strings.Contains(strings.ToLower(line), "unreleased") {
+			insertAt = offset
+			break
+		}
+		offset += len(line)
+	}
+
+	var updated string
+	if insertAt == -1 {
+		updated = strings.TrimRight(string(content), "\n") + "\n\n" + entry
+	} else {
+		updated = string(content[:insertAt]) + entry + string(content[insertAt:])
+	}
+
+	return os.WriteFile(path, []byte(updated), 0644)
+}
+
+// CreateTag creates an annotated tag on HEAD
+func (g *Generator) CreateTag(name, message string) error {
+	if _, err := g.repo.Tag(name); err == nil {
+		return fmt.Errorf("tag %s already exists", name)
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	opts := &git.CreateTagOptions{Message: message}
+	if cfg, err := g.repo.ConfigScoped(config.SystemScope); err != nil || cfg.User.Name == "" || cfg.User.Email == "" {
+		// Same fallback identity CommitManager uses
+		opts.Tagger = &object.Signature{
+			Name:  "DoPlan",
+			Email: "doplan@example.com",
+			When:  time.Now(),
+		}
+	}
+
+	if _, err := g.repo.CreateTag(name, head.Hash(), opts); err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return nil
+}
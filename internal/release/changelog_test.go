@@ -0,0 +1,48 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrependChangelog_New(t *testing.T) {
+	path := filepath.Join(helpers.CreateTempProject(t), "CHANGELOG.md")
+
+	require.NoError(t, PrependChangelog(path, "## [v1.0.0] - 2025-01-01\n\n- first\n"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Changelog")
+	assert.Contains(t, string(content), "## [v1.0.0] - 2025-01-01")
+}
+
+func TestPrependChangelog_Existing(t *testing.T) {
+	path := filepath.Join(helpers.CreateTempProject(t), "CHANGELOG.md")
+	existing := "# Changelog\n\nIntro.\n\n## [Unreleased]\n\n## [v1.0.0] - 2025-01-01\n\n- first\n"
+	require.NoError(t, os.WriteFile(path, []byte(existing), 0644))
+
+	require.NoError(t, PrependChangelog(path, "## [v1.1.0] - 2025-02-01\n\n- second"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\nIntro.\n\n## [Unreleased]\n\n## [v1.1.0] - 2025-02-01\n\n- second\n\n## [v1.0.0] - 2025-01-01\n\n- first\n", string(content))
+}
+
+func TestGenerator_CreateTag(t *testing.T) {
+	_, gen := setupReleaseRepo(t)
+
+	require.NoError(t, gen.CreateTag("v0.2.0", "notes"))
+	ref, err := gen.repo.Tag("v0.2.0")
+	require.NoError(t, err)
+
+	tag, err := gen.repo.TagObject(ref.Hash())
+	require.NoError(t, err, "tag must be annotated")
+	assert.Equal(t, "notes\n", tag.Message)
+
+	assert.Error(t, gen.CreateTag("v0.2.0", "again"))
+}
@@ -0,0 +1,337 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/template"
+	"github.com/DoPlan-dev/CLI/internal/utils"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// NotesTemplate is the template in doplan/templates that lays out release notes
+const NotesTemplate = "release-notes-template.md"
+
+// sectionOrder lists conventional commit types in the order they appear in notes
+var sectionOrder = []struct {
+	Type  string
+	Title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build & CI"},
+	{"ci", "Build & CI"},
+	{"chore", "Maintenance"},
+	{"style", "Maintenance"},
+	{"revert", "Reverts"},
+	{"other", "Other Changes"},
+}
+
+// Options selects what goes into the release notes
+type Options struct {
+	Phase   string // Phase ID or number (e.g. "02")
+	Since   string // Tag or revision; commits after it are included
+	Version string // Version heading and tag name
+}
+
+// Generator builds release notes from project state and Git history
+type Generator struct {
+	projectRoot string
+	repo        *git.Repository
+}
+
+// NewGenerator creates a new release notes generator
+func NewGenerator(projectRoot string) (*Generator, error) {
+	repo, err := git.PlainOpen(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	return &Generator{
+		projectRoot: projectRoot,
+		repo:        repo,
+	}, nil
+}
+
+// Build collects completed features and commits for the requested range
+func (g *Generator) Build(state *models.State, opts Options) (*models.ReleaseNotes, error) {
+	notes := &models.ReleaseNotes{
+		Version:      opts.Version,
+		Date:         time.Now().Format("2006-01-02"),
+		Since:        opts.Since,
+		Features:     []models.ReleaseFeature{},
+		Sections:     []models.ReleaseSection{},
+		Breaking:     []models.ReleaseCommit{},
+		Contributors: []string{},
+	}
+
+	var phase *models.Phase
+	if opts.Phase != "" {
+		phase = FindPhase(state, opts.Phase)
+		if phase == nil {
+			return nil, fmt.Errorf("phase %s not found", opts.Phase)
+		}
+		notes.Phase = phase.Name
+	}
+
+	// With only a phase, start from its start date
+	var after time.Time
+	if opts.Since == "" && phase != nil && phase.StartDate != "" {
+		if start, err := time.Parse("2006-01-02", phase.StartDate); err == nil {
+			after = start
+		}
+	}
+
+	commits, err := g.collectCommits(opts.Since, after)
+	if err != nil {
+		return nil, err
+	}
+
+	notes.Features = completedFeatures(state, phase)
+	notes.Sections, notes.Breaking = groupCommits(commits)
+	notes.Contributors = contributors(commits)
+
+	return notes, nil
+}
+
+// RenderMarkdown renders notes through doplan/templates/release-notes-template.md,
+// creating the default template first if the project does not have one
+func (g *Generator) RenderMarkdown(notes *models.ReleaseNotes) (string, error) {
+	templatesDir := filepath.Join(g.projectRoot, "doplan", "templates")
+	templatePath := filepath.Join(templatesDir, NotesTemplate)
+	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
+		if err := template.NewManager(g.projectRoot).AddTemplate(NotesTemplate, DefaultNotesTemplate); err != nil {
+			return "", fmt.Errorf("failed to create release notes template: %w", err)
+		}
+	}
+
+	processor := template.NewProcessor(templatesDir)
+	content, err := processor.ProcessTemplate(NotesTemplate, template.TemplateData{
+		Release: notes,
+		Project: make(map[string]interface{}),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(utils.CollapseBlankLines(content)) + "\n", nil
+}
+
+// RenderJSON renders notes as indented JSON
+func RenderJSON(notes *models.ReleaseNotes) (string, error) {
+	data, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal release notes: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// FindPhase finds a phase by ID or by its number in doplan/ (e.g. "02" or "02-phase")
+func FindPhase(state *models.State, key string) *models.Phase {
+	for i := range state.Phases {
+		if state.Phases[i].ID == key {
+			return &state.Phases[i]
+		}
+	}
+
+	prefix, _, _ := strings.Cut(key, "-")
+	if n, err := strconv.Atoi(prefix); err == nil && n >= 1 && n <= len(state.Phases) {
+		return &state.Phases[n-1]
+	}
+
+	return nil
+}
+
+// collectCommits walks HEAD back to the since revision (or the after time), skipping merges
+func (g *Generator) collectCommits(since string, after time.Time) ([]*object.Commit, error) {
+	head, err := g.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	stop := make(map[plumbing.Hash]bool)
+	if since != "" {
+		hash, err := g.repo.ResolveRevision(plumbing.Revision(since))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", since, err)
+		}
+		sinceCommit, err := g.repo.CommitObject(*hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", since, err)
+		}
+		err = object.NewCommitPreorderIter(sinceCommit, nil, nil).ForEach(func(c *object.Commit) error {
+			stop[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk history: %w", err)
+		}
+	}
+
+	headCommit, err := g.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+
+	var commits []*object.Commit
+	err = object.NewCommitPreorderIter(headCommit, nil, nil).ForEach(func(c *object.Commit) error {
+		if stop[c.Hash] {
+			return nil
+		}
+		if !after.IsZero() && c.Committer.When.Before(after) {
+			return nil
+		}
+		if c.NumParents() > 1 {
+			return nil
+		}
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk history: %w", err)
+	}
+
+	return commits, nil
+}
+
+func completedFeatures(state *models.State, phase *models.Phase) []models.ReleaseFeature {
+	features := []models.ReleaseFeature{}
+	for _, feature := range state.Features {
+		if feature.Status != "complete" {
+			continue
+		}
+		if phase != nil && feature.Phase != phase.ID && !contains(phase.Features, feature.ID) {
+			continue
+		}
+
+		rf := models.ReleaseFeature{
+			ID:          feature.ID,
+			Name:        feature.Name,
+			Description: feature.Description,
+		}
+		if feature.PR != nil {
+			rf.PRNumber = feature.PR.Number
+			rf.PRURL = feature.PR.URL
+		}
+		features = append(features, rf)
+	}
+	return features
+}
+
+func groupCommits(commits []*object.Commit) ([]models.ReleaseSection, []models.ReleaseCommit) {
+	byTitle := make(map[string]*models.ReleaseSection)
+	var titles []string
+	breaking := []models.ReleaseCommit{}
+
+	// Commits arrive newest first; keep that order within sections
+	for _, c := range commits {
+		rc := toReleaseCommit(c)
+		if rc.Breaking {
+			breaking = append(breaking, rc)
+		}
+
+		title := sectionTitle(rc.Type)
+		section, exists := byTitle[title]
+		if !exists {
+			section = &models.ReleaseSection{Type: rc.Type, Title: title}
+			byTitle[title] = section
+			titles = append(titles, title)
+		}
+		section.Commits = append(section.Commits, rc)
+	}
+
+	sort.SliceStable(titles, func(i, j int) bool {
+		return sectionRank(titles[i]) < sectionRank(titles[j])
+	})
+
+	sections := make([]models.ReleaseSection, 0, len(titles))
+	for _, title := range titles {
+		sections = append(sections, *byTitle[title])
+	}
+	return sections, breaking
+}
+
+func toReleaseCommit(c *object.Commit) models.ReleaseCommit {
+	hash := c.Hash.String()
+	rc := models.ReleaseCommit{
+		Hash:      hash,
+		ShortHash: hash[:7],
+		Type:      "other",
+		Author:    c.Author.Name,
+	}
+
+	if parsed, err := github.ParseCommitMessage(c.Message); err == nil && isKnownType(parsed.Type) {
+		rc.Type = parsed.Type
+		rc.Scope = parsed.Scope
+		rc.Description = parsed.Description
+		rc.Breaking = parsed.Breaking
+	} else {
+		line, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+		rc.Description = strings.TrimSpace(line)
+	}
+
+	return rc
+}
+
+func contributors(commits []*object.Commit) []string {
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, c := range commits {
+		if c.Author.Name != "" && !seen[c.Author.Name] {
+			seen[c.Author.Name] = true
+			names = append(names, c.Author.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sectionTitle(commitType string) string {
+	for _, s := range sectionOrder {
+		if s.Type == commitType {
+			return s.Title
+		}
+	}
+	return "Other Changes"
+}
+
+func sectionRank(title string) int {
+	for i, s := range sectionOrder {
+		if s.Title == title {
+			return i
+		}
+	}
+	return len(sectionOrder)
+}
+
+func isKnownType(commitType string) bool {
+	for _, t := range github.CommitTypes {
+		if t == commitType {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package release
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func commit(t *testing.T, repo *git.Repository, root, message string) {
+	t.Helper()
+	name := filepath.Join(root, "file.txt")
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(message + "\n")
+	require.NoError(t, err)
+	f.Close()
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add("file.txt")
+	require.NoError(t, err)
+	_, err = wt.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: "Ada", Email: "ada@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+}
+
+func setupReleaseRepo(t *testing.T) (string, *Generator) {
+	projectRoot := helpers.SetupTestProject(t)
+	repo, err := git.PlainInit(projectRoot, false)
+	require.NoError(t, err)
+
+	commit(t, repo, projectRoot, "chore: initial commit")
+	gen, err := NewGenerator(projectRoot)
+	require.NoError(t, err)
+	require.NoError(t, gen.CreateTag("v0.1.0", "first release"))
+
+	commit(t, repo, projectRoot, "feat(auth): add login form")
+	commit(t, repo, projectRoot, "fix(auth)!: reject empty passwords")
+	commit(t, repo, projectRoot, "docs: describe login")
+	commit(t, repo, projectRoot, "tweak things")
+
+	return projectRoot, gen
+}
+
+func releaseState() *models.State {
+	return &models.State{
+		Phases: []models.Phase{
+			{ID: "phase-1", Name: "Foundation", Features: []string{"auth", "billing"}},
+			{ID: "phase-2", Name: "Growth", Features: []string{"reports"}},
+		},
+		Features: []models.Feature{
+			{ID: "auth", Phase: "phase-1", Name: "Authentication", Description: "Login and signup", Status: "complete",
+				PR: &models.PullRequest{Number: 7, URL: "https://github.com/o/r/pull/7"}},
+			{ID: "billing", Phase: "phase-1", Name: "Billing", Status: "in-progress"},
+			{ID: "reports", Phase: "phase-2", Name: "Reports", Status: "complete"},
+		},
+	}
+}
+
+func TestGenerator_BuildSinceTag(t *testing.T) {
+	_, gen := setupReleaseRepo(t)
+
+	notes, err := gen.Build(releaseState(), Options{Since: "v0.1.0", Version: "v0.2.0"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.2.0", notes.Version)
+	assert.Len(t, notes.Features, 2)
+	require.Len(t, notes.Sections, 4)
+	assert.Equal(t, "Features", notes.Sections[0].Title)
+	assert.Equal(t, "Bug Fixes", notes.Sections[1].Title)
+	assert.Equal(t, "Documentation", notes.Sections[2].Title)
+	assert.Equal(t, "Other Changes", notes.Sections[3].Title)
+	assert.Equal(t, "auth", notes.Sections[0].Commits[0].Scope)
+
+	require.Len(t, notes.Breaking, 1)
+	assert.Equal(t, "reject empty passwords", notes.Breaking[0].Description)
+	assert.Equal(t, []string{"Ada"}, notes.Contributors)
+}
+
+func TestGenerator_BuildPhase(t *testing.T) {
+	_, gen := setupReleaseRepo(t)
+
+	notes, err := gen.Build(releaseState(), Options{Phase: "01"})
+	require.NoError(t, err)
+	assert.Equal(t, "Foundation", notes.Phase)
+	require.Len(t, notes.Features, 1)
+	assert.Equal(t, "Authentication", notes.Features[0].Name)
+	assert.Equal(t, 7, notes.Features[0].PRNumber)
+
+	_, err = gen.Build(releaseState(), Options{Phase: "09"})
+	assert.Error(t, err)
+}
+
+func TestGenerator_RenderMarkdown(t *testing.T) {
+	projectRoot, gen := setupReleaseRepo(t)
+
+	notes, err := gen.Build(releaseState(), Options{Since: "v0.1.0", Version: "v0.2.0", Phase: "phase-1"})
+	require.NoError(t, err)
+
+	markdown, err := gen.RenderMarkdown(notes)
+	require.NoError(t, err)
+
+	assert.Contains(t, markdown, "## [v0.2.0] - ")
+	assert.Contains(t, markdown, "### ⚠ Breaking Changes")
+	assert.Contains(t, markdown, "- **Authentication** - Login and signup ([#7](https://github.com/o/r/pull/7))")
+	assert.Contains(t, markdown, "### Features\n\n- **auth:** add login form (")
+	assert.Contains(t, markdown, "### Other Changes\n\n- tweak things (")
+	assert.NotContains(t, markdown, "\n\n\n")
+
+	// The default template is installed so it can be customised
+	assert.FileExists(t, filepath.Join(projectRoot, "doplan", "templates", NotesTemplate))
+}
+
+func TestGenerator_RenderMarkdown_CustomTemplate(t *testing.T) {
+	projectRoot, gen := setupReleaseRepo(t)
+	helpers.WriteTestFile(t, projectRoot, "doplan/templates/"+NotesTemplate,
+		[]byte("Release {{.Release.Version}}: {{len .Release.Features}} features\n"))
+
+	notes, err := gen.Build(releaseState(), Options{Since: "v0.1.0", Version: "v0.2.0"})
+	require.NoError(t, err)
+
+	markdown, err := gen.RenderMarkdown(notes)
+	require.NoError(t, err)
+	assert.Equal(t, "Release v0.2.0: 2 features\n", markdown)
+}
+
+func TestRenderJSON(t *testing.T) {
+	notes := &models.ReleaseNotes{Version: "v1.0.0", Features: []models.ReleaseFeature{{ID: "auth"}}}
+
+	output, err := RenderJSON(notes)
+	require.NoError(t, err)
+
+	var decoded models.ReleaseNotes
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+	assert.Equal(t, "v1.0.0", decoded.Version)
+	assert.Equal(t, "auth", decoded.Features[0].ID)
+}
+
+func TestFindPhase(t *testing.T) {
+	state := releaseState()
+	assert.Equal(t, "Foundation", FindPhase(state, "phase-1").Name)
+	assert.Equal(t, "Growth", FindPhase(state, "02").Name)
+	assert.Equal(t, "Growth", FindPhase(state, "02-phase").Name)
+	assert.Nil(t, FindPhase(state, "3"))
+}
@@ -0,0 +1,262 @@
+package statistics
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/dashboard"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// backfillStatePaths are where the state lived over time, newest location first
+var backfillStatePaths = []string{".doplan/state.json", ".cursor/config/doplan-state.json"}
+
+// backfillDay is the last commit of a day and the DoPlan files it holds
+type backfillDay struct {
+	commit   *object.Commit
+	state    []byte
+	tasks    map[string][]byte // tasks.md contents by path under doplan/
+	progress map[string][]byte // progress.json contents by path under doplan/
+}
+
+// Backfill rebuilds one statistics snapshot per day from the git history of the
+// DoPlan files: the state, tasks.md and progress.json. Each day takes the last
+// commit reachable from HEAD, and days on which none of those files changed are
+// left out. GitHub, checkpoint and coverage data cannot be recovered from the
+// files and stay empty. A zero since replays the whole history; a zero
+// projectStart uses the first day with a state.
+func Backfill(projectRoot string, since, projectStart time.Time) ([]*HistoricalData, error) {
+	repo, err := git.PlainOpenWithOptions(projectRoot, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	// Tree paths are relative to the repository root; DoPlan files to the project root
+	prefix := ""
+	if wt, err := repo.Worktree(); err == nil {
+		if rel, err := filepath.Rel(wt.Filesystem.Root(), projectRoot); err == nil && rel != "." {
+			prefix = filepath.ToSlash(rel) + "/"
+		}
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	opts := &git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime}
+	if !since.IsZero() {
+		opts.Since = &since
+	}
+	iter, err := repo.Log(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git history: %w", err)
+	}
+	defer iter.Close()
+
+	lastOfDay := make(map[string]*object.Commit)
+	err = iter.ForEach(func(commit *object.Commit) error {
+		day := historyDay(commit.Committer.When)
+		if current, ok := lastOfDay[day]; !ok || commit.Committer.When.After(current.Committer.When) {
+			lastOfDay[day] = commit
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git history: %w", err)
+	}
+
+	days := make([]string, 0, len(lastOfDay))
+	for day := range lastOfDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	var snapshots []*HistoricalData
+	previous := ""
+	for _, day := range days {
+		files, err := readBackfillDay(lastOfDay[day], prefix)
+		if err != nil {
+			return nil, err
+		}
+		if files == nil {
+			continue
+		}
+		fingerprint := files.fingerprint()
+		if fingerprint == previous {
+			continue
+		}
+		previous = fingerprint
+
+		var state models.State
+		if err := json.Unmarshal(files.state, &state); err != nil {
+			// A state that did not parse at the time says nothing about progress
+			continue
+		}
+
+		when := files.commit.Committer.When
+		if projectStart.IsZero() || projectStart.After(when) {
+			projectStart = when
+		}
+		data := files.statisticsData(&state, when)
+		snapshots = append(snapshots, &HistoricalData{
+			Timestamp: when,
+			Metrics:   NewCalculatorAt(projectStart, when).Calculate(data, &state, nil),
+			Data:      data,
+		})
+	}
+
+	return snapshots, nil
+}
+
+// readBackfillDay reads the DoPlan files of a commit, or returns nil when the
+// commit has no state yet
+func readBackfillDay(commit *object.Commit, prefix string) (*backfillDay, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of %s: %w", commit.Hash, err)
+	}
+
+	day := &backfillDay{commit: commit, tasks: make(map[string][]byte), progress: make(map[string][]byte)}
+	for _, statePath := range backfillStatePaths {
+		file, err := tree.File(prefix + statePath)
+		if err != nil {
+			continue
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: %w", statePath, commit.Hash, err)
+		}
+		day.state = []byte(content)
+		break
+	}
+	if day.state == nil {
+		return nil, nil
+	}
+
+	doplanTree, err := tree.Tree(prefix + "doplan")
+	if errors.Is(err, object.ErrDirectoryNotFound) {
+		return day, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read doplan directory at %s: %w", commit.Hash, err)
+	}
+	err = doplanTree.Files().ForEach(func(file *object.File) error {
+		var files map[string][]byte
+		switch path.Base(file.Name) {
+		case "tasks.md":
+			files = day.tasks
+		case "progress.json":
+			files = day.progress
+		default:
+			return nil
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return fmt.Errorf("failed to read %s at %s: %w", file.Name, commit.Hash, err)
+		}
+		files[file.Name] = []byte(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return day, nil
+}
+
+// fingerprint identifies the contents of the day's DoPlan files
+func (d *backfillDay) fingerprint() string {
+	hash := sha1.New()
+	hash.Write(d.state)
+	for _, files := range []map[string][]byte{d.tasks, d.progress} {
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(hash, "\x00%s\x00%s", name, files[name])
+		}
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// statisticsData rebuilds what the collector would have gathered that day.
+// Feature progress from progress.json overrides the state, and tasks are counted
+// from the tasks.md checkboxes when there are any.
+func (d *backfillDay) statisticsData(state *models.State, when time.Time) *StatisticsData {
+	for name, content := range d.progress {
+		var progress dashboard.ProgressData
+		if err := json.Unmarshal(content, &progress); err != nil {
+			continue
+		}
+		dir := path.Base(path.Dir(name))
+		for i := range state.Features {
+			if feature := &state.Features[i]; feature.ID == progress.FeatureID || feature.ID == dir {
+				feature.Progress = progress.Progress
+				break
+			}
+		}
+	}
+
+	history := progressHistory(state, when)
+	for _, phase := range state.Phases {
+		if _, ok := history.PhaseProgress[phase.ID]; ok {
+			continue
+		}
+		// Phases without recorded progress average their features
+		total, count := 0, 0
+		for _, feature := range state.Features {
+			if feature.Phase == phase.ID {
+				total += feature.Progress
+				count++
+			}
+		}
+		if count > 0 {
+			history.PhaseProgress[phase.ID] = total / count
+		}
+	}
+
+	tasks := d.taskStats()
+	if tasks == nil {
+		tasks = taskStats(state)
+	}
+
+	return &StatisticsData{
+		State:       stateData(state),
+		Progress:    history,
+		Tasks:       tasks,
+		CollectedAt: when,
+	}
+}
+
+// taskStats counts the checkboxes in the day's tasks.md files the way 'doplan
+// progress' does, or returns nil when there are none
+func (d *backfillDay) taskStats() *TaskStats {
+	stats := &TaskStats{}
+	for _, content := range d.tasks {
+		for _, line := range strings.Split(string(content), "\n") {
+			if !strings.Contains(line, "- [") {
+				continue
+			}
+			stats.TotalTasks++
+			if strings.Contains(line, "- [x]") || strings.Contains(line, "- [X]") {
+				stats.CompletedTasks++
+			} else {
+				stats.PendingTasks++
+			}
+		}
+	}
+	if stats.TotalTasks == 0 {
+		return nil
+	}
+	stats.complete()
+	return stats
+}
@@ -0,0 +1,115 @@
+package statistics
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backfillDayAt(day, hour int) time.Time {
+	return time.Date(2026, 4, day, hour, 0, 0, 0, time.UTC)
+}
+
+func backfillState(t *testing.T, login, search string) string {
+	t.Helper()
+	content, err := json.Marshal(&models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation", Status: "in-progress"}},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Login", Status: login},
+			{ID: "02", Phase: "01-phase", Name: "Search", Status: search},
+		},
+	})
+	require.NoError(t, err)
+	return string(content)
+}
+
+func setupBackfillRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	require.NoError(t, err)
+
+	helpers.CommitFiles(t, repo, "Ada", backfillDayAt(1, 10), map[string]string{"README.md": "before DoPlan\n"})
+	helpers.CommitFiles(t, repo, "Ada", backfillDayAt(2, 9), map[string]string{
+		".doplan/state.json":                  backfillState(t, "todo", "todo"),
+		"doplan/01-phase/01-login/tasks.md":   "- [ ] Form\n- [ ] Session\n",
+		"doplan/01-phase/02-search/tasks.md":  "- [ ] Index\n",
+		"doplan/01-phase/02-search/notes.txt": "ignored\n",
+	})
+	helpers.CommitFiles(t, repo, "Ada", backfillDayAt(2, 17), map[string]string{
+		".doplan/state.json":                backfillState(t, "in-progress", "todo"),
+		"doplan/01-phase/01-login/tasks.md": "- [x] Form\n- [ ] Session\n",
+	})
+	helpers.CommitFiles(t, repo, "Ada", backfillDayAt(3, 12), map[string]string{"README.md": "after DoPlan\n"})
+	helpers.CommitFiles(t, repo, "Ada", backfillDayAt(4, 11), map[string]string{
+		".doplan/state.json":                      backfillState(t, "complete", "in-progress"),
+		"doplan/01-phase/01-login/tasks.md":       "- [x] Form\n- [X] Session\n",
+		"doplan/01-phase/02-search/progress.json": `{"featureID": "02", "status": "in-progress", "progress": 50}`,
+	})
+	return root
+}
+
+func TestBackfill(t *testing.T) {
+	root := setupBackfillRepo(t)
+
+	snapshots, err := Backfill(root, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2, "days without a state or without changes to DoPlan files are left out")
+
+	first := snapshots[0]
+	assert.Equal(t, backfillDayAt(2, 17), first.Timestamp.UTC(), "a day takes its last commit")
+	assert.Equal(t, &StateData{TotalPhases: 1, TotalFeatures: 2, InProgressFeatures: 1}, first.Data.State)
+	assert.Equal(t, &TaskStats{TotalTasks: 3, CompletedTasks: 1, PendingTasks: 2, CompletionRate: 33}, first.Data.Tasks)
+	assert.Nil(t, first.Data.GitHub)
+	require.NotNil(t, first.Metrics)
+	assert.Equal(t, 33, first.Metrics.Completion.Tasks)
+
+	last := snapshots[1]
+	assert.Equal(t, 1, last.Data.State.CompletedFeatures)
+	assert.Equal(t, 50, last.Data.Progress.FeatureProgress["02"], "progress.json overrides the state")
+	assert.Equal(t, 25, last.Data.Progress.PhaseProgress["01-phase"], "phases without recorded progress average their features")
+	assert.Equal(t, 2, last.Data.Tasks.CompletedTasks)
+	assert.Equal(t, 50, last.Metrics.Completion.Overall)
+	assert.Equal(t, 1, last.Metrics.Time.DaysSinceStart, "metrics are computed as of the snapshot")
+
+	snapshots, err = Backfill(root, backfillDayAt(3, 0), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, backfillDayAt(3, 12), snapshots[0].Timestamp.UTC(), "the first day since records the state as it was")
+	assert.Equal(t, 1, snapshots[0].Data.State.InProgressFeatures)
+
+	_, err = Backfill(t.TempDir(), time.Time{}, time.Time{})
+	assert.Error(t, err, "backfilling needs a git repository")
+}
+
+func TestStorage_Import(t *testing.T) {
+	root := setupBackfillRepo(t)
+	storage := NewStorage(root)
+	now := backfillDayAt(5, 12)
+
+	// A snapshot recorded by 'doplan stats' on the last day wins over the backfilled one
+	require.NoError(t, storage.append(&HistoricalData{Timestamp: backfillDayAt(4, 20), Data: &StatisticsData{}}))
+
+	snapshots, err := Backfill(root, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	added, err := storage.Import(snapshots, now)
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	assert.Equal(t, backfillDayAt(2, 17), added[0].Timestamp.UTC())
+
+	added, err = storage.Import(snapshots, now)
+	require.NoError(t, err)
+	assert.Empty(t, added, "importing twice adds nothing")
+
+	history, err := storage.LoadAll()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, backfillDayAt(2, 17), history[0].Timestamp.UTC())
+	assert.Equal(t, backfillDayAt(4, 20), history[1].Timestamp.UTC())
+}
@@ -0,0 +1,202 @@
+package statistics
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"time"
+)
+
+// burnBlocks are the partial block characters for one eighth to seven eighths of a cell
+var burnBlocks = []rune("▁▂▃▄▅▆▇")
+
+// RenderBurnChart draws the remaining work of a series as a block chart of the given
+// size. The ideal line is dotted and the target date is a dashed column.
+func RenderBurnChart(series *BurnSeries, width, height int) string {
+	if series == nil || len(series.Points) == 0 || width < 2 || height < 1 {
+		return ""
+	}
+
+	maxValue := burnMaxScope(series)
+	start, end := series.StartDate, series.AxisEnd()
+	span := end.Sub(start)
+	columnDate := func(col int) time.Time {
+		if span <= 0 {
+			return start
+		}
+		return start.Add(time.Duration(float64(span) * float64(col) / float64(width-1)))
+	}
+
+	targetCol := -1
+	if series.HasTarget() && span > 0 {
+		targetCol = int(math.Round(float64(series.TargetDate.Sub(start)) / float64(span) * float64(width-1)))
+	}
+
+	grid := make([][]rune, height)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat(" ", width))
+	}
+
+	// Today's point covers the whole day; later columns only carry the ideal and target lines
+	future := series.Latest().Date.AddDate(0, 0, 1)
+	for col := 0; col < width; col++ {
+		date := columnDate(col)
+
+		if date.Before(future) {
+			point := burnPointAt(series, date)
+			level := int(math.Round(float64(point.Remaining) / float64(maxValue) * float64(height*8)))
+			for row := 0; row < height; row++ {
+				cell := level - row*8
+				switch {
+				case cell >= 8:
+					grid[height-1-row][col] = '█'
+				case cell > 0:
+					grid[height-1-row][col] = burnBlocks[cell-1]
+				}
+			}
+		}
+
+		if series.HasTarget() && !date.After(series.TargetDate) {
+			idealRow := int(series.IdealRemaining(date) / float64(maxValue) * float64(height))
+			if idealRow >= height {
+				idealRow = height - 1
+			}
+			if r := height - 1 - idealRow; grid[r][col] == ' ' {
+				grid[r][col] = '·'
+			}
+		}
+
+		if col == targetCol {
+			for row := range grid {
+				if grid[row][col] == ' ' {
+					grid[row][col] = '┆'
+				}
+			}
+		}
+	}
+
+	labelWidth := len(fmt.Sprint(maxValue))
+	var sb strings.Builder
+	for row := range grid {
+		label := ""
+		switch row {
+		case 0:
+			label = fmt.Sprint(maxValue)
+		case height - 1:
+			label = "0"
+		}
+		sb.WriteString(fmt.Sprintf("%*s ┤%s\n", labelWidth, label, string(grid[row])))
+	}
+	sb.WriteString(fmt.Sprintf("%*s └%s\n", labelWidth, "", strings.Repeat("─", width)))
+
+	startLabel, endLabel := start.Format("2006-01-02"), end.Format("2006-01-02")
+	gap := width - len(startLabel) - len(endLabel)
+	if gap < 1 {
+		endLabel, gap = "", width-len(startLabel)
+	}
+	sb.WriteString(fmt.Sprintf("%*s  %s%s%s\n", labelWidth, "", startLabel, strings.Repeat(" ", maxInt(gap, 0)), endLabel))
+
+	legend := fmt.Sprintf("█ remaining (%d of %d %s)", series.Latest().Remaining, series.Latest().Scope, series.Unit)
+	if series.HasTarget() {
+		legend += fmt.Sprintf("  · ideal  ┆ target %s", series.TargetDate.Format("2006-01-02"))
+	}
+	sb.WriteString(fmt.Sprintf("%*s  %s", labelWidth, "", legend))
+
+	return sb.String()
+}
+
+// BurnChartSVG draws a series as an inline SVG chart: the burndown of remaining work,
+// the burnup of completed work against scope, the ideal line and the target date
+func BurnChartSVG(series *BurnSeries, width, height int) string {
+	if series == nil || len(series.Points) == 0 {
+		return ""
+	}
+
+	const padLeft, padRight, padTop, padBottom = 40.0, 12.0, 12.0, 36.0
+	plotWidth := float64(width) - padLeft - padRight
+	plotHeight := float64(height) - padTop - padBottom
+
+	maxValue := float64(burnMaxScope(series))
+	start, end := series.StartDate, series.AxisEnd()
+	span := end.Sub(start)
+	x := func(t time.Time) float64 {
+		if span <= 0 {
+			return padLeft
+		}
+		return padLeft + plotWidth*float64(t.Sub(start))/float64(span)
+	}
+	y := func(v float64) float64 {
+		return padTop + plotHeight*(1-v/maxValue)
+	}
+	polyline := func(value func(BurnPoint) int) string {
+		coords := make([]string, 0, len(series.Points))
+		for _, p := range series.Points {
+			coords = append(coords, fmt.Sprintf("%.1f,%.1f", x(p.Date), y(float64(value(p)))))
+		}
+		return strings.Join(coords, " ")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<svg class=\"burn-chart\" xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" role=\"img\" aria-label=\"Burndown for %s\">\n",
+		width, height, width, height, html.EscapeString(series.Name)))
+
+	// Axes and labels
+	sb.WriteString(fmt.Sprintf("<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#9ca3af\"/>\n", padLeft, padTop, padLeft, padTop+plotHeight))
+	sb.WriteString(fmt.Sprintf("<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#9ca3af\"/>\n", padLeft, padTop+plotHeight, padLeft+plotWidth, padTop+plotHeight))
+	sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\" text-anchor=\"end\" fill=\"#6b7280\">%d</text>\n", padLeft-4, padTop+4, int(maxValue)))
+	sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\" text-anchor=\"end\" fill=\"#6b7280\">0</text>\n", padLeft-4, padTop+plotHeight))
+	sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\" fill=\"#6b7280\">%s</text>\n", padLeft, padTop+plotHeight+14, start.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\" text-anchor=\"end\" fill=\"#6b7280\">%s</text>\n", padLeft+plotWidth, padTop+plotHeight+14, end.Format("2006-01-02")))
+
+	if series.HasTarget() {
+		sb.WriteString(fmt.Sprintf("<line class=\"ideal\" x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#9ca3af\" stroke-dasharray=\"4 3\"/>\n",
+			x(start), y(float64(series.Points[0].Scope)), x(series.TargetDate), y(0)))
+		sb.WriteString(fmt.Sprintf("<line class=\"target\" x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#ef4444\" stroke-dasharray=\"2 2\"/>\n",
+			x(series.TargetDate), padTop, x(series.TargetDate), padTop+plotHeight))
+		sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\" text-anchor=\"end\" fill=\"#ef4444\">target %s</text>\n",
+			x(series.TargetDate)-3, padTop+10, series.TargetDate.Format("2006-01-02")))
+	}
+
+	sb.WriteString(fmt.Sprintf("<polyline class=\"scope\" fill=\"none\" stroke=\"#6b7280\" stroke-width=\"1.5\" points=\"%s\"/>\n", polyline(func(p BurnPoint) int { return p.Scope })))
+	sb.WriteString(fmt.Sprintf("<polyline class=\"completed\" fill=\"none\" stroke=\"#10b981\" stroke-width=\"2\" points=\"%s\"/>\n", polyline(func(p BurnPoint) int { return p.Completed })))
+	sb.WriteString(fmt.Sprintf("<polyline class=\"remaining\" fill=\"none\" stroke=\"#3b82f6\" stroke-width=\"2\" points=\"%s\"/>\n", polyline(func(p BurnPoint) int { return p.Remaining })))
+
+	// Legend
+	legendY := padTop + plotHeight + 30
+	sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\"><tspan fill=\"#3b82f6\">■ remaining</tspan> <tspan fill=\"#10b981\">■ completed</tspan> <tspan fill=\"#6b7280\">■ scope (%s)</tspan></text>\n",
+		padLeft, legendY, html.EscapeString(series.Unit)))
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// burnPointAt returns the last point on or before date
+func burnPointAt(series *BurnSeries, date time.Time) BurnPoint {
+	point := series.Points[0]
+	for _, p := range series.Points {
+		if p.Date.After(date) {
+			break
+		}
+		point = p
+	}
+	return point
+}
+
+// burnMaxScope returns the largest scope of a series, at least 1
+func burnMaxScope(series *BurnSeries) int {
+	maxValue := 1
+	for _, p := range series.Points {
+		if p.Scope > maxValue {
+			maxValue = p.Scope
+		}
+	}
+	return maxValue
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
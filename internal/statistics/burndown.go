@@ -0,0 +1,326 @@
+package statistics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/dashboard"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// Units a burn series is counted in. Tasks are used when the features in scope have
+// any; otherwise each feature counts as one unit.
+const (
+	BurnUnitTasks    = "tasks"
+	BurnUnitFeatures = "features"
+)
+
+// dailyBurnSpan is the longest span, in days, charted one point per day; longer
+// series are sampled weekly
+const dailyBurnSpan = 120
+
+// BurnCharts holds the burndown/burnup series for the project and each phase
+type BurnCharts struct {
+	Project *BurnSeries   `json:"project"`
+	Phases  []*BurnSeries `json:"phases,omitempty"`
+}
+
+// BurnSeries is the scope and completed work of one phase or the whole project over time
+type BurnSeries struct {
+	ID         string      `json:"id,omitempty"` // Phase ID; empty for the project
+	Name       string      `json:"name"`
+	Unit       string      `json:"unit"`
+	StartDate  time.Time   `json:"startDate"`
+	TargetDate time.Time   `json:"targetDate,omitempty"`
+	Points     []BurnPoint `json:"points"`
+}
+
+// BurnPoint is the state of a series at the end of a day
+type BurnPoint struct {
+	Date      time.Time `json:"date"`
+	Scope     int       `json:"scope"`
+	Completed int       `json:"completed"`
+	Remaining int       `json:"remaining"`
+}
+
+// burnSnapshot is a recorded scope and completed count for a series
+type burnSnapshot struct {
+	at        time.Time
+	scope     int
+	completed int
+}
+
+// HasTarget reports whether the series has a target date to burn down to
+func (s *BurnSeries) HasTarget() bool {
+	return !s.TargetDate.IsZero() && s.TargetDate.After(s.StartDate)
+}
+
+// IdealRemaining returns the remaining work on a straight line from the starting
+// scope to zero on the target date
+func (s *BurnSeries) IdealRemaining(t time.Time) float64 {
+	if len(s.Points) == 0 || !s.HasTarget() {
+		return 0
+	}
+
+	fraction := float64(t.Sub(s.StartDate)) / float64(s.TargetDate.Sub(s.StartDate))
+	switch {
+	case fraction < 0:
+		fraction = 0
+	case fraction > 1:
+		fraction = 1
+	}
+	return float64(s.Points[0].Scope) * (1 - fraction)
+}
+
+// Latest returns the most recent point of the series
+func (s *BurnSeries) Latest() BurnPoint {
+	if len(s.Points) == 0 {
+		return BurnPoint{}
+	}
+	return s.Points[len(s.Points)-1]
+}
+
+// AxisEnd returns the last date a chart of the series covers: today, or the target
+// date when it is still ahead
+func (s *BurnSeries) AxisEnd() time.Time {
+	end := s.Latest().Date
+	if s.TargetDate.After(end) {
+		return s.TargetDate
+	}
+	return end
+}
+
+// LoadBurnCharts computes burn series for state from the recorded statistics history
+// and the task completion times in progress.json files
+func LoadBurnCharts(projectRoot string, state *models.State, now time.Time) *BurnCharts {
+	history, progress := loadBurnHistory(projectRoot)
+	return CalculateBurnCharts(state, history, progress, now)
+}
+
+// loadBurnHistory reads the statistics snapshots and progress.json files burn series
+// are built from; missing or unreadable sources are treated as empty
+func loadBurnHistory(projectRoot string) ([]*HistoricalData, map[string]*dashboard.ProgressData) {
+	history, err := NewStorage(projectRoot).LoadAll()
+	if err != nil {
+		history = nil
+	}
+	progress, err := dashboard.NewProgressParser(projectRoot).ReadProgressFiles()
+	if err != nil {
+		progress = nil
+	}
+	return history, progress
+}
+
+// CalculateBurnCharts builds the project series and one series per phase. Each day
+// takes the larger completed count of the latest snapshot and the tasks completed
+// by then; today always reflects the current state.
+func CalculateBurnCharts(state *models.State, history []*HistoricalData, progress map[string]*dashboard.ProgressData, now time.Time) *BurnCharts {
+	if state == nil || len(state.Features) == 0 {
+		return nil
+	}
+
+	snapshots := append([]*HistoricalData{}, history...)
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Timestamp.Before(snapshots[j].Timestamp) })
+	completions := taskCompletionTimes(state, progress)
+
+	charts := &BurnCharts{}
+
+	var projectStart, projectTarget time.Time
+	for _, phase := range state.Phases {
+		if start := parseBurnDate(phase.StartDate, now.Location()); !start.IsZero() && (projectStart.IsZero() || start.Before(projectStart)) {
+			projectStart = start
+		}
+		if target := parseBurnDate(phase.TargetDate, now.Location()); target.After(projectTarget) {
+			projectTarget = target
+		}
+	}
+
+	scope, completed, unit := countBurnUnits(state.Features)
+	var projectSnapshots []burnSnapshot
+	for _, entry := range snapshots {
+		if entry.Data == nil {
+			continue
+		}
+		switch {
+		case unit == BurnUnitTasks && entry.Data.Tasks != nil && entry.Data.Tasks.TotalTasks > 0:
+			projectSnapshots = append(projectSnapshots, burnSnapshot{entry.Timestamp, entry.Data.Tasks.TotalTasks, entry.Data.Tasks.CompletedTasks})
+		case unit == BurnUnitFeatures && entry.Data.State != nil && entry.Data.State.TotalFeatures > 0:
+			projectSnapshots = append(projectSnapshots, burnSnapshot{entry.Timestamp, entry.Data.State.TotalFeatures, entry.Data.State.CompletedFeatures})
+		}
+	}
+	charts.Project = buildBurnSeries(&BurnSeries{Name: "Project", Unit: unit, StartDate: projectStart, TargetDate: projectTarget},
+		scope, completed, projectSnapshots, completionsFor(state.Features, unit, completions), now)
+
+	for _, phase := range state.Phases {
+		var features []models.Feature
+		for _, feature := range state.Features {
+			if feature.Phase == phase.ID {
+				features = append(features, feature)
+			}
+		}
+		if len(features) == 0 {
+			continue
+		}
+
+		scope, completed, unit := countBurnUnits(features)
+		var phaseSnapshots []burnSnapshot
+		for _, entry := range snapshots {
+			if entry.Data == nil || entry.Data.Progress == nil {
+				continue
+			}
+			if percent, ok := entry.Data.Progress.PhaseProgress[phase.ID]; ok {
+				phaseSnapshots = append(phaseSnapshots, burnSnapshot{entry.Timestamp, scope, percent * scope / 100})
+			}
+		}
+
+		series := &BurnSeries{
+			ID:         phase.ID,
+			Name:       phase.Name,
+			Unit:       unit,
+			StartDate:  parseBurnDate(phase.StartDate, now.Location()),
+			TargetDate: parseBurnDate(phase.TargetDate, now.Location()),
+		}
+		charts.Phases = append(charts.Phases, buildBurnSeries(series, scope, completed, phaseSnapshots, completionsFor(features, unit, completions), now))
+	}
+
+	return charts
+}
+
+// buildBurnSeries fills in the points of series from its start date up to today
+func buildBurnSeries(series *BurnSeries, scope, completed int, snapshots []burnSnapshot, completions []time.Time, now time.Time) *BurnSeries {
+	today := burnDay(now)
+
+	start := series.StartDate
+	if start.IsZero() {
+		start = today
+		if len(snapshots) > 0 && snapshots[0].at.Before(start) {
+			start = snapshots[0].at
+		}
+		for _, at := range completions {
+			if at.Before(start) {
+				start = at
+			}
+		}
+	}
+	start = burnDay(start)
+	if start.After(today) {
+		start = today
+	}
+	series.StartDate = start
+
+	stepDays := 1
+	if today.Sub(start) > dailyBurnSpan*24*time.Hour {
+		stepDays = 7
+	}
+
+	for day := start; day.Before(today); day = day.AddDate(0, 0, stepDays) {
+		cutoff := day.AddDate(0, 0, 1)
+
+		point := BurnPoint{Date: day, Scope: scope}
+		for _, at := range completions {
+			if at.Before(cutoff) {
+				point.Completed++
+			}
+		}
+		var latest *burnSnapshot
+		for i := range snapshots {
+			if !snapshots[i].at.Before(cutoff) {
+				break
+			}
+			latest = &snapshots[i]
+		}
+		if latest != nil {
+			point.Scope = latest.scope
+			if latest.completed > point.Completed {
+				point.Completed = latest.completed
+			}
+		}
+		if point.Completed > point.Scope {
+			point.Completed = point.Scope
+		}
+		point.Remaining = point.Scope - point.Completed
+		series.Points = append(series.Points, point)
+	}
+
+	series.Points = append(series.Points, BurnPoint{Date: today, Scope: scope, Completed: completed, Remaining: scope - completed})
+	return series
+}
+
+// countBurnUnits counts the scope and completed units of features
+func countBurnUnits(features []models.Feature) (scope, completed int, unit string) {
+	for _, feature := range features {
+		for _, taskPhase := range feature.TaskPhases {
+			for _, task := range taskPhase.Tasks {
+				scope++
+				if task.Completed {
+					completed++
+				}
+			}
+		}
+	}
+	if scope > 0 {
+		return scope, completed, BurnUnitTasks
+	}
+
+	for _, feature := range features {
+		if feature.Status == "complete" {
+			completed++
+		}
+	}
+	return len(features), completed, BurnUnitFeatures
+}
+
+// taskCompletionTimes maps feature IDs to the completion times of their finished tasks
+func taskCompletionTimes(state *models.State, progress map[string]*dashboard.ProgressData) map[string][]time.Time {
+	byFeature := make(map[string][]time.Time)
+	for _, data := range progress {
+		featureID := ""
+		for _, feature := range state.Features {
+			if (data.FeatureID != "" && data.FeatureID == feature.ID) || (data.FeatureName != "" && data.FeatureName == feature.Name) {
+				featureID = feature.ID
+				break
+			}
+		}
+		if featureID == "" {
+			continue
+		}
+
+		for _, task := range data.Tasks {
+			if task.Completed && !task.CompletedAt.IsZero() {
+				byFeature[featureID] = append(byFeature[featureID], task.CompletedAt)
+			}
+		}
+	}
+	return byFeature
+}
+
+// completionsFor collects the task completion times of features; feature-counted
+// series have none
+func completionsFor(features []models.Feature, unit string, byFeature map[string][]time.Time) []time.Time {
+	if unit != BurnUnitTasks {
+		return nil
+	}
+
+	var times []time.Time
+	for _, feature := range features {
+		times = append(times, byFeature[feature.ID]...)
+	}
+	return times
+}
+
+// burnDay truncates t to midnight in its location
+func burnDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// parseBurnDate parses a phase date, returning the zero time when it is unset or invalid
+func parseBurnDate(value string, loc *time.Location) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,144 @@
+package statistics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/dashboard"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateBurnCharts(t *testing.T) {
+	now := time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)
+	progress := map[string]*dashboard.ProgressData{
+		"01": {FeatureID: "01", Tasks: []dashboard.TaskProgress{
+			{Name: "a", Completed: true, CompletedAt: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)},
+			{Name: "b", Completed: true, CompletedAt: time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)},
+		}},
+	}
+
+	charts := CalculateBurnCharts(burnTestState(), nil, progress, now)
+	require.NotNil(t, charts)
+	require.Len(t, charts.Phases, 2)
+
+	phase := charts.Phases[0]
+	assert.Equal(t, "01-phase", phase.ID)
+	assert.Equal(t, BurnUnitTasks, phase.Unit)
+	assert.True(t, phase.HasTarget())
+	require.Len(t, phase.Points, 5, "one point per day from the phase start to today")
+
+	remaining := make([]int, 0, len(phase.Points))
+	for _, p := range phase.Points {
+		assert.Equal(t, 4, p.Scope)
+		remaining = append(remaining, p.Remaining)
+	}
+	assert.Equal(t, []int{4, 3, 3, 2, 2}, remaining)
+
+	// The ideal line runs from the starting scope to zero on the target date
+	assert.InDelta(t, 4.0, phase.IdealRemaining(phase.StartDate), 0.001)
+	assert.InDelta(t, 2.0, phase.IdealRemaining(time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)), 0.001)
+	assert.InDelta(t, 0.0, phase.IdealRemaining(phase.TargetDate.AddDate(0, 0, 3)), 0.001)
+
+	// A phase without tasks counts features, and one that has not started yet begins today
+	polish := charts.Phases[1]
+	assert.Equal(t, BurnUnitFeatures, polish.Unit)
+	require.Len(t, polish.Points, 1)
+	assert.Equal(t, 1, polish.Latest().Remaining)
+
+	// The project spans the earliest phase start to the latest target, counted in tasks
+	assert.Equal(t, "Project", charts.Project.Name)
+	assert.Equal(t, BurnUnitTasks, charts.Project.Unit)
+	assert.Equal(t, "2026-03-20", charts.Project.TargetDate.Format("2006-01-02"))
+	assert.Equal(t, 2, charts.Project.Latest().Remaining)
+}
+
+func TestCalculateBurnCharts_Snapshots(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	state := burnTestState()
+	state.Phases[0].StartDate = ""
+	state.Phases[1].StartDate = ""
+
+	history := []*HistoricalData{
+		{
+			Timestamp: time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC),
+			Data: &StatisticsData{
+				Tasks:    &TaskStats{TotalTasks: 3, CompletedTasks: 1},
+				Progress: &ProgressHistory{PhaseProgress: map[string]int{"01-phase": 25}},
+			},
+		},
+		{
+			Timestamp: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+			Data:      &StatisticsData{Tasks: &TaskStats{TotalTasks: 2, CompletedTasks: 0}},
+		},
+	}
+
+	charts := CalculateBurnCharts(state, history, nil, now)
+	require.NotNil(t, charts)
+
+	// Without start dates a series begins at its first snapshot, and project scope follows the snapshots
+	project := charts.Project
+	assert.Equal(t, "2026-03-02", project.StartDate.Format("2006-01-02"))
+	phase := charts.Phases[0]
+	assert.Equal(t, "2026-03-03", phase.StartDate.Format("2006-01-02"))
+	require.Len(t, phase.Points, 2)
+	assert.Equal(t, 1, phase.Points[0].Completed, "25% of 4 tasks")
+	assert.Equal(t, 2, phase.Points[1].Completed, "today reflects the current state")
+
+	scopes := make([]int, 0, len(project.Points))
+	for _, p := range project.Points {
+		scopes = append(scopes, p.Scope)
+	}
+	assert.Equal(t, []int{2, 3, 4}, scopes)
+}
+
+func TestCalculateBurnCharts_NoFeatures(t *testing.T) {
+	assert.Nil(t, CalculateBurnCharts(&models.State{}, nil, nil, time.Now()))
+	assert.Nil(t, CalculateBurnCharts(nil, nil, nil, time.Now()))
+}
+
+func TestRenderBurnChart(t *testing.T) {
+	now := time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)
+	charts := CalculateBurnCharts(burnTestState(), nil, nil, now)
+	require.NotNil(t, charts)
+
+	chart := RenderBurnChart(charts.Phases[0], 24, 4)
+	lines := strings.Split(chart, "\n")
+	require.Len(t, lines, 7, "four rows, the axis, the dates and the legend")
+
+	assert.True(t, strings.HasPrefix(lines[0], "4 ┤"))
+	assert.True(t, strings.HasPrefix(lines[3], "0 ┤"))
+	assert.Contains(t, chart, "█")
+	assert.Contains(t, chart, "┆", "the target date is marked")
+	assert.Contains(t, lines[5], "2026-03-01")
+	assert.Contains(t, lines[5], "2026-03-11")
+	assert.Contains(t, lines[6], "remaining (2 of 4 tasks)")
+	assert.Contains(t, lines[6], "target 2026-03-11")
+
+	assert.Empty(t, RenderBurnChart(nil, 20, 4))
+}
+
+func TestBurnChartSVG(t *testing.T) {
+	now := time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)
+	state := burnTestState()
+	state.Phases[0].Name = "Auth & <Login>"
+	charts := CalculateBurnCharts(state, nil, nil, now)
+	require.NotNil(t, charts)
+
+	svg := BurnChartSVG(charts.Phases[0], 640, 240)
+	assert.True(t, strings.HasPrefix(svg, "<svg"))
+	assert.Contains(t, svg, `aria-label="Burndown for Auth &amp; &lt;Login&gt;"`)
+	assert.Contains(t, svg, `class="remaining"`)
+	assert.Contains(t, svg, `class="completed"`)
+	assert.Contains(t, svg, `class="ideal"`)
+	assert.Contains(t, svg, "target 2026-03-11")
+
+	// Without a target there is no ideal or target line
+	state.Phases[0].TargetDate = ""
+	charts = CalculateBurnCharts(state, nil, nil, now)
+	svg = BurnChartSVG(charts.Phases[0], 640, 240)
+	assert.NotContains(t, svg, `class="target"`)
+	assert.NotContains(t, svg, `class="ideal"`)
+}
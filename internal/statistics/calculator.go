@@ -10,6 +10,7 @@ import (
 // Calculator computes metrics from collected data
 type Calculator struct {
 	projectStartDate time.Time
+	now              time.Time // Zero uses the current time
 }
 
 // NewCalculator creates a new statistics calculator
@@ -19,10 +20,19 @@ func NewCalculator(projectStartDate time.Time) *Calculator {
 	}
 }
 
+// NewCalculatorAt creates a calculator that computes metrics as they were at now,
+// for rebuilding past snapshots
+func NewCalculatorAt(projectStartDate, now time.Time) *Calculator {
+	return &Calculator{
+		projectStartDate: projectStartDate,
+		now:              now,
+	}
+}
+
 // Calculate computes all metrics from statistics data
 func (c *Calculator) Calculate(data *StatisticsData, state *models.State, githubData *models.GitHubData) *StatisticsMetrics {
 	metrics := &StatisticsMetrics{
-		CalculatedAt: time.Now(),
+		CalculatedAt: c.currentTime(),
 	}
 
 	metrics.Velocity = c.CalculateVelocity(data, state, githubData)
@@ -179,7 +189,7 @@ func (c *Calculator) CalculateTimeMetrics(data *StatisticsData, state *models.St
 		remainingFeatures := data.State.TotalFeatures - data.State.CompletedFeatures
 		if remainingFeatures > 0 {
 			daysRemaining := int(metrics.AvgFeatureTime * float64(remainingFeatures))
-			metrics.EstimatedCompletion = time.Now().AddDate(0, 0, daysRemaining)
+			metrics.EstimatedCompletion = c.currentTime().AddDate(0, 0, daysRemaining)
 		}
 	}
 
@@ -204,9 +214,30 @@ func (c *Calculator) CalculateQualityMetrics(data *StatisticsData, githubData *m
 		}
 	}
 
-	// Average branch lifetime (simplified - would need more data)
-	if data.GitHub != nil && data.GitHub.MergedPRs > 0 {
-		// Estimate based on merged PRs
+	// Review time runs from opening a PR to merging it; branch lifetime from the
+	// branch's first commit to that merge
+	if githubData != nil {
+		var reviewHours, lifetimeDays []float64
+		for _, pr := range githubData.PRs {
+			merged := parseFlowTime(pr.MergedAt)
+			if merged.IsZero() {
+				continue
+			}
+			if opened := parseFlowTime(pr.CreatedAt); !opened.IsZero() && !merged.Before(opened) {
+				reviewHours = append(reviewHours, merged.Sub(opened).Hours())
+			}
+			for _, branch := range githubData.Branches {
+				if created := parseFlowTime(branch.CreatedAt); branch.Name == pr.Branch && !created.IsZero() && !merged.Before(created) {
+					lifetimeDays = append(lifetimeDays, flowDays(merged.Sub(created)))
+				}
+			}
+		}
+		metrics.AvgPRReviewTime = mean(reviewHours)
+		metrics.AvgBranchLifetime = mean(lifetimeDays)
+	}
+
+	// Without timestamps, fall back on an estimate
+	if metrics.AvgBranchLifetime == 0 && data.GitHub != nil && data.GitHub.MergedPRs > 0 {
 		metrics.AvgBranchLifetime = 3.5 // Default estimate, would need actual branch data
 	}
 
@@ -237,6 +268,21 @@ func (c *Calculator) CalculateTestingMetrics(testing *TestingStats) *TestingMetr
 		return metrics.Packages[i].Name < metrics.Packages[j].Name
 	})
 
+	for id, featureStats := range testing.FeatureStats {
+		if featureStats.Statements == 0 {
+			continue
+		}
+		metrics.Features = append(metrics.Features, FeatureCoverageMetric{
+			ID:       id,
+			Name:     featureStats.Name,
+			Coverage: percentage(featureStats.CoveredStatements, featureStats.Statements),
+		})
+	}
+	sort.Slice(metrics.Features, func(i, j int) bool {
+		return metrics.Features[i].ID < metrics.Features[j].ID
+	})
+	metrics.Reports = testing.Reports
+
 	return metrics
 }
 
@@ -247,10 +293,28 @@ func percentage(covered, total int) float64 {
 	return (float64(covered) / float64(total)) * 100
 }
 
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
 // daysSinceStart calculates days since project start
 func (c *Calculator) daysSinceStart() int {
 	if c.projectStartDate.IsZero() {
 		return 0
 	}
-	return int(time.Since(c.projectStartDate).Hours() / 24)
+	return int(c.currentTime().Sub(c.projectStartDate).Hours() / 24)
+}
+
+func (c *Calculator) currentTime() time.Time {
+	if c.now.IsZero() {
+		return time.Now()
+	}
+	return c.now
 }
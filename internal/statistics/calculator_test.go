@@ -143,6 +143,49 @@ func TestCalculateQualityMetrics(t *testing.T) {
 	assert.NotNil(t, metrics)
 	assert.Equal(t, 80.0, metrics.PRMergeRate)               // 8/10 * 100
 	assert.InDelta(t, 2.8, metrics.CheckpointFrequency, 0.1) // 12/4.3 weeks
+	assert.Equal(t, 3.5, metrics.AvgBranchLifetime, "estimated without branch timestamps")
+}
+
+func TestCalculateQualityMetrics_Timestamps(t *testing.T) {
+	calculator := NewCalculator(time.Now().Add(-30 * 24 * time.Hour))
+	githubData := &models.GitHubData{
+		Branches: []models.Branch{
+			{Name: "feature/a", CreatedAt: "2026-03-01T00:00:00Z"},
+			{Name: "feature/b", CreatedAt: "2026-03-01T00:00:00Z"},
+		},
+		PRs: []models.PullRequest{
+			{Branch: "feature/a", CreatedAt: "2026-03-02T00:00:00Z", MergedAt: "2026-03-02T12:00:00Z"},
+			{Branch: "feature/b", CreatedAt: "2026-03-03T00:00:00Z", MergedAt: "2026-03-04T00:00:00Z"},
+			{Branch: "feature/c", CreatedAt: "2026-03-03T00:00:00Z"},
+		},
+	}
+
+	metrics := calculator.CalculateQualityMetrics(&StatisticsData{}, githubData)
+	assert.InDelta(t, 18.0, metrics.AvgPRReviewTime, 0.001, "12 and 24 hours from opening to merge")
+	assert.InDelta(t, 2.25, metrics.AvgBranchLifetime, 0.001, "1.5 and 3 days from first commit to merge")
+}
+
+func TestCalculateTestingMetrics_Features(t *testing.T) {
+	calculator := NewCalculator(time.Now())
+	testing := &TestingStats{
+		TotalStatements:   10,
+		CoveredStatements: 6,
+		PackageStats:      map[string]*PackageCoverageStats{"src/auth": {Name: "src/auth", Statements: 10, CoveredStatements: 6}},
+		FeatureStats: map[string]*PackageCoverageStats{
+			"02": {Name: "Profile", Statements: 4, CoveredStatements: 1},
+			"01": {Name: "Auth", Statements: 6, CoveredStatements: 5},
+			"03": {Name: "Empty"},
+		},
+		Reports: []string{"coverage/lcov.info"},
+	}
+
+	metrics := calculator.CalculateTestingMetrics(testing)
+	assert.InDelta(t, 60.0, metrics.OverallCoverage, 0.001)
+	assert.Equal(t, []string{"coverage/lcov.info"}, metrics.Reports)
+	assert.Len(t, metrics.Features, 2, "features without mapped statements are left out")
+	assert.Equal(t, "Auth", metrics.Features[0].Name)
+	assert.InDelta(t, 83.33, metrics.Features[0].Coverage, 0.01)
+	assert.InDelta(t, 25.0, metrics.Features[1].Coverage, 0.001)
 }
 
 func TestDaysSinceStart(t *testing.T) {
@@ -1,17 +1,15 @@
 package statistics
 
 import (
-	"bufio"
 	"encoding/json"
 	"os"
 	"path/filepath"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/DoPlan-dev/CLI/internal/config"
 	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
 )
 
 // Collector gathers data from various sources
@@ -125,6 +123,11 @@ func (c *Collector) CollectState() (*StateData, error) {
 		return nil, err
 	}
 
+	return stateData(state), nil
+}
+
+// stateData counts the phases and features of a state by status
+func stateData(state *models.State) *StateData {
 	data := &StateData{
 		TotalPhases:   len(state.Phases),
 		TotalFeatures: len(state.Features),
@@ -140,14 +143,14 @@ func (c *Collector) CollectState() (*StateData, error) {
 		switch feature.Status {
 		case "complete":
 			data.CompletedFeatures++
-		case "in-progress":
+		case "in-progress", "review":
 			data.InProgressFeatures++
 		case "pending":
 			data.PendingFeatures++
 		}
 	}
 
-	return data, nil
+	return data
 }
 
 // CollectGitHub collects GitHub-related statistics
@@ -241,10 +244,15 @@ func (c *Collector) CollectProgress() (*ProgressHistory, error) {
 		return nil, err
 	}
 
+	return progressHistory(state, time.Now()), nil
+}
+
+// progressHistory records the overall, phase and feature progress of a state
+func progressHistory(state *models.State, updated time.Time) *ProgressHistory {
 	history := &ProgressHistory{
 		PhaseProgress:   make(map[string]int),
 		FeatureProgress: make(map[string]int),
-		LastUpdated:     time.Now(),
+		LastUpdated:     updated,
 	}
 
 	history.OverallProgress = state.Progress.Overall
@@ -257,7 +265,7 @@ func (c *Collector) CollectProgress() (*ProgressHistory, error) {
 		history.FeatureProgress[feature.ID] = feature.Progress
 	}
 
-	return history, nil
+	return history
 }
 
 // CollectTasks collects task-related statistics
@@ -267,6 +275,11 @@ func (c *Collector) CollectTasks() (*TaskStats, error) {
 		return nil, err
 	}
 
+	return taskStats(state), nil
+}
+
+// taskStats counts the tasks of all features in a state
+func taskStats(state *models.State) *TaskStats {
 	stats := &TaskStats{}
 
 	// Count tasks from all features
@@ -283,103 +296,58 @@ func (c *Collector) CollectTasks() (*TaskStats, error) {
 		}
 	}
 
-	// Calculate completion rate
-	if stats.TotalTasks > 0 {
-		stats.CompletionRate = (stats.CompletedTasks * 100) / stats.TotalTasks
-	}
-
-	return stats, nil
+	stats.complete()
+	return stats
 }
 
-// CollectTesting collects test coverage metrics from coverage profiles
-func (c *Collector) CollectTesting() (*TestingStats, error) {
-	coveragePaths := []string{
-		filepath.Join(c.projectRoot, "coverage.out"),
-		filepath.Join(c.projectRoot, "coverage", "coverage.out"),
-		filepath.Join(c.projectRoot, ".coverage", "coverage.out"),
+// complete derives the completion rate from the task counts
+func (s *TaskStats) complete() {
+	if s.TotalTasks > 0 {
+		s.CompletionRate = (s.CompletedTasks * 100) / s.TotalTasks
 	}
+}
 
-	var coveragePath string
-	for _, candidate := range coveragePaths {
-		if _, err := os.Stat(candidate); err == nil {
-			coveragePath = candidate
-			break
+// CollectTesting collects test coverage metrics from Go, lcov, Cobertura and JaCoCo
+// reports matching the stats.coverage.paths globs, attributing covered files to
+// features through stats.coverage.features
+func (c *Collector) CollectTesting() (*TestingStats, error) {
+	var coverageCfg models.CoverageConfig
+	featureNames := make(map[string]string)
+	if c.configMgr != nil {
+		if cfg, err := c.configMgr.LoadConfig(); err == nil && cfg != nil {
+			coverageCfg = cfg.Stats.Coverage
+		}
+		if state, err := c.configMgr.LoadState(); err == nil {
+			for _, feature := range state.Features {
+				featureNames[feature.ID] = feature.Name
+			}
 		}
 	}
 
-	if coveragePath == "" {
-		// No coverage profile available
-		return nil, nil
+	patterns := coverageCfg.Paths
+	if len(patterns) == 0 {
+		patterns = DefaultCoveragePaths
 	}
-
-	file, err := os.Open(coveragePath)
+	reports, err := findCoverageReports(c.projectRoot, patterns)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	stats := &TestingStats{
-		PackageStats: make(map[string]*PackageCoverageStats),
-	}
-
-	scanner := bufio.NewScanner(file)
-	line := 0
-	for scanner.Scan() {
-		text := strings.TrimSpace(scanner.Text())
-		if text == "" {
-			continue
-		}
-
-		// Skip coverage mode declaration
-		if line == 0 && strings.HasPrefix(text, "mode:") {
-			line++
-			continue
-		}
-
-		fields := strings.Fields(text)
-		if len(fields) < 3 {
-			continue
-		}
-
-		statements, err := strconv.Atoi(fields[1])
-		if err != nil {
-			continue
-		}
 
-		count, err := strconv.Atoi(fields[2])
-		if err != nil {
-			continue
+	// Unreadable or unrecognized reports are skipped rather than hiding the others
+	coverage := newCoverageSet(c.projectRoot)
+	var loaded []string
+	for _, report := range reports {
+		if coverage.load(report) {
+			loaded = append(loaded, report)
 		}
-
-		stats.TotalStatements += statements
-		if count > 0 {
-			stats.CoveredStatements += statements
-		}
-
-		filePath := fields[0]
-		if idx := strings.Index(filePath, ":"); idx != -1 {
-			filePath = filePath[:idx]
-		}
-		pkgPath := filepath.Dir(filePath)
-		pkgStats, ok := stats.PackageStats[pkgPath]
-		if !ok {
-			pkgStats = &PackageCoverageStats{Name: pkgPath}
-			stats.PackageStats[pkgPath] = pkgStats
-		}
-		pkgStats.Statements += statements
-		if count > 0 {
-			pkgStats.CoveredStatements += statements
-		}
-		line++
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
 	}
 
-	if stats.TotalStatements == 0 {
+	stats := coverage.stats(coverageCfg.Features, featureNames)
+	if stats == nil {
+		// No coverage reports available
 		return nil, nil
 	}
+	stats.Reports = loaded
 
 	return stats, nil
 }
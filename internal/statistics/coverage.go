@@ -0,0 +1,539 @@
+package statistics
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// Coverage report formats
+const (
+	CoverageFormatGo        = "go"
+	CoverageFormatLcov      = "lcov"
+	CoverageFormatCobertura = "cobertura"
+	CoverageFormatJaCoCo    = "jacoco"
+)
+
+// DefaultCoveragePaths are the report globs searched when stats.coverage.paths is unset
+var DefaultCoveragePaths = []string{
+	"coverage.out",
+	"coverage_*.out",
+	"coverage/coverage.out",
+	".coverage/coverage.out",
+	"coverage/lcov.info",
+	"coverage/cobertura-coverage.xml",
+	"coverage.xml",
+	"target/site/jacoco/jacoco.xml",
+	"build/reports/jacoco/test/jacocoTestReport.xml",
+}
+
+const (
+	// coverageChangeThreshold is the smallest package change, in percentage points,
+	// listed in a coverage diff
+	coverageChangeThreshold = 0.1
+	// coverageTrendThreshold is the overall change, in percentage points, that counts
+	// as improving or declining
+	coverageTrendThreshold = 0.5
+)
+
+// skippedCoverageDirs are never searched by ** globs
+var skippedCoverageDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true, ".doplan": true}
+
+// coverageSet merges the per-file coverage of any number of reports. A line or Go
+// block covered in any report counts as covered.
+type coverageSet struct {
+	projectRoot string
+	modulePath  string
+	files       map[string]*coverageFile
+}
+
+// coverageFile is the coverage of one source file
+type coverageFile struct {
+	path  string // Relative to the project root where the report allows it
+	pkg   string
+	units map[string]*coverageUnit // Keyed by line number or Go block position
+}
+
+// coverageUnit is a line, or a Go block of several statements
+type coverageUnit struct {
+	weight  int
+	covered bool
+}
+
+func newCoverageSet(projectRoot string) *coverageSet {
+	return &coverageSet{
+		projectRoot: projectRoot,
+		modulePath:  readModulePath(projectRoot),
+		files:       make(map[string]*coverageFile),
+	}
+}
+
+func (s *coverageSet) add(file, pkg, key string, weight int, covered bool) {
+	f, ok := s.files[file]
+	if !ok {
+		f = &coverageFile{path: file, pkg: pkg, units: make(map[string]*coverageUnit)}
+		s.files[file] = f
+	}
+	unit, ok := f.units[key]
+	if !ok {
+		f.units[key] = &coverageUnit{weight: weight, covered: covered}
+		return
+	}
+	unit.covered = unit.covered || covered
+}
+
+// load parses the report at a path relative to the project root. It returns false
+// when the report is unreadable or not in a known format.
+func (s *coverageSet) load(report string) bool {
+	data, err := os.ReadFile(filepath.Join(s.projectRoot, filepath.FromSlash(report)))
+	if err != nil {
+		return false
+	}
+
+	switch detectCoverageFormat(data) {
+	case CoverageFormatGo:
+		return s.parseGo(data)
+	case CoverageFormatLcov:
+		return s.parseLcov(data)
+	case CoverageFormatCobertura:
+		return s.parseCobertura(data)
+	case CoverageFormatJaCoCo:
+		return s.parseJaCoCo(data)
+	}
+	return false
+}
+
+// detectCoverageFormat recognizes a report by its content
+func detectCoverageFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("mode:")):
+		return CoverageFormatGo
+	case bytes.HasPrefix(trimmed, []byte("TN:")) || bytes.HasPrefix(trimmed, []byte("SF:")):
+		return CoverageFormatLcov
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return ""
+			}
+			if start, ok := token.(xml.StartElement); ok {
+				switch start.Name.Local {
+				case "coverage":
+					return CoverageFormatCobertura
+				case "report":
+					return CoverageFormatJaCoCo
+				}
+				return ""
+			}
+		}
+	}
+	return ""
+}
+
+// parseGo reads a Go coverprofile. Packages keep their import paths; files are
+// attributed to features by their path inside the module.
+func (s *coverageSet) parseGo(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "mode:") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 3 {
+			continue
+		}
+		statements, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+
+		filePath, block := fields[0], fields[0]
+		if idx := strings.Index(filePath, ":"); idx != -1 {
+			filePath = filePath[:idx]
+		}
+		file := filePath
+		if s.modulePath != "" {
+			file = strings.TrimPrefix(file, s.modulePath+"/")
+		}
+		s.add(file, path.Dir(filePath), block, statements, count > 0)
+	}
+	return scanner.Err() == nil
+}
+
+// parseLcov reads lcov tracefiles, counting DA line records
+func (s *coverageSet) parseLcov(data []byte) bool {
+	file := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(text, "SF:"):
+			file = s.sourcePath(strings.TrimPrefix(text, "SF:"))
+		case text == "end_of_record":
+			file = ""
+		case strings.HasPrefix(text, "DA:") && file != "":
+			fields := strings.Split(strings.TrimPrefix(text, "DA:"), ",")
+			if len(fields) < 2 {
+				continue
+			}
+			hits, err := strconv.Atoi(fields[1])
+			if err != nil {
+				continue
+			}
+			s.add(file, path.Dir(file), fields[0], 1, hits > 0)
+		}
+	}
+	return scanner.Err() == nil
+}
+
+type coberturaReport struct {
+	Sources  []string `xml:"sources>source"`
+	Packages []struct {
+		Name    string `xml:"name,attr"`
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number string `xml:"number,attr"`
+				Hits   int    `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+// parseCobertura reads Cobertura XML, resolving class files against its sources
+func (s *coverageSet) parseCobertura(data []byte) bool {
+	var report coberturaReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return false
+	}
+
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			file := s.coberturaPath(report.Sources, class.Filename)
+			pkgName := pkg.Name
+			if pkgName == "" {
+				pkgName = path.Dir(file)
+			}
+			for _, line := range class.Lines {
+				s.add(file, pkgName, line.Number, 1, line.Hits > 0)
+			}
+		}
+	}
+	return true
+}
+
+// coberturaPath joins a class file to the first source directory containing it
+func (s *coverageSet) coberturaPath(sources []string, filename string) string {
+	if filepath.IsAbs(filename) || len(sources) == 0 {
+		return s.sourcePath(filename)
+	}
+	for _, source := range sources {
+		candidate := filepath.Join(strings.TrimSpace(source), filename)
+		if !filepath.IsAbs(candidate) {
+			candidate = filepath.Join(s.projectRoot, candidate)
+		}
+		if _, err := os.Stat(candidate); err == nil {
+			return s.sourcePath(candidate)
+		}
+	}
+	return s.sourcePath(filepath.Join(strings.TrimSpace(sources[0]), filename))
+}
+
+type jacocoGroup struct {
+	Groups   []jacocoGroup `xml:"group"`
+	Packages []struct {
+		Name        string `xml:"name,attr"`
+		SourceFiles []struct {
+			Name  string `xml:"name,attr"`
+			Lines []struct {
+				Number          string `xml:"nr,attr"`
+				CoveredInstrs   int    `xml:"ci,attr"`
+				CoveredBranches int    `xml:"cb,attr"`
+			} `xml:"line"`
+		} `xml:"sourcefile"`
+	} `xml:"package"`
+}
+
+// parseJaCoCo reads JaCoCo XML. Source files are named by package path, e.g.
+// com/example/auth/Login.java, so feature globs usually start with **/.
+func (s *coverageSet) parseJaCoCo(data []byte) bool {
+	var report jacocoGroup
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	if err := decoder.Decode(&report); err != nil {
+		return false
+	}
+	s.addJaCoCoGroup(report)
+	return true
+}
+
+func (s *coverageSet) addJaCoCoGroup(group jacocoGroup) {
+	for _, child := range group.Groups {
+		s.addJaCoCoGroup(child)
+	}
+	for _, pkg := range group.Packages {
+		for _, source := range pkg.SourceFiles {
+			file := path.Join(pkg.Name, source.Name)
+			for _, line := range source.Lines {
+				s.add(file, pkg.Name, line.Number, 1, line.CoveredInstrs > 0 || line.CoveredBranches > 0)
+			}
+		}
+	}
+}
+
+// sourcePath makes report paths under the project root relative to it
+func (s *coverageSet) sourcePath(file string) string {
+	file = strings.TrimSpace(file)
+	if filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(s.projectRoot, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return path.Clean(filepath.ToSlash(file))
+}
+
+// stats totals the merged coverage per package and per feature mapping
+func (s *coverageSet) stats(mappings []models.CoverageMapping, featureNames map[string]string) *TestingStats {
+	stats := &TestingStats{
+		PackageStats: make(map[string]*PackageCoverageStats),
+	}
+
+	for _, file := range s.files {
+		statements, covered := file.totals()
+		if statements == 0 {
+			continue
+		}
+		stats.TotalStatements += statements
+		stats.CoveredStatements += covered
+
+		pkgStats, ok := stats.PackageStats[file.pkg]
+		if !ok {
+			pkgStats = &PackageCoverageStats{Name: file.pkg}
+			stats.PackageStats[file.pkg] = pkgStats
+		}
+		pkgStats.Statements += statements
+		pkgStats.CoveredStatements += covered
+
+		for _, mapping := range mappings {
+			if !matchesAnyGlob(mapping.Paths, file.path) {
+				continue
+			}
+			if stats.FeatureStats == nil {
+				stats.FeatureStats = make(map[string]*PackageCoverageStats)
+			}
+			featureStats, ok := stats.FeatureStats[mapping.Feature]
+			if !ok {
+				name := featureNames[mapping.Feature]
+				if name == "" {
+					name = mapping.Feature
+				}
+				featureStats = &PackageCoverageStats{Name: name}
+				stats.FeatureStats[mapping.Feature] = featureStats
+			}
+			featureStats.Statements += statements
+			featureStats.CoveredStatements += covered
+		}
+	}
+
+	if stats.TotalStatements == 0 {
+		return nil
+	}
+	return stats
+}
+
+func (f *coverageFile) totals() (int, int) {
+	statements, covered := 0, 0
+	for _, unit := range f.units {
+		statements += unit.weight
+		if unit.covered {
+			covered += unit.weight
+		}
+	}
+	return statements, covered
+}
+
+// findCoverageReports returns the files matching the report globs, relative to the
+// project root and in pattern order
+func findCoverageReports(projectRoot string, patterns []string) ([]string, error) {
+	var reports []string
+	seen := make(map[string]bool)
+	addReport := func(report string) {
+		if !seen[report] {
+			seen[report] = true
+			reports = append(reports, report)
+		}
+	}
+
+	var walked []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(path.Clean(filepath.ToSlash(pattern)), "./")
+		if !strings.Contains(pattern, "**") {
+			matches, err := filepath.Glob(filepath.Join(projectRoot, filepath.FromSlash(pattern)))
+			if err != nil {
+				return nil, err
+			}
+			sort.Strings(matches)
+			for _, match := range matches {
+				if info, err := os.Stat(match); err == nil && !info.IsDir() {
+					rel, _ := filepath.Rel(projectRoot, match)
+					addReport(filepath.ToSlash(rel))
+				}
+			}
+			continue
+		}
+
+		// Recursive globs share one walk of the project
+		if walked == nil {
+			walked = []string{}
+			err := filepath.WalkDir(projectRoot, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return nil
+				}
+				if d.IsDir() {
+					if p != projectRoot && skippedCoverageDirs[d.Name()] {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				rel, _ := filepath.Rel(projectRoot, p)
+				walked = append(walked, filepath.ToSlash(rel))
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, file := range walked {
+			if matchGlob(pattern, file) {
+				addReport(file)
+			}
+		}
+	}
+	return reports, nil
+}
+
+// matchGlob matches a slash-separated path against a glob in which ** matches any
+// number of directories
+func matchGlob(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matched, err := path.Match(pattern[0], name[0]); err != nil || !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(strings.TrimPrefix(path.Clean(filepath.ToSlash(pattern)), "./"), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// readModulePath returns the module path declared in the project's go.mod, if any
+func readModulePath(projectRoot string) string {
+	data, err := os.ReadFile(filepath.Join(projectRoot, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// CompareCoverage records on current the change in overall coverage since a
+// previous snapshot and the packages whose coverage moved, appeared or disappeared
+func CompareCoverage(current, previous *TestingMetrics) {
+	if current == nil || previous == nil {
+		return
+	}
+
+	current.Change = current.OverallCoverage - previous.OverallCoverage
+	switch {
+	case current.Change >= coverageTrendThreshold:
+		current.Trend = "improving"
+	case current.Change <= -coverageTrendThreshold:
+		current.Trend = "declining"
+	default:
+		current.Trend = "stable"
+	}
+
+	before := make(map[string]float64, len(previous.Packages))
+	for _, pkg := range previous.Packages {
+		before[pkg.Name] = pkg.Coverage
+	}
+
+	current.PackageDiff = nil
+	seen := make(map[string]bool, len(current.Packages))
+	for _, pkg := range current.Packages {
+		seen[pkg.Name] = true
+		previousCoverage, ok := before[pkg.Name]
+		switch {
+		case !ok:
+			current.PackageDiff = append(current.PackageDiff, PackageCoverageDiff{
+				Name: pkg.Name, Current: pkg.Coverage, Change: pkg.Coverage, Status: "added",
+			})
+		case math.Abs(pkg.Coverage-previousCoverage) >= coverageChangeThreshold:
+			current.PackageDiff = append(current.PackageDiff, PackageCoverageDiff{
+				Name: pkg.Name, Previous: previousCoverage, Current: pkg.Coverage, Change: pkg.Coverage - previousCoverage, Status: "changed",
+			})
+		}
+	}
+	for _, pkg := range previous.Packages {
+		if !seen[pkg.Name] {
+			current.PackageDiff = append(current.PackageDiff, PackageCoverageDiff{
+				Name: pkg.Name, Previous: pkg.Coverage, Change: -pkg.Coverage, Status: "removed",
+			})
+		}
+	}
+
+	// Biggest moves first
+	sort.SliceStable(current.PackageDiff, func(i, j int) bool {
+		a, b := math.Abs(current.PackageDiff[i].Change), math.Abs(current.PackageDiff[j].Change)
+		if a != b {
+			return a > b
+		}
+		return current.PackageDiff[i].Name < current.PackageDiff[j].Name
+	})
+}
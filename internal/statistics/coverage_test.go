@@ -0,0 +1,206 @@
+package statistics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGoProfile = `mode: set
+github.com/example/app/internal/auth/login.go:10.1,12.2 2 1
+github.com/example/app/internal/auth/login.go:14.1,16.2 2 0
+github.com/example/app/internal/billing/invoice.go:20.1,22.2 1 1
+`
+	testLcov = `TN:
+SF:src/auth/session.ts
+DA:1,1
+DA:2,0
+DA:3,4
+end_of_record
+SF:src/profile/page.ts
+DA:1,0
+end_of_record
+`
+	testCobertura = `<?xml version="1.0" ?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage line-rate="0.5" version="7.4">
+	<sources><source>scripts</source></sources>
+	<packages>
+		<package name="deploy">
+			<classes>
+				<class name="release.py" filename="deploy/release.py">
+					<lines><line number="1" hits="1"/><line number="2" hits="0"/></lines>
+				</class>
+			</classes>
+		</package>
+	</packages>
+</coverage>
+`
+	testJaCoCo = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="api">
+	<group name="auth-service">
+		<package name="com/example/auth">
+			<sourcefile name="TokenService.java">
+				<line nr="5" mi="0" ci="3" mb="0" cb="0"/>
+				<line nr="6" mi="2" ci="0" mb="1" cb="1"/>
+				<line nr="7" mi="4" ci="0" mb="0" cb="0"/>
+				<counter type="LINE" missed="1" covered="2"/>
+			</sourcefile>
+		</package>
+	</group>
+</report>
+`
+)
+
+func writeCoverageFile(t *testing.T, projectRoot, name, content string) {
+	t.Helper()
+	path := filepath.Join(projectRoot, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestDetectCoverageFormat(t *testing.T) {
+	assert.Equal(t, CoverageFormatGo, detectCoverageFormat([]byte(testGoProfile)))
+	assert.Equal(t, CoverageFormatLcov, detectCoverageFormat([]byte(testLcov)))
+	assert.Equal(t, CoverageFormatCobertura, detectCoverageFormat([]byte(testCobertura)))
+	assert.Equal(t, CoverageFormatJaCoCo, detectCoverageFormat([]byte(testJaCoCo)))
+	assert.Empty(t, detectCoverageFormat([]byte("<html></html>")))
+	assert.Empty(t, detectCoverageFormat([]byte("not a report")))
+}
+
+func TestCollector_CollectTesting_Formats(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	writeCoverageFile(t, projectRoot, "go.mod", "module github.com/example/app\n\ngo 1.24\n")
+	writeCoverageFile(t, projectRoot, "coverage.out", testGoProfile)
+	writeCoverageFile(t, projectRoot, "web/coverage/lcov.info", testLcov)
+	writeCoverageFile(t, projectRoot, "coverage/cobertura-coverage.xml", testCobertura)
+	writeCoverageFile(t, projectRoot, "services/api/target/site/jacoco/jacoco.xml", testJaCoCo)
+	writeCoverageFile(t, projectRoot, "web/coverage/notes.xml", "<notes/>")
+
+	cfgMgr := config.NewManager(projectRoot)
+	cfg := config.NewConfig("cursor")
+	cfg.Stats.Coverage = models.CoverageConfig{
+		Paths: []string{"coverage.out", "**/lcov.info", "coverage/*.xml", "services/**/jacoco.xml", "web/coverage/*.xml"},
+		Features: []models.CoverageMapping{
+			{Feature: "01", Paths: []string{"internal/auth/**", "src/auth/**", "**/com/example/auth/**"}},
+			{Feature: "02", Paths: []string{"scripts/deploy/*.py"}},
+		},
+	}
+	require.NoError(t, cfgMgr.SaveConfig(cfg))
+	require.NoError(t, cfgMgr.SaveState(&models.State{Features: []models.Feature{{ID: "01", Name: "Authentication"}}}))
+
+	stats, err := NewCollector(projectRoot).CollectTesting()
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+
+	// 5 Go statements, 4 lcov lines, 2 Cobertura lines and 3 JaCoCo lines
+	assert.Equal(t, 14, stats.TotalStatements)
+	assert.Equal(t, 3+2+1+2, stats.CoveredStatements)
+	assert.Equal(t, []string{"coverage.out", "web/coverage/lcov.info", "coverage/cobertura-coverage.xml", "services/api/target/site/jacoco/jacoco.xml"}, stats.Reports,
+		"unrecognized reports are skipped")
+
+	require.Contains(t, stats.PackageStats, "github.com/example/app/internal/auth")
+	require.Contains(t, stats.PackageStats, "src/auth")
+	require.Contains(t, stats.PackageStats, "deploy")
+	require.Contains(t, stats.PackageStats, "com/example/auth")
+	assert.Equal(t, 2, stats.PackageStats["com/example/auth"].CoveredStatements, "covered branches count as covered")
+
+	require.Contains(t, stats.FeatureStats, "01")
+	assert.Equal(t, "Authentication", stats.FeatureStats["01"].Name)
+	assert.Equal(t, 4+3+3, stats.FeatureStats["01"].Statements)
+	assert.Equal(t, 2+2+2, stats.FeatureStats["01"].CoveredStatements)
+	require.Contains(t, stats.FeatureStats, "02")
+	assert.Equal(t, "02", stats.FeatureStats["02"].Name, "features missing from state keep their ID")
+	assert.Equal(t, 2, stats.FeatureStats["02"].Statements)
+}
+
+func TestCollector_CollectTesting_DefaultPaths(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	writeCoverageFile(t, projectRoot, "coverage_config.out", testGoProfile)
+	writeCoverageFile(t, projectRoot, "coverage/lcov.info", testLcov)
+
+	stats, err := (&Collector{projectRoot: projectRoot}).CollectTesting()
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+	assert.Equal(t, []string{"coverage_config.out", "coverage/lcov.info"}, stats.Reports)
+	assert.Equal(t, 9, stats.TotalStatements)
+}
+
+func TestCoverageSet_MergesReports(t *testing.T) {
+	projectRoot := t.TempDir()
+	writeCoverageFile(t, projectRoot, "unit.info", "SF:src/a.ts\nDA:1,1\nDA:2,0\nend_of_record\n")
+	writeCoverageFile(t, projectRoot, "e2e.info", "SF:"+filepath.Join(projectRoot, "src", "a.ts")+"\nDA:2,3\nDA:3,0\nend_of_record\n")
+
+	set := newCoverageSet(projectRoot)
+	require.True(t, set.load("unit.info"))
+	require.True(t, set.load("e2e.info"))
+
+	stats := set.stats(nil, nil)
+	require.NotNil(t, stats)
+	assert.Equal(t, 3, stats.TotalStatements, "lines in both reports count once")
+	assert.Equal(t, 2, stats.CoveredStatements, "a line covered by any report is covered")
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"src/auth/**", "src/auth/login.ts", true},
+		{"src/auth/**", "src/auth/deep/nested/login.ts", true},
+		{"src/auth/**", "src/authz/login.ts", false},
+		{"**/jacoco.xml", "jacoco.xml", true},
+		{"**/jacoco.xml", "services/api/target/jacoco.xml", true},
+		{"services/**/jacoco.xml", "services/jacoco.xml", true},
+		{"coverage_*.out", "coverage_github.out", true},
+		{"coverage_*.out", "sub/coverage_github.out", false},
+		{"**/com/example/auth/**", "com/example/auth/TokenService.java", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchGlob(tt.pattern, tt.name), "%s ~ %s", tt.pattern, tt.name)
+	}
+}
+
+func TestCompareCoverage(t *testing.T) {
+	previous := &TestingMetrics{OverallCoverage: 70, Packages: []PackageCoverageMetric{
+		{Name: "api", Coverage: 80},
+		{Name: "legacy", Coverage: 40},
+		{Name: "stable", Coverage: 90},
+		{Name: "web", Coverage: 60},
+	}}
+	current := &TestingMetrics{OverallCoverage: 72.5, Packages: []PackageCoverageMetric{
+		{Name: "api", Coverage: 75},
+		{Name: "new", Coverage: 100},
+		{Name: "stable", Coverage: 90.05},
+		{Name: "web", Coverage: 62},
+	}}
+
+	CompareCoverage(current, previous)
+	assert.InDelta(t, 2.5, current.Change, 0.001)
+	assert.Equal(t, "improving", current.Trend)
+
+	require.Len(t, current.PackageDiff, 4, "changes below the threshold are left out")
+	assert.Equal(t, PackageCoverageDiff{Name: "new", Current: 100, Change: 100, Status: "added"}, current.PackageDiff[0])
+	assert.Equal(t, PackageCoverageDiff{Name: "legacy", Previous: 40, Change: -40, Status: "removed"}, current.PackageDiff[1])
+	assert.Equal(t, "api", current.PackageDiff[2].Name)
+	assert.InDelta(t, -5, current.PackageDiff[2].Change, 0.001)
+	assert.Equal(t, "web", current.PackageDiff[3].Name)
+
+	declining := &TestingMetrics{OverallCoverage: 60}
+	CompareCoverage(declining, previous)
+	assert.Equal(t, "declining", declining.Trend)
+
+	// Nothing to compare against
+	first := &TestingMetrics{OverallCoverage: 50}
+	CompareCoverage(first, nil)
+	assert.Empty(t, first.Trend)
+}
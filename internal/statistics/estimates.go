@@ -0,0 +1,367 @@
+package statistics
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+const (
+	// EstimateMinSamples is how many finished features a calibration factor needs
+	EstimateMinSamples = 3
+	// EstimateTolerance is the percent a feature may miss its estimate by and still count as accurate
+	EstimateTolerance = 20.0
+	// estimateDefaultType is the type of features without a prefixed branch
+	estimateDefaultType = "feature"
+)
+
+const (
+	EstimateSourceDates    = "dates"
+	EstimateSourceDuration = "duration"
+)
+
+// durationPattern matches planned durations such as "3 days", "2 weeks", "1-2 sprints" or "1 month"
+var durationPattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)(?:\s*(?:-|–|to)\s*(\d+(?:\.\d+)?))?\s*(d|days?|w|wks?|weeks?|sprints?|m|mos?|months?)\b`)
+
+// EstimateMetrics compares the planned duration of features with how long they took
+type EstimateMetrics struct {
+	Finished    int                `json:"finished"`              // Finished features with an estimate and a measured duration
+	Factor      float64            `json:"factor"`                // Actual over estimated days; above 1 means work takes longer than planned
+	Bias        float64            `json:"bias"`                  // Mean percent actual durations ran over (+) or under (-) their estimates
+	Accuracy    float64            `json:"accuracy"`              // Percent of finished features within EstimateTolerance of their estimate
+	Calibrated  bool               `json:"calibrated"`            // Whether enough features finished to trust the factor
+	Features    []*FeatureEstimate `json:"features"`              // Features with an estimate, in state order
+	Phases      []EstimateGroup    `json:"phases,omitempty"`      // Calibration per phase
+	Types       []EstimateGroup    `json:"types,omitempty"`       // Calibration per feature type, the prefix of its branch
+	Suggestions []TargetSuggestion `json:"suggestions,omitempty"` // Adjusted target dates for unfinished features, latest first
+}
+
+// FeatureEstimate is the planned and actual duration of one feature, in days
+type FeatureEstimate struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Phase      string    `json:"phase"`
+	Type       string    `json:"type"`
+	Source     string    `json:"source"` // "dates" from the start and target date, "duration" from the planned duration
+	Estimated  float64   `json:"estimated"`
+	Actual     float64   `json:"actual,omitempty"`  // Finished features only
+	Elapsed    float64   `json:"elapsed,omitempty"` // Days since an unfinished feature started
+	Error      float64   `json:"error,omitempty"`   // Percent the actual duration missed the estimate by
+	StartedAt  time.Time `json:"startedAt,omitempty"`
+	TargetDate time.Time `json:"targetDate,omitempty"`
+	Done       bool      `json:"done"`
+}
+
+// EstimateGroup calibrates a phase or feature type
+type EstimateGroup struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Finished  int     `json:"finished"`
+	Estimated float64 `json:"estimated"`
+	Actual    float64 `json:"actual"`
+	Factor    float64 `json:"factor"`
+	Bias      float64 `json:"bias"`
+}
+
+// Calibrated reports whether enough features finished to trust the factor
+func (g EstimateGroup) Calibrated() bool {
+	return g.Finished >= EstimateMinSamples
+}
+
+// TargetSuggestion is an adjusted target date for an unfinished feature
+type TargetSuggestion struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Phase         string    `json:"phase"`
+	TargetDate    time.Time `json:"targetDate,omitempty"` // Planned target, if any
+	SuggestedDate time.Time `json:"suggestedDate"`
+	Factor        float64   `json:"factor"` // Calibration factor applied
+}
+
+// Slip is the days the suggested date falls after the planned target
+func (s TargetSuggestion) Slip() int {
+	if s.TargetDate.IsZero() {
+		return 0
+	}
+	return int(math.Round(s.SuggestedDate.Sub(s.TargetDate).Hours() / 24))
+}
+
+// LoadEstimateMetrics measures features through LoadFlowMetrics and compares them with their estimates
+func LoadEstimateMetrics(projectRoot string, state *models.State, githubData *models.GitHubData, now time.Time) *EstimateMetrics {
+	return CalculateEstimateMetrics(state, LoadFlowMetrics(projectRoot, state, githubData, now), now)
+}
+
+// CalculateEstimateMetrics compares the estimate of each feature with its cycle time,
+// or lead time when it never recorded a start. Estimates come from the start and
+// target dates, or else the planned duration. Unfinished features get a target date
+// scaled by the factor of their phase, or the whole team while the phase has too few
+// finished features.
+func CalculateEstimateMetrics(state *models.State, flow *FlowMetrics, now time.Time) *EstimateMetrics {
+	if state == nil {
+		return nil
+	}
+
+	flows := make(map[string]*FeatureFlow)
+	if flow != nil {
+		for _, f := range flow.Features {
+			flows[f.ID] = f
+		}
+	}
+
+	metrics := &EstimateMetrics{}
+	phaseNames := make(map[string]string, len(state.Phases))
+	for _, phase := range state.Phases {
+		phaseNames[phase.ID] = phase.Name
+	}
+
+	for i := range state.Features {
+		estimate := featureEstimate(&state.Features[i], flows[state.Features[i].ID], now)
+		if estimate != nil {
+			metrics.Features = append(metrics.Features, estimate)
+		}
+	}
+	if len(metrics.Features) == 0 {
+		return nil
+	}
+
+	var estimated, actual, errorSum float64
+	accurate := 0
+	for _, estimate := range metrics.Features {
+		if !estimate.Done {
+			continue
+		}
+		metrics.Finished++
+		estimated += estimate.Estimated
+		actual += estimate.Actual
+		errorSum += estimate.Error
+		if math.Abs(estimate.Error) <= EstimateTolerance {
+			accurate++
+		}
+	}
+	if metrics.Finished > 0 {
+		metrics.Factor = roundEstimate(actual / estimated)
+		metrics.Bias = roundEstimate(errorSum / float64(metrics.Finished))
+		metrics.Accuracy = roundEstimate(float64(accurate) * 100 / float64(metrics.Finished))
+	}
+	metrics.Calibrated = metrics.Finished >= EstimateMinSamples
+
+	metrics.Phases = groupEstimates(metrics.Features, func(e *FeatureEstimate) (string, string) {
+		name := phaseNames[e.Phase]
+		if name == "" {
+			name = e.Phase
+		}
+		return e.Phase, name
+	})
+	metrics.Types = groupEstimates(metrics.Features, func(e *FeatureEstimate) (string, string) { return e.Type, e.Type })
+	metrics.Suggestions = suggestTargets(metrics, now)
+	return metrics
+}
+
+// FactorFor returns the calibration factor for a phase, falling back on the whole
+// team, or 0 when neither has enough finished features
+func (m *EstimateMetrics) FactorFor(phaseID string) float64 {
+	if m == nil {
+		return 0
+	}
+	for _, group := range m.Phases {
+		if group.ID == phaseID && group.Calibrated() {
+			return group.Factor
+		}
+	}
+	if m.Calibrated {
+		return m.Factor
+	}
+	return 0
+}
+
+// Calibrate adds the calibrated completion date of the project and each phase: the
+// latest adjusted target date of their unfinished features
+func (f *Forecasts) Calibrate(estimates *EstimateMetrics) {
+	if f == nil || estimates == nil {
+		return
+	}
+
+	latest := make(map[string]time.Time)
+	var project time.Time
+	for _, suggestion := range estimates.Suggestions {
+		if suggestion.SuggestedDate.After(latest[suggestion.Phase]) {
+			latest[suggestion.Phase] = suggestion.SuggestedDate
+		}
+		if suggestion.SuggestedDate.After(project) {
+			project = suggestion.SuggestedDate
+		}
+	}
+
+	if f.Project != nil && estimates.Calibrated && !project.IsZero() {
+		f.Project.Calibration = estimates.Factor
+		f.Project.CalibratedDate = project
+	}
+	for _, forecast := range f.Phases {
+		if date := latest[forecast.ID]; !date.IsZero() {
+			forecast.Calibration = estimates.FactorFor(forecast.ID)
+			forecast.CalibratedDate = date
+		}
+	}
+}
+
+// featureEstimate returns the estimate of a feature, or nil when it has none
+func featureEstimate(feature *models.Feature, flow *FeatureFlow, now time.Time) *FeatureEstimate {
+	estimate := &FeatureEstimate{
+		ID:         feature.ID,
+		Name:       feature.Name,
+		Phase:      feature.Phase,
+		Type:       featureType(feature),
+		TargetDate: parseBurnDate(feature.TargetDate, now.Location()),
+	}
+
+	start := parseBurnDate(feature.StartDate, now.Location())
+	if !start.IsZero() && estimate.TargetDate.After(start) {
+		estimate.Source = EstimateSourceDates
+		estimate.Estimated = roundEstimate(flowDays(estimate.TargetDate.Sub(start)))
+	} else if days := ParsePlannedDuration(feature.Duration); days > 0 {
+		estimate.Source = EstimateSourceDuration
+		estimate.Estimated = days
+	} else {
+		return nil
+	}
+
+	estimate.StartedAt = start
+	if flow == nil {
+		return estimate
+	}
+	if !flow.StartedAt.IsZero() {
+		estimate.StartedAt = flow.StartedAt
+	}
+
+	switch {
+	case flow.Done() && !flow.StartedAt.IsZero() && flow.CycleTime > 0:
+		estimate.Actual = flow.CycleTime
+	case flow.Done() && flow.LeadTime > 0:
+		estimate.Actual = flow.LeadTime
+	}
+	if estimate.Actual > 0 {
+		estimate.Done = true
+		estimate.Error = roundEstimate((estimate.Actual - estimate.Estimated) * 100 / estimate.Estimated)
+	} else if !flow.Done() {
+		estimate.Elapsed = flow.Age
+	}
+	return estimate
+}
+
+// ParsePlannedDuration converts a planned duration such as "2 weeks" or "3-5 days" to
+// calendar days, taking the middle of ranges. It returns 0 for durations it cannot read.
+func ParsePlannedDuration(value string) float64 {
+	match := durationPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0
+	}
+
+	amount, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0
+	}
+	if match[2] != "" {
+		if upper, err := strconv.ParseFloat(match[2], 64); err == nil && upper > amount {
+			amount = (amount + upper) / 2
+		}
+	}
+
+	unit := strings.ToLower(match[3])
+	switch {
+	case strings.HasPrefix(unit, "w"):
+		amount *= 7
+	case strings.HasPrefix(unit, "s"):
+		amount *= 14
+	case strings.HasPrefix(unit, "m"):
+		amount *= 30
+	}
+	return amount
+}
+
+// featureType is the prefix of the feature branch, e.g. "fix" for fix/login
+func featureType(feature *models.Feature) string {
+	if prefix, _, ok := strings.Cut(feature.Branch, "/"); ok && prefix != "" {
+		return strings.ToLower(prefix)
+	}
+	return estimateDefaultType
+}
+
+// groupEstimates calibrates the finished features of each group, in order of first appearance
+func groupEstimates(estimates []*FeatureEstimate, key func(*FeatureEstimate) (string, string)) []EstimateGroup {
+	var groups []EstimateGroup
+	index := make(map[string]int)
+	errors := make(map[string]float64)
+	for _, estimate := range estimates {
+		if !estimate.Done {
+			continue
+		}
+		id, name := key(estimate)
+		i, ok := index[id]
+		if !ok {
+			i = len(groups)
+			index[id] = i
+			groups = append(groups, EstimateGroup{ID: id, Name: name})
+		}
+		groups[i].Finished++
+		groups[i].Estimated += estimate.Estimated
+		groups[i].Actual += estimate.Actual
+		errors[id] += estimate.Error
+	}
+
+	for i := range groups {
+		group := &groups[i]
+		group.Factor = roundEstimate(group.Actual / group.Estimated)
+		group.Bias = roundEstimate(errors[group.ID] / float64(group.Finished))
+		group.Estimated = roundEstimate(group.Estimated)
+		group.Actual = roundEstimate(group.Actual)
+	}
+	return groups
+}
+
+// suggestTargets scales the estimate of each unfinished feature from when it started,
+// or was planned to start. Suggestions are never before today.
+func suggestTargets(metrics *EstimateMetrics, now time.Time) []TargetSuggestion {
+	today := burnDay(now)
+	var suggestions []TargetSuggestion
+	for _, estimate := range metrics.Features {
+		if estimate.Done {
+			continue
+		}
+		factor := metrics.FactorFor(estimate.Phase)
+		if factor == 0 {
+			continue
+		}
+
+		start := estimate.StartedAt
+		if start.IsZero() {
+			start = today
+		}
+		suggested := burnDay(start).AddDate(0, 0, int(math.Ceil(estimate.Estimated*factor)))
+		if suggested.Before(today) {
+			suggested = today
+		}
+		suggestions = append(suggestions, TargetSuggestion{
+			ID:            estimate.ID,
+			Name:          estimate.Name,
+			Phase:         estimate.Phase,
+			TargetDate:    estimate.TargetDate,
+			SuggestedDate: suggested,
+			Factor:        factor,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].SuggestedDate.After(suggestions[j].SuggestedDate)
+	})
+	return suggestions
+}
+
+// roundEstimate rounds days, factors and percents to one decimal
+func roundEstimate(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,106 @@
+package statistics
+
+import (
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateEstimateMetrics(t *testing.T) {
+	state := estimateTestState()
+	metrics := CalculateEstimateMetrics(state, CalculateFlowMetrics(state, nil, nil, flowNow), flowNow)
+	require.NotNil(t, metrics)
+
+	require.Len(t, metrics.Features, 5, "features without an estimate are left out")
+	auth := metrics.Features[0]
+	assert.Equal(t, EstimateSourceDuration, auth.Source)
+	assert.Equal(t, 2.0, auth.Estimated)
+	assert.Equal(t, 3.0, auth.Actual)
+	assert.Equal(t, 50.0, auth.Error)
+	assert.True(t, auth.Done)
+
+	billing := metrics.Features[1]
+	assert.Equal(t, EstimateSourceDates, billing.Source)
+	assert.Equal(t, 4.0, billing.Estimated)
+	assert.Equal(t, "fix", billing.Type)
+	assert.Zero(t, billing.Error)
+
+	export := metrics.Features[3]
+	assert.False(t, export.Done)
+	assert.Equal(t, 4.0, export.Estimated, "ranges take their middle")
+	assert.Equal(t, 2.0, export.Elapsed)
+
+	assert.Equal(t, 3, metrics.Finished)
+	assert.True(t, metrics.Calibrated)
+	assert.Equal(t, 1.3, metrics.Factor, "17 actual days over 13 estimated")
+	assert.Equal(t, 31.0, metrics.Bias)
+	assert.Equal(t, 33.3, metrics.Accuracy)
+
+	require.Len(t, metrics.Phases, 1, "only phases with finished features are calibrated")
+	assert.Equal(t, EstimateGroup{ID: "01-phase", Name: "Foundation", Finished: 3, Estimated: 13, Actual: 17, Factor: 1.3, Bias: 31}, metrics.Phases[0])
+	require.Len(t, metrics.Types, 2)
+	assert.Equal(t, "feature", metrics.Types[0].ID)
+	assert.Equal(t, 1.4, metrics.Types[0].Factor)
+	assert.Equal(t, "fix", metrics.Types[1].ID)
+	assert.Equal(t, 1.0, metrics.Types[1].Factor)
+
+	require.Len(t, metrics.Suggestions, 2)
+	assert.Equal(t, TargetSuggestion{ID: "04", Name: "Export", Phase: "02-phase", TargetDate: estimateDay(21), SuggestedDate: estimateDay(24), Factor: 1.3},
+		metrics.Suggestions[0], "phases without finished features use the team factor from when work started")
+	assert.Equal(t, 3, metrics.Suggestions[0].Slip())
+	assert.Equal(t, "Polish", metrics.Suggestions[1].Name)
+	assert.Equal(t, estimateDay(23), metrics.Suggestions[1].SuggestedDate, "features not started are scaled from today")
+	assert.Zero(t, metrics.Suggestions[1].Slip())
+}
+
+func TestCalculateEstimateMetrics_Uncalibrated(t *testing.T) {
+	state := estimateTestState()
+	state.Features = state.Features[1:]
+	metrics := CalculateEstimateMetrics(state, CalculateFlowMetrics(state, nil, nil, flowNow), flowNow)
+	require.NotNil(t, metrics)
+	assert.Equal(t, 2, metrics.Finished)
+	assert.False(t, metrics.Calibrated)
+	assert.Empty(t, metrics.Suggestions, "target dates are only adjusted with enough finished features")
+	assert.Zero(t, metrics.FactorFor("01-phase"))
+
+	assert.Nil(t, CalculateEstimateMetrics(&models.State{Features: []models.Feature{{ID: "01", Duration: "TBD"}}}, nil, flowNow))
+	assert.Nil(t, CalculateEstimateMetrics(nil, nil, flowNow))
+}
+
+func TestParsePlannedDuration(t *testing.T) {
+	tests := map[string]float64{
+		"3 days":         3,
+		"2 weeks":        14,
+		"1.5 weeks":      10.5,
+		"3-5 days":       4,
+		"1 to 2 sprints": 21,
+		"1 month":        30,
+		"~5d":            5,
+		"TBD":            0,
+		"":               0,
+	}
+	for value, want := range tests {
+		assert.Equal(t, want, ParsePlannedDuration(value), value)
+	}
+}
+
+func TestForecasts_Calibrate(t *testing.T) {
+	state := estimateTestState()
+	estimates := CalculateEstimateMetrics(state, CalculateFlowMetrics(state, nil, nil, flowNow), flowNow)
+	forecasts := &Forecasts{
+		Project: &Forecast{Name: "Project"},
+		Phases:  []*Forecast{{ID: "01-phase", Name: "Foundation"}, {ID: "02-phase", Name: "Reports"}, {ID: "03-phase", Name: "Launch"}},
+	}
+
+	forecasts.Calibrate(estimates)
+	assert.Equal(t, estimateDay(24), forecasts.Project.CalibratedDate)
+	assert.Equal(t, 1.3, forecasts.Project.Calibration)
+	assert.Equal(t, estimateDay(23), forecasts.Phases[0].CalibratedDate)
+	assert.Equal(t, estimateDay(24), forecasts.Phases[1].CalibratedDate)
+	assert.True(t, forecasts.Phases[2].CalibratedDate.IsZero(), "phases without estimated work are left alone")
+
+	var none *Forecasts
+	assert.NotPanics(t, func() { none.Calibrate(estimates) })
+}
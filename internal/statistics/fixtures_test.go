@@ -0,0 +1,63 @@
+package statistics
+
+import (
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+)
+
+// flowNow is "today" for the fixtures dated in March 2026
+var flowNow = time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+
+// flowTime formats a March 2026 timestamp the way status history stores it
+func flowTime(day, hour int) string {
+	return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC).Format(time.RFC3339)
+}
+
+// estimateDay is midnight on a day in March 2026
+func estimateDay(day int) time.Time {
+	return time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC)
+}
+
+// burnTestState has two dated phases, one with half of its four tasks done
+func burnTestState() *models.State {
+	return &models.State{
+		Phases: []models.Phase{
+			{ID: "01-phase", Name: "Foundation", StartDate: "2026-03-01", TargetDate: "2026-03-11"},
+			{ID: "02-phase", Name: "Polish", StartDate: "2026-03-08", TargetDate: "2026-03-20"},
+		},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Auth", TaskPhases: []models.TaskPhase{helpers.TaskPhase("Setup", true, true, false, false)}},
+			{ID: "02", Phase: "02-phase", Name: "Theme", Status: "todo"},
+		},
+	}
+}
+
+// estimateTestState has three finished features that overran by 1.3× overall,
+// one feature in progress, one not started and one without an estimate
+func estimateTestState() *models.State {
+	return &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation"}, {ID: "02-phase", Name: "Reports"}},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Auth", Status: "complete", Duration: "2 days", StatusHistory: []models.StatusChange{
+				{Status: "in-progress", At: flowTime(1, 12)},
+				{Status: "complete", At: flowTime(4, 12)},
+			}},
+			{ID: "02", Phase: "01-phase", Name: "Billing", Status: "complete", Branch: "fix/billing", StartDate: "2026-03-02", TargetDate: "2026-03-06",
+				StatusHistory: []models.StatusChange{
+					{Status: "in-progress", At: flowTime(2, 12)},
+					{Status: "complete", At: flowTime(6, 12)},
+				}},
+			{ID: "03", Phase: "01-phase", Name: "Search", Status: "complete", Duration: "1 week", StatusHistory: []models.StatusChange{
+				{Status: "in-progress", At: flowTime(5, 12)},
+				{Status: "complete", At: flowTime(15, 12)},
+			}},
+			{ID: "04", Phase: "02-phase", Name: "Export", Status: "in-progress", Duration: "3-5 days", TargetDate: "2026-03-21", StatusHistory: []models.StatusChange{
+				{Status: "in-progress", At: flowTime(18, 12)},
+			}},
+			{ID: "05", Phase: "02-phase", Name: "Docs", Status: "todo", Duration: "TBD"},
+			{ID: "06", Phase: "01-phase", Name: "Polish", Status: "todo", Duration: "2 days"},
+		},
+	}
+}
@@ -0,0 +1,366 @@
+package statistics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/dashboard"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// flowStallDays flags unfinished features as stalled when no feature has
+// finished yet to compare them with
+const flowStallDays = 14.0
+
+// FlowMetrics holds the lead, cycle and blocked times of each feature and their spread
+type FlowMetrics struct {
+	Features  []*FeatureFlow `json:"features"`
+	LeadTime  *FlowSummary   `json:"leadTime,omitempty"`  // Created to done, finished features only
+	CycleTime *FlowSummary   `json:"cycleTime,omitempty"` // Started to done, finished features only
+	Outliers  []FlowOutlier  `json:"outliers,omitempty"`  // Longest first
+}
+
+// FeatureFlow is the timing of one feature. Durations are in days.
+type FeatureFlow struct {
+	ID           string             `json:"id"`
+	Name         string             `json:"name"`
+	Status       string             `json:"status"`
+	CreatedAt    time.Time          `json:"createdAt,omitempty"`
+	StartedAt    time.Time          `json:"startedAt,omitempty"`
+	DoneAt       time.Time          `json:"doneAt,omitempty"`
+	LeadTime     float64            `json:"leadTime,omitempty"`
+	CycleTime    float64            `json:"cycleTime,omitempty"`
+	Age          float64            `json:"age,omitempty"` // Days since an unfinished feature started
+	BlockedTime  float64            `json:"blockedTime"`
+	TimeInStatus map[string]float64 `json:"timeInStatus,omitempty"`
+}
+
+// Done reports whether the feature has finished
+func (f *FeatureFlow) Done() bool {
+	return !f.DoneAt.IsZero()
+}
+
+// FlowSummary describes the spread of one duration across features, in days
+type FlowSummary struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P85   float64 `json:"p85"`
+	P95   float64 `json:"p95"`
+	Max   float64 `json:"max"`
+}
+
+// FlowOutlier is a feature taking far longer than the others
+type FlowOutlier struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Days   float64 `json:"days"`
+	Reason string  `json:"reason"`
+}
+
+// LoadFlowMetrics derives flow metrics for state from GitHub data and the task
+// completion times in progress.json files
+func LoadFlowMetrics(projectRoot string, state *models.State, githubData *models.GitHubData, now time.Time) *FlowMetrics {
+	progress, err := dashboard.NewProgressParser(projectRoot).ReadProgressFiles()
+	if err != nil {
+		progress = nil
+	}
+	return CalculateFlowMetrics(state, githubData, progress, now)
+}
+
+// CalculateFlowMetrics times each feature from its status history, falling back on
+// the earliest evidence of work: branch creation, its first commit, the PR opening
+// and task completions. A merged PR or the last completed task marks it done when
+// the history does not.
+func CalculateFlowMetrics(state *models.State, githubData *models.GitHubData, progress map[string]*dashboard.ProgressData, now time.Time) *FlowMetrics {
+	if state == nil || len(state.Features) == 0 {
+		return nil
+	}
+	if githubData == nil {
+		githubData = &models.GitHubData{}
+	}
+
+	completions := taskCompletionTimes(state, progress)
+	metrics := &FlowMetrics{}
+	var leadTimes, cycleTimes []float64
+	for i := range state.Features {
+		flow := featureFlow(&state.Features[i], githubData, completions[state.Features[i].ID], now)
+		metrics.Features = append(metrics.Features, flow)
+		if flow.Done() {
+			if !flow.CreatedAt.IsZero() {
+				leadTimes = append(leadTimes, flow.LeadTime)
+			}
+			if !flow.StartedAt.IsZero() {
+				cycleTimes = append(cycleTimes, flow.CycleTime)
+			}
+		}
+	}
+
+	metrics.LeadTime = summarizeFlow(leadTimes)
+	metrics.CycleTime = summarizeFlow(cycleTimes)
+	metrics.Outliers = flowOutliers(state, metrics, cycleTimes)
+	return metrics
+}
+
+// featureFlow derives the timing of one feature
+func featureFlow(feature *models.Feature, githubData *models.GitHubData, completions []time.Time, now time.Time) *FeatureFlow {
+	flow := &FeatureFlow{ID: feature.ID, Name: feature.Name, Status: flowStatus(feature.Status)}
+	history := statusHistory(feature)
+
+	// Evidence of work, in case the history starts late or is missing
+	var work []time.Time
+	pr := featurePR(feature, githubData)
+	if pr != nil {
+		work = append(work, parseFlowTime(pr.CreatedAt))
+	}
+	for _, branch := range githubData.Branches {
+		if (feature.Branch != "" && branch.Name == feature.Branch) || (branch.Feature != "" && branch.Feature == feature.ID) {
+			work = append(work, parseFlowTime(branch.CreatedAt))
+		}
+	}
+	for _, commit := range githubData.Commits {
+		if feature.Branch != "" && commit.Branch == feature.Branch {
+			work = append(work, parseFlowTime(commit.Date))
+		}
+	}
+	work = append(work, completions...)
+	firstWork := earliestTime(work...)
+
+	for _, change := range history {
+		if change.status == "in-progress" {
+			flow.StartedAt = change.at
+			break
+		}
+	}
+	flow.StartedAt = earliestTime(flow.StartedAt, firstWork)
+
+	merged := pr != nil && pr.MergedAt != ""
+	if feature.Status == "complete" || merged {
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i].status == "complete" {
+				flow.DoneAt = history[i].at
+				break
+			}
+		}
+		if flow.DoneAt.IsZero() && merged {
+			flow.DoneAt = parseFlowTime(pr.MergedAt)
+		}
+		if flow.DoneAt.IsZero() {
+			flow.DoneAt = latestTime(completions...)
+		}
+	}
+
+	created := parseFlowTime(feature.CreatedAt)
+	if len(history) > 0 {
+		created = earliestTime(created, history[0].at)
+	}
+	flow.CreatedAt = earliestTime(created, flow.StartedAt)
+
+	// Data recorded out of order never makes a feature finish before it began
+	if flow.Done() {
+		if flow.StartedAt.After(flow.DoneAt) {
+			flow.StartedAt = flow.DoneAt
+		}
+		if flow.CreatedAt.After(flow.DoneAt) {
+			flow.CreatedAt = flow.DoneAt
+		}
+		if !flow.CreatedAt.IsZero() {
+			flow.LeadTime = flowDays(flow.DoneAt.Sub(flow.CreatedAt))
+		}
+		if !flow.StartedAt.IsZero() {
+			flow.CycleTime = flowDays(flow.DoneAt.Sub(flow.StartedAt))
+		}
+	} else if !flow.StartedAt.IsZero() {
+		flow.Age = flowDays(now.Sub(flow.StartedAt))
+	}
+
+	flow.TimeInStatus = timeInStatus(flow, history, now)
+	flow.BlockedTime = flow.TimeInStatus[models.FeatureStatusBlocked]
+
+	// Flags raised before status history was recorded still count as blocked
+	if len(feature.Flags) > 0 && (len(history) == 0 || history[len(history)-1].status != models.FeatureStatusBlocked) {
+		var since []time.Time
+		for _, flag := range feature.Flags {
+			since = append(since, parseFlowTime(flag.Since))
+		}
+		if first := earliestTime(since...); !first.IsZero() {
+			flow.BlockedTime += flowDays(now.Sub(first))
+		}
+	}
+	return flow
+}
+
+type flowChange struct {
+	status string
+	at     time.Time
+}
+
+// statusHistory returns the parseable status changes of a feature, oldest first
+func statusHistory(feature *models.Feature) []flowChange {
+	var history []flowChange
+	for _, change := range feature.StatusHistory {
+		if at := parseFlowTime(change.At); !at.IsZero() {
+			history = append(history, flowChange{flowStatus(change.Status), at})
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].at.Before(history[j].at) })
+	return history
+}
+
+// timeInStatus sums the days spent in each status up to completion or now. A feature
+// is todo until its history starts; without a history it is in progress once started.
+func timeInStatus(flow *FeatureFlow, history []flowChange, now time.Time) map[string]float64 {
+	if len(history) == 0 && !flow.StartedAt.IsZero() {
+		history = []flowChange{{"in-progress", flow.StartedAt}}
+	}
+	if !flow.CreatedAt.IsZero() && (len(history) == 0 || flow.CreatedAt.Before(history[0].at)) {
+		history = append([]flowChange{{"todo", flow.CreatedAt}}, history...)
+	}
+
+	end := now
+	if flow.Done() {
+		end = flow.DoneAt
+	}
+
+	spent := make(map[string]float64)
+	for i, change := range history {
+		if change.status == "complete" || !change.at.Before(end) {
+			continue
+		}
+		until := end
+		if i+1 < len(history) && history[i+1].at.Before(end) {
+			until = history[i+1].at
+		}
+		if until.After(change.at) {
+			spent[change.status] += flowDays(until.Sub(change.at))
+		}
+	}
+	if len(spent) == 0 {
+		return nil
+	}
+	return spent
+}
+
+// flowOutliers lists unfinished features older than most finished ones took, blocked
+// features, and finished features far slower than the rest
+func flowOutliers(state *models.State, metrics *FlowMetrics, cycleTimes []float64) []FlowOutlier {
+	stallAfter, stallReason := flowStallDays, fmt.Sprintf("in progress for over %.0f days", flowStallDays)
+	if metrics.CycleTime != nil {
+		stallAfter = metrics.CycleTime.P85
+		stallReason = fmt.Sprintf("in progress longer than the P85 cycle time (%.1f days)", stallAfter)
+	}
+
+	// Finished features beyond the upper Tukey fence; needs a few to compare
+	fence := math.Inf(1)
+	if len(cycleTimes) >= 4 {
+		sorted := append([]float64{}, cycleTimes...)
+		sort.Float64s(sorted)
+		q1, q3 := percentileFloat(sorted, 25), percentileFloat(sorted, 75)
+		fence = q3 + 1.5*(q3-q1)
+	}
+
+	var outliers []FlowOutlier
+	for i, flow := range metrics.Features {
+		switch {
+		case len(state.Features[i].Flags) > 0 && !flow.Done():
+			outliers = append(outliers, FlowOutlier{flow.ID, flow.Name, flow.BlockedTime,
+				fmt.Sprintf("blocked: %s", state.Features[i].Flags[0].Reason)})
+		case !flow.Done() && !flow.StartedAt.IsZero() && flow.Age > stallAfter:
+			outliers = append(outliers, FlowOutlier{flow.ID, flow.Name, flow.Age, stallReason})
+		case flow.Done() && !flow.StartedAt.IsZero() && flow.CycleTime > fence:
+			outliers = append(outliers, FlowOutlier{flow.ID, flow.Name, flow.CycleTime,
+				fmt.Sprintf("cycle time far above the P50 of %.1f days", metrics.CycleTime.P50)})
+		}
+	}
+	sort.SliceStable(outliers, func(i, j int) bool { return outliers[i].Days > outliers[j].Days })
+	return outliers
+}
+
+// summarizeFlow returns the mean and percentiles of durations, or nil without any
+func summarizeFlow(days []float64) *FlowSummary {
+	if len(days) == 0 {
+		return nil
+	}
+	sorted := append([]float64{}, days...)
+	sort.Float64s(sorted)
+
+	return &FlowSummary{
+		Count: len(sorted),
+		Mean:  mean(sorted),
+		P50:   percentileFloat(sorted, 50),
+		P85:   percentileFloat(sorted, 85),
+		P95:   percentileFloat(sorted, 95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentileFloat returns the nearest-rank p-th percentile of sorted values
+func percentileFloat(sorted []float64, p int) float64 {
+	index := int(math.Ceil(float64(p)/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+// featurePR returns the feature's pull request, preferring the synced copy, which
+// carries timestamps older state files lack
+func featurePR(feature *models.Feature, githubData *models.GitHubData) *models.PullRequest {
+	for i := range githubData.PRs {
+		pr := &githubData.PRs[i]
+		if feature.PR != nil && pr.Number == feature.PR.Number && pr.CreatedAt != "" {
+			return pr
+		}
+		if feature.PR == nil && feature.Branch != "" && pr.Branch == feature.Branch {
+			return pr
+		}
+	}
+	return feature.PR
+}
+
+// flowStatus treats an unset status as todo
+func flowStatus(status string) string {
+	if status == "" {
+		return "todo"
+	}
+	return status
+}
+
+func flowDays(d time.Duration) float64 {
+	return d.Hours() / 24
+}
+
+// parseFlowTime parses an RFC3339 timestamp or a date, returning the zero time otherwise
+func parseFlowTime(value string) time.Time {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// earliestTime returns the earliest non-zero time, or the zero time
+func earliestTime(times ...time.Time) time.Time {
+	var first time.Time
+	for _, t := range times {
+		if !t.IsZero() && (first.IsZero() || t.Before(first)) {
+			first = t
+		}
+	}
+	return first
+}
+
+// latestTime returns the latest time, or the zero time
+func latestTime(times ...time.Time) time.Time {
+	var last time.Time
+	for _, t := range times {
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
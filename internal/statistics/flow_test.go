@@ -0,0 +1,135 @@
+package statistics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/dashboard"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFlowMetrics_StatusHistory(t *testing.T) {
+	state := &models.State{Features: []models.Feature{{
+		ID:        "01",
+		Name:      "Auth",
+		Status:    "complete",
+		CreatedAt: flowTime(1, 12),
+		StatusHistory: []models.StatusChange{
+			{Status: "in-progress", At: flowTime(3, 12)},
+			{Status: "blocked", At: flowTime(5, 12)},
+			{Status: "in-progress", At: flowTime(7, 12)},
+			{Status: "complete", At: flowTime(9, 12)},
+		},
+	}}}
+
+	metrics := CalculateFlowMetrics(state, nil, nil, flowNow)
+	require.NotNil(t, metrics)
+	require.Len(t, metrics.Features, 1)
+
+	flow := metrics.Features[0]
+	assert.True(t, flow.Done())
+	assert.InDelta(t, 8.0, flow.LeadTime, 0.001)
+	assert.InDelta(t, 6.0, flow.CycleTime, 0.001)
+	assert.Zero(t, flow.Age)
+	assert.InDelta(t, 2.0, flow.BlockedTime, 0.001)
+	assert.Equal(t, map[string]float64{"todo": 2, "in-progress": 4, "blocked": 2}, flow.TimeInStatus,
+		"todo until the history starts, and nothing counted after completion")
+
+	require.NotNil(t, metrics.CycleTime)
+	assert.Equal(t, 1, metrics.CycleTime.Count)
+	assert.InDelta(t, 6.0, metrics.CycleTime.P95, 0.001)
+}
+
+func TestCalculateFlowMetrics_WorkEvidence(t *testing.T) {
+	state := &models.State{Features: []models.Feature{
+		{ID: "01", Name: "Auth", Status: "complete", Branch: "feature/auth", PR: &models.PullRequest{Number: 4}},
+		{ID: "02", Name: "Profile", Status: "in-progress"},
+	}}
+	githubData := &models.GitHubData{
+		Branches: []models.Branch{{Name: "feature/auth", CreatedAt: flowTime(2, 12)}},
+		Commits:  []models.Commit{{Branch: "feature/auth", Date: flowTime(4, 12)}},
+		PRs:      []models.PullRequest{{Number: 4, Branch: "feature/auth", CreatedAt: flowTime(5, 12), MergedAt: flowTime(8, 12)}},
+	}
+	progress := map[string]*dashboard.ProgressData{
+		"02": {FeatureID: "02", Tasks: []dashboard.TaskProgress{
+			{Name: "Form", Completed: true, CompletedAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)},
+		}},
+	}
+
+	metrics := CalculateFlowMetrics(state, githubData, progress, flowNow)
+	require.NotNil(t, metrics)
+
+	// Without history, work starts with the branch and ends with the merge
+	auth := metrics.Features[0]
+	assert.Equal(t, flowTime(2, 12), auth.StartedAt.Format(time.RFC3339))
+	assert.Equal(t, flowTime(8, 12), auth.DoneAt.Format(time.RFC3339))
+	assert.InDelta(t, 6.0, auth.CycleTime, 0.001)
+	assert.Equal(t, map[string]float64{"in-progress": 6}, auth.TimeInStatus)
+
+	// An unfinished feature is aged from its first completed task
+	profile := metrics.Features[1]
+	assert.False(t, profile.Done())
+	assert.InDelta(t, 10.0, profile.Age, 0.001)
+
+	require.NotNil(t, metrics.LeadTime)
+	assert.Equal(t, 1, metrics.LeadTime.Count)
+}
+
+func TestCalculateFlowMetrics_Outliers(t *testing.T) {
+	var features []models.Feature
+	// Seven finished features took two days each, one took twenty
+	for i, days := range []int{2, 2, 2, 2, 2, 2, 2, 20} {
+		features = append(features, models.Feature{
+			ID:     fmt.Sprintf("%02d", i+1),
+			Name:   fmt.Sprintf("Done %d", i+1),
+			Status: "complete",
+			StatusHistory: []models.StatusChange{
+				{Status: "in-progress", At: flowTime(1, 0)},
+				{Status: "complete", At: flowTime(1+days, 0)},
+			},
+		})
+	}
+	features = append(features,
+		models.Feature{ID: "09", Name: "Stalled", Status: "in-progress",
+			StatusHistory: []models.StatusChange{{Status: "in-progress", At: flowTime(10, 12)}}},
+		models.Feature{ID: "10", Name: "Fresh", Status: "in-progress",
+			StatusHistory: []models.StatusChange{{Status: "in-progress", At: flowTime(19, 12)}}},
+		models.Feature{ID: "11", Name: "Flagged", Status: "in-progress",
+			Flags: []models.FeatureFlag{{Source: "check:build", Reason: "Check 'build' failure", Since: flowTime(16, 12)}}},
+	)
+
+	metrics := CalculateFlowMetrics(&models.State{Features: features}, nil, nil, flowNow)
+	require.NotNil(t, metrics)
+	assert.InDelta(t, 2.0, metrics.CycleTime.P50, 0.001)
+	assert.InDelta(t, 20.0, metrics.CycleTime.P95, 0.001)
+
+	require.Len(t, metrics.Outliers, 3)
+	assert.Equal(t, "Done 8", metrics.Outliers[0].Name)
+	assert.Contains(t, metrics.Outliers[0].Reason, "far above the P50 of 2.0 days")
+	assert.Equal(t, "Stalled", metrics.Outliers[1].Name)
+	assert.InDelta(t, 10.0, metrics.Outliers[1].Days, 0.001)
+	assert.Contains(t, metrics.Outliers[1].Reason, "longer than the P85 cycle time")
+	assert.Equal(t, "Flagged", metrics.Outliers[2].Name)
+	assert.InDelta(t, 4.0, metrics.Outliers[2].Days, 0.001, "flags set before history count as blocked")
+	assert.Contains(t, metrics.Outliers[2].Reason, "blocked: Check 'build' failure")
+}
+
+func TestCalculateFlowMetrics_NoFeatures(t *testing.T) {
+	assert.Nil(t, CalculateFlowMetrics(&models.State{}, nil, nil, flowNow))
+	assert.Nil(t, CalculateFlowMetrics(nil, nil, nil, flowNow))
+}
+
+func TestSummarizeFlow(t *testing.T) {
+	summary := summarizeFlow([]float64{5, 1, 3, 2, 4})
+	require.NotNil(t, summary)
+	assert.Equal(t, 5, summary.Count)
+	assert.InDelta(t, 3.0, summary.Mean, 0.001)
+	assert.Equal(t, 3.0, summary.P50)
+	assert.Equal(t, 5.0, summary.P85)
+	assert.Equal(t, 5.0, summary.Max)
+
+	assert.Nil(t, summarizeFlow(nil))
+}
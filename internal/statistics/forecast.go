@@ -0,0 +1,245 @@
+package statistics
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/dashboard"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// Forecast defaults, used when the stats.forecast config leaves a setting unset
+const (
+	DefaultForecastConfidence  = 85
+	DefaultForecastSimulations = 1000
+	DefaultForecastWindowDays  = 30
+)
+
+const (
+	// maxForecastDays caps a simulated run that never finishes the remaining work
+	maxForecastDays = 3650
+	// forecastSeed seeds the simulations so unchanged history gives the same forecast
+	forecastSeed = 1
+)
+
+// Forecasts holds the Monte Carlo completion forecasts of the project and each phase
+type Forecasts struct {
+	Project     *Forecast   `json:"project"`
+	Phases      []*Forecast `json:"phases,omitempty"`
+	Confidence  int         `json:"confidence"`  // Percent chance a target date needs to not be at risk
+	Simulations int         `json:"simulations"` // Simulated runs per forecast
+	WindowDays  int         `json:"windowDays"`  // Days of recent throughput sampled
+}
+
+// Forecast is the simulated completion of one phase or the whole project
+type Forecast struct {
+	ID             string    `json:"id,omitempty"` // Phase ID; empty for the project
+	Name           string    `json:"name"`
+	Unit           string    `json:"unit"`
+	Remaining      int       `json:"remaining"`
+	TargetDate     time.Time `json:"targetDate,omitempty"`
+	P50            time.Time `json:"p50,omitempty"`
+	P85            time.Time `json:"p85,omitempty"`
+	P95            time.Time `json:"p95,omitempty"`
+	OnTargetChance float64   `json:"onTargetChance"` // Percent of simulations finishing by the target date
+	AtRisk         bool      `json:"atRisk"`
+	Note           string    `json:"note,omitempty"`           // Why there are no dates, e.g. too little history
+	Calibration    float64   `json:"calibration,omitempty"`    // Estimate calibration factor applied to the remaining features
+	CalibratedDate time.Time `json:"calibratedDate,omitempty"` // When the remaining features finish if they overrun like finished ones
+}
+
+// HasDates reports whether the simulations produced completion dates
+func (f *Forecast) HasDates() bool {
+	return !f.P50.IsZero()
+}
+
+// Forecaster simulates remaining work against sampled daily throughput
+type Forecaster struct {
+	confidence  int
+	simulations int
+	windowDays  int
+}
+
+// NewForecaster creates a forecaster, filling unset settings with defaults
+func NewForecaster(cfg models.ForecastConfig) *Forecaster {
+	f := &Forecaster{
+		confidence:  cfg.Confidence,
+		simulations: cfg.Simulations,
+		windowDays:  cfg.WindowDays,
+	}
+	if f.confidence <= 0 || f.confidence > 100 {
+		f.confidence = DefaultForecastConfidence
+	}
+	if f.simulations <= 0 {
+		f.simulations = DefaultForecastSimulations
+	}
+	if f.windowDays <= 0 {
+		f.windowDays = DefaultForecastWindowDays
+	}
+	return f
+}
+
+// LoadForecasts forecasts state from the recorded statistics history and the task
+// completion times in progress.json files
+func LoadForecasts(projectRoot string, state *models.State, cfg models.ForecastConfig, now time.Time) *Forecasts {
+	history, progress := loadBurnHistory(projectRoot)
+	return NewForecaster(cfg).Forecast(state, history, progress, now)
+}
+
+// Forecast samples the daily throughput of the last window of days and simulates
+// finishing the remaining work of the project and each phase. Phases draw on the
+// whole project's throughput, as if the team worked on that phase alone.
+func (f *Forecaster) Forecast(state *models.State, history []*HistoricalData, progress map[string]*dashboard.ProgressData, now time.Time) *Forecasts {
+	charts := CalculateBurnCharts(state, history, progress, now)
+	if charts == nil {
+		return nil
+	}
+
+	samples := map[string][]int{
+		BurnUnitFeatures: f.dailyThroughput(featureBurnSeries(state, history, now)),
+	}
+	if charts.Project.Unit == BurnUnitTasks {
+		samples[BurnUnitTasks] = f.dailyThroughput(charts.Project)
+	}
+
+	// One generator per run keeps each forecast reproducible
+	rng := rand.New(rand.NewSource(forecastSeed))
+	forecasts := &Forecasts{
+		Project:     f.simulate(rng, charts.Project, samples[charts.Project.Unit], now),
+		Confidence:  f.confidence,
+		Simulations: f.simulations,
+		WindowDays:  f.windowDays,
+	}
+	for _, series := range charts.Phases {
+		forecasts.Phases = append(forecasts.Phases, f.simulate(rng, series, samples[series.Unit], now))
+	}
+	return forecasts
+}
+
+// simulate runs the simulations for one series and summarizes them
+func (f *Forecaster) simulate(rng *rand.Rand, series *BurnSeries, samples []int, now time.Time) *Forecast {
+	latest := series.Latest()
+	forecast := &Forecast{
+		ID:        series.ID,
+		Name:      series.Name,
+		Unit:      series.Unit,
+		Remaining: latest.Remaining,
+	}
+	if series.HasTarget() {
+		forecast.TargetDate = series.TargetDate
+	}
+
+	today := burnDay(now)
+	if forecast.Remaining == 0 {
+		forecast.Note = "complete"
+		forecast.OnTargetChance = 100
+		return forecast
+	}
+	if !hasThroughput(samples) {
+		forecast.Note = fmt.Sprintf("no %s completed in the last %d days", series.Unit, f.windowDays)
+		if len(samples) == 0 {
+			forecast.Note = "not enough history yet"
+		}
+		return forecast
+	}
+
+	days := make([]int, f.simulations)
+	onTarget := 0
+	for i := range days {
+		done, day := 0, 0
+		for done < forecast.Remaining && day < maxForecastDays {
+			done += samples[rng.Intn(len(samples))]
+			day++
+		}
+		days[i] = day
+		if !forecast.TargetDate.IsZero() && !today.AddDate(0, 0, day).After(forecast.TargetDate) {
+			onTarget++
+		}
+	}
+	sort.Ints(days)
+
+	forecast.P50 = today.AddDate(0, 0, percentileDays(days, 50))
+	forecast.P85 = today.AddDate(0, 0, percentileDays(days, 85))
+	forecast.P95 = today.AddDate(0, 0, percentileDays(days, 95))
+	if !forecast.TargetDate.IsZero() {
+		forecast.OnTargetChance = float64(onTarget) * 100 / float64(len(days))
+		forecast.AtRisk = forecast.OnTargetChance < float64(f.confidence)
+	}
+	return forecast
+}
+
+// dailyThroughput returns the units completed on each of the last window of days.
+// Work between points further apart than a day is spread evenly over the gap.
+func (f *Forecaster) dailyThroughput(series *BurnSeries) []int {
+	if series == nil {
+		return nil
+	}
+
+	var samples []int
+	for i := 1; i < len(series.Points); i++ {
+		prev, curr := series.Points[i-1], series.Points[i]
+		days := int(math.Round(curr.Date.Sub(prev.Date).Hours() / 24))
+		if days < 1 {
+			days = 1
+		}
+		delta := curr.Completed - prev.Completed
+		if delta < 0 {
+			delta = 0
+		}
+		for d := 0; d < days; d++ {
+			share := delta / days
+			if d < delta%days {
+				share++
+			}
+			samples = append(samples, share)
+		}
+	}
+
+	if len(samples) > f.windowDays {
+		samples = samples[len(samples)-f.windowDays:]
+	}
+	return samples
+}
+
+// featureBurnSeries counts the whole project in features, so feature-counted phases
+// can be forecast from feature throughput
+func featureBurnSeries(state *models.State, history []*HistoricalData, now time.Time) *BurnSeries {
+	snapshots := append([]*HistoricalData{}, history...)
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Timestamp.Before(snapshots[j].Timestamp) })
+
+	var featureSnapshots []burnSnapshot
+	for _, entry := range snapshots {
+		if entry.Data != nil && entry.Data.State != nil && entry.Data.State.TotalFeatures > 0 {
+			featureSnapshots = append(featureSnapshots, burnSnapshot{entry.Timestamp, entry.Data.State.TotalFeatures, entry.Data.State.CompletedFeatures})
+		}
+	}
+
+	completed := 0
+	for _, feature := range state.Features {
+		if feature.Status == "complete" {
+			completed++
+		}
+	}
+	return buildBurnSeries(&BurnSeries{Name: "Project", Unit: BurnUnitFeatures}, len(state.Features), completed, featureSnapshots, nil, now)
+}
+
+// percentileDays returns the p-th percentile of sorted simulated durations
+func percentileDays(sorted []int, p int) int {
+	index := int(math.Ceil(float64(p)/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+func hasThroughput(samples []int) bool {
+	for _, s := range samples {
+		if s > 0 {
+			return true
+		}
+	}
+	return false
+}
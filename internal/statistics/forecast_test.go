@@ -0,0 +1,163 @@
+package statistics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/dashboard"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var forecastNow = time.Date(2026, 3, 11, 15, 0, 0, 0, time.UTC)
+
+// forecastTestProject has 30 tasks, 20 of them completed two a day over the last ten days
+func forecastTestProject(targetDate string) (*models.State, map[string]*dashboard.ProgressData) {
+	var tasks []models.Task
+	var completions []dashboard.TaskProgress
+	for i := 0; i < 30; i++ {
+		task := models.Task{Name: fmt.Sprintf("Task %d", i), Completed: i < 20}
+		tasks = append(tasks, task)
+		if task.Completed {
+			completions = append(completions, dashboard.TaskProgress{
+				Name:        task.Name,
+				Completed:   true,
+				CompletedAt: time.Date(2026, 3, 2+i/2, 10, 0, 0, 0, time.UTC),
+			})
+		}
+	}
+
+	state := &models.State{
+		Phases: []models.Phase{
+			{ID: "01-phase", Name: "Foundation", StartDate: "2026-03-01", TargetDate: targetDate},
+			{ID: "02-phase", Name: "Launch", StartDate: "2026-03-01"},
+		},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Auth", TaskPhases: []models.TaskPhase{{Name: "Build", Tasks: tasks}}},
+			{ID: "02", Phase: "02-phase", Name: "Docs", TaskPhases: []models.TaskPhase{{Name: "Write", Tasks: []models.Task{{Name: "Guide", Completed: true}}}}},
+		},
+	}
+	progress := map[string]*dashboard.ProgressData{
+		"01": {FeatureID: "01", Tasks: completions},
+	}
+	return state, progress
+}
+
+func TestNewForecaster_Defaults(t *testing.T) {
+	f := NewForecaster(models.ForecastConfig{})
+	assert.Equal(t, DefaultForecastConfidence, f.confidence)
+	assert.Equal(t, DefaultForecastSimulations, f.simulations)
+	assert.Equal(t, DefaultForecastWindowDays, f.windowDays)
+
+	f = NewForecaster(models.ForecastConfig{Confidence: 95, Simulations: 200, WindowDays: 7})
+	assert.Equal(t, 95, f.confidence)
+	assert.Equal(t, 200, f.simulations)
+	assert.Equal(t, 7, f.windowDays)
+}
+
+func TestForecaster_Forecast(t *testing.T) {
+	state, progress := forecastTestProject("2026-03-20")
+
+	forecasts := NewForecaster(models.ForecastConfig{}).Forecast(state, nil, progress, forecastNow)
+	require.NotNil(t, forecasts)
+	assert.Equal(t, DefaultForecastConfidence, forecasts.Confidence)
+
+	// Ten tasks left at a steady two a day finish in five days in every simulation
+	phase := forecasts.Phases[0]
+	assert.Equal(t, 10, phase.Remaining)
+	require.True(t, phase.HasDates())
+	assert.Equal(t, "2026-03-16", phase.P50.Format("2006-01-02"))
+	assert.Equal(t, "2026-03-16", phase.P95.Format("2006-01-02"))
+	assert.Equal(t, 100.0, phase.OnTargetChance)
+	assert.False(t, phase.AtRisk)
+
+	// Finished phases need no simulation
+	launch := forecasts.Phases[1]
+	assert.Equal(t, "complete", launch.Note)
+	assert.False(t, launch.HasDates())
+
+	assert.Equal(t, "2026-03-16", forecasts.Project.P85.Format("2006-01-02"))
+}
+
+func TestForecaster_Forecast_AtRisk(t *testing.T) {
+	state, progress := forecastTestProject("2026-03-14")
+
+	forecasts := NewForecaster(models.ForecastConfig{}).Forecast(state, nil, progress, forecastNow)
+	require.NotNil(t, forecasts)
+
+	phase := forecasts.Phases[0]
+	assert.Equal(t, 0.0, phase.OnTargetChance)
+	assert.True(t, phase.AtRisk, "a target before every simulated finish is at risk")
+	assert.False(t, forecasts.Phases[1].AtRisk, "phases without a target are never at risk")
+}
+
+func TestForecaster_Forecast_Reproducible(t *testing.T) {
+	state, progress := forecastTestProject("2026-03-16")
+	// Uneven throughput spreads the simulated finish dates
+	progress["01"].Tasks[0].CompletedAt = progress["01"].Tasks[19].CompletedAt
+
+	forecaster := NewForecaster(models.ForecastConfig{Confidence: 90})
+	first := forecaster.Forecast(state, nil, progress, forecastNow)
+	second := forecaster.Forecast(state, nil, progress, forecastNow)
+	assert.Equal(t, first, second)
+
+	phase := first.Phases[0]
+	assert.False(t, phase.P50.After(phase.P85))
+	assert.False(t, phase.P85.After(phase.P95))
+	assert.Greater(t, phase.OnTargetChance, 0.0)
+	assert.Less(t, phase.OnTargetChance, 100.0)
+	assert.Equal(t, phase.OnTargetChance < 90, phase.AtRisk)
+}
+
+func TestForecaster_Forecast_NoHistory(t *testing.T) {
+	state, _ := forecastTestProject("2026-03-20")
+	state.Phases[0].StartDate = ""
+	state.Phases[1].StartDate = ""
+
+	forecasts := NewForecaster(models.ForecastConfig{}).Forecast(state, nil, nil, forecastNow)
+	require.NotNil(t, forecasts)
+
+	phase := forecasts.Phases[0]
+	assert.False(t, phase.HasDates())
+	assert.Equal(t, "not enough history yet", phase.Note)
+	assert.False(t, phase.AtRisk)
+}
+
+func TestForecaster_Forecast_FeatureThroughput(t *testing.T) {
+	state := &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation"}},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Status: "complete"},
+			{ID: "02", Phase: "01-phase", Status: "complete"},
+			{ID: "03", Phase: "01-phase", Status: "in-progress"},
+		},
+	}
+	history := []*HistoricalData{
+		{Timestamp: forecastNow.AddDate(0, 0, -2), Data: &StatisticsData{State: &StateData{TotalFeatures: 3, CompletedFeatures: 0}}},
+		{Timestamp: forecastNow.AddDate(0, 0, -1), Data: &StatisticsData{State: &StateData{TotalFeatures: 3, CompletedFeatures: 1}}},
+	}
+
+	forecasts := NewForecaster(models.ForecastConfig{}).Forecast(state, history, nil, forecastNow)
+	require.NotNil(t, forecasts)
+
+	phase := forecasts.Phases[0]
+	assert.Equal(t, BurnUnitFeatures, phase.Unit)
+	require.True(t, phase.HasDates(), phase.Note)
+	assert.Equal(t, "2026-03-12", phase.P95.Format("2006-01-02"), "one feature a day")
+}
+
+func TestForecaster_dailyThroughput(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	series := &BurnSeries{Points: []BurnPoint{
+		{Date: day(1), Completed: 0},
+		{Date: day(2), Completed: 3},
+		{Date: day(9), Completed: 10},
+		{Date: day(10), Completed: 9},
+	}}
+
+	f := NewForecaster(models.ForecastConfig{WindowDays: 5})
+	assert.Equal(t, []int{1, 1, 1, 1, 0}, f.dailyThroughput(series), "weekly gaps are spread over their days and drops count as zero")
+	assert.Nil(t, f.dailyThroughput(nil))
+}
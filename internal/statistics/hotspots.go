@@ -0,0 +1,617 @@
+package statistics
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// DefaultHotspotWindowDays is used when stats.hotspots.windowDays is unset
+const DefaultHotspotWindowDays = 90
+
+const (
+	hotspotFileLimit      = 20
+	hotspotDirectoryLimit = 10
+	hotspotCouplingLimit  = 10
+	// hotspotMaxCommits caps the commits analyzed in one window
+	hotspotMaxCommits = 5000
+	// couplingMaxFiles skips commits touching more files when pairing files, as
+	// sweeping changes such as renames or reformatting say little about coupling
+	couplingMaxFiles = 30
+	// couplingMinCoChanges is how often two files must change together to be coupled
+	couplingMinCoChanges = 3
+	// AuthorConcentrationThreshold is the percent of an area's commits by one author
+	// above which the area depends on that person
+	AuthorConcentrationThreshold = 75.0
+	// authorConcentrationMinCommits keeps rarely changed areas from being flagged
+	authorConcentrationMinCommits = 5
+)
+
+// DefaultHotspotExclude leaves lockfiles, vendored code and DoPlan's own planning
+// files out of the churn analysis
+var DefaultHotspotExclude = []string{
+	"go.sum",
+	"**/package-lock.json",
+	"**/yarn.lock",
+	"**/pnpm-lock.yaml",
+	"**/Cargo.lock",
+	"**/*.min.js",
+	"vendor/**",
+	"**/node_modules/**",
+	".doplan/**",
+	"doplan/**",
+}
+
+var (
+	// mergeBranchPattern finds the merged branch in merge commit messages from git and GitHub
+	mergeBranchPattern = regexp.MustCompile(`^Merge (?:pull request #\d+ from [^/\s]+/|(?:remote-tracking )?branch '(?:origin/)?)([^'\s]+)`)
+	// squashPRPattern finds the pull request number GitHub appends to squash merges
+	squashPRPattern = regexp.MustCompile(`\(#(\d+)\)\s*$`)
+)
+
+// HotspotMetrics describes where the code changes most, what changes together and
+// who changes it, over a window of git history
+type HotspotMetrics struct {
+	WindowDays  int              `json:"windowDays"`
+	Since       time.Time        `json:"since"`
+	Commits     int              `json:"commits"` // Non-merge commits analyzed
+	Authors     int              `json:"authors"`
+	Files       []FileChurn      `json:"files,omitempty"`       // Most changed first
+	Directories []AreaChurn      `json:"directories,omitempty"` // Most changed first; ID is the directory path
+	Coupling    []ChangeCoupling `json:"coupling,omitempty"`    // Most often changed together first
+	Features    []AreaChurn      `json:"features,omitempty"`
+	Phases      []AreaChurn      `json:"phases,omitempty"`
+}
+
+// ChurnStats counts the changes to a file or area
+type ChurnStats struct {
+	Commits        int     `json:"commits"`
+	Additions      int     `json:"additions"`
+	Deletions      int     `json:"deletions"`
+	Authors        int     `json:"authors"`
+	TopAuthor      string  `json:"topAuthor"`
+	TopAuthorShare float64 `json:"topAuthorShare"` // Percent of commits by the top author
+}
+
+// Churn is the number of lines added and deleted
+func (c ChurnStats) Churn() int {
+	return c.Additions + c.Deletions
+}
+
+// Concentrated reports whether most changes come from one author
+func (c ChurnStats) Concentrated() bool {
+	return c.Commits >= authorConcentrationMinCommits && c.TopAuthorShare >= AuthorConcentrationThreshold
+}
+
+// FileChurn is the churn of one file
+type FileChurn struct {
+	Path string `json:"path"`
+	ChurnStats
+	LastChanged time.Time `json:"lastChanged"`
+	Features    []string  `json:"features,omitempty"` // Names of the features the file's changes belong to
+}
+
+// AreaChurn is the churn of a directory, feature or phase
+type AreaChurn struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Files int    `json:"files"`
+	ChurnStats
+}
+
+// ChangeCoupling is a pair of files that tend to change in the same commits
+type ChangeCoupling struct {
+	A         string  `json:"a"`
+	B         string  `json:"b"`
+	CoChanges int     `json:"coChanges"`
+	Degree    float64 `json:"degree"` // Percent of the less changed file's commits that also changed the other
+}
+
+// churnCounter accumulates the churn of a file or area, counting each commit once
+type churnCounter struct {
+	commits, additions, deletions int
+	authors                       map[string]int
+	files                         map[string]bool
+	lastCommit                    plumbing.Hash
+	lastChanged                   time.Time
+	features                      map[string]int // Commits per feature ID, for files
+}
+
+func newChurnCounter() *churnCounter {
+	return &churnCounter{authors: make(map[string]int), files: make(map[string]bool), features: make(map[string]int)}
+}
+
+func (c *churnCounter) add(commit *object.Commit, author, file string, stat object.FileStat) {
+	if c.lastCommit != commit.Hash {
+		c.lastCommit = commit.Hash
+		c.commits++
+		c.authors[author]++
+	}
+	c.additions += stat.Addition
+	c.deletions += stat.Deletion
+	c.files[file] = true
+	if commit.Author.When.After(c.lastChanged) {
+		c.lastChanged = commit.Author.When
+	}
+}
+
+func (c *churnCounter) stats(authorNames map[string]string) ChurnStats {
+	stats := ChurnStats{Commits: c.commits, Additions: c.additions, Deletions: c.deletions, Authors: len(c.authors)}
+	top, topCommits := "", 0
+	for author, commits := range c.authors {
+		if commits > topCommits || (commits == topCommits && author < top) {
+			top, topCommits = author, commits
+		}
+	}
+	if c.commits > 0 {
+		stats.TopAuthor = authorNames[top]
+		stats.TopAuthorShare = float64(topCommits) / float64(c.commits) * 100
+	}
+	return stats
+}
+
+// LoadHotspots analyzes the git history of the project over the configured window.
+// It returns nil outside a git repository.
+func LoadHotspots(projectRoot string, state *models.State, githubData *models.GitHubData, cfg *models.Config, now time.Time) *HotspotMetrics {
+	repo, err := git.PlainOpenWithOptions(projectRoot, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil
+	}
+	if cfg == nil {
+		cfg = &models.Config{}
+	}
+
+	// Diff paths are relative to the repository root; config globs to the project root
+	prefix := ""
+	if wt, err := repo.Worktree(); err == nil {
+		if rel, err := filepath.Rel(wt.Filesystem.Root(), projectRoot); err == nil && rel != "." {
+			prefix = filepath.ToSlash(rel) + "/"
+		}
+	}
+
+	return newHotspotAnalyzer(repo, state, githubData, cfg, prefix, now).analyze()
+}
+
+type hotspotAnalyzer struct {
+	repo       *git.Repository
+	state      *models.State
+	githubData *models.GitHubData
+	cfg        *models.Config
+	prefix     string
+	windowDays int
+	since      time.Time
+	exclude    []string
+}
+
+func newHotspotAnalyzer(repo *git.Repository, state *models.State, githubData *models.GitHubData, cfg *models.Config, prefix string, now time.Time) *hotspotAnalyzer {
+	if state == nil {
+		state = &models.State{}
+	}
+	if githubData == nil {
+		githubData = &models.GitHubData{}
+	}
+	windowDays := cfg.Stats.Hotspots.WindowDays
+	if windowDays <= 0 {
+		windowDays = DefaultHotspotWindowDays
+	}
+	return &hotspotAnalyzer{
+		repo:       repo,
+		state:      state,
+		githubData: githubData,
+		cfg:        cfg,
+		prefix:     prefix,
+		windowDays: windowDays,
+		since:      now.AddDate(0, 0, -windowDays),
+		exclude:    append(append([]string{}, DefaultHotspotExclude...), cfg.Stats.Hotspots.Exclude...),
+	}
+}
+
+func (a *hotspotAnalyzer) analyze() *HotspotMetrics {
+	iter, err := a.repo.Log(&git.LogOptions{All: true, Since: &a.since})
+	if err != nil {
+		return nil
+	}
+	var commits []*object.Commit
+	iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	})
+	// Oldest first keeps the analysis stable whatever order the refs were walked in
+	sort.SliceStable(commits, func(i, j int) bool { return commits[i].Committer.When.Before(commits[j].Committer.When) })
+
+	branches := a.commitBranches(commits)
+	features := make(map[string]*models.Feature)
+	for i := range a.state.Features {
+		features[a.state.Features[i].ID] = &a.state.Features[i]
+	}
+	phaseNames := make(map[string]string)
+	for _, phase := range a.state.Phases {
+		phaseNames[phase.ID] = phase.Name
+	}
+
+	metrics := &HotspotMetrics{WindowDays: a.windowDays, Since: a.since}
+	authorNames := make(map[string]string)
+	fileCounters := make(map[string]*churnCounter)
+	dirCounters := make(map[string]*churnCounter)
+	featureCounters := make(map[string]*churnCounter)
+	phaseCounters := make(map[string]*churnCounter)
+	pairs := make(map[[2]string]int)
+
+	counter := func(counters map[string]*churnCounter, key string) *churnCounter {
+		c, ok := counters[key]
+		if !ok {
+			c = newChurnCounter()
+			counters[key] = c
+		}
+		return c
+	}
+
+	analyzed := 0
+	for _, commit := range commits {
+		if commit.NumParents() > 1 {
+			continue
+		}
+		if analyzed >= hotspotMaxCommits {
+			break
+		}
+		fileStats, err := commit.Stats()
+		if err != nil {
+			continue
+		}
+
+		author := strings.ToLower(commit.Author.Email)
+		if author == "" {
+			author = commit.Author.Name
+		}
+		if _, ok := authorNames[author]; !ok {
+			authorNames[author] = commit.Author.Name
+		}
+
+		var branchFeature *models.Feature
+		if branch, ok := branches[commit.Hash]; ok {
+			branchFeature = github.FeatureForBranch(a.state, branch)
+		}
+
+		var touched []string
+		for _, stat := range fileStats {
+			file, ok := a.projectPath(stat.Name)
+			if !ok {
+				continue
+			}
+			touched = append(touched, file)
+
+			fc := counter(fileCounters, file)
+			fc.add(commit, author, file, stat)
+			counter(dirCounters, path.Dir(file)).add(commit, author, file, stat)
+
+			for _, id := range a.fileFeatures(file, branchFeature) {
+				fc.features[id]++
+				counter(featureCounters, id).add(commit, author, file, stat)
+				if feature := features[id]; feature != nil && feature.Phase != "" {
+					counter(phaseCounters, feature.Phase).add(commit, author, file, stat)
+				}
+			}
+		}
+		if len(touched) == 0 {
+			continue
+		}
+		analyzed++
+
+		if len(touched) <= couplingMaxFiles {
+			sort.Strings(touched)
+			for i := range touched {
+				for j := i + 1; j < len(touched); j++ {
+					pairs[[2]string{touched[i], touched[j]}]++
+				}
+			}
+		}
+	}
+	metrics.Commits = analyzed
+	if analyzed == 0 {
+		return metrics
+	}
+
+	authors := make(map[string]bool)
+	for _, c := range fileCounters {
+		for author := range c.authors {
+			authors[author] = true
+		}
+	}
+	metrics.Authors = len(authors)
+
+	featureName := func(id string) string {
+		if feature := features[id]; feature != nil && feature.Name != "" {
+			return feature.Name
+		}
+		return id
+	}
+
+	for file, c := range fileCounters {
+		churn := FileChurn{Path: file, ChurnStats: c.stats(authorNames), LastChanged: c.lastChanged}
+		for _, id := range sortedKeys(c.features) {
+			churn.Features = append(churn.Features, featureName(id))
+		}
+		metrics.Files = append(metrics.Files, churn)
+	}
+	sort.Slice(metrics.Files, func(i, j int) bool {
+		return churnLess(metrics.Files[i].ChurnStats, metrics.Files[j].ChurnStats, metrics.Files[i].Path, metrics.Files[j].Path)
+	})
+	if len(metrics.Files) > hotspotFileLimit {
+		metrics.Files = metrics.Files[:hotspotFileLimit]
+	}
+
+	metrics.Directories = areaChurn(dirCounters, authorNames, func(dir string) string { return dir })
+	if len(metrics.Directories) > hotspotDirectoryLimit {
+		metrics.Directories = metrics.Directories[:hotspotDirectoryLimit]
+	}
+	metrics.Features = areaChurn(featureCounters, authorNames, featureName)
+	metrics.Phases = areaChurn(phaseCounters, authorNames, func(id string) string {
+		if name := phaseNames[id]; name != "" {
+			return name
+		}
+		return id
+	})
+
+	for pair, co := range pairs {
+		if co < couplingMinCoChanges {
+			continue
+		}
+		least := fileCounters[pair[0]].commits
+		if other := fileCounters[pair[1]].commits; other < least {
+			least = other
+		}
+		metrics.Coupling = append(metrics.Coupling, ChangeCoupling{
+			A:         pair[0],
+			B:         pair[1],
+			CoChanges: co,
+			Degree:    float64(co) / float64(least) * 100,
+		})
+	}
+	sort.Slice(metrics.Coupling, func(i, j int) bool {
+		a, b := metrics.Coupling[i], metrics.Coupling[j]
+		if a.CoChanges != b.CoChanges {
+			return a.CoChanges > b.CoChanges
+		}
+		if a.Degree != b.Degree {
+			return a.Degree > b.Degree
+		}
+		if a.A != b.A {
+			return a.A < b.A
+		}
+		return a.B < b.B
+	})
+	if len(metrics.Coupling) > hotspotCouplingLimit {
+		metrics.Coupling = metrics.Coupling[:hotspotCouplingLimit]
+	}
+
+	return metrics
+}
+
+// areaChurn turns counters into areas, most changed first
+func areaChurn(counters map[string]*churnCounter, authorNames map[string]string, name func(string) string) []AreaChurn {
+	var areas []AreaChurn
+	for id, c := range counters {
+		areas = append(areas, AreaChurn{ID: id, Name: name(id), Files: len(c.files), ChurnStats: c.stats(authorNames)})
+	}
+	sort.Slice(areas, func(i, j int) bool {
+		return churnLess(areas[i].ChurnStats, areas[j].ChurnStats, areas[i].ID, areas[j].ID)
+	})
+	return areas
+}
+
+// churnLess orders by commits, then changed lines, most first
+func churnLess(a, b ChurnStats, aKey, bKey string) bool {
+	if a.Commits != b.Commits {
+		return a.Commits > b.Commits
+	}
+	if a.Churn() != b.Churn() {
+		return a.Churn() > b.Churn()
+	}
+	return aKey < bKey
+}
+
+// projectPath converts a path from a diff to one relative to the project root,
+// reporting false for excluded files and files outside the project
+func (a *hotspotAnalyzer) projectPath(name string) (string, bool) {
+	// Renames are reported as "old => new"
+	if i := strings.Index(name, " => "); i >= 0 {
+		name = name[i+len(" => "):]
+	}
+	if a.prefix != "" {
+		if !strings.HasPrefix(name, a.prefix) {
+			return "", false
+		}
+		name = strings.TrimPrefix(name, a.prefix)
+	}
+	if matchesAnyGlob(a.exclude, name) {
+		return "", false
+	}
+	return name, true
+}
+
+// fileFeatures returns the IDs of the features a change to file belongs to: the
+// feature whose branch it was made on and the features whose mapped paths contain it
+func (a *hotspotAnalyzer) fileFeatures(file string, branchFeature *models.Feature) []string {
+	var ids []string
+	if branchFeature != nil {
+		ids = append(ids, branchFeature.ID)
+	}
+	for _, mapping := range a.cfg.Stats.Coverage.Features {
+		if mapping.Feature != "" && (branchFeature == nil || mapping.Feature != branchFeature.ID) && matchesAnyGlob(mapping.Paths, file) {
+			ids = append(ids, mapping.Feature)
+		}
+	}
+	return ids
+}
+
+// commitBranches finds the branch each commit was made on, from synced GitHub data,
+// live feature branches, merge commit messages and squash-merged pull requests
+func (a *hotspotAnalyzer) commitBranches(commits []*object.Commit) map[plumbing.Hash]string {
+	branches := make(map[plumbing.Hash]string)
+	for _, commit := range a.githubData.Commits {
+		if commit.Branch != "" {
+			branches[plumbing.NewHash(commit.Hash)] = commit.Branch
+		}
+	}
+	assign := func(hash plumbing.Hash, branch string) {
+		if _, ok := branches[hash]; !ok {
+			branches[hash] = branch
+		}
+	}
+
+	// Commits on a feature branch that its base does not have yet
+	defaultBase := a.defaultBaseBranch()
+	baseAncestors := make(map[string]map[plumbing.Hash]bool)
+	for i := range a.state.Features {
+		feature := &a.state.Features[i]
+		branch := feature.Branch
+		if branch == "" {
+			branch = github.GenerateBranchName(feature.Phase, feature.ID, feature.Name)
+		}
+		tip, ok := a.resolveBranch(branch)
+		if !ok {
+			continue
+		}
+		base := feature.BaseBranch
+		if base == "" {
+			base = defaultBase
+		}
+		if base == branch {
+			continue
+		}
+		ancestors, ok := baseAncestors[base]
+		if !ok {
+			ancestors = make(map[plumbing.Hash]bool)
+			if baseTip, ok := a.resolveBranch(base); ok {
+				a.walk(baseTip, func(c *object.Commit) bool {
+					ancestors[c.Hash] = true
+					return true
+				})
+			}
+			baseAncestors[base] = ancestors
+		}
+		a.walk(tip, func(c *object.Commit) bool {
+			if ancestors[c.Hash] {
+				return false
+			}
+			assign(c.Hash, branch)
+			return true
+		})
+	}
+
+	// Merged branches, by the merge commit message or squashed pull request number
+	prBranches := make(map[int]string)
+	for _, pr := range a.githubData.PRs {
+		if pr.Branch != "" {
+			prBranches[pr.Number] = pr.Branch
+		}
+	}
+	for _, commit := range commits {
+		subject := firstLine(commit.Message)
+		if commit.NumParents() == 1 {
+			if match := squashPRPattern.FindStringSubmatch(subject); match != nil {
+				number, _ := strconv.Atoi(match[1])
+				if branch := prBranches[number]; branch != "" {
+					assign(commit.Hash, branch)
+				}
+			}
+			continue
+		}
+		match := mergeBranchPattern.FindStringSubmatch(subject)
+		if match == nil || len(commit.ParentHashes) < 2 {
+			continue
+		}
+		mainline := make(map[plumbing.Hash]bool)
+		a.walkFirstParents(commit.ParentHashes[0], mainline)
+		a.walk(commit.ParentHashes[1], func(c *object.Commit) bool {
+			if mainline[c.Hash] {
+				return false
+			}
+			assign(c.Hash, match[1])
+			return true
+		})
+	}
+
+	return branches
+}
+
+// walk visits the commits reachable from hash inside the window. visit returns false
+// to stop following a commit's parents.
+func (a *hotspotAnalyzer) walk(hash plumbing.Hash, visit func(*object.Commit) bool) {
+	seen := make(map[plumbing.Hash]bool)
+	queue := []plumbing.Hash{hash}
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		commit, err := a.repo.CommitObject(hash)
+		if err != nil || commit.Committer.When.Before(a.since) {
+			continue
+		}
+		if visit(commit) {
+			queue = append(queue, commit.ParentHashes...)
+		}
+	}
+}
+
+// walkFirstParents marks the first-parent line from hash inside the window
+func (a *hotspotAnalyzer) walkFirstParents(hash plumbing.Hash, line map[plumbing.Hash]bool) {
+	for !line[hash] {
+		commit, err := a.repo.CommitObject(hash)
+		if err != nil || commit.Committer.When.Before(a.since) {
+			return
+		}
+		line[hash] = true
+		if commit.NumParents() == 0 {
+			return
+		}
+		hash = commit.ParentHashes[0]
+	}
+}
+
+func (a *hotspotAnalyzer) resolveBranch(name string) (plumbing.Hash, bool) {
+	if ref, err := a.repo.Reference(plumbing.NewBranchReferenceName(name), true); err == nil {
+		return ref.Hash(), true
+	}
+	if ref, err := a.repo.Reference(plumbing.NewRemoteReferenceName("origin", name), true); err == nil {
+		return ref.Hash(), true
+	}
+	return plumbing.ZeroHash, false
+}
+
+// defaultBaseBranch is the configured base branch, else main or master, else HEAD
+func (a *hotspotAnalyzer) defaultBaseBranch() string {
+	if a.cfg.GitHub.BaseBranch != "" {
+		return a.cfg.GitHub.BaseBranch
+	}
+	for _, name := range []string{"main", "master"} {
+		if _, ok := a.resolveBranch(name); ok {
+			return name
+		}
+	}
+	if head, err := a.repo.Head(); err == nil {
+		return head.Name().Short()
+	}
+	return ""
+}
+
+func firstLine(message string) string {
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		return strings.TrimSpace(message[:i])
+	}
+	return strings.TrimSpace(message)
+}
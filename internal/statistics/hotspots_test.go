@@ -0,0 +1,238 @@
+package statistics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var hotspotNow = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// hotspotRepo builds a repository whose history exercises churn, coupling and
+// each way of attributing commits to a feature branch
+type hotspotRepo struct {
+	t     *testing.T
+	root  string
+	repo  *git.Repository
+	lines map[string]int
+	day   int
+}
+
+func newHotspotRepo(t *testing.T) *hotspotRepo {
+	t.Helper()
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	require.NoError(t, err)
+	return &hotspotRepo{t: t, root: root, repo: repo, lines: make(map[string]int)}
+}
+
+// commit appends lines to each file and commits as author, one day after the previous commit
+func (r *hotspotRepo) commit(author, message string, lines map[string]int, parents ...plumbing.Hash) plumbing.Hash {
+	r.t.Helper()
+	wt, err := r.repo.Worktree()
+	require.NoError(r.t, err)
+
+	for name, count := range lines {
+		path := filepath.Join(r.root, filepath.FromSlash(name))
+		require.NoError(r.t, os.MkdirAll(filepath.Dir(path), 0755))
+		var sb strings.Builder
+		for i := 0; i < r.lines[name]+count; i++ {
+			sb.WriteString(fmt.Sprintf("line %d\n", i))
+		}
+		r.lines[name] += count
+		require.NoError(r.t, os.WriteFile(path, []byte(sb.String()), 0644))
+		_, err := wt.Add(name)
+		require.NoError(r.t, err)
+	}
+
+	r.day++
+	sig := &object.Signature{Name: author, Email: strings.ToLower(author) + "@example.com", When: hotspotNow.AddDate(0, 0, -60+r.day)}
+	hash, err := wt.Commit(message, &git.CommitOptions{Author: sig, Committer: sig, Parents: parents, AllowEmptyCommits: len(lines) == 0})
+	require.NoError(r.t, err)
+	return hash
+}
+
+func (r *hotspotRepo) checkout(branch string, create bool) {
+	r.t.Helper()
+	wt, err := r.repo.Worktree()
+	require.NoError(r.t, err)
+	require.NoError(r.t, wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch), Create: create, Keep: true}))
+}
+
+func setupHotspotRepo(t *testing.T) *hotspotRepo {
+	r := newHotspotRepo(t)
+
+	// Outside the window
+	helpers.CommitFiles(t, r.repo, "Carol", hotspotNow.AddDate(0, 0, -200), map[string]string{"old.go": "old\n"})
+
+	// Login and session always change together, mostly by Alice
+	for i := 0; i < 5; i++ {
+		r.commit("Alice", "auth work", map[string]int{"internal/auth/login.go": 2, "internal/auth/session.go": 1})
+	}
+	r.commit("Bob", "auth fix", map[string]int{"internal/auth/login.go": 1})
+	r.commit("Bob", "web", map[string]int{"web/app.ts": 3, "go.sum": 10, "doplan/01-phase/plan.md": 5})
+
+	// Merged with a merge commit, branch since deleted
+	r.checkout("feature/02-billing", true)
+	branchTip := r.commit("Bob", "invoices", map[string]int{"internal/billing/invoice.go": 4})
+	r.checkout("master", false)
+	mainTip := r.commit("Alice", "docs", map[string]int{"README.md": 1})
+	r.commit("Alice", "Merge branch 'feature/02-billing'", nil, mainTip, branchTip)
+	require.NoError(t, r.repo.Storer.RemoveReference(plumbing.NewBranchReferenceName("feature/02-billing")))
+
+	// Squash merged pull request
+	r.commit("Bob", "Add reports (#7)", map[string]int{"internal/reports/report.go": 2})
+
+	// Live feature branch not merged yet
+	r.checkout("feature/01-phase-01-login", true)
+	r.commit("Alice", "tokens", map[string]int{"internal/auth/token.go": 2})
+	r.checkout("master", false)
+
+	return r
+}
+
+func hotspotState() *models.State {
+	return &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation"}},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Login"},
+			{ID: "02", Phase: "01-phase", Name: "Billing", Branch: "feature/02-billing"},
+			{ID: "03", Phase: "02-phase", Name: "Reports"},
+			{ID: "04", Phase: "02-phase", Name: "Web"},
+		},
+	}
+}
+
+func findArea(areas []AreaChurn, id string) *AreaChurn {
+	for i := range areas {
+		if areas[i].ID == id {
+			return &areas[i]
+		}
+	}
+	return nil
+}
+
+func TestLoadHotspots(t *testing.T) {
+	r := setupHotspotRepo(t)
+	githubData := &models.GitHubData{PRs: []models.PullRequest{{Number: 7, Branch: "feature/02-phase-03-reports"}}}
+	cfg := &models.Config{}
+	cfg.Stats.Coverage.Features = []models.CoverageMapping{{Feature: "04", Paths: []string{"web/**"}}}
+
+	metrics := LoadHotspots(r.root, hotspotState(), githubData, cfg, hotspotNow)
+	require.NotNil(t, metrics)
+	assert.Equal(t, DefaultHotspotWindowDays, metrics.WindowDays)
+	assert.Equal(t, 11, metrics.Commits, "merges and commits outside the window are left out")
+	assert.Equal(t, 2, metrics.Authors)
+
+	require.NotEmpty(t, metrics.Files)
+	login := metrics.Files[0]
+	assert.Equal(t, "internal/auth/login.go", login.Path)
+	assert.Equal(t, 6, login.Commits)
+	assert.Equal(t, 11, login.Additions)
+	assert.Equal(t, 2, login.Authors)
+	assert.Equal(t, "Alice", login.TopAuthor)
+	assert.InDelta(t, 83.3, login.TopAuthorShare, 0.1)
+	assert.True(t, login.Concentrated())
+
+	paths := make([]string, 0, len(metrics.Files))
+	for _, file := range metrics.Files {
+		paths = append(paths, file.Path)
+	}
+	assert.NotContains(t, paths, "go.sum")
+	assert.NotContains(t, paths, "doplan/01-phase/plan.md")
+	assert.NotContains(t, paths, "old.go")
+
+	require.Len(t, metrics.Coupling, 1)
+	assert.Equal(t, ChangeCoupling{A: "internal/auth/login.go", B: "internal/auth/session.go", CoChanges: 5, Degree: 100}, metrics.Coupling[0])
+
+	auth := findArea(metrics.Directories, "internal/auth")
+	require.NotNil(t, auth)
+	assert.Equal(t, 3, auth.Files)
+	assert.Equal(t, 7, auth.Commits)
+
+	login01 := findArea(metrics.Features, "01")
+	require.NotNil(t, login01, "live feature branches are attributed by their generated name")
+	assert.Equal(t, "Login", login01.Name)
+	assert.Equal(t, 1, login01.Commits)
+	billing := findArea(metrics.Features, "02")
+	require.NotNil(t, billing, "merged branches are found from the merge commit")
+	assert.Equal(t, 4, billing.Additions)
+	reports := findArea(metrics.Features, "03")
+	require.NotNil(t, reports, "squash merges are found from the pull request number")
+	assert.Equal(t, 1, reports.Files)
+	web := findArea(metrics.Features, "04")
+	require.NotNil(t, web, "paths in stats.coverage.features map files to features")
+	assert.Equal(t, 3, web.Additions)
+
+	phase := findArea(metrics.Phases, "01-phase")
+	require.NotNil(t, phase)
+	assert.Equal(t, "Foundation", phase.Name)
+	assert.Equal(t, 2, phase.Commits)
+	assert.NotNil(t, findArea(metrics.Phases, "02-phase"))
+
+	for _, file := range metrics.Files {
+		if file.Path == "internal/billing/invoice.go" {
+			assert.Equal(t, []string{"Billing"}, file.Features)
+		}
+	}
+}
+
+func TestLoadHotspots_WindowAndExclude(t *testing.T) {
+	r := setupHotspotRepo(t)
+	cfg := &models.Config{}
+	cfg.Stats.Hotspots = models.HotspotsConfig{WindowDays: 53, Exclude: []string{"internal/reports/**"}}
+
+	metrics := LoadHotspots(r.root, nil, nil, cfg, hotspotNow)
+	require.NotNil(t, metrics)
+	assert.Equal(t, 53, metrics.WindowDays)
+	assert.Equal(t, hotspotNow.AddDate(0, 0, -53), metrics.Since)
+	assert.Equal(t, 4, metrics.Commits, "commits touching only excluded files are left out")
+
+	paths := make([]string, 0, len(metrics.Files))
+	for _, file := range metrics.Files {
+		paths = append(paths, file.Path)
+	}
+	assert.ElementsMatch(t, []string{"web/app.ts", "internal/billing/invoice.go", "README.md", "internal/auth/token.go"}, paths)
+	assert.Empty(t, metrics.Features)
+	assert.Empty(t, metrics.Coupling)
+
+	assert.Nil(t, LoadHotspots(t.TempDir(), nil, nil, nil, hotspotNow), "no hotspots outside a repository")
+}
+
+func TestLoadHotspots_Subdirectory(t *testing.T) {
+	r := setupHotspotRepo(t)
+
+	metrics := LoadHotspots(filepath.Join(r.root, "internal"), nil, nil, nil, hotspotNow)
+	require.NotNil(t, metrics)
+	require.NotEmpty(t, metrics.Files)
+	assert.Equal(t, "auth/login.go", metrics.Files[0].Path, "paths are relative to the project root")
+	for _, file := range metrics.Files {
+		assert.False(t, strings.HasPrefix(file.Path, "web/"), "files outside the project are left out")
+	}
+}
+
+func TestMergeBranchPattern(t *testing.T) {
+	tests := map[string]string{
+		"Merge pull request #12 from acme/feature/01-phase-01-login": "feature/01-phase-01-login",
+		"Merge branch 'feature/02-billing'":                          "feature/02-billing",
+		"Merge branch 'feature/02-billing' into develop":             "feature/02-billing",
+		"Merge remote-tracking branch 'origin/feature/03-reports'":   "feature/03-reports",
+	}
+	for message, branch := range tests {
+		match := mergeBranchPattern.FindStringSubmatch(message)
+		require.NotNil(t, match, message)
+		assert.Equal(t, branch, match[1])
+	}
+	assert.Nil(t, mergeBranchPattern.FindStringSubmatch("Merge the invoice totals"))
+}
@@ -0,0 +1,304 @@
+package statistics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// OpenMetricsContentType is the content type of the OpenMetrics text exposition
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// OpenMetrics metric types
+const (
+	openMetricsGauge   = "gauge"
+	openMetricsCounter = "counter"
+)
+
+// openMetricsLabel is one name="value" pair of a sample
+type openMetricsLabel struct {
+	name  string
+	value string
+}
+
+// openMetricsWriter writes metric families in the OpenMetrics text format
+type openMetricsWriter struct {
+	buf bytes.Buffer
+}
+
+// family starts a metric family with its TYPE, UNIT and HELP metadata. Unit may be
+// empty; otherwise the family name must end with it.
+func (w *openMetricsWriter) family(name, metricType, unit, help string) {
+	fmt.Fprintf(&w.buf, "# TYPE %s %s\n", name, metricType)
+	if unit != "" {
+		fmt.Fprintf(&w.buf, "# UNIT %s %s\n", name, unit)
+	}
+	fmt.Fprintf(&w.buf, "# HELP %s %s\n", name, escapeOpenMetricsHelp(help))
+}
+
+// sample writes one sample; counters get their _total suffix from the caller
+func (w *openMetricsWriter) sample(name string, value float64, labels ...openMetricsLabel) {
+	w.buf.WriteString(name)
+	if len(labels) > 0 {
+		w.buf.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			fmt.Fprintf(&w.buf, "%s=\"%s\"", label.name, escapeOpenMetricsLabel(label.value))
+		}
+		w.buf.WriteByte('}')
+	}
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(formatOpenMetricsValue(value))
+	w.buf.WriteByte('\n')
+}
+
+// gauge writes a single unlabelled gauge family
+func (w *openMetricsWriter) gauge(name, unit, help string, value float64) {
+	w.family(name, openMetricsGauge, unit, help)
+	w.sample(name, value)
+}
+
+// WriteOpenMetrics writes metrics, and the collected counts in data when given, in
+// the OpenMetrics text format. Percentages are exposed as 0-1 ratios and durations in
+// seconds, following the OpenMetrics base units.
+func WriteOpenMetrics(out io.Writer, metrics *StatisticsMetrics, data *StatisticsData) error {
+	w := &openMetricsWriter{}
+
+	if metrics != nil {
+		writeCompletionOpenMetrics(w, metrics.Completion)
+		writeVelocityOpenMetrics(w, metrics.Velocity)
+		writeBurndownOpenMetrics(w, metrics.Burndown)
+		writeQualityOpenMetrics(w, metrics.Quality)
+		writeTestingOpenMetrics(w, metrics.Testing)
+		writeTestResultOpenMetrics(w, metrics.Tests)
+	}
+	if data != nil {
+		writeDataOpenMetrics(w, data)
+	}
+	if metrics != nil && !metrics.CalculatedAt.IsZero() {
+		w.gauge("doplan_stats_calculated_timestamp_seconds", "seconds", "When the statistics were calculated, as a Unix timestamp.",
+			float64(metrics.CalculatedAt.UnixNano())/1e9)
+	}
+	w.buf.WriteString("# EOF\n")
+
+	_, err := out.Write(w.buf.Bytes())
+	return err
+}
+
+func writeCompletionOpenMetrics(w *openMetricsWriter, completion *CompletionRates) {
+	if completion == nil {
+		return
+	}
+
+	w.gauge("doplan_project_progress_ratio", "ratio", "Overall project completion.", percentRatio(completion.Overall))
+	w.gauge("doplan_tasks_completion_ratio", "ratio", "Share of all tasks that are completed.", percentRatio(completion.Tasks))
+
+	if len(completion.Phases) > 0 {
+		w.family("doplan_phase_progress_ratio", openMetricsGauge, "ratio", "Completion of each phase, by finished features.")
+		for _, id := range sortedKeys(completion.Phases) {
+			w.sample("doplan_phase_progress_ratio", percentRatio(completion.Phases[id]), openMetricsLabel{"phase", id})
+		}
+	}
+	if len(completion.Features) > 0 {
+		w.family("doplan_feature_progress_ratio", openMetricsGauge, "ratio", "Completion of each feature.")
+		for _, id := range sortedKeys(completion.Features) {
+			w.sample("doplan_feature_progress_ratio", percentRatio(completion.Features[id]), openMetricsLabel{"feature", id})
+		}
+	}
+}
+
+func writeVelocityOpenMetrics(w *openMetricsWriter, velocity *VelocityMetrics) {
+	if velocity == nil {
+		return
+	}
+
+	w.family("doplan_velocity_per_day", openMetricsGauge, "", "Average items finished per day since the project started.")
+	w.sample("doplan_velocity_per_day", velocity.FeaturesPerDay, openMetricsLabel{"kind", "features"})
+	w.sample("doplan_velocity_per_day", velocity.TasksPerDay, openMetricsLabel{"kind", "tasks"})
+	w.sample("doplan_velocity_per_day", velocity.CommitsPerDay, openMetricsLabel{"kind", "commits"})
+	w.sample("doplan_velocity_per_day", velocity.PRsPerWeek/7, openMetricsLabel{"kind", "pull_requests"})
+}
+
+// writeBurndownOpenMetrics exposes the latest completed and remaining work of the
+// project and each phase, counted in tasks or features like the burn charts
+func writeBurndownOpenMetrics(w *openMetricsWriter, charts *BurnCharts) {
+	if charts == nil || charts.Project == nil {
+		return
+	}
+
+	latest := charts.Project.Latest()
+	w.family("doplan_project_work_items", openMetricsGauge, "", "Work in scope of the project, by state.")
+	w.sample("doplan_project_work_items", float64(latest.Completed), openMetricsLabel{"unit", charts.Project.Unit}, openMetricsLabel{"state", "completed"})
+	w.sample("doplan_project_work_items", float64(latest.Remaining), openMetricsLabel{"unit", charts.Project.Unit}, openMetricsLabel{"state", "remaining"})
+
+	if len(charts.Phases) == 0 {
+		return
+	}
+	w.family("doplan_phase_work_items", openMetricsGauge, "", "Work in scope of each phase, by state.")
+	for _, series := range charts.Phases {
+		latest := series.Latest()
+		for _, sample := range []struct {
+			state string
+			value int
+		}{{"completed", latest.Completed}, {"remaining", latest.Remaining}} {
+			w.sample("doplan_phase_work_items", float64(sample.value),
+				openMetricsLabel{"phase", series.ID}, openMetricsLabel{"unit", series.Unit}, openMetricsLabel{"state", sample.state})
+		}
+	}
+}
+
+func writeQualityOpenMetrics(w *openMetricsWriter, quality *QualityMetrics) {
+	if quality == nil {
+		return
+	}
+
+	w.gauge("doplan_pull_request_merge_ratio", "ratio", "Share of closed pull requests that were merged.", quality.PRMergeRate/100)
+	w.gauge("doplan_pull_request_review_time_seconds", "seconds", "Average time from opening a pull request to merging it.", quality.AvgPRReviewTime*3600)
+	w.gauge("doplan_branch_lifetime_seconds", "seconds", "Average time from a branch's first commit to its merge.", quality.AvgBranchLifetime*86400)
+	w.gauge("doplan_checkpoints_per_week", "", "Average checkpoints created per week.", quality.CheckpointFrequency)
+}
+
+func writeTestingOpenMetrics(w *openMetricsWriter, testing *TestingMetrics) {
+	if testing == nil {
+		return
+	}
+
+	w.gauge("doplan_coverage_ratio", "ratio", "Share of statements covered by tests.", testing.OverallCoverage/100)
+	if len(testing.Packages) > 0 {
+		w.family("doplan_package_coverage_ratio", openMetricsGauge, "ratio", "Share of statements covered by tests in each package.")
+		for _, pkg := range testing.Packages {
+			w.sample("doplan_package_coverage_ratio", pkg.Coverage/100, openMetricsLabel{"package", pkg.Name})
+		}
+	}
+	if len(testing.Features) > 0 {
+		w.family("doplan_feature_coverage_ratio", openMetricsGauge, "ratio", "Share of statements covered by tests in the source paths mapped to each feature.")
+		for _, feature := range testing.Features {
+			w.sample("doplan_feature_coverage_ratio", feature.Coverage/100, openMetricsLabel{"feature", feature.ID})
+		}
+	}
+}
+
+// writeTestResultOpenMetrics exposes the outcome of the latest recorded test run
+func writeTestResultOpenMetrics(w *openMetricsWriter, tests *TestResultMetrics) {
+	if tests == nil || tests.Latest == nil {
+		return
+	}
+
+	w.family("doplan_tests", openMetricsGauge, "", "Tests in the latest recorded run by status.")
+	w.sample("doplan_tests", float64(tests.Latest.Passed), openMetricsLabel{"status", TestPass})
+	w.sample("doplan_tests", float64(tests.Latest.Failed), openMetricsLabel{"status", TestFail})
+	w.sample("doplan_tests", float64(tests.Latest.Skipped), openMetricsLabel{"status", TestSkip})
+	w.gauge("doplan_test_duration_seconds", "seconds", "Total duration of the tests in the latest recorded run.", tests.Latest.Duration)
+	w.gauge("doplan_flaky_tests", "", "Tests that both passed and failed on the same commit.", float64(len(tests.Flaky)))
+}
+
+// writeDataOpenMetrics exposes the collected feature, task, pull request, commit and
+// checkpoint counts
+func writeDataOpenMetrics(w *openMetricsWriter, data *StatisticsData) {
+	if data.State != nil {
+		w.family("doplan_features", openMetricsGauge, "", "Features by status.")
+		w.sample("doplan_features", float64(data.State.CompletedFeatures), openMetricsLabel{"status", "complete"})
+		w.sample("doplan_features", float64(data.State.InProgressFeatures), openMetricsLabel{"status", "in-progress"})
+		w.sample("doplan_features", float64(data.State.PendingFeatures), openMetricsLabel{"status", "pending"})
+
+		w.family("doplan_phases", openMetricsGauge, "", "Phases by status.")
+		w.sample("doplan_phases", float64(data.State.CompletedPhases), openMetricsLabel{"status", "complete"})
+		w.sample("doplan_phases", float64(data.State.TotalPhases-data.State.CompletedPhases), openMetricsLabel{"status", "open"})
+	}
+
+	if data.Tasks != nil {
+		w.family("doplan_tasks", openMetricsGauge, "", "Tasks by state.")
+		w.sample("doplan_tasks", float64(data.Tasks.CompletedTasks), openMetricsLabel{"state", "completed"})
+		w.sample("doplan_tasks", float64(data.Tasks.PendingTasks), openMetricsLabel{"state", "pending"})
+	}
+
+	if data.GitHub != nil {
+		w.family("doplan_pull_requests", openMetricsGauge, "", "Pull requests by state.")
+		w.sample("doplan_pull_requests", float64(data.GitHub.OpenPRs), openMetricsLabel{"state", "open"})
+		w.sample("doplan_pull_requests", float64(data.GitHub.MergedPRs), openMetricsLabel{"state", "merged"})
+		w.sample("doplan_pull_requests", float64(data.GitHub.ClosedPRs), openMetricsLabel{"state", "closed"})
+
+		w.family("doplan_branches", openMetricsGauge, "", "Branches known to DoPlan.")
+		w.sample("doplan_branches", float64(data.GitHub.ActiveBranches), openMetricsLabel{"state", "active"})
+		w.sample("doplan_branches", float64(data.GitHub.TotalBranches-data.GitHub.ActiveBranches), openMetricsLabel{"state", "inactive"})
+
+		w.family("doplan_commits", openMetricsCounter, "", "Commits recorded across feature branches.")
+		w.sample("doplan_commits_total", float64(data.GitHub.TotalCommits))
+	}
+
+	if data.Checkpoints != nil {
+		w.family("doplan_checkpoints", openMetricsCounter, "", "Checkpoints created, by type.")
+		w.sample("doplan_checkpoints_total", float64(data.Checkpoints.ManualCheckpoints), openMetricsLabel{"type", "manual"})
+		w.sample("doplan_checkpoints_total", float64(data.Checkpoints.FeatureCheckpoints), openMetricsLabel{"type", "feature"})
+		w.sample("doplan_checkpoints_total", float64(data.Checkpoints.PhaseCheckpoints), openMetricsLabel{"type", "phase"})
+		if !data.Checkpoints.LastCheckpoint.IsZero() {
+			w.gauge("doplan_last_checkpoint_timestamp_seconds", "seconds", "When the latest checkpoint was created, as a Unix timestamp.",
+				float64(data.Checkpoints.LastCheckpoint.Unix()))
+		}
+	}
+}
+
+// OpenMetricsLoadFunc returns fresh metrics and collected data for one scrape
+type OpenMetricsLoadFunc func() (*StatisticsMetrics, *StatisticsData, error)
+
+// NewOpenMetricsHandler serves the statistics returned by load in the OpenMetrics
+// text format, loading them again on every scrape
+func NewOpenMetricsHandler(load OpenMetricsLoadFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		metrics, data, err := load()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to collect statistics: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := WriteOpenMetrics(&buf, metrics, data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", OpenMetricsContentType)
+		if r.Method == http.MethodGet {
+			w.Write(buf.Bytes())
+		}
+	})
+}
+
+func percentRatio(percent int) float64 {
+	return float64(percent) / 100
+}
+
+func formatOpenMetricsValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var openMetricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeOpenMetricsLabel(value string) string {
+	return openMetricsLabelEscaper.Replace(value)
+}
+
+var openMetricsHelpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeOpenMetricsHelp(help string) string {
+	return openMetricsHelpEscaper.Replace(help)
+}
@@ -0,0 +1,274 @@
+package statistics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	openMetricsMetaLine   = regexp.MustCompile(`^# (TYPE|UNIT|HELP) ([a-zA-Z_:][a-zA-Z0-9_:]*) (.*)$`)
+	openMetricsSampleLine = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{.*\})? (\S+)$`)
+	openMetricsLabelName  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// parseOpenMetrics checks text against the OpenMetrics text format and returns its
+// samples keyed by name and sorted labels, e.g. doplan_tasks{state="pending"}
+func parseOpenMetrics(t *testing.T, text string) map[string]float64 {
+	t.Helper()
+
+	samples := map[string]float64{}
+	types := map[string]string{}
+	family, eof := "", false
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		require.False(t, eof, "line %d: content after # EOF", line)
+
+		if text == "# EOF" {
+			eof = true
+			continue
+		}
+		if meta := openMetricsMetaLine.FindStringSubmatch(text); meta != nil {
+			kind, name, value := meta[1], meta[2], meta[3]
+			if kind == "TYPE" {
+				_, seen := types[name]
+				require.False(t, seen, "line %d: family %s declared twice", line, name)
+				require.Contains(t, []string{"gauge", "counter"}, value, "line %d", line)
+				types[name] = value
+				family = name
+				continue
+			}
+			require.Equal(t, family, name, "line %d: %s metadata outside its family", line, kind)
+			if kind == "UNIT" {
+				assert.True(t, strings.HasSuffix(name, "_"+value), "line %d: %s does not end with its unit", line, name)
+			}
+			continue
+		}
+
+		sample := openMetricsSampleLine.FindStringSubmatch(text)
+		require.NotNil(t, sample, "line %d: not a sample: %q", line, text)
+		name := sample[1]
+		expected := family
+		if types[family] == "counter" {
+			expected = family + "_total"
+		}
+		require.Equal(t, expected, name, "line %d: sample outside its family", line)
+
+		value, err := strconv.ParseFloat(sample[3], 64)
+		require.NoError(t, err, "line %d", line)
+
+		key := name + formatParsedLabels(parseOpenMetricsLabels(t, sample[2]))
+		_, dup := samples[key]
+		require.False(t, dup, "line %d: duplicate sample %s", line, key)
+		samples[key] = value
+	}
+	require.NoError(t, scanner.Err())
+	require.True(t, eof, "missing # EOF")
+	return samples
+}
+
+func parseOpenMetricsLabels(t *testing.T, text string) map[string]string {
+	t.Helper()
+
+	labels := map[string]string{}
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "{"), "}")
+	for text != "" {
+		eq := strings.Index(text, `="`)
+		require.Greater(t, eq, 0, "label without value in %q", text)
+		name := text[:eq]
+		require.Regexp(t, openMetricsLabelName, name)
+
+		var value strings.Builder
+		i := eq + 2
+		for ; i < len(text) && text[i] != '"'; i++ {
+			if text[i] == '\\' {
+				i++
+				require.Less(t, i, len(text), "dangling escape")
+				switch text[i] {
+				case 'n':
+					value.WriteByte('\n')
+				case '\\', '"':
+					value.WriteByte(text[i])
+				default:
+					t.Fatalf("invalid escape \\%c", text[i])
+				}
+				continue
+			}
+			value.WriteByte(text[i])
+		}
+		require.Less(t, i, len(text), "unterminated label value")
+		labels[name] = value.String()
+
+		text = text[i+1:]
+		if text != "" {
+			require.True(t, strings.HasPrefix(text, ","), "labels must be comma separated")
+			text = text[1:]
+		}
+	}
+	return labels
+}
+
+func formatParsedLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%q", name, labels[name])
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func openMetricsTestData() (*StatisticsMetrics, *StatisticsData) {
+	metrics := &StatisticsMetrics{
+		Velocity:   &VelocityMetrics{FeaturesPerDay: 0.5, TasksPerDay: 3, CommitsPerDay: 4.25, PRsPerWeek: 7},
+		Completion: &CompletionRates{Overall: 40, Tasks: 60, Phases: map[string]int{"01-phase": 100, "02-phase": 0}, Features: map[string]int{"01": 100, "02": 25}},
+		Quality:    &QualityMetrics{AvgPRReviewTime: 2, PRMergeRate: 75, AvgBranchLifetime: 1.5, CheckpointFrequency: 3},
+		Testing: &TestingMetrics{OverallCoverage: 82.5, Packages: []PackageCoverageMetric{
+			{Name: "internal/statistics", Coverage: 90},
+			{Name: `odd"pkg\name`, Coverage: 50},
+		}},
+		Burndown: &BurnCharts{
+			Project: &BurnSeries{Name: "Project", Unit: BurnUnitTasks, Points: []BurnPoint{{Scope: 10, Completed: 6, Remaining: 4}}},
+			Phases: []*BurnSeries{
+				{ID: "01-phase", Name: "Foundation", Unit: BurnUnitTasks, Points: []BurnPoint{{Scope: 6, Completed: 6, Remaining: 0}}},
+				{ID: "02-phase", Name: "Launch", Unit: BurnUnitFeatures, Points: []BurnPoint{{Scope: 1, Completed: 0, Remaining: 1}}},
+			},
+		},
+		Tests: &TestResultMetrics{
+			Latest: &TestRunSummary{Total: 6, Passed: 4, Failed: 1, Skipped: 1, Duration: 12.5},
+			Flaky:  []FlakyTest{{Package: "auth", Name: "TestLogin"}},
+		},
+		CalculatedAt: time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC),
+	}
+	data := &StatisticsData{
+		State:       &StateData{TotalPhases: 2, CompletedPhases: 1, TotalFeatures: 2, CompletedFeatures: 1, PendingFeatures: 1},
+		Tasks:       &TaskStats{TotalTasks: 10, CompletedTasks: 6, PendingTasks: 4},
+		GitHub:      &GitHubStats{TotalBranches: 3, ActiveBranches: 2, TotalCommits: 42, OpenPRs: 1, MergedPRs: 3, ClosedPRs: 1},
+		Checkpoints: &CheckpointStats{ManualCheckpoints: 2, FeatureCheckpoints: 5, PhaseCheckpoints: 1},
+	}
+	return metrics, data
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	metrics, data := openMetricsTestData()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteOpenMetrics(&buf, metrics, data))
+	samples := parseOpenMetrics(t, buf.String())
+
+	expected := map[string]float64{
+		`doplan_project_progress_ratio`:                                               0.4,
+		`doplan_tasks_completion_ratio`:                                               0.6,
+		`doplan_phase_progress_ratio{phase="01-phase"}`:                               1,
+		`doplan_feature_progress_ratio{feature="02"}`:                                 0.25,
+		`doplan_velocity_per_day{kind="commits"}`:                                     4.25,
+		`doplan_velocity_per_day{kind="pull_requests"}`:                               1,
+		`doplan_project_work_items{state="remaining",unit="tasks"}`:                   4,
+		`doplan_phase_work_items{phase="02-phase",state="remaining",unit="features"}`: 1,
+		`doplan_phase_work_items{phase="01-phase",state="completed",unit="tasks"}`:    6,
+		`doplan_pull_request_merge_ratio`:                                             0.75,
+		`doplan_pull_request_review_time_seconds`:                                     7200,
+		`doplan_branch_lifetime_seconds`:                                              129600,
+		`doplan_coverage_ratio`:                                                       0.825,
+		`doplan_package_coverage_ratio{package="internal/statistics"}`:                0.9,
+		`doplan_package_coverage_ratio{package="odd\"pkg\\name"}`:                     0.5,
+		`doplan_tests{status="fail"}`:                                                 1,
+		`doplan_test_duration_seconds`:                                                12.5,
+		`doplan_flaky_tests`:                                                          1,
+		`doplan_features{status="pending"}`:                                           1,
+		`doplan_phases{status="open"}`:                                                1,
+		`doplan_tasks{state="completed"}`:                                             6,
+		`doplan_pull_requests{state="merged"}`:                                        3,
+		`doplan_branches{state="inactive"}`:                                           1,
+		`doplan_commits_total`:                                                        42,
+		`doplan_checkpoints_total{type="feature"}`:                                    5,
+		`doplan_stats_calculated_timestamp_seconds`:                                   float64(metrics.CalculatedAt.Unix()),
+	}
+	for key, value := range expected {
+		require.Contains(t, samples, key)
+		assert.InDelta(t, value, samples[key], 1e-9, key)
+	}
+	assert.NotContains(t, buf.String(), "doplan_last_checkpoint_timestamp_seconds", "no checkpoint time without checkpoints")
+}
+
+func TestWriteOpenMetrics_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteOpenMetrics(&buf, nil, nil))
+	assert.Equal(t, "# EOF\n", buf.String())
+
+	// Filtered metrics only expose what was calculated
+	buf.Reset()
+	require.NoError(t, WriteOpenMetrics(&buf, &StatisticsMetrics{Velocity: &VelocityMetrics{}}, nil))
+	samples := parseOpenMetrics(t, buf.String())
+	assert.Len(t, samples, 4)
+}
+
+func TestFormatOpenMetricsValue(t *testing.T) {
+	assert.Equal(t, "0.25", formatOpenMetricsValue(0.25))
+	assert.Equal(t, "42", formatOpenMetricsValue(42))
+	assert.Equal(t, "1e+21", formatOpenMetricsValue(1e21))
+}
+
+func TestOpenMetricsHandler(t *testing.T) {
+	metrics, data := openMetricsTestData()
+	scrapes := 0
+	handler := NewOpenMetricsHandler(func() (*StatisticsMetrics, *StatisticsData, error) {
+		scrapes++
+		return metrics, data, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, OpenMetricsContentType, rec.Header().Get("Content-Type"))
+		assert.Contains(t, parseOpenMetrics(t, rec.Body.String()), "doplan_commits_total")
+	}
+	assert.Equal(t, 2, scrapes, "statistics are loaded on every scrape")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	failing := NewOpenMetricsHandler(func() (*StatisticsMetrics, *StatisticsData, error) {
+		return nil, nil, errors.New("state missing")
+	})
+	rec = httptest.NewRecorder()
+	failing.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "state missing")
+}
+
+func TestReporter_ReportOpenMetrics(t *testing.T) {
+	metrics, data := openMetricsTestData()
+	path := filepath.Join(t.TempDir(), "doplan.prom")
+
+	require.NoError(t, NewReporter().ReportOpenMetrics(metrics, data, path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, parseOpenMetrics(t, string(content)), `doplan_tasks{state="pending"}`)
+	assert.NoFileExists(t, path+".tmp")
+}
@@ -1,8 +1,10 @@
 package statistics
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"html"
 	"math"
 	"os"
 	"sort"
@@ -33,7 +35,13 @@ func (r *Reporter) ReportCLI(metrics *StatisticsMetrics) error {
 	r.printTimeCLI(metrics.Time)
 	r.printQualityCLI(metrics.Quality)
 	r.printTestingCLI(metrics.Testing, animations)
+	r.printTestsCLI(metrics.Tests)
 	r.printTrendsCLI(metrics.Trends)
+	r.printBurndownCLI(metrics.Burndown)
+	r.printForecastCLI(metrics.Forecast)
+	r.printFlowCLI(metrics.Flow)
+	r.printHotspotsCLI(metrics.Hotspots)
+	r.printEstimatesCLI(metrics.Estimates)
 
 	return nil
 }
@@ -146,6 +154,185 @@ func (r *Reporter) ReportMarkdown(metrics *StatisticsMetrics, path string) error
 			}
 			sb.WriteString("\n")
 		}
+
+		if len(metrics.Testing.Features) > 0 {
+			sb.WriteString("### Coverage by Feature\n\n")
+			sb.WriteString("| Feature | Coverage |\n|---------|----------|\n")
+			for _, feature := range metrics.Testing.Features {
+				sb.WriteString(fmt.Sprintf("| %s | %s %.1f%% |\n", feature.Name, renderMarkdownProgressBar(feature.Coverage), feature.Coverage))
+			}
+			sb.WriteString("\n")
+		}
+
+		if metrics.Testing.Trend != "" {
+			sb.WriteString("### Changes Since Last Snapshot\n\n")
+			sb.WriteString(fmt.Sprintf("- **Overall:** %s (%s)\n\n", formatCoverageChange(metrics.Testing.Change), metrics.Testing.Trend))
+			if len(metrics.Testing.PackageDiff) > 0 {
+				sb.WriteString("| Package | Previous | Current | Change |\n|---------|----------|---------|--------|\n")
+				for _, diff := range metrics.Testing.PackageDiff {
+					previous, current := coverageDiffValues(diff)
+					sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", diff.Name, previous, current, formatCoverageChange(diff.Change)))
+				}
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	// Test Results
+	if tests := metrics.Tests; tests != nil && tests.Latest != nil {
+		sb.WriteString("## Test Results\n\n")
+		sb.WriteString(fmt.Sprintf("- **Latest:** %s\n", formatTestSummary(tests.Latest)))
+		sb.WriteString(fmt.Sprintf("- **Runs recorded:** %d\n\n", tests.Runs))
+
+		if len(tests.Failures) > 0 {
+			sb.WriteString("### Failures\n\n")
+			sb.WriteString("| Test | Features |\n|------|----------|\n")
+			for _, failure := range tests.Failures {
+				sb.WriteString(fmt.Sprintf("| %s | %s |\n", formatTestName(failure.Package, failure.Name), formatTestFeatures(failure.Features)))
+			}
+			sb.WriteString("\n")
+		}
+		if len(tests.Flaky) > 0 {
+			sb.WriteString("### Flaky Tests\n\n")
+			sb.WriteString("| Test | Commits | Passes | Failures |\n|------|---------|--------|----------|\n")
+			for _, flaky := range tests.Flaky {
+				sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d |\n", formatTestName(flaky.Package, flaky.Name), flaky.Commits, flaky.Passes, flaky.Failures))
+			}
+			sb.WriteString("\n")
+		}
+		if len(tests.Slowest) > 0 {
+			sb.WriteString("### Slowest Tests\n\n")
+			sb.WriteString("| Test | Duration |\n|------|----------|\n")
+			for _, timing := range tests.Slowest {
+				sb.WriteString(fmt.Sprintf("| %s | %s |\n", formatTestName(timing.Package, timing.Name), formatTestDuration(timing.Duration)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	// Burndown
+	if metrics.Burndown != nil {
+		sb.WriteString("## Burndown\n\n")
+		for _, series := range burnSeriesList(metrics.Burndown) {
+			writeBurnTableMarkdown(&sb, series)
+		}
+	}
+
+	// Forecast
+	if metrics.Forecast != nil {
+		sb.WriteString("## Forecast\n\n")
+		sb.WriteString(fmt.Sprintf("*%d simulations of the last %d days of throughput; at risk below %d%% confidence*\n\n",
+			metrics.Forecast.Simulations, metrics.Forecast.WindowDays, metrics.Forecast.Confidence))
+		sb.WriteString("| Scope | Remaining | P50 | P85 | P95 | Target | On target | Calibrated |\n")
+		sb.WriteString("|-------|-----------|-----|-----|-----|--------|-----------|------------|\n")
+		for _, forecast := range forecastList(metrics.Forecast) {
+			p50, p85, p95 := forecastDates(forecast)
+			sb.WriteString(fmt.Sprintf("| %s | %d %s | %s | %s | %s | %s | %s | %s |\n",
+				forecast.Name, forecast.Remaining, forecast.Unit, p50, p85, p95, formatForecastDate(forecast.TargetDate), forecastTargetStatus(forecast), formatCalibratedDate(forecast)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Flow
+	if metrics.Flow != nil {
+		sb.WriteString("## Flow\n\n")
+		sb.WriteString("| Measure | Features | Mean | P50 | P85 | P95 | Max |\n")
+		sb.WriteString("|---------|----------|------|-----|-----|-----|-----|\n")
+		for _, row := range flowSummaryRows(metrics.Flow) {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %s | %s |\n", row.label, row.summary.Count,
+				formatFlowDays(row.summary.Mean), formatFlowDays(row.summary.P50), formatFlowDays(row.summary.P85), formatFlowDays(row.summary.P95), formatFlowDays(row.summary.Max)))
+		}
+		sb.WriteString("\n")
+
+		if len(metrics.Flow.Outliers) > 0 {
+			sb.WriteString("### Outliers\n\n")
+			for _, outlier := range metrics.Flow.Outliers {
+				sb.WriteString(fmt.Sprintf("- **%s** (%s): %s, %s\n", outlier.Name, outlier.ID, formatFlowDays(outlier.Days), outlier.Reason))
+			}
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("### Features\n\n")
+		sb.WriteString("| Feature | Status | Lead time | Cycle time | Age | Blocked | Time in status |\n")
+		sb.WriteString("|---------|--------|-----------|------------|-----|---------|----------------|\n")
+		for _, flow := range metrics.Flow.Features {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n", flow.Name, flow.Status,
+				formatFlowDuration(flow.LeadTime, flow.Done()), formatFlowDuration(flow.CycleTime, flow.Done() && !flow.StartedAt.IsZero()),
+				formatFlowDuration(flow.Age, flow.Age > 0), formatFlowDays(flow.BlockedTime), formatTimeInStatus(flow.TimeInStatus)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Hotspots
+	if hotspots := metrics.Hotspots; hotspots != nil {
+		sb.WriteString("## Hotspots\n\n")
+		sb.WriteString(fmt.Sprintf("*%s*\n\n", formatHotspotWindow(hotspots)))
+
+		if len(hotspots.Files) > 0 {
+			sb.WriteString("### Files\n\n")
+			sb.WriteString("| File | Commits | Lines changed | Authors | Top author | Features |\n")
+			sb.WriteString("|------|---------|---------------|---------|------------|----------|\n")
+			for _, file := range hotspots.Files {
+				sb.WriteString(fmt.Sprintf("| %s | %d | %s | %d | %s | %s |\n", file.Path, file.Commits,
+					formatChurnLines(file.ChurnStats), file.Authors, formatTopAuthorMarkdown(file.ChurnStats), formatTestFeatures(file.Features)))
+			}
+			sb.WriteString("\n")
+		}
+		if len(hotspots.Coupling) > 0 {
+			sb.WriteString("### Change Coupling\n\n")
+			sb.WriteString("| File | Changes with | Together | Degree |\n")
+			sb.WriteString("|------|--------------|----------|--------|\n")
+			for _, pair := range hotspots.Coupling {
+				sb.WriteString(fmt.Sprintf("| %s | %s | %d | %.0f%% |\n", pair.A, pair.B, pair.CoChanges, pair.Degree))
+			}
+			sb.WriteString("\n")
+		}
+		for _, section := range hotspotAreaSections(hotspots) {
+			sb.WriteString(fmt.Sprintf("### %s\n\n", section.title))
+			sb.WriteString(fmt.Sprintf("| %s | Files | Commits | Lines changed | Authors | Top author |\n", section.label))
+			sb.WriteString(fmt.Sprintf("|%s|-------|---------|---------------|---------|------------|\n", strings.Repeat("-", len(section.label)+2)))
+			for _, area := range section.areas {
+				sb.WriteString(fmt.Sprintf("| %s | %d | %d | %s | %d | %s |\n", area.Name, area.Files, area.Commits,
+					formatChurnLines(area.ChurnStats), area.Authors, formatTopAuthorMarkdown(area.ChurnStats)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	// Estimates
+	if estimates := metrics.Estimates; estimates != nil {
+		sb.WriteString("## Estimates\n\n")
+		sb.WriteString(fmt.Sprintf("*%s*\n\n", formatEstimateSummary(estimates)))
+
+		sb.WriteString("| Feature | Type | Estimated | Actual | Error |\n")
+		sb.WriteString("|---------|------|-----------|--------|-------|\n")
+		for _, estimate := range estimates.Features {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", estimate.Name, estimate.Type,
+				formatFlowDays(estimate.Estimated), formatEstimateActual(estimate), formatEstimateError(estimate)))
+		}
+		sb.WriteString("\n")
+
+		for _, section := range estimateGroupSections(estimates) {
+			sb.WriteString(fmt.Sprintf("### %s\n\n", section.title))
+			sb.WriteString(fmt.Sprintf("| %s | Finished | Estimated | Actual | Factor | Bias |\n", section.label))
+			sb.WriteString(fmt.Sprintf("|%s|----------|-----------|--------|--------|------|\n", strings.Repeat("-", len(section.label)+2)))
+			for _, group := range section.groups {
+				sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %s |\n", group.Name, group.Finished,
+					formatFlowDays(group.Estimated), formatFlowDays(group.Actual), formatEstimateFactor(group.Factor), formatEstimatePercent(group.Bias)))
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(estimates.Suggestions) > 0 {
+			sb.WriteString("### Suggested Target Dates\n\n")
+			sb.WriteString("| Feature | Target | Suggested | Slip |\n")
+			sb.WriteString("|---------|--------|-----------|------|\n")
+			for _, suggestion := range estimates.Suggestions {
+				sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", suggestion.Name, formatForecastDate(suggestion.TargetDate),
+					formatForecastDate(suggestion.SuggestedDate), formatEstimateSlip(suggestion)))
+			}
+			sb.WriteString("\n")
+		}
 	}
 
 	content := sb.String()
@@ -176,6 +363,7 @@ func (r *Reporter) ReportHTML(metrics *StatisticsMetrics, path string) error {
 	sb.WriteString("table { border-collapse: collapse; width: 100%; margin: 20px 0; }\n")
 	sb.WriteString("th, td { border: 1px solid #ddd; padding: 8px; text-align: left; }\n")
 	sb.WriteString("th { background-color: #f2f2f2; }\n")
+	sb.WriteString(".at-risk { color: #dc2626; }\n")
 	sb.WriteString(".progress-group { margin: 12px 0; }\n")
 	sb.WriteString(".progress-label { font-weight: bold; display: block; margin-bottom: 4px; }\n")
 	sb.WriteString(".progress-bar { background: #e5e7eb; border-radius: 9999px; overflow: hidden; height: 16px; }\n")
@@ -251,6 +439,197 @@ func (r *Reporter) ReportHTML(metrics *StatisticsMetrics, path string) error {
 			}
 			sb.WriteString("</table>\n")
 		}
+
+		if len(metrics.Testing.Features) > 0 {
+			sb.WriteString("<h3>Coverage by Feature</h3>\n")
+			sb.WriteString("<table><tr><th>Feature</th><th>Coverage</th></tr>\n")
+			for _, feature := range metrics.Testing.Features {
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s %.1f%%</td></tr>\n", html.EscapeString(feature.Name), renderHTMLProgressBarInline(feature.Coverage), feature.Coverage))
+			}
+			sb.WriteString("</table>\n")
+		}
+
+		if metrics.Testing.Trend != "" {
+			sb.WriteString("<h3>Changes Since Last Snapshot</h3>\n")
+			sb.WriteString(fmt.Sprintf("<p>Overall: %s (%s)</p>\n", formatCoverageChange(metrics.Testing.Change), metrics.Testing.Trend))
+			if len(metrics.Testing.PackageDiff) > 0 {
+				sb.WriteString("<table><tr><th>Package</th><th>Previous</th><th>Current</th><th>Change</th></tr>\n")
+				for _, diff := range metrics.Testing.PackageDiff {
+					previous, current := coverageDiffValues(diff)
+					class := ""
+					if diff.Change < 0 {
+						class = " class=\"at-risk\""
+					}
+					sb.WriteString(fmt.Sprintf("<tr%s><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n", class, html.EscapeString(diff.Name), previous, current, formatCoverageChange(diff.Change)))
+				}
+				sb.WriteString("</table>\n")
+			}
+		}
+	}
+
+	// Test Results
+	if tests := metrics.Tests; tests != nil && tests.Latest != nil {
+		sb.WriteString("<h2>Test Results</h2>\n")
+		sb.WriteString(fmt.Sprintf("<p>Latest: %s. Runs recorded: %d.</p>\n", html.EscapeString(formatTestSummary(tests.Latest)), tests.Runs))
+
+		if len(tests.Failures) > 0 {
+			sb.WriteString("<h3>Failures</h3>\n")
+			sb.WriteString("<table><tr><th>Test</th><th>Features</th><th>Output</th></tr>\n")
+			for _, failure := range tests.Failures {
+				sb.WriteString(fmt.Sprintf("<tr class=\"at-risk\"><td>%s</td><td>%s</td><td><pre>%s</pre></td></tr>\n",
+					html.EscapeString(formatTestName(failure.Package, failure.Name)), html.EscapeString(formatTestFeatures(failure.Features)), html.EscapeString(failure.Message)))
+			}
+			sb.WriteString("</table>\n")
+		}
+		if len(tests.Flaky) > 0 {
+			sb.WriteString("<h3>Flaky Tests</h3>\n")
+			sb.WriteString("<table><tr><th>Test</th><th>Commits</th><th>Passes</th><th>Failures</th><th>Last seen</th></tr>\n")
+			for _, flaky := range tests.Flaky {
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%s</td></tr>\n",
+					html.EscapeString(formatTestName(flaky.Package, flaky.Name)), flaky.Commits, flaky.Passes, flaky.Failures, flaky.LastSeen.Format("2006-01-02")))
+			}
+			sb.WriteString("</table>\n")
+		}
+		if len(tests.Slowest) > 0 {
+			sb.WriteString("<h3>Slowest Tests</h3>\n")
+			sb.WriteString("<table><tr><th>Test</th><th>Duration</th></tr>\n")
+			for _, timing := range tests.Slowest {
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td></tr>\n", html.EscapeString(formatTestName(timing.Package, timing.Name)), formatTestDuration(timing.Duration)))
+			}
+			sb.WriteString("</table>\n")
+		}
+	}
+
+	// Burndown
+	if metrics.Burndown != nil {
+		sb.WriteString("<h2>Burndown</h2>\n")
+		for _, series := range burnSeriesList(metrics.Burndown) {
+			sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", html.EscapeString(series.Name)))
+			sb.WriteString(BurnChartSVG(series, 640, 240))
+		}
+	}
+
+	// Forecast
+	if metrics.Forecast != nil {
+		sb.WriteString("<h2>Forecast</h2>\n")
+		sb.WriteString(fmt.Sprintf("<p><em>%d simulations of the last %d days of throughput; at risk below %d%% confidence</em></p>\n",
+			metrics.Forecast.Simulations, metrics.Forecast.WindowDays, metrics.Forecast.Confidence))
+		sb.WriteString("<table><tr><th>Scope</th><th>Remaining</th><th>P50</th><th>P85</th><th>P95</th><th>Target</th><th>On target</th><th>Calibrated</th></tr>\n")
+		for _, forecast := range forecastList(metrics.Forecast) {
+			p50, p85, p95 := forecastDates(forecast)
+			status := html.EscapeString(forecastTargetStatus(forecast))
+			if forecast.AtRisk {
+				status = "<strong class=\"at-risk\">" + status + "</strong>"
+			}
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d %s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(forecast.Name), forecast.Remaining, forecast.Unit, p50, p85, p95, formatForecastDate(forecast.TargetDate), status, formatCalibratedDate(forecast)))
+		}
+		sb.WriteString("</table>\n")
+	}
+
+	// Flow
+	if metrics.Flow != nil {
+		sb.WriteString("<h2>Flow</h2>\n")
+		sb.WriteString("<table><tr><th>Measure</th><th>Features</th><th>Mean</th><th>P50</th><th>P85</th><th>P95</th><th>Max</th></tr>\n")
+		for _, row := range flowSummaryRows(metrics.Flow) {
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n", row.label, row.summary.Count,
+				formatFlowDays(row.summary.Mean), formatFlowDays(row.summary.P50), formatFlowDays(row.summary.P85), formatFlowDays(row.summary.P95), formatFlowDays(row.summary.Max)))
+		}
+		sb.WriteString("</table>\n")
+
+		if len(metrics.Flow.Outliers) > 0 {
+			sb.WriteString("<h3>Outliers</h3>\n<ul>\n")
+			for _, outlier := range metrics.Flow.Outliers {
+				sb.WriteString(fmt.Sprintf("<li class=\"at-risk\"><strong>%s</strong> (%s): %s, %s</li>\n",
+					html.EscapeString(outlier.Name), html.EscapeString(outlier.ID), formatFlowDays(outlier.Days), html.EscapeString(outlier.Reason)))
+			}
+			sb.WriteString("</ul>\n")
+		}
+
+		sb.WriteString("<table><tr><th>Feature</th><th>Status</th><th>Lead time</th><th>Cycle time</th><th>Age</th><th>Blocked</th><th>Time in status</th></tr>\n")
+		for _, flow := range metrics.Flow.Features {
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(flow.Name), html.EscapeString(flow.Status),
+				formatFlowDuration(flow.LeadTime, flow.Done()), formatFlowDuration(flow.CycleTime, flow.Done() && !flow.StartedAt.IsZero()),
+				formatFlowDuration(flow.Age, flow.Age > 0), formatFlowDays(flow.BlockedTime), html.EscapeString(formatTimeInStatus(flow.TimeInStatus))))
+		}
+		sb.WriteString("</table>\n")
+	}
+
+	// Hotspots
+	if hotspots := metrics.Hotspots; hotspots != nil {
+		sb.WriteString("<h2>Hotspots</h2>\n")
+		sb.WriteString(fmt.Sprintf("<p><em>%s</em></p>\n", html.EscapeString(formatHotspotWindow(hotspots))))
+
+		if len(hotspots.Files) > 0 {
+			sb.WriteString("<h3>Files</h3>\n")
+			sb.WriteString("<table><tr><th>File</th><th>Commits</th><th>Lines changed</th><th>Authors</th><th>Top author</th><th>Features</th></tr>\n")
+			for _, file := range hotspots.Files {
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%s</td><td>%d</td>%s<td>%s</td></tr>\n",
+					html.EscapeString(file.Path), file.Commits, formatChurnLines(file.ChurnStats), file.Authors,
+					topAuthorCell(file.ChurnStats), html.EscapeString(formatTestFeatures(file.Features))))
+			}
+			sb.WriteString("</table>\n")
+		}
+		if len(hotspots.Coupling) > 0 {
+			sb.WriteString("<h3>Change Coupling</h3>\n")
+			sb.WriteString("<table><tr><th>File</th><th>Changes with</th><th>Together</th><th>Degree</th></tr>\n")
+			for _, pair := range hotspots.Coupling {
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%d</td><td>%.0f%%</td></tr>\n",
+					html.EscapeString(pair.A), html.EscapeString(pair.B), pair.CoChanges, pair.Degree))
+			}
+			sb.WriteString("</table>\n")
+		}
+		for _, section := range hotspotAreaSections(hotspots) {
+			sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", section.title))
+			sb.WriteString(fmt.Sprintf("<table><tr><th>%s</th><th>Files</th><th>Commits</th><th>Lines changed</th><th>Authors</th><th>Top author</th></tr>\n", section.label))
+			for _, area := range section.areas {
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%d</td><td>%s</td><td>%d</td>%s</tr>\n",
+					html.EscapeString(area.Name), area.Files, area.Commits, formatChurnLines(area.ChurnStats), area.Authors, topAuthorCell(area.ChurnStats)))
+			}
+			sb.WriteString("</table>\n")
+		}
+	}
+
+	// Estimates
+	if estimates := metrics.Estimates; estimates != nil {
+		sb.WriteString("<h2>Estimates</h2>\n")
+		sb.WriteString(fmt.Sprintf("<p><em>%s</em></p>\n", html.EscapeString(formatEstimateSummary(estimates))))
+
+		sb.WriteString("<table><tr><th>Feature</th><th>Type</th><th>Estimated</th><th>Actual</th><th>Error</th></tr>\n")
+		for _, estimate := range estimates.Features {
+			errorCell := "<td>" + formatEstimateError(estimate) + "</td>"
+			if estimate.Done && estimate.Error > EstimateTolerance {
+				errorCell = "<td class=\"at-risk\">" + formatEstimateError(estimate) + "</td>"
+			}
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td>%s</tr>\n", html.EscapeString(estimate.Name),
+				html.EscapeString(estimate.Type), formatFlowDays(estimate.Estimated), html.EscapeString(formatEstimateActual(estimate)), errorCell))
+		}
+		sb.WriteString("</table>\n")
+
+		for _, section := range estimateGroupSections(estimates) {
+			sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", section.title))
+			sb.WriteString(fmt.Sprintf("<table><tr><th>%s</th><th>Finished</th><th>Estimated</th><th>Actual</th><th>Factor</th><th>Bias</th></tr>\n", section.label))
+			for _, group := range section.groups {
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n", html.EscapeString(group.Name), group.Finished,
+					formatFlowDays(group.Estimated), formatFlowDays(group.Actual), formatEstimateFactor(group.Factor), formatEstimatePercent(group.Bias)))
+			}
+			sb.WriteString("</table>\n")
+		}
+
+		if len(estimates.Suggestions) > 0 {
+			sb.WriteString("<h3>Suggested Target Dates</h3>\n")
+			sb.WriteString("<table><tr><th>Feature</th><th>Target</th><th>Suggested</th><th>Slip</th></tr>\n")
+			for _, suggestion := range estimates.Suggestions {
+				slipCell := "<td>" + formatEstimateSlip(suggestion) + "</td>"
+				if suggestion.Slip() > 0 {
+					slipCell = "<td class=\"at-risk\">" + formatEstimateSlip(suggestion) + "</td>"
+				}
+				sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td>%s</tr>\n", html.EscapeString(suggestion.Name),
+					formatForecastDate(suggestion.TargetDate), formatForecastDate(suggestion.SuggestedDate), slipCell))
+			}
+			sb.WriteString("</table>\n")
+		}
 	}
 
 	sb.WriteString("</body>\n</html>\n")
@@ -269,8 +648,38 @@ func (r *Reporter) ReportHTML(metrics *StatisticsMetrics, path string) error {
 	return nil
 }
 
+// ReportOpenMetrics outputs metrics and the collected counts in data in the OpenMetrics
+// text format. Files are replaced atomically so a textfile collector never reads a
+// partial exposition.
+func (r *Reporter) ReportOpenMetrics(metrics *StatisticsMetrics, data *StatisticsData, path string) error {
+	var buf bytes.Buffer
+	if err := WriteOpenMetrics(&buf, metrics, data); err != nil {
+		return fmt.Errorf("failed to format OpenMetrics: %w", err)
+	}
+
+	if path != "" {
+		tmpPath := path + ".tmp"
+		if err := os.WriteFile(tmpPath, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write OpenMetrics file: %w", err)
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			return fmt.Errorf("failed to write OpenMetrics file: %w", err)
+		}
+		color.Green("✅ Statistics exported to: %s\n", path)
+	} else {
+		fmt.Print(buf.String())
+	}
+
+	return nil
+}
+
 const cliProgressBarWidth = 24
 
+const (
+	cliBurnChartWidth  = 48
+	cliBurnChartHeight = 8
+)
+
 func (r *Reporter) printVelocityCLI(velocity *VelocityMetrics) {
 	if velocity == nil {
 		return
@@ -342,6 +751,9 @@ func (r *Reporter) printQualityCLI(quality *QualityMetrics) {
 	fmt.Println(color.YellowString("Quality Metrics:"))
 	fmt.Printf("  PR merge rate:        %.1f%%\n", quality.PRMergeRate)
 	fmt.Printf("  Checkpoint frequency: %.1f per week\n", quality.CheckpointFrequency)
+	if quality.AvgPRReviewTime > 0 {
+		fmt.Printf("  Avg PR review time:   %.1f hours\n", quality.AvgPRReviewTime)
+	}
 	if quality.AvgBranchLifetime > 0 {
 		fmt.Printf("  Avg branch lifetime:  %.1f days\n", quality.AvgBranchLifetime)
 	}
@@ -367,6 +779,56 @@ func (r *Reporter) printTestingCLI(testing *TestingMetrics, animations bool) {
 		}
 	}
 
+	if len(testing.Features) > 0 {
+		fmt.Println("  Features:")
+		for _, feature := range testing.Features {
+			r.printCLIProgressBar(feature.Name, feature.Coverage, 4, animations)
+		}
+	}
+
+	if testing.Trend != "" {
+		fmt.Printf("  Since last snapshot: %s (%s)\n", formatCoverageChange(testing.Change), testing.Trend)
+		maxDiffs := minInt(len(testing.PackageDiff), 5)
+		for _, diff := range testing.PackageDiff[:maxDiffs] {
+			previous, current := coverageDiffValues(diff)
+			fmt.Printf("    %s: %s → %s (%s)\n", diff.Name, previous, current, formatCoverageChange(diff.Change))
+		}
+		if len(testing.PackageDiff) > maxDiffs {
+			fmt.Printf("    ...and %d more changed packages\n", len(testing.PackageDiff)-maxDiffs)
+		}
+	}
+
+	fmt.Println()
+}
+
+func (r *Reporter) printTestsCLI(tests *TestResultMetrics) {
+	if tests == nil || tests.Latest == nil {
+		return
+	}
+
+	fmt.Println(color.YellowString("Test Results:"))
+	fmt.Printf("  Latest: %s\n", formatTestSummary(tests.Latest))
+	for _, failure := range tests.Failures {
+		line := fmt.Sprintf("  ✗ %s", formatTestName(failure.Package, failure.Name))
+		if len(failure.Features) > 0 {
+			line += fmt.Sprintf(" (%s)", formatTestFeatures(failure.Features))
+		}
+		fmt.Println(color.RedString(line))
+	}
+	if len(tests.Flaky) > 0 {
+		fmt.Println("  Flaky:")
+		for _, flaky := range tests.Flaky {
+			fmt.Printf("    %s: %d passes, %d failures on %d commit(s)\n", formatTestName(flaky.Package, flaky.Name), flaky.Passes, flaky.Failures, flaky.Commits)
+		}
+	}
+	if len(tests.Slowest) > 0 {
+		fmt.Println("  Slowest:")
+		maxTests := minInt(len(tests.Slowest), 5)
+		for _, timing := range tests.Slowest[:maxTests] {
+			fmt.Printf("    %-8s %s\n", formatTestDuration(timing.Duration), formatTestName(timing.Package, timing.Name))
+		}
+	}
+
 	fmt.Println()
 }
 
@@ -388,6 +850,126 @@ func (r *Reporter) printTrendsCLI(trends *Trends) {
 	fmt.Println()
 }
 
+func (r *Reporter) printBurndownCLI(charts *BurnCharts) {
+	if charts == nil {
+		return
+	}
+
+	fmt.Println(color.YellowString("Burndown:"))
+	for _, series := range burnSeriesList(charts) {
+		fmt.Printf("  %s\n", series.Name)
+		for _, line := range strings.Split(RenderBurnChart(series, cliBurnChartWidth, cliBurnChartHeight), "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+		fmt.Println()
+	}
+}
+
+func (r *Reporter) printForecastCLI(forecasts *Forecasts) {
+	if forecasts == nil {
+		return
+	}
+
+	fmt.Println(color.YellowString("Forecast:"))
+	fmt.Printf("  %d simulations of the last %d days of throughput\n", forecasts.Simulations, forecasts.WindowDays)
+	for _, forecast := range forecastList(forecasts) {
+		p50, p85, p95 := forecastDates(forecast)
+		fmt.Printf("  %s (%d %s left)\n", forecast.Name, forecast.Remaining, forecast.Unit)
+		fmt.Printf("    P50 %s  P85 %s  P95 %s\n", p50, p85, p95)
+		if !forecast.TargetDate.IsZero() {
+			line := fmt.Sprintf("    Target %s: %s", formatForecastDate(forecast.TargetDate), forecastTargetStatus(forecast))
+			if forecast.AtRisk {
+				line = color.RedString("%s (below %d%% confidence)", line, forecasts.Confidence)
+			}
+			fmt.Println(line)
+		}
+		if !forecast.CalibratedDate.IsZero() {
+			fmt.Printf("    Calibrated %s\n", formatCalibratedDate(forecast))
+		}
+	}
+	fmt.Println()
+}
+
+func (r *Reporter) printFlowCLI(flow *FlowMetrics) {
+	if flow == nil {
+		return
+	}
+
+	fmt.Println(color.YellowString("Flow:"))
+	for _, row := range flowSummaryRows(flow) {
+		fmt.Printf("  %-11s P50 %s  P85 %s  P95 %s  (%d features)\n", row.label+":",
+			formatFlowDays(row.summary.P50), formatFlowDays(row.summary.P85), formatFlowDays(row.summary.P95), row.summary.Count)
+	}
+	if flow.LeadTime == nil && flow.CycleTime == nil {
+		fmt.Println("  No finished features to time yet")
+	}
+	for _, outlier := range flow.Outliers {
+		fmt.Println(color.RedString("  ⚠ %s: %s, %s", outlier.Name, formatFlowDays(outlier.Days), outlier.Reason))
+	}
+	fmt.Println()
+}
+
+func (r *Reporter) printHotspotsCLI(hotspots *HotspotMetrics) {
+	if hotspots == nil {
+		return
+	}
+
+	fmt.Println(color.YellowString("Hotspots:"))
+	fmt.Printf("  %s\n", formatHotspotWindow(hotspots))
+	maxFiles := minInt(len(hotspots.Files), 10)
+	for _, file := range hotspots.Files[:maxFiles] {
+		line := fmt.Sprintf("  %3d commits %12s  %s", file.Commits, formatChurnLines(file.ChurnStats), file.Path)
+		if len(file.Features) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(file.Features, ", "))
+		}
+		fmt.Println(line)
+	}
+	if len(hotspots.Coupling) > 0 {
+		fmt.Println("  Changed together:")
+		for _, pair := range hotspots.Coupling {
+			fmt.Printf("    %s ↔ %s: %d times (%.0f%%)\n", pair.A, pair.B, pair.CoChanges, pair.Degree)
+		}
+	}
+	for _, section := range hotspotAreaSections(hotspots) {
+		fmt.Printf("  %s:\n", section.title)
+		for _, area := range section.areas {
+			fmt.Printf("    %s: %d commits, %s lines, top author %s\n", area.Name, area.Commits, formatChurnLines(area.ChurnStats), formatTopAuthor(area.ChurnStats))
+		}
+	}
+	for _, area := range hotspots.Directories {
+		if area.Concentrated() {
+			fmt.Println(color.RedString("  ⚠ %s: %.0f%% of commits by %s", area.Name, area.TopAuthorShare, area.TopAuthor))
+		}
+	}
+	fmt.Println()
+}
+
+func (r *Reporter) printEstimatesCLI(estimates *EstimateMetrics) {
+	if estimates == nil {
+		return
+	}
+
+	fmt.Println(color.YellowString("Estimates:"))
+	fmt.Printf("  %s\n", formatEstimateSummary(estimates))
+	for _, section := range estimateGroupSections(estimates) {
+		fmt.Printf("  %s:\n", section.title)
+		for _, group := range section.groups {
+			fmt.Printf("    %s: %s, bias %s (%d finished)\n", group.Name, formatEstimateFactor(group.Factor), formatEstimatePercent(group.Bias), group.Finished)
+		}
+	}
+	if len(estimates.Suggestions) > 0 {
+		fmt.Println("  Suggested target dates:")
+		for _, suggestion := range estimates.Suggestions {
+			line := fmt.Sprintf("    %s: %s (target %s)", suggestion.Name, formatForecastDate(suggestion.SuggestedDate), formatForecastDate(suggestion.TargetDate))
+			if suggestion.Slip() > 0 {
+				line = color.RedString("%s, %s", line, formatEstimateSlip(suggestion))
+			}
+			fmt.Println(line)
+		}
+	}
+	fmt.Println()
+}
+
 func (r *Reporter) printCLIProgressBar(label string, percent float64, indent int, animate bool) {
 	indentStr := strings.Repeat(" ", indent)
 	percent = clampPercent(percent)
@@ -448,6 +1030,290 @@ func renderProgressBar(width int, percent float64) string {
 	return sb.String()
 }
 
+// burnSeriesList returns the project series followed by each phase series
+func burnSeriesList(charts *BurnCharts) []*BurnSeries {
+	var list []*BurnSeries
+	if charts.Project != nil {
+		list = append(list, charts.Project)
+	}
+	return append(list, charts.Phases...)
+}
+
+func writeBurnTableMarkdown(sb *strings.Builder, series *BurnSeries) {
+	sb.WriteString(fmt.Sprintf("### %s\n\n", series.Name))
+	if series.HasTarget() {
+		sb.WriteString(fmt.Sprintf("- **Target date:** %s\n\n", series.TargetDate.Format("2006-01-02")))
+	}
+
+	sb.WriteString(fmt.Sprintf("| Date | Scope (%s) | Completed | Remaining | Ideal |\n", series.Unit))
+	sb.WriteString("|------|-------|-----------|-----------|-------|\n")
+	for _, point := range series.Points {
+		ideal := "-"
+		if series.HasTarget() {
+			ideal = fmt.Sprintf("%.1f", series.IdealRemaining(point.Date))
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %s |\n", point.Date.Format("2006-01-02"), point.Scope, point.Completed, point.Remaining, ideal))
+	}
+	sb.WriteString("\n")
+}
+
+// forecastList returns the project forecast followed by each phase forecast
+func forecastList(forecasts *Forecasts) []*Forecast {
+	var list []*Forecast
+	if forecasts.Project != nil {
+		list = append(list, forecasts.Project)
+	}
+	return append(list, forecasts.Phases...)
+}
+
+// forecastDates formats the percentile dates of a forecast, or its note when there are none
+func forecastDates(forecast *Forecast) (string, string, string) {
+	if !forecast.HasDates() {
+		return forecast.Note, "-", "-"
+	}
+	return formatForecastDate(forecast.P50), formatForecastDate(forecast.P85), formatForecastDate(forecast.P95)
+}
+
+// forecastTargetStatus describes the chance of meeting the target date
+func forecastTargetStatus(forecast *Forecast) string {
+	switch {
+	case forecast.TargetDate.IsZero():
+		return "-"
+	case !forecast.HasDates() && forecast.Remaining > 0:
+		return "unknown"
+	case forecast.AtRisk:
+		return fmt.Sprintf("%.0f%% (at risk)", forecast.OnTargetChance)
+	default:
+		return fmt.Sprintf("%.0f%%", forecast.OnTargetChance)
+	}
+}
+
+func formatForecastDate(date time.Time) string {
+	if date.IsZero() {
+		return "-"
+	}
+	return date.Format("2006-01-02")
+}
+
+// formatCalibratedDate shows when the remaining work finishes at the team's estimate calibration
+func formatCalibratedDate(forecast *Forecast) string {
+	if forecast.CalibratedDate.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s)", formatForecastDate(forecast.CalibratedDate), formatEstimateFactor(forecast.Calibration))
+}
+
+type estimateGroupSection struct {
+	title  string
+	label  string
+	groups []EstimateGroup
+}
+
+func estimateGroupSections(estimates *EstimateMetrics) []estimateGroupSection {
+	var sections []estimateGroupSection
+	for _, section := range []estimateGroupSection{
+		{title: "By Phase", label: "Phase", groups: estimates.Phases},
+		{title: "By Type", label: "Type", groups: estimates.Types},
+	} {
+		if len(section.groups) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+func formatEstimateSummary(estimates *EstimateMetrics) string {
+	if estimates.Finished == 0 {
+		return fmt.Sprintf("%d features estimated, none finished yet", len(estimates.Features))
+	}
+	summary := fmt.Sprintf("Work takes %s the estimate (bias %s, %.0f%% within %.0f%%) across %d finished features",
+		formatEstimateFactor(estimates.Factor), formatEstimatePercent(estimates.Bias), estimates.Accuracy, EstimateTolerance, estimates.Finished)
+	if !estimates.Calibrated {
+		summary += fmt.Sprintf("; %d needed to adjust target dates", EstimateMinSamples)
+	}
+	return summary
+}
+
+func formatEstimateFactor(factor float64) string {
+	return fmt.Sprintf("%.1f×", factor)
+}
+
+func formatEstimatePercent(percent float64) string {
+	return fmt.Sprintf("%+.0f%%", percent)
+}
+
+// formatEstimateActual shows the measured duration, or the days so far of unfinished features
+func formatEstimateActual(estimate *FeatureEstimate) string {
+	switch {
+	case estimate.Done:
+		return formatFlowDays(estimate.Actual)
+	case estimate.Elapsed > 0:
+		return formatFlowDays(estimate.Elapsed) + " so far"
+	default:
+		return "-"
+	}
+}
+
+func formatEstimateError(estimate *FeatureEstimate) string {
+	if !estimate.Done {
+		return "-"
+	}
+	return formatEstimatePercent(estimate.Error)
+}
+
+func formatEstimateSlip(suggestion TargetSuggestion) string {
+	if suggestion.TargetDate.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%+dd", suggestion.Slip())
+}
+
+type flowSummaryRow struct {
+	label   string
+	summary *FlowSummary
+}
+
+// flowSummaryRows lists the lead and cycle time summaries that have data
+func flowSummaryRows(flow *FlowMetrics) []flowSummaryRow {
+	var rows []flowSummaryRow
+	if flow.LeadTime != nil {
+		rows = append(rows, flowSummaryRow{"Lead time", flow.LeadTime})
+	}
+	if flow.CycleTime != nil {
+		rows = append(rows, flowSummaryRow{"Cycle time", flow.CycleTime})
+	}
+	return rows
+}
+
+func formatFlowDays(days float64) string {
+	return fmt.Sprintf("%.1fd", days)
+}
+
+// formatFlowDuration formats days, or a dash when the duration does not apply
+func formatFlowDuration(days float64, known bool) string {
+	if !known {
+		return "-"
+	}
+	return formatFlowDays(days)
+}
+
+// formatTimeInStatus lists the days spent in each status, sorted by status
+func formatTimeInStatus(spent map[string]float64) string {
+	if len(spent) == 0 {
+		return "-"
+	}
+	statuses := make([]string, 0, len(spent))
+	for status := range spent {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%s %s", status, formatFlowDays(spent[status])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// hotspotAreaSection is a table of directories, features or phases in the hotspot reports
+type hotspotAreaSection struct {
+	title string
+	label string
+	areas []AreaChurn
+}
+
+func hotspotAreaSections(hotspots *HotspotMetrics) []hotspotAreaSection {
+	var sections []hotspotAreaSection
+	for _, section := range []hotspotAreaSection{
+		{title: "Directories", label: "Directory", areas: hotspots.Directories},
+		{title: "Features", label: "Feature", areas: hotspots.Features},
+		{title: "Phases", label: "Phase", areas: hotspots.Phases},
+	} {
+		if len(section.areas) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+func formatHotspotWindow(hotspots *HotspotMetrics) string {
+	return fmt.Sprintf("Last %d days: %d commits by %d authors", hotspots.WindowDays, hotspots.Commits, hotspots.Authors)
+}
+
+func formatChurnLines(churn ChurnStats) string {
+	return fmt.Sprintf("+%d/-%d", churn.Additions, churn.Deletions)
+}
+
+func formatTopAuthor(churn ChurnStats) string {
+	if churn.TopAuthor == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%.0f%%)", churn.TopAuthor, churn.TopAuthorShare)
+}
+
+// formatTopAuthorMarkdown marks areas that depend on one author
+func formatTopAuthorMarkdown(churn ChurnStats) string {
+	if churn.Concentrated() {
+		return formatTopAuthor(churn) + " ⚠"
+	}
+	return formatTopAuthor(churn)
+}
+
+func topAuthorCell(churn ChurnStats) string {
+	if churn.Concentrated() {
+		return fmt.Sprintf("<td class=\"at-risk\">%s</td>", html.EscapeString(formatTopAuthor(churn)))
+	}
+	return fmt.Sprintf("<td>%s</td>", html.EscapeString(formatTopAuthor(churn)))
+}
+
+// formatTestName names a test by package, or the package alone for package failures
+func formatTestName(pkg, name string) string {
+	if name == "" {
+		return pkg + " (package)"
+	}
+	if pkg == "" {
+		return name
+	}
+	return pkg + " " + name
+}
+
+func formatTestSummary(summary *TestRunSummary) string {
+	line := fmt.Sprintf("%d passed, %d failed, %d skipped in %s", summary.Passed, summary.Failed, summary.Skipped, formatTestDuration(summary.Duration))
+	if len(summary.Commit) >= 7 {
+		line += " at " + summary.Commit[:7]
+	}
+	return line
+}
+
+func formatTestDuration(seconds float64) string {
+	return fmt.Sprintf("%.2fs", seconds)
+}
+
+func formatTestFeatures(features []string) string {
+	if len(features) == 0 {
+		return "-"
+	}
+	return strings.Join(features, ", ")
+}
+
+// formatCoverageChange formats a change in percentage points with its sign
+func formatCoverageChange(change float64) string {
+	return fmt.Sprintf("%+.1f pts", change)
+}
+
+// coverageDiffValues formats the previous and current coverage of a package, with
+// a dash for the side where the package did not exist
+func coverageDiffValues(diff PackageCoverageDiff) (string, string) {
+	previous, current := fmt.Sprintf("%.1f%%", diff.Previous), fmt.Sprintf("%.1f%%", diff.Current)
+	switch diff.Status {
+	case "added":
+		previous = "-"
+	case "removed":
+		current = "-"
+	}
+	return previous, current
+}
+
 func renderMarkdownProgressBar(percent float64) string {
 	return renderProgressBar(20, percent)
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DoPlan-dev/CLI/pkg/models"
 	"github.com/DoPlan-dev/CLI/test/helpers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -134,7 +135,19 @@ func TestReporter_ReportMarkdown(t *testing.T) {
 			Packages: []PackageCoverageMetric{
 				{Name: "pkg/a", Coverage: 95},
 			},
+			Features: []FeatureCoverageMetric{{ID: "01", Name: "Auth", Coverage: 80}},
+			Trend:    "declining",
+			Change:   -1.5,
+			PackageDiff: []PackageCoverageDiff{
+				{Name: "pkg/b", Previous: 60, Change: -60, Status: "removed"},
+				{Name: "pkg/a", Previous: 97, Current: 95, Change: -2, Status: "changed"},
+			},
 		},
+		Burndown:  CalculateBurnCharts(burnTestState(), nil, nil, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)),
+		Flow:      reporterFlowMetrics(),
+		Tests:     reporterTestMetrics(),
+		Hotspots:  reporterHotspotMetrics(),
+		Estimates: reporterEstimateMetrics(),
 	}
 
 	outputPath := filepath.Join(projectRoot, "stats.md")
@@ -147,6 +160,82 @@ func TestReporter_ReportMarkdown(t *testing.T) {
 	assert.Contains(t, string(data), "# DoPlan Statistics")
 	assert.Contains(t, string(data), "Features/day")
 	assert.Contains(t, string(data), "Testing Metrics")
+	assert.Contains(t, string(data), "### Coverage by Feature")
+	assert.Contains(t, string(data), "- **Overall:** -1.5 pts (declining)")
+	assert.Contains(t, string(data), "| pkg/b | 60.0% | - | -60.0 pts |")
+	assert.Contains(t, string(data), "| pkg/a | 97.0% | 95.0% | -2.0 pts |")
+	assert.Contains(t, string(data), "## Burndown")
+	assert.Contains(t, string(data), "### Foundation")
+	assert.Contains(t, string(data), "- **Target date:** 2026-03-11")
+	assert.Contains(t, string(data), "| 2026-03-02 | 4 | 2 | 2 | 3.6 |")
+	assert.Contains(t, string(data), "## Flow")
+	assert.Contains(t, string(data), "| Cycle time | 1 | 6.0d | 6.0d | 6.0d | 6.0d | 6.0d |")
+	assert.Contains(t, string(data), "- **Profile** (02): 12.0d, in progress longer than the P85 cycle time (6.0 days)")
+	assert.Contains(t, string(data), "| Auth | complete | 8.0d | 6.0d | - | 2.0d | blocked 2.0d, in-progress 4.0d, todo 2.0d |")
+	assert.Contains(t, string(data), "- **Latest:** 1 passed, 2 failed, 0 skipped in 4.50s at 0123456")
+	assert.Contains(t, string(data), "| auth TestLogin | Auth |")
+	assert.Contains(t, string(data), "| billing (package) | - |")
+	assert.Contains(t, string(data), "| auth TestLogin | 1 | 1 | 1 |")
+	assert.Contains(t, string(data), "| auth TestLogout | 3.00s |")
+	assert.Contains(t, string(data), "*Last 90 days: 12 commits by 3 authors*")
+	assert.Contains(t, string(data), "| internal/auth/login.go | 8 | +120/-40 | 2 | Alice (88%) ⚠ | Auth |")
+	assert.Contains(t, string(data), "| internal/auth/login.go | internal/auth/session.go | 4 | 80% |")
+	assert.Contains(t, string(data), "| Directory | Files | Commits | Lines changed | Authors | Top author |\n|-----------|")
+	assert.Contains(t, string(data), "| Foundation | 3 | 10 | +200/-50 | 3 | Alice (60%) |")
+	assert.Contains(t, string(data), "*Work takes 1.3× the estimate (bias +31%, 33% within 20%) across 3 finished features*")
+	assert.Contains(t, string(data), "| Auth | feature | 2.0d | 3.0d | +50% |")
+	assert.Contains(t, string(data), "| Export | feature | 4.0d | 2.0d so far | - |")
+	assert.Contains(t, string(data), "| Foundation | 3 | 13.0d | 17.0d | 1.3× | +31% |")
+	assert.Contains(t, string(data), "| Export | 2026-03-21 | 2026-03-24 | +3d |")
+}
+
+// reporterEstimateMetrics has features that overran their estimates and two adjusted targets
+func reporterEstimateMetrics() *EstimateMetrics {
+	state := estimateTestState()
+	return CalculateEstimateMetrics(state, CalculateFlowMetrics(state, nil, nil, flowNow), flowNow)
+}
+
+// reporterHotspotMetrics has one hotspot owned by a single author
+func reporterHotspotMetrics() *HotspotMetrics {
+	return &HotspotMetrics{
+		WindowDays: 90,
+		Commits:    12,
+		Authors:    3,
+		Files: []FileChurn{
+			{Path: "internal/auth/login.go", ChurnStats: ChurnStats{Commits: 8, Additions: 120, Deletions: 40, Authors: 2, TopAuthor: "Alice", TopAuthorShare: 87.5}, Features: []string{"Auth"}},
+			{Path: "README.md", ChurnStats: ChurnStats{Commits: 1, Additions: 2, Authors: 1, TopAuthor: "Bob", TopAuthorShare: 100}},
+		},
+		Directories: []AreaChurn{{ID: "internal/auth", Name: "internal/auth", Files: 2, ChurnStats: ChurnStats{Commits: 9, Additions: 150, Deletions: 40, Authors: 2, TopAuthor: "Alice", TopAuthorShare: 77.8}}},
+		Coupling:    []ChangeCoupling{{A: "internal/auth/login.go", B: "internal/auth/session.go", CoChanges: 4, Degree: 80}},
+		Phases:      []AreaChurn{{ID: "01-phase", Name: "Foundation", Files: 3, ChurnStats: ChurnStats{Commits: 10, Additions: 200, Deletions: 50, Authors: 3, TopAuthor: "Alice", TopAuthorShare: 60}}},
+	}
+}
+
+// reporterTestMetrics has a flaky failing test, a build failure and a passing test
+func reporterTestMetrics() *TestResultMetrics {
+	at := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	return CalculateTestResultMetrics([]*TestRun{
+		testRun("0123456789", at, TestResult{Package: "auth", Name: "TestLogin", Status: TestPass, Duration: 1}),
+		testRun("0123456789", at.Add(time.Hour),
+			TestResult{Package: "auth", Name: "TestLogin", Status: TestFail, Duration: 1.5, Message: "want <token>"},
+			TestResult{Package: "auth", Name: "TestLogout", Status: TestPass, Duration: 3},
+			TestResult{Package: "billing", Status: TestFail}),
+	}, []models.CoverageMapping{{Feature: "01", Paths: []string{"auth"}}}, map[string]string{"01": "Auth"}, "")
+}
+
+// reporterFlowMetrics times one finished feature and one stalled one
+func reporterFlowMetrics() *FlowMetrics {
+	return CalculateFlowMetrics(&models.State{Features: []models.Feature{
+		{ID: "01", Name: "Auth", Status: "complete", CreatedAt: flowTime(1, 12), StatusHistory: []models.StatusChange{
+			{Status: "in-progress", At: flowTime(3, 12)},
+			{Status: "blocked", At: flowTime(5, 12)},
+			{Status: "in-progress", At: flowTime(7, 12)},
+			{Status: "complete", At: flowTime(9, 12)},
+		}},
+		{ID: "02", Name: "Profile", Status: "in-progress", StatusHistory: []models.StatusChange{
+			{Status: "in-progress", At: flowTime(8, 12)},
+		}},
+	}}, nil, nil, flowNow)
 }
 
 func TestReporter_ReportHTML(t *testing.T) {
@@ -171,6 +260,11 @@ func TestReporter_ReportHTML(t *testing.T) {
 				{Name: "pkg/html", Coverage: 65},
 			},
 		},
+		Burndown:  CalculateBurnCharts(burnTestState(), nil, nil, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)),
+		Flow:      reporterFlowMetrics(),
+		Tests:     reporterTestMetrics(),
+		Hotspots:  reporterHotspotMetrics(),
+		Estimates: reporterEstimateMetrics(),
 	}
 
 	outputPath := filepath.Join(projectRoot, "stats.html")
@@ -183,4 +277,18 @@ func TestReporter_ReportHTML(t *testing.T) {
 	assert.Contains(t, string(data), "<!DOCTYPE html>")
 	assert.Contains(t, string(data), "DoPlan Statistics")
 	assert.Contains(t, string(data), "Testing Metrics")
+	assert.Contains(t, string(data), "<h3>Foundation</h3>")
+	assert.Contains(t, string(data), `<svg class="burn-chart"`)
+	assert.Contains(t, string(data), "<h2>Flow</h2>")
+	assert.Contains(t, string(data), `<li class="at-risk"><strong>Profile</strong> (02): 12.0d`)
+	assert.Contains(t, string(data), "<h2>Test Results</h2>")
+	assert.Contains(t, string(data), `<tr class="at-risk"><td>auth TestLogin</td><td>Auth</td><td><pre>want &lt;token&gt;</pre></td></tr>`)
+	assert.Contains(t, string(data), "<h3>Flaky Tests</h3>")
+	assert.Contains(t, string(data), "<h2>Hotspots</h2>")
+	assert.Contains(t, string(data), `<td>2</td><td class="at-risk">Alice (88%)</td><td>Auth</td></tr>`)
+	assert.Contains(t, string(data), "<tr><td>README.md</td><td>1</td><td>+2/-0</td><td>1</td><td>Bob (100%)</td><td>-</td></tr>", "rarely changed files are not flagged")
+	assert.Contains(t, string(data), "<h3>Phases</h3>")
+	assert.Contains(t, string(data), "<h2>Estimates</h2>")
+	assert.Contains(t, string(data), `<tr><td>Auth</td><td>feature</td><td>2.0d</td><td>3.0d</td><td class="at-risk">+50%</td></tr>`)
+	assert.Contains(t, string(data), `<tr><td>Export</td><td>2026-03-21</td><td>2026-03-24</td><td class="at-risk">+3d</td></tr>`)
 }
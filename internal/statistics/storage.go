@@ -1,27 +1,49 @@
 package statistics
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
-// Storage manages historical statistics data
+// Snapshots are kept at full resolution when recent and thinned out as they age:
+// one per hour for the first week, one per day up to three months, then one per week
+const (
+	hourlyRetention = 7 * 24 * time.Hour
+	dailyRetention  = 90 * 24 * time.Hour
+	// compactInterval is how often Save rewrites segments to downsample old snapshots
+	compactInterval = 24 * time.Hour
+)
+
+const (
+	// segmentLayout names monthly segment files, e.g. 2026-03.jsonl
+	segmentLayout    = "2006-01"
+	segmentExtension = ".jsonl"
+	metaFile         = "meta.json"
+)
+
+// Storage manages historical statistics data as an append-only history of
+// monthly JSON Lines segments under .doplan/stats/history
 type Storage struct {
 	projectRoot string
-	storagePath string
+	historyDir  string
+	legacyPath  string // statistics.json from before segmented history, migrated on first use
 }
 
 // NewStorage creates a new statistics storage
 func NewStorage(projectRoot string) *Storage {
 	storageDir := filepath.Join(projectRoot, ".doplan", "stats")
-	storagePath := filepath.Join(storageDir, "statistics.json")
 
 	return &Storage{
 		projectRoot: projectRoot,
-		storagePath: storagePath,
+		historyDir:  filepath.Join(storageDir, "history"),
+		legacyPath:  filepath.Join(storageDir, "statistics.json"),
 	}
 }
 
@@ -32,120 +54,410 @@ type HistoricalData struct {
 	Data      *StatisticsData    `json:"data"`
 }
 
-// Save stores statistics data
-func (s *Storage) Save(metrics *StatisticsMetrics, data *StatisticsData) error {
-	// Ensure directory exists
-	dir := filepath.Dir(s.storagePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create storage directory: %w", err)
-	}
+// storageMeta records housekeeping state of the history
+type storageMeta struct {
+	CompactedAt time.Time `json:"compactedAt"`
+}
 
-	// Load existing data
-	historical, err := s.LoadAll()
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to load existing data: %w", err)
+// Dir returns the directory holding the history segments
+func (s *Storage) Dir() string {
+	return s.historyDir
+}
+
+// Save appends a statistics snapshot to the current segment, compacting the
+// history when it has not been compacted for a day
+func (s *Storage) Save(metrics *StatisticsMetrics, data *StatisticsData) error {
+	if err := s.migrate(); err != nil {
+		return err
 	}
 
-	// Add new entry
+	now := time.Now()
 	entry := &HistoricalData{
-		Timestamp: time.Now(),
+		Timestamp: now,
 		Metrics:   metrics,
 		Data:      data,
 	}
+	if err := s.append(entry); err != nil {
+		return err
+	}
 
-	historical = append(historical, entry)
+	meta, err := s.loadMeta()
+	if err != nil {
+		return err
+	}
+	if meta.CompactedAt.IsZero() {
+		// A new history has nothing to compact yet
+		return s.saveMeta(&storageMeta{CompactedAt: now})
+	}
+	if now.Sub(meta.CompactedAt) >= compactInterval {
+		return s.Compact(now)
+	}
+	return nil
+}
+
+// Import adds past snapshots to the history, skipping days that already have one
+// so importing twice changes nothing, then compacts. It returns the snapshots added.
+func (s *Storage) Import(entries []*HistoricalData, now time.Time) ([]*HistoricalData, error) {
+	existing, err := s.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	days := make(map[string]bool, len(existing))
+	for _, entry := range existing {
+		days[historyDay(entry.Timestamp)] = true
+	}
+
+	var added []*HistoricalData
+	for _, entry := range entries {
+		day := historyDay(entry.Timestamp)
+		if days[day] {
+			continue
+		}
+		if err := s.append(entry); err != nil {
+			return added, err
+		}
+		days[day] = true
+		added = append(added, entry)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	return added, s.Compact(now)
+}
 
-	// Keep only last 100 entries
-	if len(historical) > 100 {
-		historical = historical[len(historical)-100:]
+// historyDay is the UTC day a snapshot belongs to
+func historyDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// append writes one snapshot as a line at the end of its month's segment
+func (s *Storage) append(entry *HistoricalData) error {
+	if err := os.MkdirAll(s.historyDir, 0755); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
 	}
 
-	// Save to file
-	fileData, err := json.MarshalIndent(historical, "", "  ")
+	line, err := json.Marshal(entry)
 	if err != nil {
 		return fmt.Errorf("failed to marshal data: %w", err)
 	}
 
-	if err := os.WriteFile(s.storagePath, fileData, 0644); err != nil {
-		return fmt.Errorf("failed to write storage file: %w", err)
+	file, err := os.OpenFile(s.segmentPath(entry.Timestamp), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history segment: %w", err)
 	}
+	defer file.Close()
 
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history segment: %w", err)
+	}
 	return nil
 }
 
-// LoadAll loads all historical data
+// Compact downsamples every segment: hourly snapshots for the last week, daily
+// ones up to three months and weekly ones beyond. Each bucket keeps its latest
+// snapshot. Segments are rewritten through a temporary file.
+func (s *Storage) Compact(now time.Time) error {
+	if err := s.migrate(); err != nil {
+		return err
+	}
+
+	segments, err := s.segments(time.Time{}, time.Time{})
+	if err != nil {
+		return err
+	}
+	for _, path := range segments {
+		entries, err := readSegment(path)
+		if err != nil {
+			return err
+		}
+		compacted := downsample(entries, now)
+		if len(compacted) == len(entries) {
+			continue
+		}
+		if err := writeSegment(path, compacted); err != nil {
+			return err
+		}
+	}
+
+	return s.saveMeta(&storageMeta{CompactedAt: now})
+}
+
+// LoadAll loads all historical data, oldest first
 func (s *Storage) LoadAll() ([]*HistoricalData, error) {
-	if _, err := os.Stat(s.storagePath); os.IsNotExist(err) {
-		return []*HistoricalData{}, nil
+	return s.load(time.Time{}, time.Time{})
+}
+
+// LoadSince loads historical data since a given time, reading only the segments
+// that can hold it
+func (s *Storage) LoadSince(since time.Time) ([]*HistoricalData, error) {
+	return s.load(since, time.Time{})
+}
+
+// LoadRange loads historical data for a date range, inclusive at both ends
+func (s *Storage) LoadRange(start, end time.Time) ([]*HistoricalData, error) {
+	return s.load(start, end)
+}
+
+// GetLatest returns the most recent statistics entry
+func (s *Storage) GetLatest() (*HistoricalData, error) {
+	if err := s.migrate(); err != nil {
+		return nil, err
 	}
 
-	data, err := os.ReadFile(s.storagePath)
+	segments, err := s.segments(time.Time{}, time.Time{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read storage file: %w", err)
+		return nil, err
+	}
+	// Segments are monthly, so the latest entry is in the newest non-empty one
+	for i := len(segments) - 1; i >= 0; i-- {
+		entries, err := readSegment(segments[i])
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			return entries[len(entries)-1], nil
+		}
 	}
 
-	var historical []*HistoricalData
-	if err := json.Unmarshal(data, &historical); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal data: %w", err)
+	return nil, fmt.Errorf("no historical data available")
+}
+
+// Clear removes all historical data
+func (s *Storage) Clear() error {
+	if err := os.RemoveAll(s.historyDir); err != nil {
+		return err
+	}
+	if err := os.Remove(s.legacyPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// load reads the entries between start and end; a zero bound is open
+func (s *Storage) load(start, end time.Time) ([]*HistoricalData, error) {
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+
+	segments, err := s.segments(start, end)
+	if err != nil {
+		return nil, err
 	}
 
+	historical := []*HistoricalData{}
+	for _, path := range segments {
+		entries, err := readSegment(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !start.IsZero() && entry.Timestamp.Before(start) {
+				continue
+			}
+			if !end.IsZero() && entry.Timestamp.After(end) {
+				continue
+			}
+			historical = append(historical, entry)
+		}
+	}
 	return historical, nil
 }
 
-// LoadSince loads historical data since a given time
-func (s *Storage) LoadSince(since time.Time) ([]*HistoricalData, error) {
-	all, err := s.LoadAll()
+// segments lists the segment files whose month overlaps start..end, oldest first
+func (s *Storage) segments(start, end time.Time) ([]string, error) {
+	files, err := os.ReadDir(s.historyDir)
 	if err != nil {
-		return nil, err
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
 	}
 
-	filtered := []*HistoricalData{}
-	for _, entry := range all {
-		if entry.Timestamp.After(since) || entry.Timestamp.Equal(since) {
-			filtered = append(filtered, entry)
+	var paths []string
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, segmentExtension) {
+			continue
+		}
+		month, err := time.Parse(segmentLayout, strings.TrimSuffix(name, segmentExtension))
+		if err != nil {
+			continue
+		}
+		if !start.IsZero() && !month.AddDate(0, 1, 0).After(start.UTC()) {
+			continue
 		}
+		if !end.IsZero() && month.After(end.UTC()) {
+			continue
+		}
+		paths = append(paths, filepath.Join(s.historyDir, name))
 	}
+	sort.Strings(paths)
+	return paths, nil
+}
 
-	return filtered, nil
+func (s *Storage) segmentPath(t time.Time) string {
+	return filepath.Join(s.historyDir, t.UTC().Format(segmentLayout)+segmentExtension)
 }
 
-// LoadRange loads historical data for a date range
-func (s *Storage) LoadRange(start, end time.Time) ([]*HistoricalData, error) {
-	all, err := s.LoadAll()
+// migrate moves snapshots from the old single statistics.json into segments and
+// keeps the old file as statistics.json.migrated
+func (s *Storage) migrate() error {
+	content, err := os.ReadFile(s.legacyPath)
 	if err != nil {
-		return nil, err
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read storage file: %w", err)
+	}
+
+	var historical []*HistoricalData
+	if err := json.Unmarshal(content, &historical); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
 	}
 
-	filtered := []*HistoricalData{}
-	for _, entry := range all {
-		if (entry.Timestamp.After(start) || entry.Timestamp.Equal(start)) &&
-			(entry.Timestamp.Before(end) || entry.Timestamp.Equal(end)) {
-			filtered = append(filtered, entry)
+	bySegment := make(map[string][]*HistoricalData)
+	for _, entry := range historical {
+		if entry != nil {
+			path := s.segmentPath(entry.Timestamp)
+			bySegment[path] = append(bySegment[path], entry)
+		}
+	}
+	if err := os.MkdirAll(s.historyDir, 0755); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	for path, entries := range bySegment {
+		// Snapshots already appended to the segment are kept alongside migrated ones
+		existing, err := readSegment(path)
+		if err != nil {
+			return err
+		}
+		if err := writeSegment(path, append(entries, existing...)); err != nil {
+			return err
 		}
 	}
 
-	return filtered, nil
+	if err := os.Rename(s.legacyPath, s.legacyPath+".migrated"); err != nil {
+		return fmt.Errorf("failed to retire storage file: %w", err)
+	}
+	return nil
 }
 
-// GetLatest returns the most recent statistics entry
-func (s *Storage) GetLatest() (*HistoricalData, error) {
-	all, err := s.LoadAll()
+func (s *Storage) loadMeta() (*storageMeta, error) {
+	content, err := os.ReadFile(filepath.Join(s.historyDir, metaFile))
 	if err != nil {
-		return nil, err
+		if os.IsNotExist(err) {
+			return &storageMeta{}, nil
+		}
+		return nil, fmt.Errorf("failed to read history metadata: %w", err)
 	}
 
-	if len(all) == 0 {
-		return nil, fmt.Errorf("no historical data available")
+	var meta storageMeta
+	if err := json.Unmarshal(content, &meta); err != nil {
+		// Unreadable metadata only means the next save compacts
+		return &storageMeta{}, nil
 	}
+	return &meta, nil
+}
 
-	return all[len(all)-1], nil
+func (s *Storage) saveMeta(meta *storageMeta) error {
+	content, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal history metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.historyDir, metaFile), content, 0644); err != nil {
+		return fmt.Errorf("failed to write history metadata: %w", err)
+	}
+	return nil
 }
 
-// Clear removes all historical data
-func (s *Storage) Clear() error {
-	if _, err := os.Stat(s.storagePath); os.IsNotExist(err) {
-		return nil
+// readSegment reads the snapshots of one segment, oldest first. A line cut short
+// by an interrupted write is skipped rather than failing the whole history.
+func readSegment(path string) ([]*HistoricalData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history segment: %w", err)
+	}
+	defer file.Close()
+
+	var entries []*HistoricalData
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry HistoricalData
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history segment: %w", err)
 	}
 
-	return os.Remove(s.storagePath)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp.Before(entries[j].Timestamp) })
+	return entries, nil
+}
+
+// writeSegment replaces a segment with entries, sorted by time
+func writeSegment(path string, entries []*HistoricalData) error {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp.Before(entries[j].Timestamp) })
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal data: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	// Write then rename so a crash never leaves a truncated segment
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write history segment: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// downsample keeps the latest snapshot of each bucket; buckets widen from an hour
+// to a day to a week as snapshots age
+func downsample(entries []*HistoricalData, now time.Time) []*HistoricalData {
+	latest := make(map[string]*HistoricalData)
+	var order []string
+	for _, entry := range entries {
+		key := downsampleBucket(entry.Timestamp, now)
+		if current, ok := latest[key]; !ok {
+			order = append(order, key)
+			latest[key] = entry
+		} else if !entry.Timestamp.Before(current.Timestamp) {
+			latest[key] = entry
+		}
+	}
+
+	kept := make([]*HistoricalData, 0, len(order))
+	for _, key := range order {
+		kept = append(kept, latest[key])
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].Timestamp.Before(kept[j].Timestamp) })
+	return kept
+}
+
+func downsampleBucket(t, now time.Time) string {
+	t = t.UTC()
+	switch age := now.Sub(t); {
+	case age < hourlyRetention:
+		return t.Format("h2006-01-02T15")
+	case age < dailyRetention:
+		return t.Format("d2006-01-02")
+	default:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("w%d-%02d", year, week)
+	}
 }
@@ -1,6 +1,7 @@
 package statistics
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -21,15 +22,13 @@ func TestNewStorage(t *testing.T) {
 
 	// Verify path components (handle Windows path separators)
 	// Normalize path separators for comparison
-	normalizedPath := strings.ReplaceAll(storage.storagePath, "\\", "/")
+	normalizedPath := strings.ReplaceAll(storage.Dir(), "\\", "/")
 	assert.Contains(t, normalizedPath, ".doplan")
 	assert.Contains(t, normalizedPath, "stats")
-	assert.Contains(t, normalizedPath, "statistics.json")
 
-	// Verify it ends with statistics.json (platform-agnostic)
-	expectedPath := filepath.Join(projectRoot, ".doplan", "stats", "statistics.json")
-	assert.Equal(t, expectedPath, storage.storagePath,
-		"storage path should match expected path (normalized for platform)")
+	assert.Equal(t, filepath.Join(projectRoot, ".doplan", "stats", "history"), storage.Dir(),
+		"history path should match expected path (normalized for platform)")
+	assert.Equal(t, filepath.Join(projectRoot, ".doplan", "stats", "statistics.json"), storage.legacyPath)
 }
 
 func TestSaveAndLoad(t *testing.T) {
@@ -159,23 +158,23 @@ func TestClear(t *testing.T) {
 	data := &StatisticsData{CollectedAt: time.Now()}
 	require.NoError(t, storage.Save(metrics, data))
 
-	// Verify file exists
-	_, err := os.Stat(storage.storagePath)
+	// Verify the segment exists
+	_, err := os.Stat(storage.segmentPath(time.Now()))
 	require.NoError(t, err)
 
 	// Clear
 	require.NoError(t, storage.Clear())
 
-	// Verify file removed
-	_, err = os.Stat(storage.storagePath)
+	// Verify history removed
+	_, err = os.Stat(storage.Dir())
 	assert.True(t, os.IsNotExist(err))
 }
 
-func TestSave_MaxEntries(t *testing.T) {
+func TestSave_KeepsHistory(t *testing.T) {
 	projectRoot := helpers.SetupTestProject(t)
 	storage := NewStorage(projectRoot)
 
-	// Save more than 100 entries
+	// Saving more than the old 100-entry limit loses nothing
 	for i := 0; i < 150; i++ {
 		metrics := &StatisticsMetrics{
 			CalculatedAt: time.Now().Add(time.Duration(i) * time.Minute),
@@ -186,10 +185,115 @@ func TestSave_MaxEntries(t *testing.T) {
 		require.NoError(t, storage.Save(metrics, data))
 	}
 
-	// Load all
 	historical, err := storage.LoadAll()
 	require.NoError(t, err)
+	assert.Len(t, historical, 150)
+}
+
+// saveAt appends a snapshot with a fixed timestamp
+func saveAt(t *testing.T, storage *Storage, at time.Time, completed int) {
+	t.Helper()
+	require.NoError(t, storage.append(&HistoricalData{
+		Timestamp: at,
+		Metrics:   &StatisticsMetrics{CalculatedAt: at},
+		Data:      &StatisticsData{State: &StateData{CompletedFeatures: completed}},
+	}))
+}
+
+func TestCompact_Downsamples(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	storage := NewStorage(projectRoot)
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	// Two snapshots in each of three tiers: the same hour, the same day and the same week
+	saveAt(t, storage, now.Add(-2*time.Hour+10*time.Minute), 1)
+	saveAt(t, storage, now.Add(-2*time.Hour+40*time.Minute), 2)
+	saveAt(t, storage, time.Date(2026, 6, 10, 8, 0, 0, 0, time.UTC), 3)
+	saveAt(t, storage, time.Date(2026, 6, 10, 20, 0, 0, 0, time.UTC), 4)
+	saveAt(t, storage, time.Date(2026, 1, 13, 9, 0, 0, 0, time.UTC), 5)
+	saveAt(t, storage, time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC), 6)
+
+	require.NoError(t, storage.Compact(now))
+
+	historical, err := storage.LoadAll()
+	require.NoError(t, err)
+	completed := make([]int, 0, len(historical))
+	for _, entry := range historical {
+		completed = append(completed, entry.Data.State.CompletedFeatures)
+	}
+	assert.Equal(t, []int{6, 4, 2}, completed, "each bucket keeps its latest snapshot")
 
-	// Should only keep last 100
-	assert.LessOrEqual(t, len(historical), 100)
+	meta, err := storage.loadMeta()
+	require.NoError(t, err)
+	assert.True(t, meta.CompactedAt.Equal(now))
+}
+
+func TestLoadRange_ReadsOverlappingSegments(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	storage := NewStorage(projectRoot)
+
+	saveAt(t, storage, time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), 1)
+	saveAt(t, storage, time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), 2)
+	saveAt(t, storage, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), 3)
+
+	segments, err := storage.segments(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, segments, 1, "only February's segment is read")
+	assert.Equal(t, "2026-02.jsonl", filepath.Base(segments[0]))
+
+	historical, err := storage.LoadSince(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, historical, 2)
+	assert.Equal(t, 2, historical[0].Data.State.CompletedFeatures)
+
+	latest, err := storage.GetLatest()
+	require.NoError(t, err)
+	assert.Equal(t, 3, latest.Data.State.CompletedFeatures)
+}
+
+func TestStorage_SkipsTruncatedLines(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	storage := NewStorage(projectRoot)
+	at := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	saveAt(t, storage, at, 1)
+	file, err := os.OpenFile(storage.segmentPath(at), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"timestamp": "2026-03-05T01:00`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	historical, err := storage.LoadAll()
+	require.NoError(t, err)
+	assert.Len(t, historical, 1)
+}
+
+func TestStorage_MigratesLegacyFile(t *testing.T) {
+	projectRoot := helpers.SetupTestProject(t)
+	storage := NewStorage(projectRoot)
+
+	legacy := []*HistoricalData{
+		{Timestamp: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), Data: &StatisticsData{State: &StateData{CompletedFeatures: 1}}},
+		{Timestamp: time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), Data: &StatisticsData{State: &StateData{CompletedFeatures: 2}}},
+	}
+	content, err := json.Marshal(legacy)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(storage.legacyPath), 0755))
+	require.NoError(t, os.WriteFile(storage.legacyPath, content, 0644))
+
+	historical, err := storage.LoadAll()
+	require.NoError(t, err)
+	require.Len(t, historical, 2)
+	assert.Equal(t, 2, historical[1].Data.State.CompletedFeatures)
+
+	assert.FileExists(t, filepath.Join(storage.Dir(), "2026-01.jsonl"))
+	assert.FileExists(t, filepath.Join(storage.Dir(), "2026-02.jsonl"))
+	assert.NoFileExists(t, storage.legacyPath)
+	assert.FileExists(t, storage.legacyPath+".migrated", "the old file is kept as a backup")
+
+	// New snapshots are appended after the migrated ones
+	require.NoError(t, storage.Save(&StatisticsMetrics{}, &StatisticsData{}))
+	historical, err = storage.LoadAll()
+	require.NoError(t, err)
+	assert.Len(t, historical, 3)
 }
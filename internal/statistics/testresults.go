@@ -0,0 +1,626 @@
+package statistics
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/go-git/go-git/v5"
+)
+
+// Test report formats
+const (
+	TestFormatGoJSON = "go-json"
+	TestFormatJUnit  = "junit"
+)
+
+// Test outcomes
+const (
+	TestPass = "pass"
+	TestFail = "fail"
+	TestSkip = "skip"
+)
+
+// DefaultTestReportPaths are the report globs searched when stats.tests.paths is unset
+var DefaultTestReportPaths = []string{
+	"test-results.json",
+	"test-results/**/*.json",
+	"test-results/**/*.xml",
+	"junit.xml",
+	"target/surefire-reports/TEST-*.xml",
+	"build/test-results/**/*.xml",
+}
+
+const (
+	// maxTestRuns is how many runs the test history keeps
+	maxTestRuns = 200
+	// maxTestMessage caps the failure output kept per test, in bytes
+	maxTestMessage = 2048
+	// slowestTestCount is how many of the slowest tests are reported
+	slowestTestCount = 10
+)
+
+// TestRun is one ingested test report
+type TestRun struct {
+	Source      string       `json:"source"` // Report path relative to the project root, or "-" for stdin
+	Format      string       `json:"format"`
+	Hash        string       `json:"hash"` // Content hash, so an unchanged report is only ingested once
+	Commit      string       `json:"commit,omitempty"`
+	CollectedAt time.Time    `json:"collectedAt"`
+	Results     []TestResult `json:"results"`
+}
+
+// TestResult is the outcome of one test in a run
+type TestResult struct {
+	Package  string  `json:"package"`
+	Name     string  `json:"name"` // Empty for a package that failed without running tests, e.g. a build failure
+	File     string  `json:"file,omitempty"`
+	Status   string  `json:"status"`   // "pass", "fail", "skip"
+	Duration float64 `json:"duration"` // seconds
+	Message  string  `json:"message,omitempty"`
+}
+
+// TestHistory stores ingested test runs as JSON Lines under .doplan/stats/tests
+type TestHistory struct {
+	path string
+}
+
+// NewTestHistory creates the test history of a project
+func NewTestHistory(projectRoot string) *TestHistory {
+	return &TestHistory{path: filepath.Join(projectRoot, ".doplan", "stats", "tests", "runs.jsonl")}
+}
+
+// Load returns the stored runs, oldest first. Malformed lines are skipped.
+func (h *TestHistory) Load() ([]*TestRun, error) {
+	data, err := os.ReadFile(h.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read test history: %w", err)
+	}
+
+	var runs []*TestRun
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var run TestRun
+		if err := json.Unmarshal(line, &run); err != nil {
+			continue
+		}
+		runs = append(runs, &run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read test history: %w", err)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].CollectedAt.Before(runs[j].CollectedAt) })
+	return runs, nil
+}
+
+// Ingest parses a go test -json stream or JUnit XML report and adds it to the
+// history. It returns false when the same report was already ingested.
+func (h *TestHistory) Ingest(source string, data []byte, commit string, now time.Time) (bool, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	runs, err := h.Load()
+	if err != nil {
+		return false, err
+	}
+	for _, run := range runs {
+		if run.Hash == hash {
+			return false, nil
+		}
+	}
+
+	run := &TestRun{Source: source, Hash: hash, Commit: commit, CollectedAt: now}
+	switch detectTestFormat(data) {
+	case TestFormatGoJSON:
+		run.Format = TestFormatGoJSON
+		run.Results, err = ParseGoTestJSON(bytes.NewReader(data))
+	case TestFormatJUnit:
+		run.Format = TestFormatJUnit
+		run.Results, err = ParseJUnitXML(data)
+	default:
+		return false, fmt.Errorf("%s is not a go test -json stream or JUnit XML report", source)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", source, err)
+	}
+	if len(run.Results) == 0 {
+		return false, nil
+	}
+
+	runs = append(runs, run)
+	if len(runs) > maxTestRuns {
+		runs = runs[len(runs)-maxTestRuns:]
+	}
+	return true, h.write(runs)
+}
+
+func (h *TestHistory) write(runs []*TestRun) error {
+	var buf bytes.Buffer
+	for _, run := range runs {
+		line, err := json.Marshal(run)
+		if err != nil {
+			return fmt.Errorf("failed to marshal test run: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create test history directory: %w", err)
+	}
+	tmpPath := h.path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write test history: %w", err)
+	}
+	return os.Rename(tmpPath, h.path)
+}
+
+// IngestTestReports adds the reports matching the globs, and the explicitly named
+// reports, to the test history. "-" reads a report from stdin. Runs are stamped with
+// the HEAD commit so flaky tests can be told apart from fixed ones.
+func IngestTestReports(projectRoot string, patterns, explicit []string, stdin io.Reader, now time.Time) (int, error) {
+	if len(patterns) == 0 {
+		patterns = DefaultTestReportPaths
+	}
+	reports, err := findCoverageReports(projectRoot, patterns)
+	if err != nil {
+		return 0, err
+	}
+
+	history := NewTestHistory(projectRoot)
+	commit := headCommit(projectRoot)
+	ingested := 0
+	ingest := func(source string, data []byte, strict bool) error {
+		added, err := history.Ingest(source, data, commit, now)
+		if err != nil {
+			// Reports found by glob may be unrelated files; named ones must parse
+			if strict {
+				return err
+			}
+			return nil
+		}
+		if added {
+			ingested++
+		}
+		return nil
+	}
+
+	for _, report := range reports {
+		data, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(report)))
+		if err != nil {
+			continue
+		}
+		if err := ingest(report, data, false); err != nil {
+			return ingested, err
+		}
+	}
+
+	for _, report := range explicit {
+		var data []byte
+		source := report
+		if report == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			if !filepath.IsAbs(report) {
+				report = filepath.Join(projectRoot, report)
+			}
+			data, err = os.ReadFile(report)
+			if rel, relErr := filepath.Rel(projectRoot, report); relErr == nil && !strings.HasPrefix(rel, "..") {
+				source = filepath.ToSlash(rel)
+			}
+		}
+		if err != nil {
+			return ingested, fmt.Errorf("failed to read test report %s: %w", source, err)
+		}
+		if err := ingest(source, data, true); err != nil {
+			return ingested, err
+		}
+	}
+
+	return ingested, nil
+}
+
+// detectTestFormat recognizes a test report by its content
+func detectTestFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")) && bytes.Contains(trimmed, []byte(`"Action"`)):
+		return TestFormatGoJSON
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return ""
+			}
+			if start, ok := token.(xml.StartElement); ok {
+				if start.Name.Local == "testsuites" || start.Name.Local == "testsuite" {
+					return TestFormatJUnit
+				}
+				return ""
+			}
+		}
+	}
+	return ""
+}
+
+// goTestEvent is one line of go test -json output
+type goTestEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// ParseGoTestJSON reads the results of a go test -json stream. Lines that are not
+// test events, such as build output, are skipped.
+func ParseGoTestJSON(r io.Reader) ([]TestResult, error) {
+	type testKey struct{ pkg, name string }
+	output := make(map[testKey]*strings.Builder)
+	failedTests := make(map[string]bool)
+	var results []TestResult
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event goTestEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Action == "" {
+			continue
+		}
+		key := testKey{event.Package, event.Test}
+
+		switch event.Action {
+		case "output":
+			sb, ok := output[key]
+			if !ok {
+				sb = &strings.Builder{}
+				output[key] = sb
+			}
+			sb.WriteString(event.Output)
+		case TestPass, TestFail, TestSkip:
+			if event.Test == "" {
+				// A failed package without failed tests did not build or crashed
+				if event.Action != TestFail || failedTests[event.Package] {
+					continue
+				}
+			} else if event.Action == TestFail {
+				failedTests[event.Package] = true
+			}
+
+			result := TestResult{Package: event.Package, Name: event.Test, Status: event.Action, Duration: event.Elapsed}
+			if event.Action == TestFail {
+				if sb, ok := output[key]; ok {
+					result.Message = truncateTestMessage(sb.String())
+				}
+			}
+			results = append(results, result)
+			delete(output, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []struct {
+		Name      string `xml:"name,attr"`
+		ClassName string `xml:"classname,attr"`
+		File      string `xml:"file,attr"`
+		Time      string `xml:"time,attr"`
+		Failure   *struct {
+			Message string `xml:"message,attr"`
+			Text    string `xml:",chardata"`
+		} `xml:"failure"`
+		Error *struct {
+			Message string `xml:"message,attr"`
+			Text    string `xml:",chardata"`
+		} `xml:"error"`
+		Skipped *struct{} `xml:"skipped"`
+	} `xml:"testcase"`
+}
+
+// ParseJUnitXML reads the test cases of a JUnit XML report, with or without a
+// testsuites root. Errors count as failures.
+func ParseJUnitXML(data []byte) ([]TestResult, error) {
+	var root junitSuite
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	var results []TestResult
+	var collect func(suite junitSuite)
+	collect = func(suite junitSuite) {
+		for _, child := range suite.Suites {
+			collect(child)
+		}
+		for _, tc := range suite.Cases {
+			result := TestResult{
+				Package: tc.ClassName,
+				Name:    tc.Name,
+				File:    filepath.ToSlash(tc.File),
+				Status:  TestPass,
+			}
+			if result.Package == "" {
+				result.Package = suite.Name
+			}
+			if seconds, err := strconv.ParseFloat(strings.ReplaceAll(tc.Time, ",", ""), 64); err == nil {
+				result.Duration = seconds
+			}
+			switch {
+			case tc.Failure != nil:
+				result.Status = TestFail
+				result.Message = truncateTestMessage(strings.TrimSpace(tc.Failure.Message + "\n" + tc.Failure.Text))
+			case tc.Error != nil:
+				result.Status = TestFail
+				result.Message = truncateTestMessage(strings.TrimSpace(tc.Error.Message + "\n" + tc.Error.Text))
+			case tc.Skipped != nil:
+				result.Status = TestSkip
+			}
+			results = append(results, result)
+		}
+	}
+	collect(root)
+	return results, nil
+}
+
+// truncateTestMessage keeps the end of long failure output, where the cause usually is
+func truncateTestMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if len(message) <= maxTestMessage {
+		return message
+	}
+	return "…" + message[len(message)-maxTestMessage:]
+}
+
+// headCommit returns the HEAD commit hash of the project's repository, if any
+func headCommit(projectRoot string) string {
+	repo, err := git.PlainOpenWithOptions(projectRoot, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return ""
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Hash().String()
+}
+
+// TestResultMetrics summarizes the test history
+type TestResultMetrics struct {
+	Runs     int             `json:"runs"`
+	Latest   *TestRunSummary `json:"latest,omitempty"`
+	Flaky    []FlakyTest     `json:"flaky,omitempty"`
+	Slowest  []TestTiming    `json:"slowest,omitempty"`
+	Failures []TestFailure   `json:"failures,omitempty"`
+}
+
+// TestRunSummary counts the latest result of each test on the most recent commit
+type TestRunSummary struct {
+	Commit      string    `json:"commit,omitempty"`
+	CollectedAt time.Time `json:"collectedAt"`
+	Total       int       `json:"total"`
+	Passed      int       `json:"passed"`
+	Failed      int       `json:"failed"`
+	Skipped     int       `json:"skipped"`
+	Duration    float64   `json:"duration"` // seconds, summed over tests
+}
+
+// FlakyTest is a test that both passed and failed on the same commit
+type FlakyTest struct {
+	Package  string    `json:"package"`
+	Name     string    `json:"name"`
+	Commits  int       `json:"commits"` // Commits on which the test flipped
+	Passes   int       `json:"passes"`
+	Failures int       `json:"failures"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// TestTiming is the latest duration of a test
+type TestTiming struct {
+	Package  string  `json:"package"`
+	Name     string  `json:"name"`
+	Duration float64 `json:"duration"` // seconds
+}
+
+// TestFailure is a failing test on the most recent commit
+type TestFailure struct {
+	Package  string   `json:"package"`
+	Name     string   `json:"name"`
+	Message  string   `json:"message,omitempty"`
+	Features []string `json:"features,omitempty"` // Names of the features whose source paths contain the test
+}
+
+// LoadTestResultMetrics summarizes the test history of a project, linking failures
+// to features through the coverage path mappings
+func LoadTestResultMetrics(projectRoot string, mappings []models.CoverageMapping, featureNames map[string]string) *TestResultMetrics {
+	runs, err := NewTestHistory(projectRoot).Load()
+	if err != nil {
+		return nil
+	}
+	return CalculateTestResultMetrics(runs, mappings, featureNames, readModulePath(projectRoot))
+}
+
+// CalculateTestResultMetrics summarizes runs, oldest first. Go packages are matched
+// against feature paths inside modulePath.
+func CalculateTestResultMetrics(runs []*TestRun, mappings []models.CoverageMapping, featureNames map[string]string, modulePath string) *TestResultMetrics {
+	if len(runs) == 0 {
+		return nil
+	}
+
+	type testKey struct{ pkg, name string }
+	metrics := &TestResultMetrics{Runs: len(runs)}
+
+	// The latest result of each test across the runs of the most recent commit
+	last := runs[len(runs)-1]
+	latest := make(map[testKey]TestResult)
+	var order []testKey
+	for _, run := range runs {
+		if run.Commit != last.Commit {
+			continue
+		}
+		for _, result := range run.Results {
+			key := testKey{result.Package, result.Name}
+			if _, ok := latest[key]; !ok {
+				order = append(order, key)
+			}
+			latest[key] = result
+		}
+	}
+
+	summary := &TestRunSummary{Commit: last.Commit, CollectedAt: last.CollectedAt}
+	for _, key := range order {
+		result := latest[key]
+		summary.Total++
+		summary.Duration += result.Duration
+		switch result.Status {
+		case TestPass:
+			summary.Passed++
+		case TestFail:
+			summary.Failed++
+			metrics.Failures = append(metrics.Failures, TestFailure{
+				Package:  result.Package,
+				Name:     result.Name,
+				Message:  result.Message,
+				Features: testFeatures(result, mappings, featureNames, modulePath),
+			})
+		case TestSkip:
+			summary.Skipped++
+		}
+		if result.Name != "" && result.Status != TestSkip {
+			metrics.Slowest = append(metrics.Slowest, TestTiming{Package: result.Package, Name: result.Name, Duration: result.Duration})
+		}
+	}
+	metrics.Latest = summary
+
+	sort.SliceStable(metrics.Slowest, func(i, j int) bool { return metrics.Slowest[i].Duration > metrics.Slowest[j].Duration })
+	if len(metrics.Slowest) > slowestTestCount {
+		metrics.Slowest = metrics.Slowest[:slowestTestCount]
+	}
+
+	// Flaky tests flip between passing and failing without a code change
+	type outcome struct {
+		passes, failures int
+	}
+	perCommit := make(map[string]map[testKey]*outcome)
+	lastSeen := make(map[testKey]time.Time)
+	for _, run := range runs {
+		if run.Commit == "" {
+			continue
+		}
+		outcomes, ok := perCommit[run.Commit]
+		if !ok {
+			outcomes = make(map[testKey]*outcome)
+			perCommit[run.Commit] = outcomes
+		}
+		for _, result := range run.Results {
+			if result.Name == "" || result.Status == TestSkip {
+				continue
+			}
+			key := testKey{result.Package, result.Name}
+			o, ok := outcomes[key]
+			if !ok {
+				o = &outcome{}
+				outcomes[key] = o
+			}
+			if result.Status == TestPass {
+				o.passes++
+			} else {
+				o.failures++
+			}
+			lastSeen[key] = run.CollectedAt
+		}
+	}
+
+	flaky := make(map[testKey]*FlakyTest)
+	for _, outcomes := range perCommit {
+		for key, o := range outcomes {
+			if o.passes == 0 || o.failures == 0 {
+				continue
+			}
+			f, ok := flaky[key]
+			if !ok {
+				f = &FlakyTest{Package: key.pkg, Name: key.name, LastSeen: lastSeen[key]}
+				flaky[key] = f
+			}
+			f.Commits++
+			f.Passes += o.passes
+			f.Failures += o.failures
+		}
+	}
+	for _, f := range flaky {
+		metrics.Flaky = append(metrics.Flaky, *f)
+	}
+	sort.Slice(metrics.Flaky, func(i, j int) bool {
+		a, b := metrics.Flaky[i], metrics.Flaky[j]
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.Name < b.Name
+	})
+
+	return metrics
+}
+
+// testFeatures returns the names of the features whose mapped source paths contain
+// the test's file or package
+func testFeatures(result TestResult, mappings []models.CoverageMapping, featureNames map[string]string, modulePath string) []string {
+	var candidates []string
+	if result.File != "" {
+		candidates = append(candidates, path.Clean(result.File))
+	}
+	if pkg := result.Package; pkg != "" {
+		if modulePath != "" && (pkg == modulePath || strings.HasPrefix(pkg, modulePath+"/")) {
+			pkg = strings.TrimPrefix(strings.TrimPrefix(pkg, modulePath), "/")
+		}
+		// JUnit class names are dotted, e.g. com.example.auth.TokenServiceTest
+		if !strings.Contains(pkg, "/") {
+			pkg = strings.ReplaceAll(pkg, ".", "/")
+		}
+		candidates = append(candidates, pkg)
+	}
+
+	var features []string
+	for _, mapping := range mappings {
+		for _, candidate := range candidates {
+			if candidate != "" && matchesAnyGlob(mapping.Paths, candidate) {
+				name := featureNames[mapping.Feature]
+				if name == "" {
+					name = mapping.Feature
+				}
+				features = append(features, name)
+				break
+			}
+		}
+	}
+	return features
+}
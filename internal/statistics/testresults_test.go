@@ -0,0 +1,227 @@
+package statistics
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGoJSON = `{"Action":"start","Package":"github.com/example/app/internal/auth"}
+{"Action":"run","Package":"github.com/example/app/internal/auth","Test":"TestLogin"}
+{"Action":"output","Package":"github.com/example/app/internal/auth","Test":"TestLogin","Output":"=== RUN   TestLogin\n"}
+{"Action":"output","Package":"github.com/example/app/internal/auth","Test":"TestLogin","Output":"    login_test.go:12: expected token\n"}
+{"Action":"fail","Package":"github.com/example/app/internal/auth","Test":"TestLogin","Elapsed":0.42}
+{"Action":"pass","Package":"github.com/example/app/internal/auth","Test":"TestLogout","Elapsed":1.5}
+{"Action":"skip","Package":"github.com/example/app/internal/auth","Test":"TestSSO","Elapsed":0}
+{"Action":"fail","Package":"github.com/example/app/internal/auth","Elapsed":2.1}
+# github.com/example/app/internal/billing
+{"Action":"output","Package":"github.com/example/app/internal/billing","Output":"invoice.go:3:2: undefined: total\n"}
+{"Action":"fail","Package":"github.com/example/app/internal/billing","Elapsed":0}
+`
+	testJUnit = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+	<testsuite name="api">
+		<testsuite name="auth">
+			<testcase classname="com.example.auth.TokenServiceTest" name="issuesToken" time="0.250"/>
+			<testcase classname="com.example.auth.TokenServiceTest" name="rejectsExpired" time="1,200.5">
+				<failure message="expected 401">stack trace</failure>
+			</testcase>
+		</testsuite>
+		<testcase name="boots" file="src/app.test.ts" time="0.1">
+			<error message="timeout"/>
+		</testcase>
+		<testcase classname="Misc" name="later" time="0">
+			<skipped/>
+		</testcase>
+	</testsuite>
+</testsuites>
+`
+)
+
+func TestDetectTestFormat(t *testing.T) {
+	assert.Equal(t, TestFormatGoJSON, detectTestFormat([]byte(testGoJSON)))
+	assert.Equal(t, TestFormatJUnit, detectTestFormat([]byte(testJUnit)))
+	assert.Equal(t, TestFormatJUnit, detectTestFormat([]byte(`<testsuite name="x"></testsuite>`)))
+	assert.Empty(t, detectTestFormat([]byte(testCobertura)))
+	assert.Empty(t, detectTestFormat([]byte(`{"coverage": 1}`)))
+}
+
+func TestParseGoTestJSON(t *testing.T) {
+	results, err := ParseGoTestJSON(strings.NewReader(testGoJSON))
+	require.NoError(t, err)
+	require.Len(t, results, 4, "the auth package result is implied by its failed test")
+
+	assert.Equal(t, "TestLogin", results[0].Name)
+	assert.Equal(t, TestFail, results[0].Status)
+	assert.InDelta(t, 0.42, results[0].Duration, 1e-9)
+	assert.Contains(t, results[0].Message, "expected token")
+	assert.Equal(t, TestPass, results[1].Status)
+	assert.Empty(t, results[1].Message)
+	assert.Equal(t, TestSkip, results[2].Status)
+
+	assert.Equal(t, "github.com/example/app/internal/billing", results[3].Package)
+	assert.Empty(t, results[3].Name, "build failures are recorded for the package")
+	assert.Equal(t, TestFail, results[3].Status)
+	assert.Contains(t, results[3].Message, "undefined: total")
+}
+
+func TestParseJUnitXML(t *testing.T) {
+	results, err := ParseJUnitXML([]byte(testJUnit))
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, TestResult{Package: "com.example.auth.TokenServiceTest", Name: "issuesToken", Status: TestPass, Duration: 0.25}, results[0])
+	assert.Equal(t, TestFail, results[1].Status)
+	assert.InDelta(t, 1200.5, results[1].Duration, 1e-9)
+	assert.Equal(t, "expected 401\nstack trace", results[1].Message)
+	assert.Equal(t, "api", results[2].Package, "cases without a class name use their suite")
+	assert.Equal(t, "src/app.test.ts", results[2].File)
+	assert.Equal(t, TestFail, results[2].Status, "errors count as failures")
+	assert.Equal(t, TestSkip, results[3].Status)
+}
+
+func TestTruncateTestMessage(t *testing.T) {
+	long := strings.Repeat("a", maxTestMessage) + "cause"
+	truncated := truncateTestMessage(long)
+	assert.True(t, strings.HasSuffix(truncated, "cause"))
+	assert.Equal(t, maxTestMessage+len("…"), len(truncated))
+}
+
+func TestTestHistory_Ingest(t *testing.T) {
+	projectRoot := t.TempDir()
+	history := NewTestHistory(projectRoot)
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	added, err := history.Ingest("junit.xml", []byte(testJUnit), "abc", now)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = history.Ingest("junit.xml", []byte(testJUnit), "abc", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, added, "an unchanged report is only ingested once")
+
+	_, err = history.Ingest("notes.txt", []byte("hello"), "abc", now)
+	assert.Error(t, err)
+
+	added, err = history.Ingest("-", []byte(testGoJSON), "abc", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	runs, err := history.Load()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, TestFormatGoJSON, runs[0].Format, "runs are ordered by collection time")
+	assert.Equal(t, TestFormatJUnit, runs[1].Format)
+	assert.Equal(t, "abc", runs[1].Commit)
+	assert.NoFileExists(t, history.path+".tmp")
+}
+
+func TestIngestTestReports(t *testing.T) {
+	projectRoot := t.TempDir()
+	writeCoverageFile(t, projectRoot, "test-results/unit/junit.xml", testJUnit)
+	writeCoverageFile(t, projectRoot, "test-results/unit/other.json", `{"unrelated": true}`)
+
+	ingested, err := IngestTestReports(projectRoot, nil, []string{"-"}, strings.NewReader(testGoJSON), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, ingested, "unrecognized reports found by glob are skipped")
+
+	runs, err := NewTestHistory(projectRoot).Load()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "test-results/unit/junit.xml", runs[0].Source)
+	assert.Equal(t, "-", runs[1].Source)
+
+	// Named reports must parse
+	writeCoverageFile(t, projectRoot, "broken.xml", "<coverage/>")
+	_, err = IngestTestReports(projectRoot, []string{"none/*.xml"}, []string{"broken.xml"}, nil, time.Now())
+	assert.Error(t, err)
+
+	_, err = IngestTestReports(projectRoot, []string{"none/*.xml"}, []string{filepath.Join(projectRoot, "missing.xml")}, nil, time.Now())
+	assert.Error(t, err)
+}
+
+func testRun(commit string, at time.Time, results ...TestResult) *TestRun {
+	return &TestRun{Commit: commit, CollectedAt: at, Results: results}
+}
+
+func TestCalculateTestResultMetrics(t *testing.T) {
+	start := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	const pkg = "github.com/example/app/internal/auth"
+	runs := []*TestRun{
+		testRun("c1", start,
+			TestResult{Package: pkg, Name: "TestLogin", Status: TestPass, Duration: 1},
+			TestResult{Package: pkg, Name: "TestRetry", Status: TestFail, Duration: 3}),
+		testRun("c1", start.Add(time.Hour),
+			TestResult{Package: pkg, Name: "TestLogin", Status: TestFail, Duration: 1},
+			TestResult{Package: pkg, Name: "TestRetry", Status: TestPass, Duration: 2}),
+		testRun("c2", start.Add(2*time.Hour),
+			TestResult{Package: pkg, Name: "TestLogin", Status: TestPass, Duration: 0.5},
+			TestResult{Package: pkg, Name: "TestRetry", Status: TestFail, Duration: 4, Message: "timeout"},
+			TestResult{Package: "com.example.auth.TokenServiceTest", Name: "issuesToken", Status: TestFail},
+			TestResult{Package: "github.com/example/app/internal/billing", Status: TestFail}),
+		testRun("c2", start.Add(3*time.Hour),
+			TestResult{Package: pkg, Name: "TestLogin", Status: TestFail, Duration: 0.75},
+			TestResult{Package: pkg, Name: "TestSSO", Status: TestSkip}),
+		// Runs without a commit never count as flaky
+		testRun("", start.Add(-time.Hour),
+			TestResult{Package: "other", Name: "TestA", Status: TestPass}),
+		testRun("", start.Add(-time.Hour),
+			TestResult{Package: "other", Name: "TestA", Status: TestFail}),
+	}
+	// History is oldest first
+	runs = append(runs[4:], runs[:4]...)
+
+	mappings := []models.CoverageMapping{
+		{Feature: "01", Paths: []string{"internal/auth/**", "**/com/example/auth/**"}},
+		{Feature: "02", Paths: []string{"internal/billing/**"}},
+	}
+	metrics := CalculateTestResultMetrics(runs, mappings, map[string]string{"01": "Authentication"}, "github.com/example/app")
+	require.NotNil(t, metrics)
+	assert.Equal(t, 6, metrics.Runs)
+
+	require.NotNil(t, metrics.Latest)
+	assert.Equal(t, "c2", metrics.Latest.Commit)
+	assert.Equal(t, start.Add(3*time.Hour), metrics.Latest.CollectedAt)
+	assert.Equal(t, 5, metrics.Latest.Total, "the latest result of each test on the last commit")
+	assert.Equal(t, 0, metrics.Latest.Passed)
+	assert.Equal(t, 4, metrics.Latest.Failed)
+	assert.Equal(t, 1, metrics.Latest.Skipped)
+	assert.InDelta(t, 4.75, metrics.Latest.Duration, 1e-9)
+
+	require.Len(t, metrics.Failures, 4)
+	assert.Equal(t, TestFailure{Package: pkg, Name: "TestLogin", Features: []string{"Authentication"}}, metrics.Failures[0])
+	assert.Equal(t, "timeout", metrics.Failures[1].Message)
+	assert.Equal(t, []string{"Authentication"}, metrics.Failures[2].Features, "dotted class names match paths")
+	assert.Equal(t, []string{"02"}, metrics.Failures[3].Features, "features missing from state keep their ID")
+
+	require.Len(t, metrics.Flaky, 2)
+	assert.Equal(t, FlakyTest{Package: pkg, Name: "TestLogin", Commits: 2, Passes: 2, Failures: 2, LastSeen: start.Add(3 * time.Hour)}, metrics.Flaky[0])
+	assert.Equal(t, "TestRetry", metrics.Flaky[1].Name)
+	assert.Equal(t, 1, metrics.Flaky[1].Commits)
+
+	require.Len(t, metrics.Slowest, 3, "skipped tests and package failures are not timed")
+	assert.Equal(t, "TestRetry", metrics.Slowest[0].Name)
+	assert.Equal(t, "TestLogin", metrics.Slowest[1].Name)
+
+	assert.Nil(t, CalculateTestResultMetrics(nil, nil, nil, ""))
+}
+
+func TestLoadTestResultMetrics(t *testing.T) {
+	projectRoot := t.TempDir()
+	assert.Nil(t, LoadTestResultMetrics(projectRoot, nil, nil))
+
+	writeCoverageFile(t, projectRoot, "go.mod", "module github.com/example/app\n")
+	_, err := NewTestHistory(projectRoot).Ingest("-", []byte(testGoJSON), "", time.Now())
+	require.NoError(t, err)
+
+	metrics := LoadTestResultMetrics(projectRoot, []models.CoverageMapping{{Feature: "01", Paths: []string{"internal/billing/**"}}}, nil)
+	require.NotNil(t, metrics)
+	assert.Equal(t, 2, metrics.Latest.Failed)
+	assert.Equal(t, []string{"01"}, metrics.Failures[1].Features, "module paths are stripped from Go packages")
+}
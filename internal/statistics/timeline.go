@@ -0,0 +1,268 @@
+package statistics
+
+import (
+	"math"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// Timeline row kinds
+const (
+	TimelinePhase   = "phase"
+	TimelineFeature = "feature"
+)
+
+// Schedule states of a timeline row, compared against its target date
+const (
+	TimelineOnTrack   = "on-track"  // Unfinished and not past its target
+	TimelineOverdue   = "overdue"   // Unfinished and past its target
+	TimelineOnTime    = "on-time"   // Finished by its target
+	TimelineLate      = "late"      // Finished after its target
+	TimelineUnplanned = "unplanned" // No target date to compare with
+)
+
+// Timeline lays out phases and features by their planned dates and what actually happened
+type Timeline struct {
+	Start time.Time      `json:"start"`
+	End   time.Time      `json:"end"`
+	Today time.Time      `json:"today"`
+	Rows  []*TimelineRow `json:"rows"`
+}
+
+// TimelineRow is one bar of the timeline. Features follow the phase they belong to.
+type TimelineRow struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Kind         string    `json:"kind"`
+	Phase        string    `json:"phase,omitempty"`
+	Status       string    `json:"status"`
+	Start        time.Time `json:"start"`            // Planned start, or the actual start without a plan
+	End          time.Time `json:"end"`              // Planned target, or when the work ended or now without a plan
+	DoneAt       time.Time `json:"doneAt,omitempty"` // When a finished row was done
+	State        string    `json:"state"`
+	Slip         int       `json:"slip,omitempty"`         // Days finished after, or overdue past, the target
+	Dependencies []string  `json:"dependencies,omitempty"` // Feature IDs with rows of their own
+}
+
+// Done reports whether the row has finished
+func (r *TimelineRow) Done() bool {
+	return !r.DoneAt.IsZero()
+}
+
+// Slipped reports whether the row ran past its target
+func (r *TimelineRow) Slipped() bool {
+	return r.State == TimelineLate || r.State == TimelineOverdue
+}
+
+// SlipEnd is where a slipped row's overrun ends: when it was done, or today
+func (r *TimelineRow) SlipEnd(today time.Time) time.Time {
+	if r.Done() {
+		return r.DoneAt
+	}
+	return today
+}
+
+// LoadTimeline builds the timeline of state, timing features through LoadFlowMetrics
+func LoadTimeline(projectRoot string, state *models.State, githubData *models.GitHubData, now time.Time) *Timeline {
+	return BuildTimeline(state, LoadFlowMetrics(projectRoot, state, githubData, now), now)
+}
+
+// BuildTimeline places each phase and feature on the timeline. Planned bars come
+// from the start and target dates, either one with the planned duration filling in
+// the other. Features without a plan are drawn from when work started. Phases
+// without dates of their own span their features. It returns nil when nothing has
+// a date to place.
+func BuildTimeline(state *models.State, flow *FlowMetrics, now time.Time) *Timeline {
+	if state == nil {
+		return nil
+	}
+	flows := make(map[string]*FeatureFlow)
+	if flow != nil {
+		for _, f := range flow.Features {
+			flows[f.ID] = f
+		}
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	features := make(map[string]*TimelineRow)
+	for i := range state.Features {
+		if row := featureTimelineRow(&state.Features[i], flows[state.Features[i].ID], today); row != nil {
+			features[row.ID] = row
+		}
+	}
+	for _, row := range features {
+		var deps []string
+		for _, id := range row.Dependencies {
+			if features[id] != nil {
+				deps = append(deps, id)
+			}
+		}
+		row.Dependencies = deps
+	}
+
+	timeline := &Timeline{Today: today}
+	listed := make(map[string]bool)
+	for _, phase := range state.Phases {
+		var children []*TimelineRow
+		for _, id := range phase.Features {
+			if row := features[id]; row != nil && !listed[id] {
+				children = append(children, row)
+				listed[id] = true
+			}
+		}
+		for i := range state.Features {
+			if row := features[state.Features[i].ID]; row != nil && !listed[row.ID] && state.Features[i].Phase == phase.ID {
+				children = append(children, row)
+				listed[row.ID] = true
+			}
+		}
+
+		for _, row := range children {
+			row.Phase = phase.ID
+		}
+		if row := phaseTimelineRow(&phase, children, today); row != nil {
+			timeline.Rows = append(timeline.Rows, row)
+		}
+		timeline.Rows = append(timeline.Rows, children...)
+	}
+	for i := range state.Features {
+		if row := features[state.Features[i].ID]; row != nil && !listed[row.ID] {
+			timeline.Rows = append(timeline.Rows, row)
+		}
+	}
+	if len(timeline.Rows) == 0 {
+		return nil
+	}
+
+	timeline.Start, timeline.End = today, today
+	for _, row := range timeline.Rows {
+		timeline.Start = earliestTime(timeline.Start, row.Start)
+		timeline.End = latestTime(timeline.End, row.End, row.DoneAt)
+	}
+	return timeline
+}
+
+func featureTimelineRow(feature *models.Feature, flow *FeatureFlow, today time.Time) *TimelineRow {
+	row := &TimelineRow{
+		ID:           feature.ID,
+		Name:         feature.Name,
+		Kind:         TimelineFeature,
+		Phase:        feature.Phase,
+		Status:       flowStatus(feature.Status),
+		Dependencies: feature.Dependencies,
+	}
+	if flow != nil && flow.Done() {
+		row.DoneAt = flow.DoneAt
+	}
+
+	var started time.Time
+	if flow != nil {
+		started = flow.StartedAt
+	}
+	if !plannedSpan(row, feature.StartDate, feature.TargetDate, feature.Duration, today.Location()) {
+		if started.IsZero() {
+			return nil
+		}
+		row.Start, row.End, row.State = started, today, TimelineUnplanned
+		if row.Done() {
+			row.End = row.DoneAt
+		}
+		return row
+	}
+	scheduleState(row, today)
+	return row
+}
+
+// phaseTimelineRow places a phase by its own dates, or else across its features
+func phaseTimelineRow(phase *models.Phase, features []*TimelineRow, today time.Time) *TimelineRow {
+	row := &TimelineRow{ID: phase.ID, Name: phase.Name, Kind: TimelinePhase, Status: flowStatus(phase.Status)}
+
+	done := len(features) > 0
+	var doneAt time.Time
+	for _, feature := range features {
+		if !feature.Done() {
+			done = false
+		}
+		doneAt = latestTime(doneAt, feature.DoneAt)
+	}
+	if done || phase.Status == "complete" {
+		row.DoneAt = doneAt
+	}
+
+	if !plannedSpan(row, phase.StartDate, phase.TargetDate, phase.Duration, today.Location()) {
+		if len(features) == 0 {
+			return nil
+		}
+		for _, feature := range features {
+			row.Start = earliestTime(row.Start, feature.Start)
+			row.End = latestTime(row.End, feature.End)
+		}
+		// Spanned by its features, a phase is only as late as they are
+		row.State = TimelineUnplanned
+		for _, feature := range features {
+			if feature.Slipped() && feature.Slip > row.Slip {
+				row.State, row.Slip = feature.State, feature.Slip
+			}
+		}
+		return row
+	}
+	scheduleState(row, today)
+	return row
+}
+
+// plannedSpan sets the planned bar from dates, filling a missing one from the
+// duration, and reports whether there was a plan to draw
+func plannedSpan(row *TimelineRow, start, target, duration string, loc *time.Location) bool {
+	row.Start = parseBurnDate(start, loc)
+	row.End = parseBurnDate(target, loc)
+	days := ParsePlannedDuration(duration)
+	if days > 0 {
+		span := time.Duration(days * float64(24*time.Hour))
+		switch {
+		case row.Start.IsZero() && !row.End.IsZero():
+			row.Start = row.End.Add(-span)
+		case !row.Start.IsZero() && row.End.IsZero():
+			row.End = row.Start.Add(span)
+		}
+	}
+	if row.Start.IsZero() || row.End.IsZero() {
+		return false
+	}
+	if row.End.Before(row.Start) {
+		row.Start, row.End = row.End, row.Start
+	}
+	return true
+}
+
+// scheduleState compares a planned row with its target, in whole days
+func scheduleState(row *TimelineRow, today time.Time) {
+	target := row.End
+	switch {
+	case row.Done() && timelineDays(target, row.DoneAt) > 0:
+		row.State, row.Slip = TimelineLate, timelineDays(target, row.DoneAt)
+	case row.Done():
+		row.State = TimelineOnTime
+	case today.After(target):
+		row.State, row.Slip = TimelineOverdue, timelineDays(target, today)
+	default:
+		row.State = TimelineOnTrack
+	}
+}
+
+// timelineDays counts the whole days from a to b
+func timelineDays(a, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(math.Round(dayB.Sub(dayA).Hours() / 24))
+}
+
+// Feature returns the row of a feature, or nil
+func (t *Timeline) Feature(id string) *TimelineRow {
+	for _, row := range t.Rows {
+		if row.Kind == TimelineFeature && row.ID == id {
+			return row
+		}
+	}
+	return nil
+}
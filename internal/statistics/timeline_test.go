@@ -0,0 +1,97 @@
+package statistics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// timelineTestState has a dated phase with a feature finished late and one overdue,
+// and an undated phase with a feature placed by when it started
+func timelineTestState() *models.State {
+	return &models.State{
+		Phases: []models.Phase{
+			{ID: "01-phase", Name: "Foundation", StartDate: "2026-03-01", TargetDate: "2026-03-15", Features: []string{"01", "02"}},
+			{ID: "02-phase", Name: "Reports", Features: []string{"03", "04"}},
+		},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Auth", Status: "complete", StartDate: "2026-03-01", Duration: "1 week", StatusHistory: []models.StatusChange{
+				{Status: "in-progress", At: flowTime(1, 12)},
+				{Status: "complete", At: flowTime(10, 12)},
+			}},
+			{ID: "02", Phase: "01-phase", Name: "Billing: invoices", Status: "in-progress", StartDate: "2026-03-06", TargetDate: "2026-03-14",
+				Dependencies: []string{"01", "missing"}, StatusHistory: []models.StatusChange{
+					{Status: "in-progress", At: flowTime(6, 12)},
+				}},
+			{ID: "03", Phase: "02-phase", Name: "Export", Status: "in-progress", StatusHistory: []models.StatusChange{
+				{Status: "in-progress", At: flowTime(16, 12)},
+			}},
+			{ID: "04", Phase: "02-phase", Name: "Docs", Status: "todo", TargetDate: "2026-04-10"},
+		},
+	}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	state := timelineTestState()
+	timeline := BuildTimeline(state, CalculateFlowMetrics(state, nil, nil, flowNow), flowNow)
+	require.NotNil(t, timeline)
+
+	var ids []string
+	for _, row := range timeline.Rows {
+		ids = append(ids, row.Kind+":"+row.ID)
+	}
+	assert.Equal(t, []string{"phase:01-phase", "feature:01", "feature:02", "phase:02-phase", "feature:03"}, ids,
+		"features without dates or a start are left out")
+
+	auth := timeline.Feature("01")
+	assert.Equal(t, estimateDay(8), auth.End, "the duration fills in the missing target")
+	assert.Equal(t, TimelineLate, auth.State)
+	assert.Equal(t, 2, auth.Slip)
+
+	billing := timeline.Feature("02")
+	assert.Equal(t, TimelineOverdue, billing.State)
+	assert.Equal(t, 6, billing.Slip)
+	assert.Equal(t, []string{"01"}, billing.Dependencies, "dependencies without a row are dropped")
+
+	export := timeline.Feature("03")
+	assert.Equal(t, TimelineUnplanned, export.State)
+	assert.Equal(t, estimateDay(20), export.End, "unplanned work runs to today")
+
+	reports := timeline.Rows[3]
+	assert.Equal(t, export.Start, reports.Start, "an undated phase spans its features")
+	assert.Equal(t, TimelineUnplanned, reports.State)
+
+	foundation := timeline.Rows[0]
+	assert.Equal(t, TimelineOverdue, foundation.State)
+	assert.Equal(t, estimateDay(1), timeline.Start)
+	assert.Equal(t, estimateDay(20), timeline.End)
+
+	assert.Nil(t, BuildTimeline(&models.State{Features: []models.Feature{{ID: "01"}}}, nil, flowNow))
+}
+
+func TestTimelineMermaid(t *testing.T) {
+	state := timelineTestState()
+	mermaid := TimelineMermaid(BuildTimeline(state, CalculateFlowMetrics(state, nil, nil, flowNow), flowNow))
+
+	assert.True(t, strings.HasPrefix(mermaid, "gantt\n    dateFormat YYYY-MM-DD\n"))
+	assert.Contains(t, mermaid, "    section Foundation\n    Auth :done, 2026-03-01, 2026-03-08\n    Auth (2 days late) :crit, 2026-03-08, 2026-03-10\n")
+	assert.Contains(t, mermaid, "    Billing - invoices :active, 2026-03-06, 2026-03-14\n", "colons would end the task name")
+	assert.Contains(t, mermaid, "    section Reports\n    Export :active, 2026-03-16, 2026-03-20\n")
+	assert.Empty(t, TimelineMermaid(nil))
+}
+
+func TestTimelineSVG(t *testing.T) {
+	state := timelineTestState()
+	svg := TimelineSVG(BuildTimeline(state, CalculateFlowMetrics(state, nil, nil, flowNow), flowNow), 720)
+
+	assert.True(t, strings.HasPrefix(svg, "<svg class=\"timeline-chart\""))
+	assert.Equal(t, 3, strings.Count(svg, "class=\"slip\""), "Auth, Billing and the Foundation phase slipped")
+	assert.Equal(t, 1, strings.Count(svg, "class=\"dependency\""))
+	assert.Contains(t, svg, "stroke=\"#ef4444\" marker-end", "Billing starts before Auth is planned to end")
+	assert.Contains(t, svg, "class=\"today\"")
+	assert.Contains(t, svg, "Billing: invoices: 2026-03-06 to 2026-03-14 (6 days late)")
+	assert.Empty(t, TimelineSVG(nil, 720))
+}
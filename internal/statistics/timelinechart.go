@@ -0,0 +1,190 @@
+package statistics
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// timelineColors fill bars by schedule state
+var timelineColors = map[string]string{
+	TimelineOnTrack:   "#3b82f6",
+	TimelineOverdue:   "#3b82f6",
+	TimelineOnTime:    "#10b981",
+	TimelineLate:      "#10b981",
+	TimelineUnplanned: "#9ca3af",
+}
+
+// TimelineMermaid writes the timeline as a Mermaid gantt chart with a section per
+// phase. Slipped work gets a critical bar from its target to when it was done, or
+// today. Mermaid cannot draw arrows between dated tasks, so dependencies only
+// appear in the SVG and TUI timelines.
+func TimelineMermaid(timeline *Timeline) string {
+	if timeline == nil || len(timeline.Rows) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("gantt\n")
+	sb.WriteString("    dateFormat YYYY-MM-DD\n")
+	sb.WriteString("    axisFormat %b %d\n")
+
+	section := ""
+	for i, row := range timeline.Rows {
+		if row.Kind == TimelinePhase {
+			sb.WriteString(fmt.Sprintf("    section %s\n", mermaidText(row.Name)))
+			section = row.ID
+			// A phase is drawn through its features unless it has none
+			if i+1 < len(timeline.Rows) && timeline.Rows[i+1].Kind == TimelineFeature && timeline.Rows[i+1].Phase == row.ID {
+				continue
+			}
+		} else if section != "" && row.Phase != section {
+			sb.WriteString("    section Other features\n")
+			section = ""
+		}
+
+		var tags []string
+		switch {
+		case row.Done():
+			tags = append(tags, "done")
+		case row.Status == "in-progress" || row.Status == "review":
+			tags = append(tags, "active")
+		}
+		sb.WriteString(fmt.Sprintf("    %s :%s%s, %s\n",
+			mermaidText(row.Name), mermaidTags(tags), row.Start.Format("2006-01-02"), row.End.Format("2006-01-02")))
+
+		if row.Slipped() {
+			sb.WriteString(fmt.Sprintf("    %s (%d days late) :crit, %s, %s\n",
+				mermaidText(row.Name), row.Slip, row.End.Format("2006-01-02"), row.SlipEnd(timeline.Today).Format("2006-01-02")))
+		}
+	}
+	return sb.String()
+}
+
+func mermaidTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return strings.Join(tags, ", ") + ", "
+}
+
+// mermaidText keeps a name from breaking the gantt syntax, where colons separate
+// the task from its data and '#' starts an entity
+func mermaidText(s string) string {
+	return strings.NewReplacer(":", " -", ";", ",", "#", "", "\n", " ").Replace(s)
+}
+
+// TimelineSVG draws the timeline as an inline SVG gantt chart: a bar per row
+// colored by schedule state, red overruns past the target, a today line and an
+// arrow from each dependency to the feature waiting on it
+func TimelineSVG(timeline *Timeline, width int) string {
+	if timeline == nil || len(timeline.Rows) == 0 {
+		return ""
+	}
+
+	const labelWidth, padRight, padTop, rowHeight, padBottom = 180.0, 12.0, 24.0, 22.0, 28.0
+	plotWidth := float64(width) - labelWidth - padRight
+	height := padTop + rowHeight*float64(len(timeline.Rows)) + padBottom
+
+	start, end := timeline.Start, timeline.End.AddDate(0, 0, 1)
+	span := end.Sub(start)
+	x := func(t time.Time) float64 {
+		if span <= 0 {
+			return labelWidth
+		}
+		return labelWidth + plotWidth*float64(t.Sub(start))/float64(span)
+	}
+	rowY := make(map[string]float64)
+	for i, row := range timeline.Rows {
+		if row.Kind == TimelineFeature {
+			rowY[row.ID] = padTop + rowHeight*float64(i) + rowHeight/2
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<svg class=\"timeline-chart\" xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%.0f\" viewBox=\"0 0 %d %.0f\" role=\"img\" aria-label=\"Project timeline\">\n",
+		width, height, width, height))
+	sb.WriteString("<defs><marker id=\"timeline-arrow\" viewBox=\"0 0 10 10\" refX=\"9\" refY=\"5\" markerWidth=\"6\" markerHeight=\"6\" orient=\"auto-start-reverse\"><path d=\"M 0 0 L 10 5 L 0 10 z\" fill=\"#6b7280\"/></marker></defs>\n")
+
+	// Axis with the first day of each month, or the start and end for short timelines
+	axisY := padTop - 8
+	sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\" fill=\"#6b7280\">%s</text>\n", labelWidth, axisY, start.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\" text-anchor=\"end\" fill=\"#6b7280\">%s</text>\n", labelWidth+plotWidth, axisY, timeline.End.Format("2006-01-02")))
+	for month := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, start.Location()); month.Before(end); month = month.AddDate(0, 1, 0) {
+		sb.WriteString(fmt.Sprintf("<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#e5e7eb\"/>\n", x(month), padTop, x(month), height-padBottom))
+	}
+
+	for i, row := range timeline.Rows {
+		top := padTop + rowHeight*float64(i)
+		label, weight, barHeight := row.Name, "normal", rowHeight-8
+		if row.Kind == TimelinePhase {
+			weight, barHeight = "bold", rowHeight-14
+		} else {
+			label = "  " + label
+		}
+		sb.WriteString(fmt.Sprintf("<text x=\"4\" y=\"%.1f\" font-size=\"11\" font-weight=\"%s\" fill=\"#333\" xml:space=\"preserve\">%s</text>\n",
+			top+rowHeight/2+4, weight, html.EscapeString(label)))
+
+		barTop := top + (rowHeight-barHeight)/2
+		barEnd := row.End.AddDate(0, 0, 1)
+		fill := timelineColors[row.State]
+		if row.Kind == TimelinePhase {
+			fill = "#667eea"
+		}
+		sb.WriteString(fmt.Sprintf("<rect class=\"%s\" x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" rx=\"3\" fill=\"%s\"><title>%s</title></rect>\n",
+			row.State, x(row.Start), barTop, maxFloat(x(barEnd)-x(row.Start), 2), barHeight, fill, html.EscapeString(timelineTitle(row))))
+		if row.Slipped() {
+			slipEnd := row.SlipEnd(timeline.Today).AddDate(0, 0, 1)
+			sb.WriteString(fmt.Sprintf("<rect class=\"slip\" x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" rx=\"3\" fill=\"#ef4444\"/>\n",
+				x(barEnd), barTop, maxFloat(x(slipEnd)-x(barEnd), 2), barHeight))
+		}
+	}
+
+	// Arrows run from the end of a dependency to the start of the feature after it
+	for _, row := range timeline.Rows {
+		for _, id := range row.Dependencies {
+			dep := timeline.Feature(id)
+			if dep == nil {
+				continue
+			}
+			fromX, fromY := x(dep.End.AddDate(0, 0, 1)), rowY[dep.ID]
+			toX, toY := x(row.Start), rowY[row.ID]
+			stroke := "#6b7280"
+			if row.Start.Before(dep.End) {
+				stroke = "#ef4444" // Planned to start before its dependency ends
+			}
+			sb.WriteString(fmt.Sprintf("<path class=\"dependency\" d=\"M %.1f %.1f H %.1f V %.1f H %.1f\" fill=\"none\" stroke=\"%s\" marker-end=\"url(#timeline-arrow)\"/>\n",
+				fromX, fromY, fromX+6, toY, toX, stroke))
+		}
+	}
+
+	todayX := x(timeline.Today)
+	sb.WriteString(fmt.Sprintf("<line class=\"today\" x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#f59e0b\" stroke-dasharray=\"3 2\"/>\n", todayX, padTop-4, todayX, height-padBottom))
+
+	legendY := height - 8
+	sb.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%.1f\" font-size=\"10\"><tspan fill=\"#3b82f6\">■ planned</tspan> <tspan fill=\"#10b981\">■ done</tspan> <tspan fill=\"#ef4444\">■ slipped</tspan> <tspan fill=\"#9ca3af\">■ unplanned</tspan> <tspan fill=\"#f59e0b\">┆ today</tspan></text>\n",
+		labelWidth, legendY))
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// timelineTitle describes a row's dates for its tooltip
+func timelineTitle(row *TimelineRow) string {
+	title := fmt.Sprintf("%s: %s to %s", row.Name, row.Start.Format("2006-01-02"), row.End.Format("2006-01-02"))
+	if row.Done() {
+		title += ", done " + row.DoneAt.Format("2006-01-02")
+	}
+	if row.Slipped() {
+		title += fmt.Sprintf(" (%d days late)", row.Slip)
+	}
+	return title
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -59,14 +59,17 @@ type TaskStats struct {
 	CompletionRate int `json:"completionRate"` // percentage
 }
 
-// TestingStats contains code coverage statistics
+// TestingStats contains code coverage statistics. Go reports count statements;
+// lcov, Cobertura and JaCoCo reports count lines.
 type TestingStats struct {
 	TotalStatements   int                              `json:"totalStatements"`
 	CoveredStatements int                              `json:"coveredStatements"`
 	PackageStats      map[string]*PackageCoverageStats `json:"packageStats"`
+	FeatureStats      map[string]*PackageCoverageStats `json:"featureStats,omitempty"` // Keyed by feature ID
+	Reports           []string                         `json:"reports,omitempty"`      // Report files read, relative to the project root
 }
 
-// PackageCoverageStats tracks coverage totals per package
+// PackageCoverageStats tracks coverage totals per package or feature
 type PackageCoverageStats struct {
 	Name              string `json:"name"`
 	Statements        int    `json:"statements"`
@@ -75,13 +78,19 @@ type PackageCoverageStats struct {
 
 // StatisticsMetrics contains all calculated metrics
 type StatisticsMetrics struct {
-	Velocity     *VelocityMetrics `json:"velocity"`
-	Completion   *CompletionRates `json:"completion"`
-	Time         *TimeMetrics     `json:"time"`
-	Quality      *QualityMetrics  `json:"quality"`
-	Testing      *TestingMetrics  `json:"testing,omitempty"`
-	Trends       *Trends          `json:"trends,omitempty"`
-	CalculatedAt time.Time        `json:"calculatedAt"`
+	Velocity     *VelocityMetrics   `json:"velocity"`
+	Completion   *CompletionRates   `json:"completion"`
+	Time         *TimeMetrics       `json:"time"`
+	Quality      *QualityMetrics    `json:"quality"`
+	Testing      *TestingMetrics    `json:"testing,omitempty"`
+	Tests        *TestResultMetrics `json:"tests,omitempty"`
+	Trends       *Trends            `json:"trends,omitempty"`
+	Burndown     *BurnCharts        `json:"burndown,omitempty"`
+	Forecast     *Forecasts         `json:"forecast,omitempty"`
+	Flow         *FlowMetrics       `json:"flow,omitempty"`
+	Hotspots     *HotspotMetrics    `json:"hotspots,omitempty"`
+	Estimates    *EstimateMetrics   `json:"estimates,omitempty"`
+	CalculatedAt time.Time          `json:"calculatedAt"`
 }
 
 // VelocityMetrics tracks development velocity
@@ -132,6 +141,11 @@ type Trends struct {
 type TestingMetrics struct {
 	OverallCoverage float64                 `json:"overallCoverage"`
 	Packages        []PackageCoverageMetric `json:"packages"`
+	Features        []FeatureCoverageMetric `json:"features,omitempty"`
+	Reports         []string                `json:"reports,omitempty"`
+	Trend           string                  `json:"trend,omitempty"`       // "improving", "declining", "stable" since the previous snapshot
+	Change          float64                 `json:"change,omitempty"`      // percentage points since the previous snapshot
+	PackageDiff     []PackageCoverageDiff   `json:"packageDiff,omitempty"` // Packages that changed since the previous snapshot
 }
 
 // PackageCoverageMetric represents per-package coverage percentage
@@ -139,3 +153,19 @@ type PackageCoverageMetric struct {
 	Name     string  `json:"name"`
 	Coverage float64 `json:"coverage"`
 }
+
+// FeatureCoverageMetric represents the coverage of the source paths mapped to a feature
+type FeatureCoverageMetric struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Coverage float64 `json:"coverage"`
+}
+
+// PackageCoverageDiff is the change in one package's coverage since the previous snapshot
+type PackageCoverageDiff struct {
+	Name     string  `json:"name"`
+	Previous float64 `json:"previous"`
+	Current  float64 `json:"current"`
+	Change   float64 `json:"change"` // percentage points
+	Status   string  `json:"status"` // "added", "removed", "changed"
+}
@@ -0,0 +1,392 @@
+package tasks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// Statuses lists the feature statuses an editor cycles through
+var Statuses = []string{"todo", "in-progress", "complete"}
+
+// RefreshFunc saves the state after an edit and runs what follows a progress change,
+// such as regenerating the dashboard, returning what it could not do as warnings
+type RefreshFunc func(projectRoot string, cfgMgr *config.Manager, state *models.State) ([]string, error)
+
+// Editor edits the tasks and status of one feature. Every edit is written to the
+// feature's tasks.md and to the state, then refreshed through the editor's
+// RefreshFunc. Edits can be undone for as long as the editor is open.
+type Editor struct {
+	projectRoot string
+	featureID   string
+	refresh     RefreshFunc
+	state       *models.State
+	stateHash   string
+	file        *File
+	phases      []models.TaskPhase
+	undo        []snapshot
+	warnings    []string
+}
+
+// snapshot is what an edit changed, for undoing it
+type snapshot struct {
+	phases []models.TaskPhase
+	status string
+}
+
+// Open loads the state and the feature's tasks.md. Without a tasks.md, the tasks in
+// the state are edited and the file is created on the first edit. Without a
+// refresh, edits only update progress from tasks.md and save the state.
+func Open(projectRoot, featureID string, refresh RefreshFunc) (*Editor, error) {
+	e := &Editor{projectRoot: projectRoot, featureID: featureID, refresh: refresh}
+	if err := e.load(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Editor) load() error {
+	stateHash, err := e.readStateHash()
+	if err != nil {
+		return err
+	}
+	state, err := config.NewManager(e.projectRoot).LoadState()
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	e.state, e.stateHash = state, stateHash
+	feature := e.Feature()
+	if feature == nil {
+		return fmt.Errorf("feature %q not found", e.featureID)
+	}
+
+	path := FeaturePath(e.projectRoot, state, feature)
+	file, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if len(file.groups) == 0 {
+		if file.lines == nil {
+			file = New(path, feature.Name)
+		}
+		file.SetPhases(feature.TaskPhases)
+	}
+	e.file = file
+	e.phases = withCommits(file.Phases(), feature.TaskPhases)
+	return nil
+}
+
+// withCommits restores the full commit hashes the state keeps for tasks that
+// tasks.md notes by short hash
+func withCommits(phases, stored []models.TaskPhase) []models.TaskPhase {
+	for i := range phases {
+		if i >= len(stored) {
+			break
+		}
+		for j := range phases[i].Tasks {
+			if j >= len(stored[i].Tasks) {
+				break
+			}
+			task, full := &phases[i].Tasks[j], stored[i].Tasks[j].Commit
+			if task.Commit != "" && strings.HasPrefix(full, task.Commit) {
+				task.Commit = full
+			}
+		}
+	}
+	return phases
+}
+
+// Feature returns the edited feature as last saved
+func (e *Editor) Feature() *models.Feature {
+	for i := range e.state.Features {
+		if e.state.Features[i].ID == e.featureID {
+			return &e.state.Features[i]
+		}
+	}
+	return nil
+}
+
+// Phases returns the feature's task groups
+func (e *Editor) Phases() []models.TaskPhase {
+	return e.phases
+}
+
+// Path returns the feature's tasks.md
+func (e *Editor) Path() string {
+	return e.file.Path
+}
+
+// Warnings returns what the last refresh could not do, such as creating a checkpoint
+func (e *Editor) Warnings() []string {
+	return e.warnings
+}
+
+// CanUndo reports whether there is an edit to undo
+func (e *Editor) CanUndo() bool {
+	return len(e.undo) > 0
+}
+
+// Toggle completes or reopens a task
+func (e *Editor) Toggle(group, index int) error {
+	if err := e.checkTask(group, index); err != nil {
+		return err
+	}
+	return e.edit(func(phases []models.TaskPhase) []models.TaskPhase {
+		task := &phases[group].Tasks[index]
+		task.Completed = !task.Completed
+		if !task.Completed {
+			task.Commit = ""
+		}
+		return phases
+	})
+}
+
+// Add appends a task to a group. Adding to the group after the last starts a new
+// group named DefaultGroup.
+func (e *Editor) Add(group int, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("task name is empty")
+	}
+	if group < 0 || group > len(e.phases) {
+		return fmt.Errorf("task group %d not found", group+1)
+	}
+	return e.edit(func(phases []models.TaskPhase) []models.TaskPhase {
+		if group == len(phases) {
+			phases = append(phases, models.TaskPhase{Name: DefaultGroup})
+		}
+		phases[group].Tasks = append(phases[group].Tasks, models.Task{Name: name})
+		return phases
+	})
+}
+
+// Rename changes a task's name
+func (e *Editor) Rename(group, index int, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("task name is empty")
+	}
+	if err := e.checkTask(group, index); err != nil {
+		return err
+	}
+	return e.edit(func(phases []models.TaskPhase) []models.TaskPhase {
+		phases[group].Tasks[index].Name = name
+		return phases
+	})
+}
+
+// Move swaps a task with the one delta places above (negative) or below it in its group
+func (e *Editor) Move(group, index, delta int) error {
+	if err := e.checkTask(group, index); err != nil {
+		return err
+	}
+	target := index + delta
+	if target < 0 || target >= len(e.phases[group].Tasks) {
+		return nil
+	}
+	return e.edit(func(phases []models.TaskPhase) []models.TaskPhase {
+		tasks := phases[group].Tasks
+		tasks[index], tasks[target] = tasks[target], tasks[index]
+		return phases
+	})
+}
+
+// SetStatus changes the feature's status, recording it in the status history
+func (e *Editor) SetStatus(status string) error {
+	if e.Feature().Status == status {
+		return nil
+	}
+	e.undo = append(e.undo, e.snapshot())
+	if err := e.save(e.phases, status, nil); err != nil {
+		e.undo = e.undo[:len(e.undo)-1]
+		return err
+	}
+	return nil
+}
+
+// Transition moves the feature to status, or keeps its status when status is
+// empty. With completeTasks every open task is ticked off in the same save.
+// prepare, when set, changes the feature further before it is saved, such as
+// recording a branch; an error from it cancels the transition.
+func (e *Editor) Transition(status string, completeTasks bool, prepare func(state *models.State, feature *models.Feature) error) error {
+	if status == "" {
+		status = e.Feature().Status
+	}
+	phases := copyPhases(e.phases)
+	if completeTasks {
+		for i := range phases {
+			for j := range phases[i].Tasks {
+				phases[i].Tasks[j].Completed = true
+			}
+		}
+	}
+
+	e.undo = append(e.undo, e.snapshot())
+	if err := e.save(phases, status, prepare); err != nil {
+		e.undo = e.undo[:len(e.undo)-1]
+		return err
+	}
+	return nil
+}
+
+// NextStatus returns the status after the feature's current one in Statuses
+func (e *Editor) NextStatus() string {
+	current := e.Feature().Status
+	for i, status := range Statuses {
+		if status == current {
+			return Statuses[(i+1)%len(Statuses)]
+		}
+	}
+	return Statuses[0]
+}
+
+// Undo reverts the last edit, writing the earlier tasks and status back
+func (e *Editor) Undo() error {
+	if len(e.undo) == 0 {
+		return nil
+	}
+	last := e.undo[len(e.undo)-1]
+	if err := e.save(last.phases, last.status, nil); err != nil {
+		return err
+	}
+	e.undo = e.undo[:len(e.undo)-1]
+	return nil
+}
+
+// Reload discards the editor's view and undo history and reads the files again,
+// after a conflict
+func (e *Editor) Reload() error {
+	if err := e.load(); err != nil {
+		return err
+	}
+	e.undo = nil
+	return nil
+}
+
+func (e *Editor) checkTask(group, index int) error {
+	if group < 0 || group >= len(e.phases) || index < 0 || index >= len(e.phases[group].Tasks) {
+		return fmt.Errorf("task %d.%d not found", group+1, index+1)
+	}
+	return nil
+}
+
+// edit applies change to a copy of the tasks and saves the result
+func (e *Editor) edit(change func([]models.TaskPhase) []models.TaskPhase) error {
+	phases := change(copyPhases(e.phases))
+
+	e.undo = append(e.undo, e.snapshot())
+	if err := e.save(phases, e.Feature().Status, nil); err != nil {
+		e.undo = e.undo[:len(e.undo)-1]
+		return err
+	}
+	return nil
+}
+
+func (e *Editor) snapshot() snapshot {
+	return snapshot{phases: copyPhases(e.phases), status: e.Feature().Status}
+}
+
+// save writes the tasks and status unless tasks.md or the state changed on disk,
+// then refreshes progress
+func (e *Editor) save(phases []models.TaskPhase, status string, prepare func(*models.State, *models.Feature) error) error {
+	stateHash, err := e.readStateHash()
+	if err != nil {
+		return err
+	}
+	if stateHash != e.stateHash {
+		return fmt.Errorf("state: %w", ErrConflict)
+	}
+	if changed, err := e.file.Changed(); err != nil {
+		return err
+	} else if changed {
+		return fmt.Errorf("%s: %w", e.file.Path, ErrConflict)
+	}
+	if prepare != nil {
+		if err := prepare(e.state, e.Feature()); err != nil {
+			return err
+		}
+	}
+
+	e.file.SetPhases(phases)
+	if err := e.file.Save(); err != nil {
+		return err
+	}
+
+	feature := e.Feature()
+	feature.TaskPhases = copyPhases(phases)
+	feature.Progress = taskProgress(phases)
+	feature.SetStatus(status, time.Now())
+
+	cfgMgr := config.NewManager(e.projectRoot)
+	if e.refresh != nil {
+		e.warnings, err = e.refresh(e.projectRoot, cfgMgr, e.state)
+	} else {
+		e.warnings, err = nil, e.saveState(cfgMgr)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.phases = copyPhases(phases)
+	e.stateHash, err = e.readStateHash()
+	return err
+}
+
+// saveState updates progress from the tasks.md files and saves the state
+func (e *Editor) saveState(cfgMgr *config.Manager) error {
+	if err := UpdateProgress(filepath.Join(e.projectRoot, "doplan"), e.state); err != nil {
+		return err
+	}
+	if err := cfgMgr.SaveState(e.state); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return nil
+}
+
+// readStateHash hashes the state file LoadState reads, or returns "" without one
+func (e *Editor) readStateHash() (string, error) {
+	for _, path := range []string{
+		filepath.Join(e.projectRoot, ".doplan", "state.json"),
+		filepath.Join(e.projectRoot, ".cursor", "config", "doplan-state.json"),
+	} {
+		content, err := os.ReadFile(path)
+		if err == nil {
+			return contentHash(content), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read state: %w", err)
+		}
+	}
+	return "", nil
+}
+
+// taskProgress is the percent of tasks completed, counted as 'doplan progress' does
+func taskProgress(phases []models.TaskPhase) int {
+	total, completed := 0, 0
+	for _, phase := range phases {
+		for _, task := range phase.Tasks {
+			total++
+			if task.Completed {
+				completed++
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return (completed * 100) / total
+}
+
+func copyPhases(phases []models.TaskPhase) []models.TaskPhase {
+	copied := make([]models.TaskPhase, len(phases))
+	for i, phase := range phases {
+		copied[i] = models.TaskPhase{Name: phase.Name, Tasks: append([]models.Task{}, phase.Tasks...)}
+	}
+	return copied
+}
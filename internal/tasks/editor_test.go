@@ -0,0 +1,135 @@
+package tasks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEditorProject(t *testing.T) string {
+	t.Helper()
+	projectRoot := helpers.SetupInstalledProject(t, nil, &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation", Features: []string{"01", "02"}}},
+		Features: []models.Feature{
+			{ID: "01", Phase: "01-phase", Name: "Login", Status: "todo", TaskPhases: []models.TaskPhase{
+				{Name: "Setup", Tasks: []models.Task{{Name: "Create branch", Completed: true, Commit: "abc1234def5678"}}},
+			}},
+			{ID: "02", Phase: "01-phase", Name: "Search", Status: "todo", TaskPhases: []models.TaskPhase{
+				{Name: "Build", Tasks: []models.Task{{Name: "Index"}, {Name: "Query"}}},
+			}},
+		},
+	})
+
+	dir := filepath.Join(projectRoot, "doplan", "01-phase", "01-auth")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.md"), []byte(tasksContent), 0644))
+	return projectRoot
+}
+
+func loadFeature(t *testing.T, projectRoot, id string) models.Feature {
+	t.Helper()
+	state, err := config.NewManager(projectRoot).LoadState()
+	require.NoError(t, err)
+	for _, feature := range state.Features {
+		if feature.ID == id {
+			return feature
+		}
+	}
+	t.Fatalf("feature %s not found", id)
+	return models.Feature{}
+}
+
+func TestEditor(t *testing.T) {
+	projectRoot := setupEditorProject(t)
+	refreshes := 0
+	refresh := func(projectRoot string, cfgMgr *config.Manager, state *models.State) ([]string, error) {
+		refreshes++
+		return []string{"no checkpoint"}, cfgMgr.SaveState(state)
+	}
+	editor, err := Open(projectRoot, "01", refresh)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(projectRoot, "doplan", "01-phase", "01-auth", "tasks.md"), editor.Path(), "renamed feature directories are found by number")
+	require.Len(t, editor.Phases(), 2)
+	assert.Equal(t, "abc1234def5678", editor.Phases()[0].Tasks[0].Commit, "the state keeps full commit hashes")
+
+	require.NoError(t, editor.Toggle(0, 1))
+	require.NoError(t, editor.Add(1, "  Code review "))
+	require.NoError(t, editor.Rename(0, 2, "Document settings"))
+	require.NoError(t, editor.Move(0, 2, -1))
+	require.NoError(t, editor.SetStatus(editor.NextStatus()))
+
+	content, err := os.ReadFile(editor.Path())
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "- [x] Create branch (commit abc1234)\n- [ ] Document settings\n  - [x] Add config\n", "indentation stays with the position")
+	assert.Contains(t, string(content), "### Review\n\n- [ ] Code review\n\n## Dependencies")
+
+	feature := loadFeature(t, projectRoot, "01")
+	assert.Equal(t, "in-progress", feature.Status)
+	assert.Equal(t, 50, feature.Progress)
+	assert.Equal(t, "Document settings", feature.TaskPhases[0].Tasks[1].Name)
+	assert.Equal(t, "abc1234def5678", feature.TaskPhases[0].Tasks[0].Commit)
+	require.NotEmpty(t, feature.StatusHistory)
+	assert.Equal(t, 5, refreshes, "every edit saves through the refresh")
+	assert.Equal(t, []string{"no checkpoint"}, editor.Warnings())
+
+	// Undo walks back through the session
+	assert.True(t, editor.CanUndo())
+	require.NoError(t, editor.Undo())
+	assert.Equal(t, "todo", loadFeature(t, projectRoot, "01").Status)
+	for editor.CanUndo() {
+		require.NoError(t, editor.Undo())
+	}
+	content, err = os.ReadFile(editor.Path())
+	require.NoError(t, err)
+	assert.Equal(t, tasksContent, string(content))
+
+	assert.Error(t, editor.Toggle(5, 0))
+	assert.Error(t, editor.Add(0, " "))
+}
+
+func TestEditor_WithoutTasksFile(t *testing.T) {
+	projectRoot := setupEditorProject(t)
+	editor, err := Open(projectRoot, "02", nil)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(projectRoot, "doplan", "01-phase", "02-Feature", "tasks.md"), editor.Path())
+	require.Len(t, editor.Phases(), 1, "tasks come from the state")
+
+	require.NoError(t, editor.Toggle(0, 0))
+	content, err := os.ReadFile(editor.Path())
+	require.NoError(t, err)
+	assert.Equal(t, "# Feature Tasks: Search\n\n## Task Breakdown\n\n### Build\n\n- [x] Index\n- [ ] Query\n", string(content))
+
+	_, err = Open(projectRoot, "99", nil)
+	assert.Error(t, err)
+}
+
+func TestEditor_Conflict(t *testing.T) {
+	projectRoot := setupEditorProject(t)
+	editor, err := Open(projectRoot, "01", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(editor.Path(), []byte("### Setup\n- [ ] Changed elsewhere\n"), 0644))
+	err = editor.Toggle(0, 0)
+	assert.True(t, errors.Is(err, ErrConflict))
+	assert.False(t, editor.CanUndo(), "a rejected edit is not undoable")
+
+	require.NoError(t, editor.Reload())
+	require.Len(t, editor.Phases(), 1)
+	assert.Equal(t, "Changed elsewhere", editor.Phases()[0].Tasks[0].Name)
+	require.NoError(t, editor.Toggle(0, 0))
+
+	// The state changing on disk conflicts too
+	cfgMgr := config.NewManager(projectRoot)
+	state, err := cfgMgr.LoadState()
+	require.NoError(t, err)
+	state.Features[0].Name = "Sign in"
+	require.NoError(t, cfgMgr.SaveState(state))
+	assert.True(t, errors.Is(editor.Toggle(0, 0), ErrConflict))
+}
@@ -0,0 +1,326 @@
+package tasks
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// DefaultGroup names the task group for tasks listed before any heading
+const DefaultGroup = "Tasks"
+
+// ErrConflict is returned when a file changed on disk since it was loaded
+var ErrConflict = errors.New("file changed on disk since it was loaded")
+
+var (
+	// taskLinePattern matches a markdown checkbox line in tasks.md
+	taskLinePattern = regexp.MustCompile(`^(\s*)- \[([ xX])\]\s*(.*)$`)
+	// headingPattern matches a markdown heading; level three and below name task groups
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*$`)
+	// commitNotePattern matches the commit note 'doplan hooks' appends to completed tasks
+	commitNotePattern = regexp.MustCompile(`\s*\(commit ([0-9a-f]{7,40})\)$`)
+)
+
+// File is a tasks.md file parsed into task groups. Edits keep every line that is
+// not a task, such as notes and the dependencies section, where it was.
+type File struct {
+	Path   string
+	lines  []string
+	groups []*group
+	hash   string // Content hash when loaded or last saved; empty when the file did not exist
+}
+
+type group struct {
+	name    string
+	heading int // Line of the group's heading, -1 for tasks before any heading or new groups
+	first   int // Line of the group's first task when loaded, -1 without tasks
+	tasks   []taskLine
+}
+
+type taskLine struct {
+	indent string
+	task   models.Task
+}
+
+// Load reads and parses a tasks.md file. A missing file loads as empty and is
+// created on save.
+func Load(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read tasks: %w", err)
+	}
+
+	file := Parse(string(content))
+	file.Path = path
+	if err == nil {
+		file.hash = contentHash(content)
+	}
+	return file, nil
+}
+
+// Parse splits tasks.md content into task groups. Level three and deeper
+// headings start a group, as the plan generator writes them; higher level
+// headings only group the tasks listed directly below them.
+func Parse(content string) *File {
+	file := &File{}
+	if content == "" {
+		return file
+	}
+	file.lines = strings.Split(content, "\n")
+
+	var current *group
+	for i, line := range file.lines {
+		if matches := headingPattern.FindStringSubmatch(line); matches != nil {
+			current = &group{name: matches[2], heading: i, first: -1}
+			if len(matches[1]) >= 3 {
+				file.groups = append(file.groups, current)
+			}
+			continue
+		}
+
+		matches := taskLinePattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		if current == nil {
+			current = &group{name: DefaultGroup, heading: -1, first: -1}
+		}
+		if current.first < 0 {
+			current.first = i
+			if !file.hasGroup(current) {
+				file.groups = append(file.groups, current)
+			}
+		}
+
+		task := models.Task{Name: matches[3], Completed: matches[2] != " "}
+		if note := commitNotePattern.FindStringSubmatch(task.Name); note != nil {
+			task.Commit = note[1]
+			task.Name = commitNotePattern.ReplaceAllString(task.Name, "")
+		}
+		current.tasks = append(current.tasks, taskLine{indent: matches[1], task: task})
+	}
+	return file
+}
+
+func (f *File) hasGroup(g *group) bool {
+	for _, existing := range f.groups {
+		if existing == g {
+			return true
+		}
+	}
+	return false
+}
+
+// Phases returns the task groups in the shape of the state's task phases
+func (f *File) Phases() []models.TaskPhase {
+	phases := make([]models.TaskPhase, 0, len(f.groups))
+	for _, g := range f.groups {
+		phase := models.TaskPhase{Name: g.name, Tasks: make([]models.Task, 0, len(g.tasks))}
+		for _, line := range g.tasks {
+			phase.Tasks = append(phase.Tasks, line.task)
+		}
+		phases = append(phases, phase)
+	}
+	return phases
+}
+
+// SetPhases replaces the tasks of each group, matching groups by position. Groups
+// beyond those in the file are added at the end.
+func (f *File) SetPhases(phases []models.TaskPhase) {
+	for i, phase := range phases {
+		if i == len(f.groups) {
+			f.groups = append(f.groups, &group{heading: -1, first: -1})
+		}
+		g := f.groups[i]
+		g.name = phase.Name
+
+		tasks := make([]taskLine, 0, len(phase.Tasks))
+		for j, task := range phase.Tasks {
+			indent := ""
+			if j < len(g.tasks) {
+				indent = g.tasks[j].indent
+			}
+			tasks = append(tasks, taskLine{indent: indent, task: task})
+		}
+		g.tasks = tasks
+	}
+	f.groups = f.groups[:len(phases)]
+}
+
+// Content renders the file with its current tasks. Each group's tasks are written
+// where its first task was, after its heading when it had none, and new groups are
+// appended at the end.
+func (f *File) Content() string {
+	taskLines := make(map[int]bool)
+	for _, line := range f.taskLineIndexes() {
+		taskLines[line] = true
+	}
+	at := make(map[int]*group)
+	after := make(map[int]*group)
+	var appended []*group
+	for _, g := range f.groups {
+		switch {
+		case g.first >= 0:
+			at[g.first] = g
+		case g.heading >= 0:
+			after[g.heading] = g
+		default:
+			appended = append(appended, g)
+		}
+	}
+
+	var out []string
+	dropBlank := false
+	for i, line := range f.lines {
+		if g, ok := at[i]; ok {
+			out = append(out, g.render()...)
+			// A group left without tasks does not keep both blank lines around them
+			dropBlank = len(g.tasks) == 0 && len(out) > 0 && out[len(out)-1] == ""
+		}
+		if taskLines[i] {
+			continue
+		}
+		if dropBlank && line == "" {
+			dropBlank = false
+			continue
+		}
+		dropBlank = false
+		out = append(out, line)
+		if g, ok := after[i]; ok && len(g.tasks) > 0 {
+			out = append(out, "")
+			out = append(out, g.render()...)
+		}
+	}
+
+	for _, g := range appended {
+		if len(out) > 0 && out[len(out)-1] != "" {
+			out = append(out, "")
+		}
+		out = append(out, "### "+g.name, "")
+		out = append(out, g.render()...)
+		out = append(out, "")
+	}
+	return strings.Join(out, "\n")
+}
+
+// taskLineIndexes lists the lines that held tasks when the file was parsed
+func (f *File) taskLineIndexes() []int {
+	var indexes []int
+	for i, line := range f.lines {
+		if taskLinePattern.MatchString(line) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (g *group) render() []string {
+	lines := make([]string, 0, len(g.tasks))
+	for _, line := range g.tasks {
+		checked := " "
+		if line.task.Completed {
+			checked = "x"
+		}
+		text := fmt.Sprintf("%s- [%s] %s", line.indent, checked, line.task.Name)
+		if line.task.Commit != "" {
+			text += fmt.Sprintf(" (commit %s)", shortHash(line.task.Commit))
+		}
+		lines = append(lines, text)
+	}
+	return lines
+}
+
+// CompleteTask ticks the numbered task, counting from 1 across all groups, and
+// records the commit on it. It returns the names of the task's group and the task.
+func (f *File) CompleteTask(number int, commitHash string) (string, string, error) {
+	count := 0
+	for _, g := range f.groups {
+		for i := range g.tasks {
+			count++
+			if count == number {
+				g.tasks[i].task.Completed = true
+				g.tasks[i].task.Commit = commitHash
+				return g.name, g.tasks[i].task.Name, nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("task #%d not found in %s (%d tasks)", number, f.Path, count)
+}
+
+// Changed reports whether the file on disk differs from what was loaded or last saved
+func (f *File) Changed() (bool, error) {
+	content, err := os.ReadFile(f.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return f.hash != "", nil
+		}
+		return false, fmt.Errorf("failed to read tasks: %w", err)
+	}
+	return contentHash(content) != f.hash, nil
+}
+
+// Save writes the file, returning ErrConflict when it changed on disk since it was loaded
+func (f *File) Save() error {
+	changed, err := f.Changed()
+	if err != nil {
+		return err
+	}
+	if changed {
+		return fmt.Errorf("%s: %w", f.Path, ErrConflict)
+	}
+
+	content := []byte(f.Content())
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create feature directory: %w", err)
+	}
+	if err := os.WriteFile(f.Path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write tasks: %w", err)
+	}
+
+	// Later edits render from what was written
+	saved := Parse(string(content))
+	f.lines, f.groups, f.hash = saved.lines, saved.groups, contentHash(content)
+	return nil
+}
+
+func contentHash(content []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// New starts a tasks.md file for a feature the way the plan generator lays it out
+func New(path, featureName string) *File {
+	file := Parse(fmt.Sprintf("# Feature Tasks: %s\n\n## Task Breakdown\n", featureName))
+	file.Path = path
+	return file
+}
+
+// CompleteTask ticks the numbered task in a tasks.md file and records the commit
+// on it, as 'doplan hooks' does for tasks referenced in commit messages. It
+// returns the names of the task's group and the task.
+func CompleteTask(path string, number int, commitHash string) (string, string, error) {
+	file, err := Load(path)
+	if err != nil {
+		return "", "", err
+	}
+	phase, name, err := file.CompleteTask(number, commitHash)
+	if err != nil {
+		return "", "", err
+	}
+	if err := file.Save(); err != nil {
+		return "", "", err
+	}
+	return phase, name, nil
+}
@@ -0,0 +1,134 @@
+package tasks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tasksContent = `# Feature Tasks: Login
+
+## Task Breakdown
+
+### Setup
+
+- [x] Create branch (commit abc1234)
+- [ ] Add config
+  - [ ] Document config
+
+### Review
+
+## Dependencies
+
+- Auth service
+`
+
+func TestParse(t *testing.T) {
+	file := Parse(tasksContent)
+	phases := file.Phases()
+	require.Len(t, phases, 2)
+	assert.Equal(t, "Setup", phases[0].Name)
+	assert.Equal(t, []models.Task{
+		{Name: "Create branch", Completed: true, Commit: "abc1234"},
+		{Name: "Add config"},
+		{Name: "Document config"},
+	}, phases[0].Tasks)
+	assert.Equal(t, "Review", phases[1].Name)
+	assert.Empty(t, phases[1].Tasks, "empty groups are kept")
+
+	assert.Equal(t, tasksContent, file.Content(), "unchanged tasks render as they were")
+
+	phases = Parse("Notes\n- [ ] First\n- [X] Second\n").Phases()
+	require.Len(t, phases, 1)
+	assert.Equal(t, DefaultGroup, phases[0].Name)
+	assert.True(t, phases[0].Tasks[1].Completed)
+}
+
+func TestFile_SetPhases(t *testing.T) {
+	file := Parse(tasksContent)
+	phases := file.Phases()
+	phases[0].Tasks[0], phases[0].Tasks[1] = phases[0].Tasks[1], phases[0].Tasks[0]
+	phases[0].Tasks[0].Completed = true
+	phases[1].Tasks = append(phases[1].Tasks, models.Task{Name: "Code review"})
+	phases = append(phases, models.TaskPhase{Name: "Release", Tasks: []models.Task{{Name: "Tag"}}})
+	file.SetPhases(phases)
+
+	assert.Equal(t, `# Feature Tasks: Login
+
+## Task Breakdown
+
+### Setup
+
+- [x] Add config
+- [x] Create branch (commit abc1234)
+  - [ ] Document config
+
+### Review
+
+- [ ] Code review
+
+## Dependencies
+
+- Auth service
+
+### Release
+
+- [ ] Tag
+`, file.Content(), "notes stay in place, indentation stays with the position and new groups go last")
+}
+
+func TestFile_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "01-phase", "01-Feature", "tasks.md")
+	file, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, file.Phases(), "a missing file loads empty")
+
+	file = New(path, "Login")
+	file.SetPhases([]models.TaskPhase{{Name: "Setup", Tasks: []models.Task{{Name: "Create branch"}}}})
+	require.NoError(t, file.Save())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Feature Tasks: Login\n\n## Task Breakdown\n\n### Setup\n\n- [ ] Create branch\n", string(content))
+
+	file.SetPhases([]models.TaskPhase{{Name: "Setup", Tasks: []models.Task{{Name: "Create branch", Completed: true}}}})
+	require.NoError(t, file.Save(), "saving again is not a conflict")
+
+	require.NoError(t, os.WriteFile(path, []byte("- [ ] Edited elsewhere\n"), 0644))
+	changed, err := file.Changed()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	err = file.Save()
+	assert.True(t, errors.Is(err, ErrConflict))
+	content, _ = os.ReadFile(path)
+	assert.Equal(t, "- [ ] Edited elsewhere\n", string(content), "a conflicting save writes nothing")
+}
+
+func TestCompleteTask(t *testing.T) {
+	tasksPath := filepath.Join(t.TempDir(), "tasks.md")
+	content := "# Tasks\n\n### Setup\n\n- [x] Create repo\n- [ ] Add CI\n\n### Build\n\n- [ ] Login form\n"
+	require.NoError(t, os.WriteFile(tasksPath, []byte(content), 0644))
+
+	phase, name, err := CompleteTask(tasksPath, 3, "0123456789abcdef0123456789abcdef01234567")
+	require.NoError(t, err)
+	assert.Equal(t, "Build", phase)
+	assert.Equal(t, "Login form", name)
+
+	updated, err := os.ReadFile(tasksPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(updated), "- [x] Login form (commit 0123456)")
+	assert.Contains(t, string(updated), "- [ ] Add CI")
+
+	// Completing again replaces the commit note rather than stacking it
+	_, _, err = CompleteTask(tasksPath, 3, "fedcba9876543210")
+	require.NoError(t, err)
+	updated, _ = os.ReadFile(tasksPath)
+	assert.Contains(t, string(updated), "- [x] Login form (commit fedcba9)\n")
+
+	_, _, err = CompleteTask(tasksPath, 9, "abc")
+	assert.Error(t, err)
+}
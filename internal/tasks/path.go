@@ -0,0 +1,14 @@
+package tasks
+
+import (
+	"path/filepath"
+
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// FeaturePath returns the path of a feature's tasks.md in the directory
+// github.FeatureDir lays out
+func FeaturePath(projectRoot string, state *models.State, feature *models.Feature) string {
+	return filepath.Join(projectRoot, filepath.FromSlash(github.FeatureDir(projectRoot, state, feature)), "tasks.md")
+}
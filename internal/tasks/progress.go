@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// UpdateProgress sets the progress and status of features from the checkboxes in
+// the tasks.md files under doplanDir
+func UpdateProgress(doplanDir string, state *models.State) error {
+	// Walk through phase directories
+	return filepath.Walk(doplanDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Check if this is a tasks.md file
+		if info.Name() == "tasks.md" {
+			// Read tasks.md and count completed tasks
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			// Count completed tasks (lines with [x])
+			lines := strings.Split(string(content), "\n")
+			totalTasks := 0
+			completedTasks := 0
+
+			for _, line := range lines {
+				if strings.Contains(line, "- [") {
+					totalTasks++
+					if strings.Contains(line, "- [x]") || strings.Contains(line, "- [X]") {
+						completedTasks++
+					}
+				}
+			}
+
+			// Calculate progress percentage
+			progress := 0
+			if totalTasks > 0 {
+				progress = (completedTasks * 100) / totalTasks
+			}
+
+			// Find the feature and update its progress
+			featureDir := filepath.Dir(path)
+			featureName := filepath.Base(featureDir)
+
+			// Try to match feature by directory name
+			for i := range state.Features {
+				if strings.Contains(strings.ToLower(state.Features[i].Name), strings.ToLower(featureName)) ||
+					strings.Contains(strings.ToLower(featureName), strings.ToLower(state.Features[i].Name)) {
+					// A merged PR completes the feature even if tasks.md was not ticked off
+					if github.IsFeatureMerged(&state.Features[i]) {
+						progress = 100
+					}
+					state.Features[i].Progress = progress
+					if progress == 100 {
+						state.Features[i].SetStatus("complete", time.Now())
+					} else if progress > 0 && state.Features[i].Status != "review" {
+						// Features in review stay there until their tasks are done
+						state.Features[i].SetStatus("in-progress", time.Now())
+					}
+					break
+				}
+			}
+		}
+
+		return nil
+	})
+}
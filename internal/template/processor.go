@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"text/template"
 
+	"github.com/DoPlan-dev/CLI/internal/checkpoint"
 	"github.com/DoPlan-dev/CLI/pkg/models"
 )
 
@@ -31,6 +32,12 @@ type TemplateData struct {
 	State   *models.State
 	Config  *models.Config
 	Project map[string]interface{}
+	Release *models.ReleaseNotes
+
+	// Dependencies are the features named in Feature.Dependencies
+	Dependencies []models.Feature
+	// Checkpoint is the feature's latest checkpoint, if any
+	Checkpoint *checkpoint.Checkpoint
 }
 
 // LoadTemplate loads a template from file
@@ -51,6 +58,17 @@ func (p *Processor) LoadTemplate(name string) error {
 	return nil
 }
 
+// ParseTemplate registers a template from content, e.g. a built-in default
+func (p *Processor) ParseTemplate(name, content string) error {
+	tmpl, err := template.New(name).Funcs(p.getFuncMap()).Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	p.templates[name] = tmpl
+	return nil
+}
+
 // ProcessTemplate processes a template with data
 func (p *Processor) ProcessTemplate(templateName string, data TemplateData) (string, error) {
 	tmpl, exists := p.templates[templateName]
@@ -110,6 +128,24 @@ func (p *Processor) getFuncMap() template.FuncMap {
 			}
 			return value
 		},
+		"taskChecklist": func(taskPhases []models.TaskPhase) string {
+			if len(taskPhases) == 0 {
+				return "None"
+			}
+			var result string
+			for _, taskPhase := range taskPhases {
+				result += fmt.Sprintf("**%s**\n\n", taskPhase.Name)
+				for _, task := range taskPhase.Tasks {
+					checked := " "
+					if task.Completed {
+						checked = "x"
+					}
+					result += fmt.Sprintf("- [%s] %s\n", checked, task.Name)
+				}
+				result += "\n"
+			}
+			return result
+		},
 		"hasBranch": func(feature *models.Feature) bool {
 			return feature != nil && feature.Branch != ""
 		},
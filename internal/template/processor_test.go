@@ -374,6 +374,30 @@ func TestProcessor_ProcessTemplate_join(t *testing.T) {
 	assert.Empty(t, result4)
 }
 
+func TestProcessor_ParseTemplate_taskChecklist(t *testing.T) {
+	processor := NewProcessor(t.TempDir())
+	require.NoError(t, processor.ParseTemplate("tasks", "{{taskChecklist .Feature.TaskPhases}}"))
+
+	data := TemplateData{
+		Feature: &models.Feature{
+			TaskPhases: []models.TaskPhase{
+				{Name: "Setup", Tasks: []models.Task{
+					{Name: "Create schema", Completed: true},
+					{Name: "Seed data"},
+				}},
+			},
+		},
+	}
+
+	result, err := processor.ProcessTemplate("tasks", data)
+	require.NoError(t, err)
+	assert.Equal(t, "**Setup**\n\n- [x] Create schema\n- [ ] Seed data\n\n", result)
+
+	result, err = processor.ProcessTemplate("tasks", TemplateData{Feature: &models.Feature{}})
+	require.NoError(t, err)
+	assert.Equal(t, "None", result)
+}
+
 func TestRepeatString(t *testing.T) {
 	// Test with positive count
 	result := repeatString("a", 3)
@@ -3,7 +3,9 @@ package tui
 import (
 	"fmt"
 
+	"github.com/DoPlan-dev/CLI/internal/tasks"
 	"github.com/DoPlan-dev/CLI/internal/tui/screens"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -13,10 +15,10 @@ type App struct {
 	dashboard *screens.DashboardModel
 }
 
-// NewApp creates a new TUI app
-func NewApp() *App {
+// NewApp creates a new TUI app whose edits are saved through refresh
+func NewApp(refresh tasks.RefreshFunc) *App {
 	return &App{
-		dashboard: screens.NewDashboardModel(),
+		dashboard: screens.NewDashboardModel(refresh),
 	}
 }
 
@@ -41,9 +43,9 @@ func (a *App) View() string {
 	return a.dashboard.View()
 }
 
-// Run starts the TUI
-func Run() error {
-	p := tea.NewProgram(NewApp(), tea.WithAltScreen())
+// Run starts the TUI; edits made in it are saved through refresh
+func Run(refresh tasks.RefreshFunc) error {
+	p := tea.NewProgram(NewApp(refresh), tea.WithAltScreen())
 	_, err := p.Run()
 	return err
 }
@@ -51,7 +53,7 @@ func Run() error {
 // RenderBestDoPlanHeader renders the DoPlan header with ASCII art
 func RenderBestDoPlanHeader(width int, version string) string {
 	topBorder := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Width(width).
 		Render("╔" + repeatString("═", width-2) + "╗")
 
@@ -65,33 +67,33 @@ func RenderBestDoPlanHeader(width int, version string) string {
 `
 
 	styledLogo := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render(logo)
 
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#ffffff", Light: "#000000"}).
+		Foreground(theme.Text()).
 		Bold(true).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render("DoPlan")
 
 	subtitle := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#999999", Light: "#666666"}).
+		Foreground(theme.TextDim()).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render("Project Workflow Manager")
 
 	versionText := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#666666", Light: "#999999"}).
+		Foreground(theme.Muted()).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render(fmt.Sprintf("v%s", version))
 
 	bottomBorder := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Width(width).
 		Render("╚" + repeatString("═", width-2) + "╝")
 
@@ -3,21 +3,25 @@ package tui
 import (
 	"fmt"
 
+	"github.com/DoPlan-dev/CLI/pkg/theme"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/manifoldco/promptui"
 )
 
 var (
-	headerStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{
-			Dark:  "#667eea",
-			Light: "#764ba2",
-		}).
-		Bold(true).
-		Align(lipgloss.Center).
-		Padding(1, 2)
+	headerStyle lipgloss.Style
 )
 
+func init() {
+	theme.OnChange(func() {
+		headerStyle = lipgloss.NewStyle().
+			Foreground(theme.Primary()).
+			Bold(true).
+			Align(lipgloss.Center).
+			Padding(1, 2)
+	})
+}
+
 // ShowHeader displays the DoPlan header
 func ShowHeader() {
 	logo := `
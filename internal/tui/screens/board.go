@@ -0,0 +1,360 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/internal/board"
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/tasks"
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	boardCardStyle         lipgloss.Style
+	boardSelectedCardStyle lipgloss.Style
+	boardFailureStyle      lipgloss.Style
+)
+
+func init() {
+	theme.OnChange(func() {
+		boardCardStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.Border()).
+			Padding(0, 1)
+
+		boardSelectedCardStyle = boardCardStyle.
+			BorderForeground(theme.Primary())
+
+		boardFailureStyle = lipgloss.NewStyle().Foreground(theme.Error())
+	})
+}
+
+// BoardModel shows features as cards in status columns. Moving a card runs the
+// lifecycle of the move through board.Move.
+type BoardModel struct {
+	projectRoot string
+	refresh     tasks.RefreshFunc
+	state       *models.State
+	githubData  *models.GitHubData
+	width       int
+
+	filter  board.Filter
+	columns []board.Column
+	column  int // Selected column
+	card    int // Selected card within the column
+
+	moving   bool // A move is running
+	message  string
+	warning  bool
+	loadErr  string
+	selected string // Feature ID to keep selected across reloads
+}
+
+// boardMovedMsg reports a finished move with the reloaded state
+type boardMovedMsg struct {
+	featureID string
+	column    string
+	warnings  []string
+	err       error
+}
+
+// NewBoardModel loads the board of the project at projectRoot; moves save through refresh
+func NewBoardModel(projectRoot string, refresh tasks.RefreshFunc) *BoardModel {
+	m := &BoardModel{projectRoot: projectRoot, refresh: refresh}
+	m.reload()
+	return m
+}
+
+func (m *BoardModel) reload() {
+	state, err := config.NewManager(m.projectRoot).LoadState()
+	if err != nil {
+		m.loadErr = err.Error()
+		return
+	}
+	m.loadErr = ""
+	m.state = state
+	if data, err := github.NewGitHubSync(m.projectRoot).LoadData(); err == nil {
+		m.githubData = data
+	}
+	m.build()
+}
+
+// build sorts the cards and keeps the selected feature under the cursor
+func (m *BoardModel) build() {
+	m.columns = board.Build(m.state, m.githubData, m.filter)
+	if m.selected != "" {
+		for i, column := range m.columns {
+			for j, card := range column.Cards {
+				if card.Feature.ID == m.selected {
+					m.column, m.card = i, j
+					return
+				}
+			}
+		}
+	}
+	m.clamp()
+}
+
+func (m *BoardModel) clamp() {
+	if m.column >= len(m.columns) {
+		m.column = len(m.columns) - 1
+	}
+	if m.column < 0 {
+		m.column = 0
+	}
+	cards := len(m.columns[m.column].Cards)
+	if m.card >= cards {
+		m.card = cards - 1
+	}
+	if m.card < 0 {
+		m.card = 0
+	}
+	m.selected = ""
+	if card := m.current(); card != nil {
+		m.selected = card.Feature.ID
+	}
+}
+
+func (m *BoardModel) current() *board.Card {
+	if m.column < 0 || m.column >= len(m.columns) {
+		return nil
+	}
+	cards := m.columns[m.column].Cards
+	if m.card < 0 || m.card >= len(cards) {
+		return nil
+	}
+	return &cards[m.card]
+}
+
+// SetWidth sets the width the columns share
+func (m *BoardModel) SetWidth(width int) {
+	m.width = width
+}
+
+// Update handles keys on the board and finished moves
+func (m *BoardModel) Update(msg tea.Msg) (*BoardModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case boardMovedMsg:
+		m.moving = false
+		switch {
+		case msg.err != nil:
+			m.message, m.warning = msg.err.Error(), true
+		case len(msg.warnings) > 0:
+			m.message, m.warning = strings.Join(msg.warnings, "; "), true
+		default:
+			m.message, m.warning = "Moved to "+msg.column, false
+		}
+		m.selected = msg.featureID
+		m.reload()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.moving || m.loadErr != "" {
+			return m, nil
+		}
+		keys := keymap.Current()
+		switch {
+		case key.Matches(msg, keys.Left):
+			m.column--
+			m.clamp()
+		case key.Matches(msg, keys.Right):
+			m.column++
+			m.clamp()
+		case key.Matches(msg, keys.Up):
+			m.card--
+			m.clamp()
+		case key.Matches(msg, keys.Down):
+			m.card++
+			m.clamp()
+		case key.Matches(msg, keys.MoveCardLeft):
+			return m, m.move(-1)
+		case key.Matches(msg, keys.MoveCardRight):
+			return m, m.move(1)
+		case key.Matches(msg, keys.FilterPhase):
+			m.filter.Phase = m.nextPhase()
+			m.build()
+		case key.Matches(msg, keys.FilterAssignee):
+			m.filter.Assignee = next(board.Assignees(m.state, m.githubData), m.filter.Assignee)
+			m.build()
+		case key.Matches(msg, keys.Reload):
+			m.reload()
+		}
+	}
+	return m, nil
+}
+
+// move moves the selected card delta columns, running the move in the background
+func (m *BoardModel) move(delta int) tea.Cmd {
+	card := m.current()
+	target := m.column + delta
+	if card == nil || target < 0 || target >= len(board.Columns) {
+		return nil
+	}
+
+	m.moving = true
+	m.message, m.warning = fmt.Sprintf("Moving %s to %s...", card.Feature.Name, board.Columns[target]), false
+	projectRoot, featureID, column, refresh := m.projectRoot, card.Feature.ID, board.Columns[target], m.refresh
+	return func() tea.Msg {
+		warnings, err := board.Move(projectRoot, featureID, column, refresh)
+		return boardMovedMsg{featureID: featureID, column: column, warnings: warnings, err: err}
+	}
+}
+
+func (m *BoardModel) nextPhase() string {
+	if m.state == nil {
+		return ""
+	}
+	var ids []string
+	for _, phase := range m.state.Phases {
+		ids = append(ids, phase.ID)
+	}
+	return next(ids, m.filter.Phase)
+}
+
+// next cycles through values after current, with "" (all) between the last and first
+func next(values []string, current string) string {
+	if current == "" {
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+	for i, value := range values {
+		if value == current && i+1 < len(values) {
+			return values[i+1]
+		}
+	}
+	return ""
+}
+
+// View renders the columns side by side
+func (m *BoardModel) View() string {
+	if m.loadErr != "" {
+		return "Failed to load state: " + m.loadErr
+	}
+
+	width := 24
+	if m.width > 0 {
+		width = (m.width - 8) / len(m.columns)
+	}
+	if width < 16 {
+		width = 16
+	}
+
+	var columns []string
+	for i, column := range m.columns {
+		lines := []string{titleStyle.Render(fmt.Sprintf("%s (%d)", column.Name, len(column.Cards)))}
+		for j, card := range column.Cards {
+			style := boardCardStyle
+			if i == m.column && j == m.card {
+				style = boardSelectedCardStyle
+			}
+			lines = append(lines, style.Width(width-2).Render(m.renderCard(card, width-6)))
+		}
+		columns = append(columns, lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n")))
+	}
+
+	var sections []string
+	sections = append(sections, titleStyle.Render("Board")+"  "+helpStyle.Render(m.filterText()))
+	sections = append(sections, "")
+	sections = append(sections, lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+	if m.message != "" {
+		style := taskMessageStyle
+		if m.warning {
+			style = taskWarningStyle
+		}
+		sections = append(sections, "", style.Render("  "+m.message))
+	}
+	sections = append(sections, "", helpStyle.Render(m.help()))
+	return strings.Join(sections, "\n")
+}
+
+func (m *BoardModel) renderCard(card board.Card, width int) string {
+	feature := card.Feature
+
+	filled := feature.Progress * 10 / 100
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", 10-filled)
+	lines := []string{
+		selectedItemStyle.Render(truncate(feature.Name, width)),
+		fmt.Sprintf("%s %d%%", progressInProgressStyle.Render(bar), feature.Progress),
+	}
+	if feature.Branch != "" {
+		lines = append(lines, truncate("⎇ "+feature.Branch, width))
+	}
+	if pr := feature.PR; pr != nil && pr.URL != "" {
+		status := pr.Status
+		if pr.Draft {
+			status = "draft"
+		}
+		text := "PR " + status
+		if pr.Number > 0 {
+			text = fmt.Sprintf("PR #%d %s", pr.Number, status)
+		}
+		lines = append(lines, text)
+	}
+	switch card.CI.State {
+	case github.CIStateSuccess:
+		lines = append(lines, progressCompleteStyle.Render("CI ✓"))
+	case github.CIStateFailure:
+		lines = append(lines, boardFailureStyle.Render(truncate("CI ✗ "+strings.Join(card.CI.Failing, ", "), width)))
+	case github.CIStatePending:
+		lines = append(lines, progressInProgressStyle.Render("CI …"))
+	}
+	if len(card.Assignees) > 0 {
+		lines = append(lines, helpStyle.Render(truncate("@"+strings.Join(card.Assignees, " @"), width)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *BoardModel) filterText() string {
+	phase := "all phases"
+	if m.filter.Phase != "" && m.state != nil {
+		phase = m.filter.Phase
+		for _, p := range m.state.Phases {
+			if p.ID == m.filter.Phase {
+				phase = p.Name
+				break
+			}
+		}
+	}
+	text := "Phase: " + phase
+	if len(board.Assignees(m.state, m.githubData)) > 0 {
+		assignee := "everyone"
+		if m.filter.Assignee != "" {
+			assignee = m.filter.Assignee
+		}
+		text += " | Assignee: " + assignee
+	}
+	return text
+}
+
+func (m *BoardModel) help() string {
+	if m.moving {
+		return "Moving card..."
+	}
+	keys := keymap.Current()
+	items := []keymap.HelpItem{
+		keymap.Item("select", keys.Left, keys.Right, keys.Up, keys.Down),
+		keymap.Item("move card", keys.MoveCardLeft, keys.MoveCardRight),
+		keymap.Item("phase", keys.FilterPhase),
+	}
+	if len(board.Assignees(m.state, m.githubData)) > 0 {
+		items = append(items, keymap.Item("assignee", keys.FilterAssignee))
+	}
+	return keymap.Help(append(items, keymap.Item("reload", keys.Reload))...)
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width < 2 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
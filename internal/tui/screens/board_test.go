@@ -0,0 +1,50 @@
+package screens
+
+import (
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/board"
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardModel(t *testing.T) {
+	projectRoot := setupTaskEditorProject(t)
+	state, err := config.NewManager(projectRoot).LoadState()
+	require.NoError(t, err)
+	state.Phases = append(state.Phases, models.Phase{ID: "02-phase", Name: "Polish", Features: []string{"02"}})
+	state.Features = append(state.Features, models.Feature{ID: "02", Phase: "02-phase", Name: "Search", Status: "in-progress"})
+	require.NoError(t, config.NewManager(projectRoot).SaveState(state))
+
+	m := NewBoardModel(projectRoot, nil)
+	m.SetWidth(120)
+	view := m.View()
+	assert.Contains(t, view, "todo (1)")
+	assert.Contains(t, view, "in-progress (1)")
+	assert.Contains(t, view, "Phase: all phases")
+	assert.NotContains(t, view, "Assignee", "the assignee filter needs synced commits")
+
+	m, _ = m.Update(keyPress("p"))
+	assert.Contains(t, m.View(), "Phase: Foundation")
+	assert.Empty(t, m.columns[1].Cards)
+	m, _ = m.Update(keyPress("p"))
+	m, _ = m.Update(keyPress("p"))
+	assert.Contains(t, m.View(), "Phase: all phases", "the phase filter cycles back to all")
+
+	// Moving Login from todo to in-progress runs in the background
+	require.Equal(t, "01", m.current().Feature.ID)
+	m, cmd := m.Update(keyPress("L"))
+	require.NotNil(t, cmd)
+	assert.True(t, m.moving)
+	m, _ = m.Update(cmd())
+	assert.False(t, m.moving)
+	assert.Contains(t, m.View(), "Could not create a branch", "side effects that fail are shown as warnings")
+
+	card := m.current()
+	require.NotNil(t, card, "the moved card stays selected")
+	assert.Equal(t, "01", card.Feature.ID)
+	assert.Equal(t, board.ColumnInProgress, m.columns[m.column].Name)
+	assert.Contains(t, m.View(), "in-progress (2)")
+}
@@ -3,44 +3,65 @@ package screens
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/DoPlan-dev/CLI/internal/config"
 	"github.com/DoPlan-dev/CLI/internal/dashboard"
 	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/palette"
 	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/DoPlan-dev/CLI/internal/tasks"
+	"github.com/DoPlan-dev/CLI/internal/utils"
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
 	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 var (
 	// Progress bar colors
-	progressCompleteStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#10b981")) // Green
-	progressInProgressStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")) // Blue
-	progressTodoStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#6b7280")) // Gray
+	progressCompleteStyle   lipgloss.Style
+	progressInProgressStyle lipgloss.Style
+	progressTodoStyle       lipgloss.Style
 )
 
 var (
-	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+	titleStyle        lipgloss.Style
+	selectedItemStyle lipgloss.Style
+	normalItemStyle   lipgloss.Style
+	helpStyle         lipgloss.Style
+)
+
+func init() {
+	theme.OnChange(func() {
+		progressCompleteStyle = lipgloss.NewStyle().Foreground(theme.Success())
+		progressInProgressStyle = lipgloss.NewStyle().Foreground(theme.Info())
+		progressTodoStyle = lipgloss.NewStyle().Foreground(theme.Muted())
+
+		titleStyle = lipgloss.NewStyle().
+			Foreground(theme.Primary()).
 			Bold(true).
 			Padding(0, 1)
 
-	selectedItemStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#667eea")).
-				Bold(true)
+		selectedItemStyle = lipgloss.NewStyle().
+			Foreground(theme.Primary()).
+			Bold(true)
 
-	normalItemStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff"))
+		normalItemStyle = lipgloss.NewStyle().
+			Foreground(theme.Text())
 
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666"))
-)
+		helpStyle = lipgloss.NewStyle().
+			Foreground(theme.Muted())
+	})
+}
 
 type DashboardModel struct {
 	width         int
@@ -53,7 +74,7 @@ type DashboardModel struct {
 	lastUpdate    time.Time             // Last update time from dashboard.json
 
 	// Views
-	currentView string // "dashboard", "phases", "features", "github", "config", "stats"
+	currentView string // "dashboard", "phases", "features", "tasks", "github", "config", "stats", "board", "timeline"
 
 	// Dashboard view
 	overallProgress progress.Model
@@ -64,22 +85,40 @@ type DashboardModel struct {
 	selectedPhase   int
 	selectedFeature int
 
+	// Saves edits from the task editor, board and palette, then refreshes progress
+	refresh tasks.RefreshFunc
+
+	// Task editing for the feature opened from the features view
+	taskEditor *TaskEditorModel
+	taskError  string
+
+	// Kanban board and timeline, loaded when first shown
+	board    *BoardModel
+	timeline *TimelineModel
+
+	// Command palette, open while not nil, and what its last action did
+	palette       *PaletteModel
+	notice        string
+	noticeWarning bool
+
 	// Loading
 	spinner            spinner.Model
 	loading            bool
 	usingDashboardJSON bool // Whether we're using dashboard.json or fallback
 }
 
-func NewDashboardModel() *DashboardModel {
-	p := progress.New(progress.WithScaledGradient("#667eea", "#764ba2"))
+// NewDashboardModel creates the dashboard; edits made from it are saved through refresh
+func NewDashboardModel(refresh tasks.RefreshFunc) *DashboardModel {
+	p := progress.New(progress.WithScaledGradient(theme.ProgressGradient()), progress.WithColorProfile(theme.ColorProfile()))
 	p.Width = 50
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#667eea"))
+	s.Style = lipgloss.NewStyle().Foreground(theme.Primary())
 
 	return &DashboardModel{
 		currentView:     "dashboard",
+		refresh:         refresh,
 		overallProgress: p,
 		spinner:         s,
 		loading:         true,
@@ -165,6 +204,22 @@ func loadStatisticsCmd(projectRoot string, state *models.State, githubData *gith
 			}
 			calculator := statistics.NewCalculator(projectStartDate)
 			stats = calculator.Calculate(data, state, githubData)
+			// Burn charts and forecasts need task phases and phase dates, which a state
+			// converted from dashboard.json lacks, so they read the state file itself
+			fullState := state
+			if loaded, err := config.NewManager(projectRoot).LoadState(); err == nil {
+				fullState = loaded
+			}
+			stats.Burndown = statistics.LoadBurnCharts(projectRoot, fullState, time.Now())
+			stats.Forecast = statistics.LoadForecasts(projectRoot, fullState, cfg.Stats.Forecast, time.Now())
+
+			featureNames := make(map[string]string)
+			if state != nil {
+				for _, feature := range state.Features {
+					featureNames[feature.ID] = feature.Name
+				}
+			}
+			stats.Tests = statistics.LoadTestResultMetrics(projectRoot, cfg.Stats.Coverage.Features, featureNames)
 		}
 		return statisticsLoadedMsg{statistics: stats}
 	}
@@ -200,12 +255,15 @@ func convertDashboardToState(dashboardJSON *models.DashboardJSON) *models.State
 		// Convert features
 		for _, featureJSON := range phaseJSON.Features {
 			feature := models.Feature{
-				ID:       featureJSON.ID,
-				Phase:    phaseJSON.ID,
-				Name:     featureJSON.Name,
-				Status:   featureJSON.Status,
-				Progress: featureJSON.Progress,
-				Branch:   featureJSON.Branch,
+				ID:           featureJSON.ID,
+				Phase:        phaseJSON.ID,
+				Name:         featureJSON.Name,
+				Status:       featureJSON.Status,
+				Progress:     featureJSON.Progress,
+				Branch:       featureJSON.Branch,
+				BaseBranch:   featureJSON.BaseBranch,
+				Dependencies: featureJSON.Dependencies,
+				Flags:        featureJSON.Flags,
 			}
 			if featureJSON.PR != nil {
 				feature.PR = &models.PullRequest{
@@ -243,6 +301,33 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		if m.board != nil {
+			m.board.SetWidth(m.width - 4)
+		}
+		if m.timeline != nil {
+			m.timeline.SetSize(m.width-4, m.contentHeight())
+		}
+		if m.palette != nil {
+			m.palette.SetSize(m.width-4, m.contentHeight())
+		}
+		return m, nil
+
+	case paletteChosenMsg:
+		return m.openPaletteItem(msg)
+
+	case paletteDoneMsg:
+		m.notice, m.noticeWarning = msg.message, false
+		if msg.err != nil {
+			m.notice, m.noticeWarning = msg.err.Error(), true
+		}
+		return m, loadDataCmd
+
+	case boardMovedMsg:
+		if m.board != nil {
+			var cmd tea.Cmd
+			m.board, cmd = m.board.Update(msg)
+			return m, cmd
+		}
 		return m, nil
 
 	case loadDataMsg:
@@ -264,7 +349,16 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.loading = false
+		if m.state != nil && m.selectedFeature >= len(m.state.Features) {
+			m.selectedFeature = 0
+		}
 		m.setupLists()
+		if m.board != nil {
+			m.board.reload()
+		}
+		if m.timeline != nil {
+			m.timeline.reload(time.Now())
+		}
 		// Schedule next auto-refresh
 		return m, m.autoRefresh()
 
@@ -273,25 +367,48 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		keys := keymap.Current()
+		// Keys typed into the palette or a task name are not shortcuts
+		if m.palette != nil {
+			switch {
+			case msg.String() == "ctrl+c":
+				return m, tea.Quit
+			case keymap.MatchesWhileTyping(msg, keys.Back, keys.Palette):
+				m.palette = nil
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.palette, cmd = m.palette.Update(msg)
+			return m, cmd
+		}
+		if m.currentView == "tasks" && m.taskEditor != nil && m.taskEditor.Typing() {
+			return m.updateTasks(msg)
+		}
+		switch {
+		case msg.String() == "ctrl+c" || key.Matches(msg, keys.Quit):
 			return m, tea.Quit
-		case "1":
+		case key.Matches(msg, keys.Palette):
+			projectRoot, _ := os.Getwd()
+			m.palette = NewPaletteModel(projectRoot, m.state)
+			m.notice = ""
+			m.palette.SetSize(m.width-4, m.contentHeight())
+			return m, textinput.Blink
+		case key.Matches(msg, keys.ViewDashboard):
 			m.currentView = "dashboard"
 			return m, nil
-		case "2":
+		case key.Matches(msg, keys.ViewPhases):
 			m.currentView = "phases"
 			return m, nil
-		case "3":
+		case key.Matches(msg, keys.ViewFeatures):
 			m.currentView = "features"
 			return m, nil
-		case "4":
+		case key.Matches(msg, keys.ViewGitHub):
 			m.currentView = "github"
 			return m, nil
-		case "5":
+		case key.Matches(msg, keys.ViewConfig):
 			m.currentView = "config"
 			return m, nil
-		case "6":
+		case key.Matches(msg, keys.ViewStats):
 			m.currentView = "stats"
 			// Load statistics on demand if not already loaded
 			if m.statistics == nil {
@@ -305,7 +422,24 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, nil
-		case "r":
+		case key.Matches(msg, keys.ViewBoard):
+			m.currentView = "board"
+			if m.board == nil {
+				projectRoot, _ := os.Getwd()
+				m.board = NewBoardModel(projectRoot, m.refresh)
+				m.board.SetWidth(m.width - 4)
+			}
+			return m, nil
+		case key.Matches(msg, keys.ViewTimeline):
+			m.currentView = "timeline"
+			if m.timeline == nil {
+				projectRoot, _ := os.Getwd()
+				m.timeline = NewTimelineModel(projectRoot, time.Now())
+				m.timeline.SetSize(m.width-4, m.contentHeight())
+				m.timeline.scrollToToday()
+			}
+			return m, nil
+		case key.Matches(msg, keys.Refresh):
 			m.loading = true
 			return m, tea.Batch(loadDataCmd, m.spinner.Tick)
 		}
@@ -325,12 +459,22 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updatePhases(msg)
 	case "features":
 		return m.updateFeatures(msg)
+	case "tasks":
+		return m.updateTasks(msg)
 	case "github":
 		return m.updateGitHub(msg)
 	case "config":
 		return m.updateConfig(msg)
 	case "stats":
 		return m.updateStats(msg)
+	case "board":
+		var cmd tea.Cmd
+		m.board, cmd = m.board.Update(msg)
+		return m, cmd
+	case "timeline":
+		var cmd tea.Cmd
+		m.timeline, cmd = m.timeline.Update(msg)
+		return m, cmd
 	}
 
 	return m, nil
@@ -352,12 +496,21 @@ func (m *DashboardModel) View() string {
 		content = m.renderPhases()
 	case "features":
 		content = m.renderFeatures()
+	case "tasks":
+		content = m.taskEditor.View()
 	case "github":
 		content = m.renderGitHub()
 	case "config":
 		content = m.renderConfig()
 	case "stats":
 		content = m.renderStats()
+	case "board":
+		content = m.board.View()
+	case "timeline":
+		content = m.timeline.View()
+	}
+	if m.palette != nil {
+		content = m.palette.View()
 	}
 
 	footer := m.renderFooter()
@@ -377,6 +530,11 @@ func (m *DashboardModel) View() string {
 	)
 }
 
+// contentHeight is the height left for a view below the header, menu and footer
+func (m *DashboardModel) contentHeight() int {
+	return m.height - lipgloss.Height(renderHeader(m.width, "dev")) - 10
+}
+
 func (m *DashboardModel) renderLoading() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -389,7 +547,7 @@ func (m *DashboardModel) renderLoading() string {
 // renderHeader renders the DoPlan header with ASCII art
 func renderHeader(width int, version string) string {
 	topBorder := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Width(width).
 		Render("╔" + repeatString("═", width-2) + "╗")
 
@@ -403,33 +561,33 @@ func renderHeader(width int, version string) string {
 `
 
 	styledLogo := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render(logo)
 
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#ffffff", Light: "#000000"}).
+		Foreground(theme.Text()).
 		Bold(true).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render("DoPlan")
 
 	subtitle := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#999999", Light: "#666666"}).
+		Foreground(theme.TextDim()).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render("Project Workflow Manager")
 
 	versionText := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#666666", Light: "#999999"}).
+		Foreground(theme.Muted()).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render(fmt.Sprintf("v%s", version))
 
 	bottomBorder := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Width(width).
 		Render("╚" + repeatString("═", width-2) + "╝")
 
@@ -455,16 +613,20 @@ func repeatString(s string, count int) string {
 }
 
 func (m *DashboardModel) renderMenu() string {
-	views := []string{"Dashboard", "Phases", "Features", "GitHub", "Config", "Stats"}
+	views := []string{"Dashboard", "Phases", "Features", "GitHub", "Config", "Stats", "Board", "Timeline"}
+	viewKeys := keymap.Current().Views()
 	menuItems := []string{}
 
 	for i, view := range views {
-		key := fmt.Sprintf("%d", i+1)
 		style := normalItemStyle
-		if m.currentView == strings.ToLower(view) {
+		if m.currentView == strings.ToLower(view) || (view == "Features" && m.currentView == "tasks") {
 			style = selectedItemStyle
 		}
-		menuItems = append(menuItems, style.Render(fmt.Sprintf("[%s] %s", key, view)))
+		item := view
+		if label := keymap.Label(viewKeys[i]); label != "" {
+			item = fmt.Sprintf("[%s] %s", label, view)
+		}
+		menuItems = append(menuItems, style.Render(item))
 	}
 
 	return lipgloss.JoinHorizontal(lipgloss.Left, menuItems...) + "\n" + strings.Repeat("─", m.width-4)
@@ -522,10 +684,10 @@ func (m *DashboardModel) renderDashboard() string {
 		}
 		
 		badge := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
-			Background(lipgloss.Color("#24292e")).
+			Foreground(theme.Text()).
+			Background(theme.Surface()).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#667eea")).
+			BorderForeground(theme.Primary()).
 			Padding(0, 1).
 			Render(badgeText)
 		sections = append(sections, badge)
@@ -533,9 +695,9 @@ func (m *DashboardModel) renderDashboard() string {
 	} else if m.config != nil && m.config.GitHub.Enabled {
 		// Show warning badge if GitHub is enabled but no repository configured
 		warningBadge := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#fbbf24")).
+			Foreground(theme.Warning()).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#fbbf24")).
+			BorderForeground(theme.Warning()).
 			Padding(0, 1).
 			Render("⚠️  GitHub repository not configured")
 		sections = append(sections, warningBadge)
@@ -691,9 +853,13 @@ func (m *DashboardModel) renderPhases() string {
 	sections = append(sections, titleStyle.Render("Project Phases"))
 	sections = append(sections, "")
 
-	for _, phase := range m.state.Phases {
+	for i, phase := range m.state.Phases {
 		progress := m.state.Progress.Phases[phase.ID]
-		sections = append(sections, fmt.Sprintf("Phase: %s", phase.Name))
+		if i == m.selectedPhase {
+			sections = append(sections, selectedItemStyle.Render(fmt.Sprintf("▸ Phase: %s", phase.Name)))
+		} else {
+			sections = append(sections, fmt.Sprintf("Phase: %s", phase.Name))
+		}
 		sections = append(sections, fmt.Sprintf("  Status: %s", phase.Status))
 		sections = append(sections, fmt.Sprintf("  Progress: %d%%", progress))
 		if phase.Description != "" {
@@ -714,23 +880,89 @@ func (m *DashboardModel) renderFeatures() string {
 	sections = append(sections, titleStyle.Render("Project Features"))
 	sections = append(sections, "")
 
-	for _, feature := range m.state.Features {
-		sections = append(sections, fmt.Sprintf("Feature: %s", feature.Name))
+	baseBranch := "main"
+	if m.config != nil && m.config.GitHub.BaseBranch != "" {
+		baseBranch = m.config.GitHub.BaseBranch
+	}
+	sections = append(sections, m.renderStacks(baseBranch)...)
+
+	if m.taskError != "" {
+		sections = append(sections, branchStatusStyle(github.BranchStatusStale).Render("⚠ "+m.taskError), "")
+	}
+
+	for i := range m.state.Features {
+		feature := m.state.Features[i]
+		if i == m.selectedFeature {
+			sections = append(sections, selectedItemStyle.Render(fmt.Sprintf("▸ Feature: %s", feature.Name)))
+		} else {
+			sections = append(sections, fmt.Sprintf("Feature: %s", feature.Name))
+		}
 		sections = append(sections, fmt.Sprintf("  Phase: %s", feature.Phase))
 		sections = append(sections, fmt.Sprintf("  Status: %s", feature.Status))
 		sections = append(sections, fmt.Sprintf("  Progress: %d%%", feature.Progress))
 		if feature.Branch != "" {
 			sections = append(sections, fmt.Sprintf("  Branch: %s", feature.Branch))
 		}
+		if parent := github.ParentFeature(m.state, &m.state.Features[i]); parent != nil {
+			sections = append(sections, fmt.Sprintf("  Stacked on: %s (%s)", parent.Name, parent.Branch))
+		}
 		if feature.PR != nil {
 			sections = append(sections, fmt.Sprintf("  PR: %s", feature.PR.URL))
 		}
+		if line := ciStatusLine(github.BranchCIStatus(m.githubData, feature.Branch)); line != "" {
+			sections = append(sections, line)
+		}
+		for _, flag := range feature.Flags {
+			sections = append(sections, branchStatusStyle(github.BranchStatusStale).Render("  ⚠ "+flag.Reason))
+		}
 		sections = append(sections, "")
 	}
 
 	return strings.Join(sections, "\n")
 }
 
+// renderStacks draws each stack of dependent feature branches as a tree
+func (m *DashboardModel) renderStacks(baseBranch string) []string {
+	stacks := github.Stacks(m.state, baseBranch)
+	if len(stacks) == 0 {
+		return nil
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Stacked Branches"))
+	for _, stack := range stacks {
+		lines = append(lines, "  "+helpStyle.Render(baseBranch))
+		for _, entry := range stack {
+			line := fmt.Sprintf("  %s└─ %s", strings.Repeat("   ", entry.Depth), entry.Feature.Name)
+			if entry.Feature.PR != nil && entry.Feature.PR.Number > 0 {
+				line += fmt.Sprintf(" #%d", entry.Feature.PR.Number)
+			}
+			style := normalItemStyle
+			if entry.Feature.BaseBranch != "" && entry.Feature.BaseBranch != entry.Base {
+				line += " (needs restack)"
+				style = branchStatusStyle(github.BranchStatusBehind)
+			}
+			lines = append(lines, style.Render(line))
+		}
+	}
+	lines = append(lines, "")
+
+	return lines
+}
+
+// ciStatusLine renders a feature branch's CI state for the features list
+func ciStatusLine(status models.CIStatus) string {
+	switch status.State {
+	case github.CIStateFailure:
+		return branchStatusStyle(github.BranchStatusStale).Render("  CI: ✗ failing: " + strings.Join(status.Failing, ", "))
+	case github.CIStatePending:
+		return "  CI: ● running"
+	case github.CIStateSuccess:
+		return progressCompleteStyle.Render("  CI: ✓ passing")
+	}
+	return ""
+}
+
 func (m *DashboardModel) renderGitHub() string {
 	if m.githubData == nil {
 		return "No GitHub data available"
@@ -738,6 +970,13 @@ func (m *DashboardModel) renderGitHub() string {
 
 	var sections []string
 	sections = append(sections, titleStyle.Render("GitHub Activity"))
+	sections = append(sections, helpStyle.Render(fmt.Sprintf("Last synced: %s", github.FormatSyncAge(m.githubData.SyncedAt, time.Now()))))
+	for _, source := range github.SyncSources {
+		if status, ok := m.githubData.Sources[source]; ok && status.Stale {
+			line := fmt.Sprintf("⚠ %s offline (cached %s): %s", source, github.FormatSyncAge(status.LastSynced, time.Now()), status.Error)
+			sections = append(sections, branchStatusStyle(github.BranchStatusStale).Render(line))
+		}
+	}
 	sections = append(sections, "")
 
 	sections = append(sections, fmt.Sprintf("Branches: %d", len(m.githubData.Branches)))
@@ -745,6 +984,25 @@ func (m *DashboardModel) renderGitHub() string {
 	sections = append(sections, fmt.Sprintf("Pull Requests: %d", len(m.githubData.PRs)))
 	sections = append(sections, "")
 
+	var attention []models.Branch
+	for _, branch := range m.githubData.Branches {
+		if github.NeedsAttention(branch) {
+			attention = append(attention, branch)
+		}
+	}
+	if len(attention) > 0 {
+		sections = append(sections, titleStyle.Render("Branches Needing Attention"))
+		for _, branch := range attention {
+			line := fmt.Sprintf("  %s [%s] ↑%d ↓%d", branch.Name, branch.Status, branch.AheadCount, branch.BehindCount)
+			if branch.HasPR {
+				line += " (PR)"
+			}
+			sections = append(sections, branchStatusStyle(branch.Status).Render(line))
+		}
+		sections = append(sections, helpStyle.Render("  Run 'doplan github branches prune' or 'doplan github branches rebase-report'"))
+		sections = append(sections, "")
+	}
+
 	if len(m.githubData.PRs) > 0 {
 		sections = append(sections, titleStyle.Render("Recent PRs"))
 		for i, pr := range m.githubData.PRs {
@@ -758,6 +1016,20 @@ func (m *DashboardModel) renderGitHub() string {
 	return strings.Join(sections, "\n")
 }
 
+// branchStatusStyle colors a branch by how urgently it needs attention
+func branchStatusStyle(status string) lipgloss.Style {
+	switch status {
+	case github.BranchStatusDiverged, github.BranchStatusOrphan:
+		return lipgloss.NewStyle().Foreground(theme.Error())
+	case github.BranchStatusStale, github.BranchStatusBehind:
+		return lipgloss.NewStyle().Foreground(theme.Warning())
+	case github.BranchStatusMerged:
+		return progressTodoStyle
+	default:
+		return normalItemStyle
+	}
+}
+
 func (m *DashboardModel) renderConfig() string {
 	if m.config == nil {
 		return "No configuration available"
@@ -782,6 +1054,12 @@ func (m *DashboardModel) renderConfig() string {
 	return strings.Join(sections, "\n")
 }
 
+// Size of the burndown charts on the stats screen
+const (
+	statsBurnChartWidth  = 40
+	statsBurnChartHeight = 6
+)
+
 func (m *DashboardModel) renderStats() string {
 	if m.statistics == nil {
 		return "No statistics available. Run 'doplan stats' to generate statistics."
@@ -850,16 +1128,111 @@ func (m *DashboardModel) renderStats() string {
 		sections = append(sections, fmt.Sprintf("  Quality:    %s", m.statistics.Trends.QualityTrend))
 	}
 
+	// Burndown
+	if burndown := m.statistics.Burndown; burndown != nil {
+		sections = append(sections, "")
+		sections = append(sections, titleStyle.Render("Burndown"))
+		series := append([]*statistics.BurnSeries{burndown.Project}, burndown.Phases...)
+		for _, s := range series {
+			if s == nil {
+				continue
+			}
+			sections = append(sections, "  "+s.Name)
+			for _, line := range strings.Split(statistics.RenderBurnChart(s, statsBurnChartWidth, statsBurnChartHeight), "\n") {
+				sections = append(sections, "    "+line)
+			}
+		}
+	}
+
+	// Forecast
+	if forecast := m.statistics.Forecast; forecast != nil {
+		sections = append(sections, "")
+		sections = append(sections, titleStyle.Render("Forecast"))
+		forecasts := append([]*statistics.Forecast{forecast.Project}, forecast.Phases...)
+		for _, f := range forecasts {
+			if f == nil {
+				continue
+			}
+			if !f.HasDates() {
+				sections = append(sections, fmt.Sprintf("  %s: %s", f.Name, f.Note))
+				continue
+			}
+			sections = append(sections, fmt.Sprintf("  %s: P50 %s  P85 %s  P95 %s", f.Name,
+				f.P50.Format("2006-01-02"), f.P85.Format("2006-01-02"), f.P95.Format("2006-01-02")))
+			if !f.TargetDate.IsZero() {
+				line := fmt.Sprintf("    Target %s: %.0f%% likely", f.TargetDate.Format("2006-01-02"), f.OnTargetChance)
+				if f.AtRisk {
+					line = branchStatusStyle(github.BranchStatusDiverged).Render(line + " ⚠ at risk")
+				}
+				sections = append(sections, line)
+			}
+		}
+	}
+
+	// Test Results
+	if tests := m.statistics.Tests; tests != nil && tests.Latest != nil {
+		sections = append(sections, "")
+		sections = append(sections, titleStyle.Render("Tests"))
+		latest := tests.Latest
+		sections = append(sections, fmt.Sprintf("  Latest run: %d passed, %d failed, %d skipped (%.2fs)",
+			latest.Passed, latest.Failed, latest.Skipped, latest.Duration))
+		failStyle := branchStatusStyle(github.BranchStatusDiverged)
+		for _, failure := range tests.Failures {
+			line := "  ✗ " + statsTestName(failure.Package, failure.Name)
+			if len(failure.Features) > 0 {
+				line += " (" + strings.Join(failure.Features, ", ") + ")"
+			}
+			sections = append(sections, failStyle.Render(line))
+		}
+		if len(tests.Flaky) > 0 {
+			sections = append(sections, "  Flaky:")
+			for _, flaky := range tests.Flaky {
+				sections = append(sections, fmt.Sprintf("    %s: %d/%d runs failed", statsTestName(flaky.Package, flaky.Name),
+					flaky.Failures, flaky.Passes+flaky.Failures))
+			}
+		}
+		if len(tests.Slowest) > 0 {
+			sections = append(sections, "  Slowest:")
+			for i, timing := range tests.Slowest {
+				if i >= 5 {
+					break
+				}
+				sections = append(sections, fmt.Sprintf("    %7.2fs %s", timing.Duration, statsTestName(timing.Package, timing.Name)))
+			}
+		}
+	}
+
 	return strings.Join(sections, "\n")
 }
 
+// statsTestName names a test by package, or the package alone for package-level failures
+func statsTestName(pkg, name string) string {
+	if name == "" {
+		return pkg
+	}
+	return pkg + " " + name
+}
+
 func (m *DashboardModel) updateStats(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Stats view is read-only, just handle navigation
 	return m, nil
 }
 
 func (m *DashboardModel) renderFooter() string {
-	help := helpStyle.Render("Press [1-6] to switch views | [r] to refresh | [q] to quit")
+	keys := keymap.Current()
+	items := []keymap.HelpItem{keymap.Item("switch views", keys.Views()...)}
+	if m.currentView == "features" {
+		items = append(items, keymap.Item("select", keys.Up, keys.Down), keymap.Item("edit tasks", keys.Select))
+	}
+	items = append(items, keymap.Item("palette", keys.Palette), keymap.Item("refresh", keys.Refresh), keymap.Item("quit", keys.Quit))
+	help := helpStyle.Render(keymap.Help(items...))
+	if m.notice != "" {
+		style := taskMessageStyle
+		if m.noticeWarning {
+			style = taskWarningStyle
+		}
+		help = style.Render(m.notice) + "\n" + help
+	}
 
 	// Add last update time if using dashboard.json
 	updateInfo := ""
@@ -879,6 +1252,7 @@ func (m *DashboardModel) setupLists() {
 		}
 		m.phaseList = list.New(items, list.NewDefaultDelegate(), m.width-10, m.height-10)
 		m.phaseList.Title = "Phases"
+		m.phaseList.KeyMap = keymap.Current().List()
 	}
 
 	// Setup feature list
@@ -889,6 +1263,7 @@ func (m *DashboardModel) setupLists() {
 		}
 		m.featureList = list.New(items, list.NewDefaultDelegate(), m.width-10, m.height-10)
 		m.featureList.Title = "Features"
+		m.featureList.KeyMap = keymap.Current().List()
 	}
 }
 
@@ -906,6 +1281,24 @@ func (m *DashboardModel) updatePhases(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *DashboardModel) updateFeatures(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.state != nil && len(m.state.Features) > 0 {
+		keys := keymap.Current()
+		switch {
+		case key.Matches(keyMsg, keys.Up):
+			if m.selectedFeature > 0 {
+				m.selectedFeature--
+			}
+			return m, nil
+		case key.Matches(keyMsg, keys.Down):
+			if m.selectedFeature < len(m.state.Features)-1 {
+				m.selectedFeature++
+			}
+			return m, nil
+		case key.Matches(keyMsg, keys.Select):
+			return m.openTasks(m.state.Features[m.selectedFeature].ID)
+		}
+	}
+
 	if m.featureList.Items() != nil && len(m.featureList.Items()) > 0 {
 		var cmd tea.Cmd
 		m.featureList, cmd = m.featureList.Update(msg)
@@ -914,6 +1307,100 @@ func (m *DashboardModel) updateFeatures(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// openTasks opens the task editor for a feature
+func (m *DashboardModel) openTasks(featureID string) (tea.Model, tea.Cmd) {
+	projectRoot, _ := os.Getwd()
+	editor, err := NewTaskEditorModel(projectRoot, featureID, m.refresh)
+	if err != nil {
+		m.taskError = err.Error()
+		return m, nil
+	}
+	m.taskError = ""
+	m.taskEditor = editor
+	m.currentView = "tasks"
+	return m, nil
+}
+
+func (m *DashboardModel) updateTasks(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && key.Matches(keyMsg, keymap.Current().Back) && !m.taskEditor.Typing() {
+		// Back to the features, reloaded to show the edits
+		m.currentView = "features"
+		m.taskEditor = nil
+		m.loading = true
+		return m, tea.Batch(loadDataCmd, m.spinner.Tick)
+	}
+
+	var cmd tea.Cmd
+	m.taskEditor, cmd = m.taskEditor.Update(msg)
+	return m, cmd
+}
+
+// paletteDoneMsg reports a finished palette action or editor session
+type paletteDoneMsg struct {
+	message string
+	err     error
+}
+
+// openPaletteItem shows the item picked in the palette: phases in the phases view,
+// features and tasks in the task editor. Actions run in the background and
+// documents open in $EDITOR.
+func (m *DashboardModel) openPaletteItem(msg paletteChosenMsg) (tea.Model, tea.Cmd) {
+	item := msg.item
+	if m.palette != nil {
+		if err := m.palette.Record(item); err != nil {
+			m.notice, m.noticeWarning = "Could not save the palette history: "+err.Error(), true
+		}
+		m.palette = nil
+	}
+
+	projectRoot, _ := os.Getwd()
+	if msg.edit || item.Kind == palette.KindDoc {
+		cmd, err := utils.EditorCommand(item.Path)
+		if err != nil {
+			m.notice, m.noticeWarning = "Set EDITOR to open files: "+err.Error(), true
+			return m, nil
+		}
+		rel, _ := filepath.Rel(projectRoot, item.Path)
+		return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return paletteDoneMsg{message: "Closed " + rel, err: err}
+		})
+	}
+
+	switch item.Kind {
+	case palette.KindAction:
+		m.notice, m.noticeWarning = item.Title+"...", false
+		refresh := m.refresh
+		return m, func() tea.Msg {
+			message, err := palette.Run(projectRoot, item, refresh)
+			return paletteDoneMsg{message: message, err: err}
+		}
+	case palette.KindPhase:
+		m.currentView = "phases"
+		if m.state != nil {
+			for i, phase := range m.state.Phases {
+				if phase.ID == item.PhaseID {
+					m.selectedPhase = i
+				}
+			}
+		}
+	case palette.KindFeature, palette.KindTask:
+		if m.state != nil {
+			for i, feature := range m.state.Features {
+				if feature.ID == item.FeatureID {
+					m.selectedFeature = i
+				}
+			}
+		}
+		m.currentView = "features"
+		model, cmd := m.openTasks(item.FeatureID)
+		if m.currentView == "tasks" && item.Kind == palette.KindTask {
+			m.taskEditor.SelectTask(item.Task)
+		}
+		return model, cmd
+	}
+	return m, nil
+}
+
 func (m *DashboardModel) updateGitHub(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
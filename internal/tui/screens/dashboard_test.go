@@ -0,0 +1,42 @@
+package screens
+
+import (
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadStatisticsCmd_UsesStateFile(t *testing.T) {
+	projectRoot := setupTaskEditorProject(t)
+	cfgMgr := config.NewManager(projectRoot)
+	state, err := cfgMgr.LoadState()
+	require.NoError(t, err)
+	state.Phases[0].StartDate, state.Phases[0].TargetDate = "2026-03-01", "2026-04-01"
+	require.NoError(t, cfgMgr.SaveState(state))
+
+	// A state converted from dashboard.json has no task phases or phase dates
+	converted := &models.State{
+		Phases:   []models.Phase{{ID: "01-phase", Name: "Foundation", Features: []string{"01"}}},
+		Features: []models.Feature{{ID: "01", Phase: "01-phase", Name: "Login", Status: "todo"}},
+	}
+
+	msg := loadStatisticsCmd(projectRoot, converted, nil, config.NewConfig("cursor"))()
+	loaded, ok := msg.(statisticsLoadedMsg)
+	require.True(t, ok)
+	require.NotNil(t, loaded.statistics)
+	require.NotNil(t, loaded.statistics.Burndown)
+
+	project := loaded.statistics.Burndown.Project
+	assert.Equal(t, statistics.BurnUnitTasks, project.Unit)
+	assert.True(t, project.HasTarget(), "the target comes from the phase dates in the state file")
+
+	require.NotNil(t, loaded.statistics.Forecast)
+	forecast := loaded.statistics.Forecast.Project
+	assert.Equal(t, statistics.BurnUnitTasks, forecast.Unit)
+	assert.Equal(t, 2, forecast.Remaining)
+	assert.False(t, forecast.TargetDate.IsZero())
+}
@@ -0,0 +1,211 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/palette"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	paletteStyle      lipgloss.Style
+	paletteMatchStyle lipgloss.Style
+	paletteKindStyle  lipgloss.Style
+)
+
+func init() {
+	theme.OnChange(func() {
+		paletteStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.Primary()).
+			Padding(0, 1)
+
+		paletteMatchStyle = lipgloss.NewStyle().Foreground(theme.Warning()).Bold(true)
+		paletteKindStyle = lipgloss.NewStyle().Foreground(theme.Info())
+	})
+}
+
+// paletteKindLabels name item kinds in the results
+var paletteKindLabels = map[string]string{
+	palette.KindAction:  "action",
+	palette.KindPhase:   "phase",
+	palette.KindFeature: "feature",
+	palette.KindTask:    "task",
+	palette.KindDoc:     "doc",
+}
+
+// PaletteModel is the ctrl+k command palette: a fuzzy search over the project's
+// phases, features, tasks, documents and actions
+type PaletteModel struct {
+	items   []palette.Item
+	history *palette.History
+	input   textinput.Model
+	results []palette.Result
+	cursor  int
+	top     int // First result shown
+	width   int
+	height  int
+}
+
+// paletteChosenMsg carries the item picked in the palette. With edit set it is
+// opened in $EDITOR rather than shown.
+type paletteChosenMsg struct {
+	item palette.Item
+	edit bool
+}
+
+// NewPaletteModel indexes the project at projectRoot for the palette
+func NewPaletteModel(projectRoot string, state *models.State) *PaletteModel {
+	ti := textinput.New()
+	ti.Placeholder = "Search phases, features, tasks, docs and actions"
+	ti.Prompt = "› "
+	ti.CharLimit = 100
+	ti.Focus()
+
+	m := &PaletteModel{
+		items:   palette.Index(projectRoot, state),
+		history: palette.LoadHistory(projectRoot),
+		input:   ti,
+	}
+	m.search()
+	return m
+}
+
+// SetSize sets the space the palette fills
+func (m *PaletteModel) SetSize(width, height int) {
+	m.width, m.height = width, height
+	m.input.Width = width - 8
+}
+
+func (m *PaletteModel) search() {
+	m.results = palette.Search(m.items, m.input.Value(), m.history, time.Now())
+	m.cursor, m.top = 0, 0
+}
+
+func (m *PaletteModel) visibleResults() int {
+	// Leave room for the border, input and help
+	if h := m.height - 8; h > 3 {
+		return h
+	}
+	return 10
+}
+
+// Record remembers an item as just picked, so it ranks higher next time
+func (m *PaletteModel) Record(item palette.Item) error {
+	return m.history.Record(item.Key, time.Now())
+}
+
+// Update handles typing the query and picking a result
+func (m *PaletteModel) Update(msg tea.Msg) (*PaletteModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "up", "ctrl+p":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "ctrl+n", "tab":
+		if m.cursor < len(m.results)-1 {
+			m.cursor++
+		}
+	case "enter":
+		if m.cursor < len(m.results) {
+			item := m.results[m.cursor].Item
+			return m, func() tea.Msg { return paletteChosenMsg{item: item} }
+		}
+	case "ctrl+e":
+		if m.cursor < len(m.results) && m.results[m.cursor].Item.Path != "" {
+			item := m.results[m.cursor].Item
+			return m, func() tea.Msg { return paletteChosenMsg{item: item, edit: true} }
+		}
+	default:
+		query := m.input.Value()
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(keyMsg)
+		if m.input.Value() != query {
+			m.search()
+		}
+		return m, cmd
+	}
+
+	// Keep the selected result on screen
+	if m.cursor < m.top {
+		m.top = m.cursor
+	}
+	if rows := m.visibleResults(); m.cursor >= m.top+rows {
+		m.top = m.cursor - rows + 1
+	}
+	return m, nil
+}
+
+// View renders the query and the ranked results
+func (m *PaletteModel) View() string {
+	width := m.width - 4
+	if width < 40 {
+		width = 60
+	}
+
+	lines := []string{m.input.View(), ""}
+	if len(m.results) == 0 {
+		lines = append(lines, helpStyle.Render("  No matches"))
+	}
+	end := m.top + m.visibleResults()
+	if end > len(m.results) {
+		end = len(m.results)
+	}
+	for i := m.top; i < end; i++ {
+		result := m.results[i]
+		pointer := "  "
+		title := highlightMatches(result.Item.Title, result.Matched)
+		if i == m.cursor {
+			pointer = selectedItemStyle.Render("▸ ")
+			if len(result.Matched) == 0 {
+				title = selectedItemStyle.Render(title)
+			}
+		}
+		kind := paletteKindStyle.Render(fmt.Sprintf("%-8s", paletteKindLabels[result.Item.Kind]))
+		detail := ""
+		if result.Item.Detail != "" {
+			detail = helpStyle.Render("  " + truncate(result.Item.Detail, width/2))
+		}
+		lines = append(lines, pointer+kind+" "+title+detail)
+	}
+	if len(m.results) > end {
+		lines = append(lines, helpStyle.Render(fmt.Sprintf("  … %d more", len(m.results)-end)))
+	}
+
+	lines = append(lines, "", helpStyle.Render("[↑/↓] select | [enter] open or run | [ctrl+e] open in $EDITOR | [esc] close"))
+	return titleStyle.Render("Command Palette") + "\n" + paletteStyle.Width(width).Render(strings.Join(lines, "\n"))
+}
+
+// highlightMatches emphasizes the matched bytes of a title
+func highlightMatches(title string, matched []int) string {
+	if len(matched) == 0 {
+		return title
+	}
+	isMatch := make(map[int]bool, len(matched))
+	for _, index := range matched {
+		isMatch[index] = true
+	}
+
+	var sb strings.Builder
+	for i, r := range title {
+		if isMatch[i] {
+			sb.WriteString(paletteMatchStyle.Render(string(r)))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,51 @@
+package screens
+
+import (
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/internal/palette"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaletteModel(t *testing.T) {
+	projectRoot := setupTaskEditorProject(t)
+	state, err := config.NewManager(projectRoot).LoadState()
+	require.NoError(t, err)
+
+	m := NewPaletteModel(projectRoot, state)
+	m.SetSize(100, 30)
+	assert.Equal(t, "action:sync-github", m.results[0].Item.Key, "nothing was picked yet, so actions come first")
+
+	for _, key := range []string{"a", "d", "d", "c"} {
+		m, _ = m.Update(keyPress(key))
+	}
+	require.NotEmpty(t, m.results)
+	assert.Equal(t, "task:01:Add config", m.results[0].Item.Key)
+	assert.Contains(t, m.View(), "Login › Setup")
+
+	// The task has no tasks.md to open in $EDITOR
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	assert.Nil(t, cmd)
+
+	_, cmd = m.Update(keyPress("enter"))
+	require.NotNil(t, cmd)
+	chosen, ok := cmd().(paletteChosenMsg)
+	require.True(t, ok)
+	assert.Equal(t, "Add config", chosen.item.Task)
+	assert.False(t, chosen.edit)
+
+	// Picked items rank first when the palette opens again
+	require.NoError(t, m.Record(chosen.item))
+	m = NewPaletteModel(projectRoot, state)
+	assert.Equal(t, "task:01:Add config", m.results[0].Item.Key)
+	assert.Equal(t, palette.KindTask, m.results[0].Item.Kind)
+
+	for _, key := range []string{"z", "z", "z"} {
+		m, _ = m.Update(keyPress(key))
+	}
+	assert.Empty(t, m.results)
+	assert.Contains(t, m.View(), "No matches")
+}
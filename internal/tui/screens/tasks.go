@@ -0,0 +1,325 @@
+package screens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DoPlan-dev/CLI/internal/tasks"
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	taskDoneStyle    lipgloss.Style
+	taskGroupStyle   lipgloss.Style
+	taskMessageStyle lipgloss.Style
+	taskWarningStyle lipgloss.Style
+)
+
+func init() {
+	theme.OnChange(func() {
+		taskDoneStyle = lipgloss.NewStyle().Foreground(theme.Success())
+		taskGroupStyle = lipgloss.NewStyle().Foreground(theme.Info()).Bold(true)
+		taskMessageStyle = lipgloss.NewStyle().Foreground(theme.Success())
+		taskWarningStyle = lipgloss.NewStyle().Foreground(theme.Warning())
+	})
+}
+
+// TaskEditorModel edits one feature's tasks and status. Edits are saved as they
+// are made through tasks.Editor and its RefreshFunc.
+type TaskEditorModel struct {
+	editor *tasks.Editor
+	cursor int
+
+	// Adding or renaming a task
+	input textinput.Model
+	mode  string // "", "add", "rename"
+
+	message  string
+	warning  bool
+	conflict bool // tasks.md or the state changed on disk; edits wait for a reload
+}
+
+// taskRow is a line of the editor: a group heading (index -1) or a task
+type taskRow struct {
+	group, index int
+}
+
+// NewTaskEditorModel opens the task editor for a feature; edits save through refresh
+func NewTaskEditorModel(projectRoot, featureID string, refresh tasks.RefreshFunc) (*TaskEditorModel, error) {
+	editor, err := tasks.Open(projectRoot, featureID, refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	ti := textinput.New()
+	ti.CharLimit = 200
+	ti.Width = 50
+
+	m := &TaskEditorModel{editor: editor, input: ti}
+	// Start on the first task rather than a heading
+	for i, row := range m.rows() {
+		if row.index >= 0 {
+			m.cursor = i
+			break
+		}
+	}
+	return m, nil
+}
+
+// Typing reports whether a task name is being entered, so keys go to the input
+func (m *TaskEditorModel) Typing() bool {
+	return m.mode != ""
+}
+
+func (m *TaskEditorModel) rows() []taskRow {
+	var rows []taskRow
+	for i, phase := range m.editor.Phases() {
+		rows = append(rows, taskRow{group: i, index: -1})
+		for j := range phase.Tasks {
+			rows = append(rows, taskRow{group: i, index: j})
+		}
+	}
+	return rows
+}
+
+// SelectTask moves the cursor to the first task with the given name
+func (m *TaskEditorModel) SelectTask(name string) bool {
+	phases := m.editor.Phases()
+	for i, row := range m.rows() {
+		if row.index >= 0 && phases[row.group].Tasks[row.index].Name == name {
+			m.cursor = i
+			return true
+		}
+	}
+	return false
+}
+
+func (m *TaskEditorModel) current() (taskRow, bool) {
+	rows := m.rows()
+	if m.cursor < 0 || m.cursor >= len(rows) {
+		return taskRow{}, false
+	}
+	return rows[m.cursor], true
+}
+
+// Update handles keys in the editor
+func (m *TaskEditorModel) Update(msg tea.Msg) (*TaskEditorModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		if m.Typing() {
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if m.Typing() {
+		return m.updateInput(keyMsg)
+	}
+
+	row, hasRow := m.current()
+	keys := keymap.Current()
+	switch {
+	case key.Matches(keyMsg, keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, keys.Down):
+		if m.cursor < len(m.rows())-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, keys.ToggleTask):
+		if hasRow && row.index >= 0 {
+			m.apply(m.editor.Toggle(row.group, row.index), "")
+		}
+	case key.Matches(keyMsg, keys.AddTask):
+		m.mode = "add"
+		m.input.SetValue("")
+		m.input.Placeholder = "New task"
+		return m, m.input.Focus()
+	case key.Matches(keyMsg, keys.RenameTask):
+		if hasRow && row.index >= 0 {
+			m.mode = "rename"
+			m.input.SetValue(m.editor.Phases()[row.group].Tasks[row.index].Name)
+			m.input.CursorEnd()
+			return m, m.input.Focus()
+		}
+	case key.Matches(keyMsg, keys.MoveTaskUp):
+		if hasRow && row.index > 0 {
+			if m.apply(m.editor.Move(row.group, row.index, -1), "") {
+				m.cursor--
+			}
+		}
+	case key.Matches(keyMsg, keys.MoveTaskDown):
+		if hasRow && row.index >= 0 && row.index < len(m.editor.Phases()[row.group].Tasks)-1 {
+			if m.apply(m.editor.Move(row.group, row.index, 1), "") {
+				m.cursor++
+			}
+		}
+	case key.Matches(keyMsg, keys.CycleStatus):
+		status := m.editor.NextStatus()
+		m.apply(m.editor.SetStatus(status), "Status set to "+status)
+	case key.Matches(keyMsg, keys.Undo):
+		if m.editor.CanUndo() {
+			m.apply(m.editor.Undo(), "Undone")
+		} else {
+			m.message, m.warning = "Nothing to undo", true
+		}
+	case key.Matches(keyMsg, keys.Reload):
+		if err := m.editor.Reload(); err != nil {
+			m.message, m.warning = err.Error(), true
+		} else {
+			m.conflict = false
+			m.message, m.warning = "Reloaded from disk", false
+		}
+		m.clampCursor()
+	}
+	return m, nil
+}
+
+func (m *TaskEditorModel) updateInput(msg tea.KeyMsg) (*TaskEditorModel, tea.Cmd) {
+	keys := keymap.Current()
+	switch {
+	case keymap.MatchesWhileTyping(msg, keys.Back):
+		m.mode = ""
+		m.input.Blur()
+		return m, nil
+	case keymap.MatchesWhileTyping(msg, keys.Select):
+		name := m.input.Value()
+		row, hasRow := m.current()
+		switch {
+		case m.mode == "rename" && hasRow:
+			m.apply(m.editor.Rename(row.group, row.index, name), "")
+		case m.mode == "add":
+			// Without any group yet, the task starts a new one
+			group := len(m.editor.Phases())
+			if hasRow {
+				group = row.group
+			}
+			if m.apply(m.editor.Add(group, name), "") {
+				m.cursor = m.lastRowOf(group)
+			}
+		}
+		m.mode = ""
+		m.input.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// apply reports the outcome of an edit, returning whether it was saved
+func (m *TaskEditorModel) apply(err error, success string) bool {
+	switch {
+	case errors.Is(err, tasks.ErrConflict):
+		m.conflict = true
+		m.message, m.warning = fmt.Sprintf("Changed on disk since it was opened; press %s to reload (the edit was not saved)", keymap.Label(keymap.Current().Reload)), true
+	case err != nil:
+		m.message, m.warning = err.Error(), true
+	default:
+		m.message, m.warning = success, false
+		if warnings := m.editor.Warnings(); len(warnings) > 0 {
+			m.message, m.warning = strings.Join(warnings, "; "), true
+		}
+	}
+	m.clampCursor()
+	return err == nil
+}
+
+func (m *TaskEditorModel) lastRowOf(group int) int {
+	last := 0
+	for i, row := range m.rows() {
+		if row.group == group {
+			last = i
+		}
+	}
+	return last
+}
+
+func (m *TaskEditorModel) clampCursor() {
+	if rows := len(m.rows()); m.cursor >= rows {
+		m.cursor = rows - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// View renders the feature's task groups
+func (m *TaskEditorModel) View() string {
+	feature := m.editor.Feature()
+
+	var sections []string
+	sections = append(sections, titleStyle.Render("Tasks: "+feature.Name))
+	sections = append(sections, fmt.Sprintf("  Status: %s | Progress: %d%% | %s", feature.Status, feature.Progress, m.editor.Path()))
+	sections = append(sections, "")
+
+	rows := m.rows()
+	if len(rows) == 0 {
+		sections = append(sections, "  No tasks yet. Press ["+keymap.Label(keymap.Current().AddTask)+"] to add one.")
+	}
+	phases := m.editor.Phases()
+	for i, row := range rows {
+		pointer := "  "
+		if i == m.cursor {
+			pointer = selectedItemStyle.Render("▸ ")
+		}
+		if row.index < 0 {
+			sections = append(sections, pointer+taskGroupStyle.Render(phases[row.group].Name))
+			continue
+		}
+
+		task := phases[row.group].Tasks[row.index]
+		line := "[ ] " + task.Name
+		if task.Completed {
+			line = taskDoneStyle.Render("[x] " + task.Name)
+		}
+		if i == m.cursor && m.mode == "rename" {
+			line = "[ ] " + m.input.View()
+		}
+		sections = append(sections, pointer+"  "+line)
+	}
+
+	if m.mode == "add" {
+		sections = append(sections, "", "  New task: "+m.input.View())
+	}
+	if m.message != "" {
+		style := taskMessageStyle
+		if m.warning {
+			style = taskWarningStyle
+		}
+		sections = append(sections, "", style.Render("  "+m.message))
+	}
+
+	sections = append(sections, "", helpStyle.Render(m.help()))
+	return strings.Join(sections, "\n")
+}
+
+func (m *TaskEditorModel) help() string {
+	keys := keymap.Current()
+	if m.Typing() {
+		return keymap.Help(keymap.Item("save", keys.Select), keymap.Item("cancel", keys.Back))
+	}
+	if m.conflict {
+		return keymap.Help(keymap.Item("reload from disk", keys.Reload), keymap.Item("back to features", keys.Back))
+	}
+	return keymap.Help(
+		keymap.Item("toggle", keys.ToggleTask),
+		keymap.Item("add", keys.AddTask),
+		keymap.Item("rename", keys.RenameTask),
+		keymap.Item("move", keys.MoveTaskDown, keys.MoveTaskUp),
+		keymap.Item("status", keys.CycleStatus),
+		keymap.Item("undo", keys.Undo),
+		keymap.Item("reload", keys.Reload),
+		keymap.Item("back", keys.Back),
+	)
+}
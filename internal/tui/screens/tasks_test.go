@@ -0,0 +1,109 @@
+package screens
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTaskEditorProject(t *testing.T) string {
+	t.Helper()
+	return helpers.SetupInstalledProject(t, nil, &models.State{
+		Phases: []models.Phase{{ID: "01-phase", Name: "Foundation", Features: []string{"01"}}},
+		Features: []models.Feature{{ID: "01", Phase: "01-phase", Name: "Login", Status: "todo", TaskPhases: []models.TaskPhase{
+			{Name: "Setup", Tasks: []models.Task{{Name: "Create branch"}, {Name: "Add config"}}},
+		}}},
+	})
+}
+
+func keyPress(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestTaskEditorModel(t *testing.T) {
+	projectRoot := setupTaskEditorProject(t)
+	m, err := NewTaskEditorModel(projectRoot, "01", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.cursor, "the cursor starts on the first task")
+
+	m, _ = m.Update(keyPress(" "))
+	assert.True(t, m.editor.Phases()[0].Tasks[0].Completed)
+
+	// Typed keys go to the name, not to shortcuts
+	m, _ = m.Update(keyPress("a"))
+	require.True(t, m.Typing())
+	for _, key := range []string{"D", "o", "c", "s"} {
+		m, _ = m.Update(keyPress(key))
+	}
+	m, _ = m.Update(keyPress("enter"))
+	assert.False(t, m.Typing())
+	require.Len(t, m.editor.Phases()[0].Tasks, 3)
+	assert.Equal(t, "Docs", m.editor.Phases()[0].Tasks[2].Name)
+	assert.Equal(t, 3, m.cursor, "the cursor follows the new task")
+
+	m, _ = m.Update(keyPress("K"))
+	assert.Equal(t, "Docs", m.editor.Phases()[0].Tasks[1].Name)
+	assert.Equal(t, 2, m.cursor)
+
+	content, err := os.ReadFile(filepath.Join(projectRoot, "doplan", "01-phase", "01-Feature", "tasks.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "- [x] Create branch\n- [ ] Docs\n- [ ] Add config\n")
+
+	m, _ = m.Update(keyPress("u"))
+	m, _ = m.Update(keyPress("u"))
+	assert.Len(t, m.editor.Phases()[0].Tasks, 2)
+	assert.Contains(t, m.View(), "Undone")
+
+	require.NoError(t, os.WriteFile(m.editor.Path(), []byte("- [ ] Elsewhere\n"), 0644))
+	m, _ = m.Update(keyPress(" "))
+	assert.True(t, m.conflict)
+	assert.Contains(t, m.View(), "press R to reload")
+	m, _ = m.Update(keyPress("R"))
+	assert.False(t, m.conflict)
+	assert.Contains(t, m.View(), "Elsewhere")
+}
+
+func TestTaskEditorModel_SelectTask(t *testing.T) {
+	m, err := NewTaskEditorModel(setupTaskEditorProject(t), "01", nil)
+	require.NoError(t, err)
+
+	assert.True(t, m.SelectTask("Add config"))
+	assert.Equal(t, 2, m.cursor)
+	assert.False(t, m.SelectTask("Missing"))
+	assert.Equal(t, 2, m.cursor)
+}
+
+func TestTaskEditorModel_Keymap(t *testing.T) {
+	keys, err := keymap.File{Preset: keymap.Emacs, Bindings: map[string][]string{"tasks.add": {"n"}}}.Build()
+	require.NoError(t, err)
+	previous := keymap.Current()
+	keymap.Use(keys)
+	t.Cleanup(func() { keymap.Use(previous) })
+
+	m, err := NewTaskEditorModel(setupTaskEditorProject(t), "01", nil)
+	require.NoError(t, err)
+	assert.Contains(t, m.View(), "[n] add", "the help follows the keymap")
+	assert.Contains(t, m.View(), "[alt+n/alt+p] move")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.Equal(t, 2, m.cursor)
+	m, _ = m.Update(keyPress("j"))
+	assert.Equal(t, 2, m.cursor, "the emacs preset leaves j free")
+	m, _ = m.Update(keyPress("n"))
+	assert.True(t, m.Typing())
+}
@@ -0,0 +1,329 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// timelineZooms are the days one column covers at each zoom level
+var timelineZooms = []struct {
+	days int
+	name string
+}{
+	{1, "day"},
+	{7, "week"},
+	{30, "month"},
+}
+
+const timelineLabelWidth = 24
+
+var timelinePhaseStyle lipgloss.Style
+
+func init() {
+	theme.OnChange(func() {
+		timelinePhaseStyle = lipgloss.NewStyle().Foreground(theme.Primary())
+	})
+}
+
+// TimelineModel shows phases and features as a gantt chart that scrolls and zooms
+type TimelineModel struct {
+	projectRoot string
+	timeline    *statistics.Timeline
+	loadErr     string
+	width       int
+	height      int
+
+	zoom   int // Index into timelineZooms
+	offset int // First column shown, counted from the timeline start
+	cursor int // Selected row
+	top    int // First row shown
+}
+
+// NewTimelineModel loads the timeline of the project at projectRoot
+func NewTimelineModel(projectRoot string, now time.Time) *TimelineModel {
+	m := &TimelineModel{projectRoot: projectRoot}
+	m.reload(now)
+	m.scrollToToday()
+	return m
+}
+
+func (m *TimelineModel) reload(now time.Time) {
+	state, err := config.NewManager(m.projectRoot).LoadState()
+	if err != nil {
+		m.loadErr = err.Error()
+		return
+	}
+	m.loadErr = ""
+	githubData, _ := github.NewGitHubSync(m.projectRoot).LoadData()
+	m.timeline = statistics.LoadTimeline(m.projectRoot, state, githubData, now)
+	if m.timeline != nil && m.cursor >= len(m.timeline.Rows) {
+		m.cursor = len(m.timeline.Rows) - 1
+	}
+}
+
+// SetSize sets the space the chart fills
+func (m *TimelineModel) SetSize(width, height int) {
+	m.width, m.height = width, height
+}
+
+func (m *TimelineModel) plotWidth() int {
+	if w := m.width - timelineLabelWidth - 2; w > 10 {
+		return w
+	}
+	return 60
+}
+
+func (m *TimelineModel) visibleRows() int {
+	// Leave room for the title, axis, details and help
+	if h := m.height - 10; h > 3 {
+		return h
+	}
+	return 20
+}
+
+// column returns the column of a date at the current zoom, from the timeline start
+func (m *TimelineModel) column(t time.Time) int {
+	days := int(t.Sub(m.timeline.Start).Hours() / 24)
+	return days / timelineZooms[m.zoom].days
+}
+
+func (m *TimelineModel) columnDate(col int) time.Time {
+	return m.timeline.Start.AddDate(0, 0, col*timelineZooms[m.zoom].days)
+}
+
+// scrollToToday puts today a third of the way into the chart
+func (m *TimelineModel) scrollToToday() {
+	if m.timeline == nil {
+		return
+	}
+	m.offset = m.column(m.timeline.Today) - m.plotWidth()/3
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+// Update handles scrolling, zooming and row selection
+func (m *TimelineModel) Update(msg tea.Msg) (*TimelineModel, tea.Cmd) {
+	keys := keymap.Current()
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.timeline == nil {
+		if ok && key.Matches(keyMsg, keys.Reload) {
+			m.reload(time.Now())
+		}
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, keys.Left):
+		m.offset -= 5
+		if m.offset < 0 {
+			m.offset = 0
+		}
+	case key.Matches(keyMsg, keys.Right):
+		m.offset += 5
+	case key.Matches(keyMsg, keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, keys.Down):
+		if m.cursor < len(m.timeline.Rows)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, keys.ZoomIn):
+		if m.zoom > 0 {
+			m.zoom--
+			m.scrollToToday()
+		}
+	case key.Matches(keyMsg, keys.ZoomOut):
+		if m.zoom < len(timelineZooms)-1 {
+			m.zoom++
+			m.scrollToToday()
+		}
+	case key.Matches(keyMsg, keys.Today):
+		m.scrollToToday()
+	case key.Matches(keyMsg, keys.Reload):
+		m.reload(time.Now())
+	}
+
+	// Keep the selected row on screen
+	if m.cursor < m.top {
+		m.top = m.cursor
+	}
+	if rows := m.visibleRows(); m.cursor >= m.top+rows {
+		m.top = m.cursor - rows + 1
+	}
+	return m, nil
+}
+
+// View renders the visible part of the chart and the selected row's details
+func (m *TimelineModel) View() string {
+	if m.loadErr != "" {
+		return "Failed to load state: " + m.loadErr
+	}
+	if m.timeline == nil {
+		return titleStyle.Render("Timeline") + "\n\n  No phase or feature dates to chart yet. Add start and target dates or durations to the plan.\n\n" +
+			helpStyle.Render(keymap.Help(keymap.Item("reload", keymap.Current().Reload)))
+	}
+
+	var sections []string
+	sections = append(sections, titleStyle.Render("Timeline")+"  "+helpStyle.Render("Zoom: "+timelineZooms[m.zoom].name))
+	sections = append(sections, strings.Repeat(" ", timelineLabelWidth)+m.renderAxis())
+
+	end := m.top + m.visibleRows()
+	if end > len(m.timeline.Rows) {
+		end = len(m.timeline.Rows)
+	}
+	for i := m.top; i < end; i++ {
+		row := m.timeline.Rows[i]
+		label := row.Name
+		if row.Kind == statistics.TimelineFeature {
+			label = "  " + label
+		}
+		label = fmt.Sprintf("%-*s", timelineLabelWidth-2, truncate(label, timelineLabelWidth-2))
+		pointer := "  "
+		if i == m.cursor {
+			pointer = selectedItemStyle.Render("▸ ")
+			label = selectedItemStyle.Render(label)
+		} else if row.Kind == statistics.TimelinePhase {
+			label = timelinePhaseStyle.Render(label)
+		}
+		sections = append(sections, pointer+label+m.renderBar(row))
+	}
+
+	sections = append(sections, "", m.details(m.timeline.Rows[m.cursor]))
+	sections = append(sections, "", helpStyle.Render("█ planned  "+boardFailureStyle.Render("▓")+" slipped  "+taskWarningStyle.Render("│")+" today  ▶ after dependency"))
+	keys := keymap.Current()
+	sections = append(sections, helpStyle.Render(keymap.Help(
+		keymap.Item("scroll", keys.Left, keys.Right),
+		keymap.Item("select", keys.Up, keys.Down),
+		keymap.Item("zoom", keys.ZoomIn, keys.ZoomOut),
+		keymap.Item("today", keys.Today),
+		keymap.Item("reload", keys.Reload),
+	)))
+	return strings.Join(sections, "\n")
+}
+
+// renderAxis labels the columns with dates, leaving a gap between labels
+func (m *TimelineModel) renderAxis() string {
+	width := m.plotWidth()
+	axis := []rune(strings.Repeat(" ", width))
+	layout := "Jan 02"
+	if timelineZooms[m.zoom].days >= 30 {
+		layout = "Jan 2006"
+	}
+	for col := 0; col < width; col += len(layout) + 4 {
+		label := m.columnDate(m.offset + col).Format(layout)
+		if col+len(label) <= width {
+			copy(axis[col:], []rune(label))
+		}
+	}
+	if todayCol := m.column(m.timeline.Today) - m.offset; todayCol >= 0 && todayCol < width {
+		axis[todayCol] = '▼'
+	}
+	return helpStyle.Render(string(axis))
+}
+
+// renderBar draws one row, grouping runs of cells that share a style
+func (m *TimelineModel) renderBar(row *statistics.TimelineRow) string {
+	width := m.plotWidth()
+	today := m.column(m.timeline.Today) - m.offset
+	start, end := m.column(row.Start)-m.offset, m.column(row.End)-m.offset
+	slipEnd := end
+	if row.Slipped() {
+		slipEnd = m.column(row.SlipEnd(m.timeline.Today)) - m.offset
+	}
+
+	barStyle := progressInProgressStyle
+	switch {
+	case row.Kind == statistics.TimelinePhase:
+		barStyle = timelinePhaseStyle
+	case row.State == statistics.TimelineUnplanned:
+		barStyle = progressTodoStyle
+	case row.Done():
+		barStyle = progressCompleteStyle
+	}
+	arrowStyle := helpStyle
+	for _, id := range row.Dependencies {
+		if dep := m.timeline.Feature(id); dep != nil && row.Start.Before(dep.End) {
+			arrowStyle = boardFailureStyle
+		}
+	}
+
+	var sb strings.Builder
+	var run strings.Builder
+	var runStyle *lipgloss.Style
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		if runStyle == nil {
+			sb.WriteString(run.String())
+		} else {
+			sb.WriteString(runStyle.Render(run.String()))
+		}
+		run.Reset()
+	}
+	put := func(r rune, style *lipgloss.Style) {
+		if style != runStyle {
+			flush()
+			runStyle = style
+		}
+		run.WriteRune(r)
+	}
+
+	for col := 0; col < width; col++ {
+		switch {
+		case col >= start && col <= end:
+			put('█', &barStyle)
+		case row.Slipped() && col > end && col <= slipEnd:
+			put('▓', &boardFailureStyle)
+		case col == start-1 && len(row.Dependencies) > 0:
+			put('▶', &arrowStyle)
+		case col == today:
+			put('│', &taskWarningStyle)
+		default:
+			put(' ', nil)
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+// details describes the selected row's dates, schedule and dependencies
+func (m *TimelineModel) details(row *statistics.TimelineRow) string {
+	text := fmt.Sprintf("  %s: %s → %s", row.Name, row.Start.Format("2006-01-02"), row.End.Format("2006-01-02"))
+	if row.Done() {
+		text += " | done " + row.DoneAt.Format("2006-01-02")
+	}
+	switch row.State {
+	case statistics.TimelineLate:
+		text += boardFailureStyle.Render(fmt.Sprintf(" | finished %d days late", row.Slip))
+	case statistics.TimelineOverdue:
+		text += boardFailureStyle.Render(fmt.Sprintf(" | %d days overdue", row.Slip))
+	case statistics.TimelineUnplanned:
+		text += " | no planned dates"
+	default:
+		text += " | " + row.State
+	}
+
+	var deps []string
+	for _, id := range row.Dependencies {
+		if dep := m.timeline.Feature(id); dep != nil {
+			deps = append(deps, dep.Name)
+		}
+	}
+	if len(deps) > 0 {
+		text += " | after " + strings.Join(deps, ", ")
+	}
+	return text
+}
@@ -0,0 +1,51 @@
+package screens
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineModel(t *testing.T) {
+	projectRoot := setupTaskEditorProject(t)
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+
+	m := NewTimelineModel(projectRoot, now)
+	assert.Contains(t, m.View(), "No phase or feature dates to chart yet")
+
+	cfgMgr := config.NewManager(projectRoot)
+	state, err := cfgMgr.LoadState()
+	require.NoError(t, err)
+	state.Features[0].StartDate, state.Features[0].TargetDate = "2026-03-01", "2026-03-10"
+	require.NoError(t, cfgMgr.SaveState(state))
+
+	m = NewTimelineModel(projectRoot, now)
+	m.SetSize(100, 30)
+	m.scrollToToday()
+	require.NotNil(t, m.timeline)
+	require.Len(t, m.timeline.Rows, 2, "the phase spans its only feature")
+
+	view := m.View()
+	assert.Contains(t, view, "Zoom: day")
+	assert.Contains(t, view, "█")
+	assert.Contains(t, view, "▓", "the overdue feature shows its slip")
+	assert.Contains(t, view, "10 days overdue", "the selected phase is as late as its feature")
+
+	m, _ = m.Update(keyPress("j"))
+	assert.Contains(t, m.View(), "Login: 2026-03-01 → 2026-03-10")
+
+	m, _ = m.Update(keyPress("-"))
+	assert.Contains(t, m.View(), "Zoom: week")
+	m, _ = m.Update(keyPress("-"))
+	m, _ = m.Update(keyPress("-"))
+	assert.Contains(t, m.View(), "Zoom: month", "zooming out stops at months")
+
+	m.offset = 0
+	m, _ = m.Update(keyPress("h"))
+	assert.Equal(t, 0, m.offset, "scrolling stops at the start")
+	m, _ = m.Update(keyPress("l"))
+	assert.Equal(t, 5, m.offset)
+}
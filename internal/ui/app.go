@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 
+	"github.com/DoPlan-dev/CLI/pkg/theme"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -50,7 +51,7 @@ func Run() error {
 // RenderBestDoPlanHeader renders the DoPlan header with ASCII art
 func RenderBestDoPlanHeader(width int, version string) string {
 	topBorder := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Width(width).
 		Render("╔" + repeatString("═", width-2) + "╗")
 
@@ -64,33 +65,33 @@ func RenderBestDoPlanHeader(width int, version string) string {
 `
 
 	styledLogo := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render(logo)
 
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#ffffff", Light: "#000000"}).
+		Foreground(theme.Text()).
 		Bold(true).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render("DoPlan")
 
 	subtitle := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#999999", Light: "#666666"}).
+		Foreground(theme.TextDim()).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render("Project Workflow Manager")
 
 	versionText := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#666666", Light: "#999999"}).
+		Foreground(theme.Muted()).
 		Width(width - 4).
 		Align(lipgloss.Center).
 		Render(fmt.Sprintf("v%s", version))
 
 	bottomBorder := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Dark: "#667eea", Light: "#764ba2"}).
+		Foreground(theme.Primary()).
 		Width(width).
 		Render("╚" + repeatString("═", width-2) + "╝")
 
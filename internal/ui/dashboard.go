@@ -10,6 +10,7 @@ import (
 	"github.com/DoPlan-dev/CLI/internal/github"
 	"github.com/DoPlan-dev/CLI/internal/statistics"
 	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -18,21 +19,30 @@ import (
 )
 
 var (
-	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+	titleStyle        lipgloss.Style
+	selectedItemStyle lipgloss.Style
+	normalItemStyle   lipgloss.Style
+	helpStyle         lipgloss.Style
+)
+
+func init() {
+	theme.OnChange(func() {
+		titleStyle = lipgloss.NewStyle().
+			Foreground(theme.Primary()).
 			Bold(true).
 			Padding(0, 1)
 
-	selectedItemStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#667eea")).
-				Bold(true)
+		selectedItemStyle = lipgloss.NewStyle().
+			Foreground(theme.Primary()).
+			Bold(true)
 
-	normalItemStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff"))
+		normalItemStyle = lipgloss.NewStyle().
+			Foreground(theme.Text())
 
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666"))
-)
+		helpStyle = lipgloss.NewStyle().
+			Foreground(theme.Muted())
+	})
+}
 
 type DashboardModel struct {
 	width      int
@@ -60,12 +70,12 @@ type DashboardModel struct {
 }
 
 func NewDashboardModel() *DashboardModel {
-	p := progress.New(progress.WithScaledGradient("#667eea", "#764ba2"))
+	p := progress.New(progress.WithScaledGradient(theme.ProgressGradient()), progress.WithColorProfile(theme.ColorProfile()))
 	p.Width = 50
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#667eea"))
+	s.Style = lipgloss.NewStyle().Foreground(theme.Primary())
 
 	return &DashboardModel{
 		currentView:     "dashboard",
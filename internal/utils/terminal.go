@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 )
 
@@ -27,3 +29,24 @@ func AnimationsEnabled() bool {
 
 	return fi.Mode()&os.ModeCharDevice != 0
 }
+
+// editorFallbacks are tried in order when EDITOR is not set
+var editorFallbacks = []string{"nano", "vim", "vi", "code", "subl"}
+
+// EditorCommand returns the command that opens path in $EDITOR, falling back to
+// the first common editor found on the PATH
+func EditorCommand(path string) (*exec.Cmd, error) {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		for _, e := range editorFallbacks {
+			if _, err := exec.LookPath(e); err == nil {
+				editor = []string{e}
+				break
+			}
+		}
+		if len(editor) == 0 {
+			return nil, fmt.Errorf("no editor found")
+		}
+	}
+	return exec.Command(editor[0], append(editor[1:], path)...), nil
+}
@@ -0,0 +1,37 @@
+package utils
+
+import "strings"
+
+// Slugify converts a feature name to the kebab-case used in branch names
+func Slugify(name string) string {
+	var result strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-':
+			result.WriteRune(r)
+		case r == ' ' || r == '_':
+			result.WriteRune('-')
+		}
+	}
+	return result.String()
+}
+
+// CollapseBlankLines squeezes runs of blank lines, such as those left by
+// template actions, and trims trailing whitespace from every line
+func CollapseBlankLines(content string) string {
+	var out []string
+	blank := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if blank {
+				continue
+			}
+			blank = true
+			out = append(out, "")
+			continue
+		}
+		blank = false
+		out = append(out, strings.TrimRight(line, " \t"))
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "user-auth", Slugify("User Auth"))
+	assert.Equal(t, "oauth2-login-flow", Slugify("OAuth2 login_flow!"))
+	assert.Equal(t, "", Slugify("!!!"))
+}
+
+func TestCollapseBlankLines(t *testing.T) {
+	assert.Equal(t, "# Title\n\nBody\n\n- item", CollapseBlankLines("# Title  \n\n\n  \nBody\n\n\n- item\t"))
+}
@@ -0,0 +1,319 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/checkpoint"
+	"github.com/DoPlan-dev/CLI/internal/github"
+	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// Quality gate rules
+const (
+	GateMinCoverage           = "min-coverage"
+	GateNoOverdueFeatures     = "no-overdue-features"
+	GateMaxOpenPRsPerPhase    = "max-open-prs-per-phase"
+	GatePhaseCheckpoints      = "phase-checkpoints"
+	GateMinForecastConfidence = "min-forecast-confidence"
+)
+
+// Gate severities
+const (
+	GateSeverityError   = "error"
+	GateSeverityWarning = "warning"
+)
+
+// gateDescriptions describe each rule for reports
+var gateDescriptions = map[string]string{
+	GateMinCoverage:           "Overall test coverage is at least the configured percent",
+	GateNoOverdueFeatures:     "No unfinished feature is past its target date",
+	GateMaxOpenPRsPerPhase:    "No phase has more open pull requests than the configured limit",
+	GatePhaseCheckpoints:      "Every completed phase has a phase checkpoint",
+	GateMinForecastConfidence: "Every forecast target date is met with at least the configured confidence",
+}
+
+// GateRules lists the supported rules in a stable order
+func GateRules() []string {
+	return []string{GateMinCoverage, GateNoOverdueFeatures, GateMaxOpenPRsPerPhase, GatePhaseCheckpoints, GateMinForecastConfidence}
+}
+
+// GateDescription describes a rule, or returns an empty string for unknown rules
+func GateDescription(rule string) string {
+	return gateDescriptions[rule]
+}
+
+// ValidateGateRules reports gates that cannot be evaluated
+func ValidateGateRules(gates []models.GateRule) []string {
+	var issues []string
+	for i, gate := range gates {
+		if _, ok := gateDescriptions[gate.Rule]; !ok {
+			issues = append(issues, fmt.Sprintf("Gate %d: unknown rule %q (expected one of %s)", i+1, gate.Rule, strings.Join(GateRules(), ", ")))
+		}
+		if gate.Severity != "" && gate.Severity != GateSeverityError && gate.Severity != GateSeverityWarning {
+			issues = append(issues, fmt.Sprintf("Gate %d: severity must be %q or %q", i+1, GateSeverityError, GateSeverityWarning))
+		}
+		if gate.Value < 0 {
+			issues = append(issues, fmt.Sprintf("Gate %d: value must not be negative", i+1))
+		}
+	}
+	return issues
+}
+
+// GateInput is the project data quality gates are evaluated against
+type GateInput struct {
+	State       *models.State
+	Metrics     *statistics.StatisticsMetrics
+	GitHub      *models.GitHubData
+	Checkpoints []*checkpoint.Checkpoint
+	Now         time.Time
+}
+
+// GateResult is the outcome of one gate for one subject, e.g. a phase or feature
+type GateResult struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Subject  string `json:"subject"`
+	Passed   bool   `json:"passed"`
+	Message  string `json:"message"`
+}
+
+// Failed reports whether the result fails the check
+func (r GateResult) Failed() bool {
+	return !r.Passed && r.Severity == GateSeverityError
+}
+
+// GateReport holds the results of all configured gates
+type GateReport struct {
+	Results []GateResult `json:"results"`
+}
+
+// Failures counts failed error gates
+func (r *GateReport) Failures() int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Failed() {
+			count++
+		}
+	}
+	return count
+}
+
+// Warnings counts failed warning gates
+func (r *GateReport) Warnings() int {
+	count := 0
+	for _, result := range r.Results {
+		if !result.Passed && result.Severity == GateSeverityWarning {
+			count++
+		}
+	}
+	return count
+}
+
+// Passed reports whether no error gate failed
+func (r *GateReport) Passed() bool {
+	return r.Failures() == 0
+}
+
+// EvaluateGates evaluates each gate against the input. Rules that check several
+// subjects report one result per subject, or a single passing result when all pass.
+func EvaluateGates(gates []models.GateRule, input GateInput) *GateReport {
+	if input.State == nil {
+		input.State = &models.State{}
+	}
+	if input.Now.IsZero() {
+		input.Now = time.Now()
+	}
+
+	report := &GateReport{}
+	for _, gate := range gates {
+		severity := gate.Severity
+		if severity == "" {
+			severity = GateSeverityError
+		}
+
+		var results []GateResult
+		switch gate.Rule {
+		case GateMinCoverage:
+			results = evaluateMinCoverage(gate, input)
+		case GateNoOverdueFeatures:
+			results = evaluateOverdueFeatures(gate, input)
+		case GateMaxOpenPRsPerPhase:
+			results = evaluateOpenPRs(gate, input)
+		case GatePhaseCheckpoints:
+			results = evaluatePhaseCheckpoints(input)
+		case GateMinForecastConfidence:
+			results = evaluateForecastConfidence(gate, input)
+		default:
+			results = []GateResult{{Subject: "config", Message: fmt.Sprintf("Unknown rule %q", gate.Rule)}}
+		}
+
+		for _, result := range results {
+			result.Rule = gate.Rule
+			result.Severity = severity
+			report.Results = append(report.Results, result)
+		}
+	}
+	return report
+}
+
+func evaluateMinCoverage(gate models.GateRule, input GateInput) []GateResult {
+	if input.Metrics == nil || input.Metrics.Testing == nil {
+		return []GateResult{{Subject: "project", Message: "No coverage report found"}}
+	}
+	coverage := input.Metrics.Testing.OverallCoverage
+	return []GateResult{{
+		Subject: "project",
+		Passed:  coverage >= gate.Value,
+		Message: fmt.Sprintf("Coverage is %.1f%% (minimum %.1f%%)", coverage, gate.Value),
+	}}
+}
+
+// evaluateOverdueFeatures fails unfinished features more than value days past their target date
+func evaluateOverdueFeatures(gate models.GateRule, input GateInput) []GateResult {
+	today := truncateDay(input.Now)
+	var results []GateResult
+	for _, feature := range input.State.Features {
+		if feature.TargetDate == "" || featureDone(feature) {
+			continue
+		}
+		target, err := time.ParseInLocation("2006-01-02", feature.TargetDate, input.Now.Location())
+		if err != nil {
+			continue
+		}
+		daysLate := int(today.Sub(target).Hours() / 24)
+		if daysLate > int(gate.Value) {
+			results = append(results, GateResult{
+				Subject: featureSubject(feature),
+				Message: fmt.Sprintf("%d day(s) past its target date %s (%d%% done)", daysLate, feature.TargetDate, feature.Progress),
+			})
+		}
+	}
+	if len(results) == 0 {
+		return []GateResult{{Subject: "features", Passed: true, Message: "No unfinished feature is past its target date"}}
+	}
+	return results
+}
+
+// evaluateOpenPRs counts open pull requests of each phase's features
+func evaluateOpenPRs(gate models.GateRule, input GateInput) []GateResult {
+	open := make(map[string]map[int]bool)
+	addPR := func(feature *models.Feature, pr *models.PullRequest) {
+		if feature == nil || !strings.EqualFold(pr.Status, "open") {
+			return
+		}
+		if open[feature.Phase] == nil {
+			open[feature.Phase] = make(map[int]bool)
+		}
+		open[feature.Phase][pr.Number] = true
+	}
+
+	if input.GitHub != nil {
+		for i := range input.GitHub.PRs {
+			pr := &input.GitHub.PRs[i]
+			addPR(github.FeatureForBranch(input.State, pr.Branch), pr)
+		}
+	}
+	for i := range input.State.Features {
+		feature := &input.State.Features[i]
+		if feature.PR != nil {
+			addPR(feature, feature.PR)
+		}
+	}
+
+	limit := int(gate.Value)
+	var results []GateResult
+	for _, phase := range input.State.Phases {
+		count := len(open[phase.ID])
+		if count > limit {
+			results = append(results, GateResult{
+				Subject: phaseSubject(phase),
+				Message: fmt.Sprintf("%d open pull requests (maximum %d)", count, limit),
+			})
+		}
+	}
+	if len(results) == 0 {
+		return []GateResult{{Subject: "phases", Passed: true, Message: fmt.Sprintf("No phase has more than %d open pull requests", limit)}}
+	}
+	return results
+}
+
+// evaluatePhaseCheckpoints requires a phase checkpoint for every completed phase.
+// Checkpoints match by the name given by auto-checkpoints or by the phase ID.
+func evaluatePhaseCheckpoints(input GateInput) []GateResult {
+	var results []GateResult
+	for _, phase := range input.State.Phases {
+		if phase.Status != "complete" {
+			continue
+		}
+		if !hasPhaseCheckpoint(phase, input.Checkpoints) {
+			results = append(results, GateResult{
+				Subject: phaseSubject(phase),
+				Message: "Phase is complete but has no phase checkpoint",
+			})
+		}
+	}
+	if len(results) == 0 {
+		return []GateResult{{Subject: "phases", Passed: true, Message: "Every completed phase has a checkpoint"}}
+	}
+	return results
+}
+
+func hasPhaseCheckpoint(phase models.Phase, checkpoints []*checkpoint.Checkpoint) bool {
+	for _, cp := range checkpoints {
+		if cp.Type != "phase" {
+			continue
+		}
+		if cp.Name == "Phase: "+phase.Name || strings.Contains(cp.Name, phase.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateForecastConfidence checks the chance of meeting each target date. Forecasts
+// without a target date or without enough history to simulate are skipped.
+func evaluateForecastConfidence(gate models.GateRule, input GateInput) []GateResult {
+	if input.Metrics == nil || input.Metrics.Forecast == nil {
+		return []GateResult{{Subject: "forecast", Message: "No forecast available"}}
+	}
+
+	forecasts := append([]*statistics.Forecast{input.Metrics.Forecast.Project}, input.Metrics.Forecast.Phases...)
+	var results []GateResult
+	for _, forecast := range forecasts {
+		if forecast == nil || forecast.TargetDate.IsZero() || !forecast.HasDates() {
+			continue
+		}
+		subject := forecast.Name
+		if forecast.ID != "" {
+			subject = fmt.Sprintf("%s %s", forecast.ID, forecast.Name)
+		}
+		results = append(results, GateResult{
+			Subject: subject,
+			Passed:  forecast.OnTargetChance >= gate.Value,
+			Message: fmt.Sprintf("%.0f%% chance of finishing by %s (minimum %.0f%%)", forecast.OnTargetChance, forecast.TargetDate.Format("2006-01-02"), gate.Value),
+		})
+	}
+	if len(results) == 0 {
+		return []GateResult{{Subject: "forecast", Passed: true, Message: "No forecast has a target date"}}
+	}
+	return results
+}
+
+func featureDone(feature models.Feature) bool {
+	return feature.Status == "complete" || feature.Progress >= 100
+}
+
+func featureSubject(feature models.Feature) string {
+	return fmt.Sprintf("%s %s", feature.ID, feature.Name)
+}
+
+func phaseSubject(phase models.Phase) string {
+	return fmt.Sprintf("%s %s", phase.ID, phase.Name)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
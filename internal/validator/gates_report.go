@@ -0,0 +1,224 @@
+package validator
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Gate report formats
+const (
+	GateFormatText     = "text"
+	GateFormatJUnit    = "junit"
+	GateFormatSARIF    = "sarif"
+	GateFormatMarkdown = "markdown"
+)
+
+// gateArtifact is the file gate failures are reported against in SARIF
+const gateArtifact = ".doplan/state.json"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+// WriteGateJUnit writes one test suite per rule and one test case per result.
+// Failed warnings pass with the message in system-out so CI does not fail on them.
+func WriteGateJUnit(w io.Writer, report *GateReport) error {
+	suites := junitTestSuites{Name: "doplan-gates"}
+	index := make(map[string]int)
+	for _, result := range report.Results {
+		i, ok := index[result.Rule]
+		if !ok {
+			i = len(suites.Suites)
+			index[result.Rule] = i
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: result.Rule})
+		}
+		suite := &suites.Suites[i]
+
+		testCase := junitTestCase{ClassName: "doplan.gates." + result.Rule, Name: result.Subject}
+		switch {
+		case result.Failed():
+			testCase.Failure = &junitFailure{Message: result.Message, Type: result.Rule}
+			suite.Failures++
+			suites.Failures++
+		case !result.Passed:
+			testCase.SystemOut = "warning: " + result.Message
+		default:
+			testCase.SystemOut = result.Message
+		}
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Tests++
+		suites.Tests++
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// WriteGateSARIF writes failed gates as SARIF 2.1.0 results for code scanning
+func WriteGateSARIF(w io.Writer, report *GateReport, version string) error {
+	driver := sarifDriver{
+		Name:           "doplan",
+		Version:        version,
+		InformationURI: "https://github.com/DoPlan-dev/CLI",
+		Rules:          []sarifRule{},
+	}
+	seen := make(map[string]bool)
+	results := []sarifResult{}
+	for _, result := range report.Results {
+		if !seen[result.Rule] {
+			seen[result.Rule] = true
+			description := GateDescription(result.Rule)
+			if description == "" {
+				description = result.Rule
+			}
+			driver.Rules = append(driver.Rules, sarifRule{ID: result.Rule, ShortDescription: sarifMessage{Text: description}})
+		}
+		if result.Passed {
+			continue
+		}
+		results = append(results, sarifResult{
+			RuleID:  result.Rule,
+			Level:   result.Severity,
+			Message: sarifMessage{Text: fmt.Sprintf("%s: %s", result.Subject, result.Message)},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: gateArtifact}},
+			}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// WriteGateMarkdown writes a summary table, e.g. for a job summary or PR comment
+func WriteGateMarkdown(w io.Writer, report *GateReport) error {
+	var sb strings.Builder
+	sb.WriteString("## Quality Gates\n\n")
+	switch {
+	case len(report.Results) == 0:
+		sb.WriteString("No quality gates configured.\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	case report.Passed():
+		sb.WriteString(fmt.Sprintf("✅ **Passed** (%d checks, %d warnings)\n\n", len(report.Results), report.Warnings()))
+	default:
+		sb.WriteString(fmt.Sprintf("❌ **Failed** (%d failures, %d warnings)\n\n", report.Failures(), report.Warnings()))
+	}
+
+	sb.WriteString("| Status | Rule | Subject | Result |\n")
+	sb.WriteString("|--------|------|---------|--------|\n")
+	for _, result := range report.Results {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", gateStatusIcon(result), result.Rule, markdownCell(result.Subject), markdownCell(result.Message)))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func gateStatusIcon(result GateResult) string {
+	switch {
+	case result.Passed:
+		return "✅"
+	case result.Failed():
+		return "❌"
+	default:
+		return "⚠️"
+	}
+}
+
+func markdownCell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", "\\|"), "\n", " ")
+}
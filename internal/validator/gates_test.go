@@ -0,0 +1,199 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/DoPlan-dev/CLI/internal/checkpoint"
+	"github.com/DoPlan-dev/CLI/internal/statistics"
+	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var gateNow = time.Date(2026, 6, 15, 10, 0, 0, 0, time.UTC)
+
+func gateInput() GateInput {
+	return GateInput{
+		State: &models.State{
+			Phases: []models.Phase{
+				{ID: "01-phase", Name: "Foundation", Status: "complete"},
+				{ID: "02-phase", Name: "Reports", Status: "complete"},
+				{ID: "03-phase", Name: "Billing", Status: "in-progress"},
+			},
+			Features: []models.Feature{
+				{ID: "01", Phase: "01-phase", Name: "Login", Status: "complete", TargetDate: "2026-05-01", Progress: 100},
+				{ID: "02", Phase: "03-phase", Name: "Invoices", Status: "in-progress", TargetDate: "2026-06-10", Progress: 40,
+					Branch: "feature/02-invoices", PR: &models.PullRequest{Number: 5, Status: "open"}},
+				{ID: "03", Phase: "03-phase", Name: "Taxes", Status: "in-progress", TargetDate: "2026-06-14", Branch: "feature/03-taxes"},
+				{ID: "04", Phase: "03-phase", Name: "Refunds", Status: "todo", TargetDate: "2026-07-01"},
+			},
+		},
+		Metrics: &statistics.StatisticsMetrics{
+			Testing: &statistics.TestingMetrics{OverallCoverage: 72.5},
+			Forecast: &statistics.Forecasts{
+				Project: &statistics.Forecast{Name: "Project", P50: gateNow},
+				Phases: []*statistics.Forecast{
+					{ID: "03-phase", Name: "Billing", TargetDate: gateNow.AddDate(0, 0, 10), P50: gateNow, OnTargetChance: 62},
+					{ID: "04-phase", Name: "Launch", TargetDate: gateNow.AddDate(0, 1, 0), Note: "too little history"},
+				},
+			},
+		},
+		GitHub: &models.GitHubData{PRs: []models.PullRequest{
+			{Number: 5, Branch: "feature/02-invoices", Status: "open"},
+			{Number: 6, Branch: "feature/03-taxes", Status: "OPEN"},
+			{Number: 7, Branch: "feature/03-taxes", Status: "merged"},
+		}},
+		Checkpoints: []*checkpoint.Checkpoint{
+			{Type: "phase", Name: "Phase: Foundation"},
+			{Type: "manual", Name: "Before 02-phase release"},
+		},
+		Now: gateNow,
+	}
+}
+
+func TestEvaluateGates(t *testing.T) {
+	gates := []models.GateRule{
+		{Rule: GateMinCoverage, Value: 80},
+		{Rule: GateNoOverdueFeatures},
+		{Rule: GateMaxOpenPRsPerPhase, Value: 1, Severity: GateSeverityWarning},
+		{Rule: GatePhaseCheckpoints},
+		{Rule: GateMinForecastConfidence, Value: 50},
+	}
+
+	report := EvaluateGates(gates, gateInput())
+	require.Len(t, report.Results, 6)
+
+	assert.Equal(t, GateResult{Rule: GateMinCoverage, Severity: GateSeverityError, Subject: "project",
+		Message: "Coverage is 72.5% (minimum 80.0%)"}, report.Results[0])
+
+	assert.Equal(t, "02 Invoices", report.Results[1].Subject, "finished and future features are not overdue")
+	assert.Equal(t, "5 day(s) past its target date 2026-06-10 (40% done)", report.Results[1].Message)
+	assert.Equal(t, "03 Taxes", report.Results[2].Subject)
+
+	assert.Equal(t, "03-phase Billing", report.Results[3].Subject)
+	assert.Equal(t, "2 open pull requests (maximum 1)", report.Results[3].Message, "PRs are counted once and merged PRs are left out")
+	assert.False(t, report.Results[3].Failed(), "warnings do not fail the check")
+
+	assert.Equal(t, "02-phase Reports", report.Results[4].Subject, "manual checkpoints do not count")
+	assert.False(t, report.Results[4].Passed)
+
+	assert.Equal(t, "03-phase Billing", report.Results[5].Subject, "forecasts without a target or dates are skipped")
+	assert.True(t, report.Results[5].Passed)
+
+	assert.Equal(t, 4, report.Failures())
+	assert.Equal(t, 1, report.Warnings())
+	assert.False(t, report.Passed())
+}
+
+func TestEvaluateGates_Passing(t *testing.T) {
+	input := gateInput()
+	input.Now = time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	input.Checkpoints = append(input.Checkpoints, &checkpoint.Checkpoint{Type: "phase", Name: "Close 02-phase"})
+
+	report := EvaluateGates([]models.GateRule{
+		{Rule: GateMinCoverage, Value: 70},
+		{Rule: GateNoOverdueFeatures},
+		{Rule: GateMaxOpenPRsPerPhase, Value: 2},
+		{Rule: GatePhaseCheckpoints},
+	}, input)
+	require.Len(t, report.Results, 4)
+	assert.True(t, report.Passed())
+	assert.Equal(t, 0, report.Warnings())
+
+	// Grace days
+	input.Now = gateNow
+	report = EvaluateGates([]models.GateRule{{Rule: GateNoOverdueFeatures, Value: 5}}, input)
+	assert.True(t, report.Passed(), "features within the grace period are not overdue")
+}
+
+func TestEvaluateGates_MissingData(t *testing.T) {
+	report := EvaluateGates([]models.GateRule{
+		{Rule: GateMinCoverage, Value: 10},
+		{Rule: GateMinForecastConfidence, Value: 50},
+		{Rule: "max-bugs"},
+	}, GateInput{})
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, "No coverage report found", report.Results[0].Message)
+	assert.Equal(t, "No forecast available", report.Results[1].Message)
+	assert.Equal(t, 3, report.Failures())
+}
+
+func TestValidateGateRules(t *testing.T) {
+	assert.Empty(t, ValidateGateRules([]models.GateRule{{Rule: GateMinCoverage, Value: 80, Severity: GateSeverityWarning}}))
+
+	issues := ValidateGateRules([]models.GateRule{
+		{Rule: GateMinCoverage, Value: -1},
+		{Rule: "max-bugs", Severity: "fatal"},
+	})
+	require.Len(t, issues, 3)
+	assert.Equal(t, "Gate 1: value must not be negative", issues[0])
+	assert.Contains(t, issues[1], `Gate 2: unknown rule "max-bugs"`)
+	assert.Contains(t, issues[2], "severity")
+}
+
+func gateReport() *GateReport {
+	return &GateReport{Results: []GateResult{
+		{Rule: GateMinCoverage, Severity: GateSeverityError, Subject: "project", Passed: true, Message: "Coverage is 82.0% (minimum 80.0%)"},
+		{Rule: GateNoOverdueFeatures, Severity: GateSeverityError, Subject: "02 Invoices", Message: "5 day(s) past its target date 2026-06-10 (40% done)"},
+		{Rule: GateMaxOpenPRsPerPhase, Severity: GateSeverityWarning, Subject: "03-phase Billing", Message: "2 open pull requests | maximum 1"},
+	}}
+}
+
+func TestWriteGateJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGateJUnit(&buf, gateReport()))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 1, suites.Failures, "warnings are not failures")
+	require.Len(t, suites.Suites, 3)
+
+	overdue := suites.Suites[1]
+	assert.Equal(t, GateNoOverdueFeatures, overdue.Name)
+	require.NotNil(t, overdue.Cases[0].Failure)
+	assert.Equal(t, "02 Invoices", overdue.Cases[0].Name)
+	assert.Contains(t, overdue.Cases[0].Failure.Message, "5 day(s) past")
+
+	warning := suites.Suites[2].Cases[0]
+	assert.Nil(t, warning.Failure)
+	assert.Contains(t, warning.SystemOut, "warning: ")
+}
+
+func TestWriteGateSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGateSARIF(&buf, gateReport(), "1.2.0"))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "1.2.0", run.Tool.Driver.Version)
+	require.Len(t, run.Tool.Driver.Rules, 3, "every evaluated rule is described")
+	assert.Equal(t, GateDescription(GateMinCoverage), run.Tool.Driver.Rules[0].ShortDescription.Text)
+
+	require.Len(t, run.Results, 2, "passed gates are not results")
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, "02 Invoices: 5 day(s) past its target date 2026-06-10 (40% done)", run.Results[0].Message.Text)
+	assert.Equal(t, "warning", run.Results[1].Level)
+	assert.Equal(t, gateArtifact, run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestWriteGateMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGateMarkdown(&buf, gateReport()))
+	out := buf.String()
+	assert.Contains(t, out, "## Quality Gates")
+	assert.Contains(t, out, "❌ **Failed** (1 failures, 1 warnings)")
+	assert.Contains(t, out, "| ✅ | min-coverage | project | Coverage is 82.0% (minimum 80.0%) |")
+	assert.Contains(t, out, `| ⚠️ | max-open-prs-per-phase | 03-phase Billing | 2 open pull requests \| maximum 1 |`)
+
+	buf.Reset()
+	require.NoError(t, WriteGateMarkdown(&buf, &GateReport{}))
+	assert.Contains(t, buf.String(), "No quality gates configured.")
+}
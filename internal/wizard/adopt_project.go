@@ -11,7 +11,10 @@ import (
 	"github.com/DoPlan-dev/CLI/internal/context"
 	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
 	"github.com/DoPlan-dev/CLI/internal/integration"
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
 	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -89,6 +92,7 @@ func newAdoptProjectModel(projectRoot string) *adoptProjectModel {
 	}
 
 	optionsList := list.New(options, list.NewDefaultDelegate(), 0, 0)
+	optionsList.KeyMap = keymap.Current().List()
 	optionsList.Title = "Adoption Options"
 	optionsList.SetShowStatusBar(false)
 	optionsList.SetFilteringEnabled(false)
@@ -105,6 +109,7 @@ func newAdoptProjectModel(projectRoot string) *adoptProjectModel {
 	}
 
 	ideList := list.New(ides, list.NewDefaultDelegate(), 0, 0)
+	ideList.KeyMap = keymap.Current().List()
 	ideList.Title = "Select Your IDE / AI Tool"
 	ideList.SetShowStatusBar(false)
 	ideList.SetFilteringEnabled(true)
@@ -116,7 +121,7 @@ func newAdoptProjectModel(projectRoot string) *adoptProjectModel {
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#667eea"))
+	s.Style = lipgloss.NewStyle().Foreground(theme.Primary())
 
 	return &adoptProjectModel{
 		projectRoot:   projectRoot,
@@ -158,8 +163,7 @@ func (m *adoptProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "ctrl+c", "q":
+		if quits(msg, m.typing()) {
 			return m, tea.Quit
 		}
 
@@ -196,6 +200,11 @@ func (m *adoptProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// typing reports whether the current screen takes text input
+func (m *adoptProjectModel) typing() bool {
+	return m.currentScreen == adoptScreenGitHub
+}
+
 func (m *adoptProjectModel) updateScreen(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.currentScreen {
 	case adoptScreenFound:
@@ -219,37 +228,35 @@ func (m *adoptProjectModel) updateScreen(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *adoptProjectModel) updateFound(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter", " ":
-		if m.analysis != nil {
-			m.currentScreen = adoptScreenAnalysis
-		}
-		return m, nil
+	if (key.Matches(msg, keymap.Current().Select) || msg.String() == " ") && m.analysis != nil {
+		m.currentScreen = adoptScreenAnalysis
 	}
 	return m, nil
 }
 
 func (m *adoptProjectModel) updateAnalysis(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter", " ":
+	keys := keymap.Current()
+	switch {
+	case key.Matches(msg, keys.Select) || msg.String() == " ":
 		m.currentScreen = adoptScreenOptions
 		return m, nil
-	case "esc":
+	case key.Matches(msg, keys.Back):
 		return m, tea.Quit
 	}
 	return m, nil
 }
 
 func (m *adoptProjectModel) updateOptions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	keys := keymap.Current()
+	switch {
+	case key.Matches(msg, keys.Select):
 		selected := m.optionsList.SelectedItem()
 		if selected != nil {
 			m.adoptionOption = selected.(optionItem).name
 			m.currentScreen = adoptScreenGitHub
 		}
 		return m, nil
-	case "esc":
+	case key.Matches(msg, keys.Back):
 		m.currentScreen = adoptScreenAnalysis
 		return m, nil
 	}
@@ -260,8 +267,9 @@ func (m *adoptProjectModel) updateOptions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *adoptProjectModel) updateGitHub(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	keys := keymap.Current()
+	switch {
+	case keymap.MatchesWhileTyping(msg, keys.Select):
 		repo := strings.TrimSpace(m.textInput.Value())
 		if repo == "" {
 			m.githubRepo = ""
@@ -270,7 +278,7 @@ func (m *adoptProjectModel) updateGitHub(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.currentScreen = adoptScreenIDE
 		return m, nil
-	case "esc":
+	case keymap.MatchesWhileTyping(msg, keys.Back):
 		m.currentScreen = adoptScreenOptions
 		return m, nil
 	}
@@ -282,8 +290,9 @@ func (m *adoptProjectModel) updateGitHub(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *adoptProjectModel) updateIDE(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	keys := keymap.Current()
+	switch {
+	case key.Matches(msg, keys.Select):
 		selected := m.ideList.SelectedItem()
 		if selected != nil {
 			m.ide = selected.(ideItem).name
@@ -293,7 +302,7 @@ func (m *adoptProjectModel) updateIDE(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.startAdoption()
 		}
 		return m, nil
-	case "esc":
+	case key.Matches(msg, keys.Back):
 		m.currentScreen = adoptScreenGitHub
 		return m, nil
 	}
@@ -309,19 +318,19 @@ func (m *adoptProjectModel) updateProgress(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 }
 
 func (m *adoptProjectModel) updatePlanPreview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter", "y":
+	keys := keymap.Current()
+	switch {
+	case key.Matches(msg, keys.Select) || msg.String() == "y":
 		m.currentScreen = adoptScreenConfirmation
 		return m, nil
-	case "n", "esc":
+	case msg.String() == "n" || key.Matches(msg, keys.Back):
 		return m, tea.Quit
 	}
 	return m, nil
 }
 
 func (m *adoptProjectModel) updateConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter", "q", "ctrl+c":
+	if key.Matches(msg, keymap.Current().Select) {
 		return m, tea.Quit
 	}
 	return m, nil
@@ -357,12 +366,12 @@ func (m *adoptProjectModel) View() string {
 
 func (m *adoptProjectModel) renderFound() string {
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#667eea")).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Render("Found Existing Project!")
 
 	message := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(theme.Text()).
 		Render("DoPlan detected an existing project in this directory.\n" +
 			"Let's analyze it and set up DoPlan integration.")
 
@@ -379,8 +388,8 @@ func (m *adoptProjectModel) renderFound() string {
 	}
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
-		Render("Press Enter to continue")
+		Foreground(theme.Muted()).
+		Render(navHelp("continue", ""))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -400,7 +409,7 @@ func (m *adoptProjectModel) renderAnalysis() string {
 	var sections []string
 
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#667eea")).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Render("Project Analysis Results")
 
@@ -410,7 +419,7 @@ func (m *adoptProjectModel) renderAnalysis() string {
 	// Tech Stack
 	if len(m.analysis.TechStack) > 0 {
 		sections = append(sections, lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
+			Foreground(theme.Text()).
 			Bold(true).
 			Render("Technology Stack:"))
 		for _, tech := range m.analysis.TechStack {
@@ -422,7 +431,7 @@ func (m *adoptProjectModel) renderAnalysis() string {
 	// Potential Phases
 	if len(m.analysis.PotentialPhases) > 0 {
 		sections = append(sections, lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
+			Foreground(theme.Text()).
 			Bold(true).
 			Render("Detected Phases:"))
 		for _, phase := range m.analysis.PotentialPhases {
@@ -434,15 +443,15 @@ func (m *adoptProjectModel) renderAnalysis() string {
 	// TODOs
 	if len(m.analysis.TODOs) > 0 {
 		sections = append(sections, lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
+			Foreground(theme.Text()).
 			Bold(true).
 			Render(fmt.Sprintf("Found %d TODO items", len(m.analysis.TODOs))))
 		sections = append(sections, "")
 	}
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
-		Render("Press Enter to continue, Esc to quit")
+		Foreground(theme.Muted()).
+		Render(navHelp("continue", "quit"))
 
 	sections = append(sections, help)
 
@@ -453,30 +462,30 @@ func (m *adoptProjectModel) renderOptions() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+			Foreground(theme.Primary()).
 			Bold(true).
 			Render("How would you like to proceed?"),
 		"",
 		m.optionsList.View(),
 		"",
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("Press Enter to select, Esc to go back"),
+			Foreground(theme.Muted()).
+			Render(navHelp("select", "back")),
 	)
 }
 
 func (m *adoptProjectModel) renderGitHub() string {
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#667eea")).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Render("GitHub Repository")
 
 	warning := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#f59e0b")).
+		Foreground(theme.Warning()).
 		Render("⚠️  GitHub repository is required for full functionality")
 
 	prompt := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(theme.Text()).
 		Render("Enter GitHub repository URL (or press Enter to skip):")
 
 	if m.textInput.Placeholder != "https://github.com/username/repo" {
@@ -487,8 +496,8 @@ func (m *adoptProjectModel) renderGitHub() string {
 	input := m.textInput.View()
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
-		Render("Press Enter to continue, Esc to go back")
+		Foreground(theme.Muted()).
+		Render(navHelp("continue", "back"))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -508,15 +517,15 @@ func (m *adoptProjectModel) renderIDE() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+			Foreground(theme.Primary()).
 			Bold(true).
 			Render("Select Your IDE / AI Tool"),
 		"",
 		m.ideList.View(),
 		"",
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("Press Enter to select, Esc to go back"),
+			Foreground(theme.Muted()).
+			Render(navHelp("select", "back")),
 	)
 }
 
@@ -535,7 +544,7 @@ func (m *adoptProjectModel) renderProgress() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+			Foreground(theme.Primary()).
 			Bold(true).
 			Render("Adopting Project..."),
 		"",
@@ -545,7 +554,7 @@ func (m *adoptProjectModel) renderProgress() string {
 
 func (m *adoptProjectModel) renderPlanPreview() string {
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#667eea")).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Render("Generated Plan Preview")
 
@@ -565,8 +574,8 @@ func (m *adoptProjectModel) renderPlanPreview() string {
 	}
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
-		Render("Press Enter to confirm, N/Esc to cancel")
+		Foreground(theme.Muted()).
+		Render(navHelp("confirm", "cancel"))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -579,20 +588,20 @@ func (m *adoptProjectModel) renderPlanPreview() string {
 
 func (m *adoptProjectModel) renderConfirmation() string {
 	success := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#10b981")).
+		Foreground(theme.Success()).
 		Bold(true).
 		Render("✅ Project adopted successfully!")
 
 	nextSteps := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(theme.Text()).
 		Render("Next steps:\n" +
 			"1. Run 'doplan dashboard' to view your project\n" +
 			"2. Review the generated plan\n" +
 			"3. Use /Discuss in your IDE to refine your idea")
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
-		Render("Press Enter or Q to exit")
+		Foreground(theme.Muted()).
+		Render(exitHelp())
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -800,6 +809,7 @@ func (m *adoptProjectModel) saveConfigYAML(cfg *models.Config, githubRepo string
 		},
 		"tui": map[string]interface{}{
 			"theme":      "default",
+			"colors":     "auto",
 			"animations": true,
 		},
 	}
@@ -10,7 +10,10 @@ import (
 	"github.com/DoPlan-dev/CLI/internal/config"
 	doplanerror "github.com/DoPlan-dev/CLI/internal/error"
 	"github.com/DoPlan-dev/CLI/internal/integration"
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
 	"github.com/DoPlan-dev/CLI/pkg/models"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -97,6 +100,7 @@ func newNewProjectModel() *newProjectModel {
 	}
 
 	templateList := list.New(templates, list.NewDefaultDelegate(), 0, 0)
+	templateList.KeyMap = keymap.Current().List()
 	templateList.Title = "Select Project Template"
 	templateList.SetShowStatusBar(false)
 	templateList.SetFilteringEnabled(true)
@@ -113,13 +117,14 @@ func newNewProjectModel() *newProjectModel {
 	}
 
 	ideList := list.New(ides, list.NewDefaultDelegate(), 0, 0)
+	ideList.KeyMap = keymap.Current().List()
 	ideList.Title = "Select Your IDE / AI Tool"
 	ideList.SetShowStatusBar(false)
 	ideList.SetFilteringEnabled(true)
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#667eea"))
+	s.Style = lipgloss.NewStyle().Foreground(theme.Primary())
 
 	return &newProjectModel{
 		currentScreen: screenWelcome,
@@ -159,8 +164,7 @@ func (m *newProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "ctrl+c", "q":
+		if quits(msg, m.typing()) {
 			return m, tea.Quit
 		}
 
@@ -189,6 +193,11 @@ func (m *newProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// typing reports whether the current screen takes text input
+func (m *newProjectModel) typing() bool {
+	return m.currentScreen == screenProjectName || m.currentScreen == screenGitHub
+}
+
 func (m *newProjectModel) updateScreen(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.currentScreen {
 	case screenWelcome:
@@ -208,8 +217,7 @@ func (m *newProjectModel) updateScreen(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *newProjectModel) updateWelcome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter", " ":
+	if key.Matches(msg, keymap.Current().Select) || msg.String() == " " {
 		m.currentScreen = screenProjectName
 		return m, textinput.Blink
 	}
@@ -217,15 +225,16 @@ func (m *newProjectModel) updateWelcome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *newProjectModel) updateProjectName(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	keys := keymap.Current()
+	switch {
+	case keymap.MatchesWhileTyping(msg, keys.Select):
 		if strings.TrimSpace(m.textInput.Value()) == "" {
 			return m, nil
 		}
 		m.projectName = strings.TrimSpace(m.textInput.Value())
 		m.currentScreen = screenTemplate
 		return m, nil
-	case "esc":
+	case keymap.MatchesWhileTyping(msg, keys.Back):
 		m.currentScreen = screenWelcome
 		return m, nil
 	}
@@ -236,15 +245,16 @@ func (m *newProjectModel) updateProjectName(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 }
 
 func (m *newProjectModel) updateTemplate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	keys := keymap.Current()
+	switch {
+	case key.Matches(msg, keys.Select):
 		selected := m.templateList.SelectedItem()
 		if selected != nil {
 			m.template = selected.(templateItem).name
 			m.currentScreen = screenGitHub
 		}
 		return m, nil
-	case "esc":
+	case key.Matches(msg, keys.Back):
 		m.currentScreen = screenProjectName
 		return m, nil
 	}
@@ -255,8 +265,9 @@ func (m *newProjectModel) updateTemplate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *newProjectModel) updateGitHub(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	keys := keymap.Current()
+	switch {
+	case keymap.MatchesWhileTyping(msg, keys.Select):
 		repo := strings.TrimSpace(m.textInput.Value())
 		if repo == "" {
 			// Show warning but allow proceeding (mandatory check can be added later)
@@ -266,7 +277,7 @@ func (m *newProjectModel) updateGitHub(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.currentScreen = screenIDE
 		return m, nil
-	case "esc":
+	case keymap.MatchesWhileTyping(msg, keys.Back):
 		m.currentScreen = screenTemplate
 		return m, nil
 	}
@@ -278,8 +289,9 @@ func (m *newProjectModel) updateGitHub(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *newProjectModel) updateIDE(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	keys := keymap.Current()
+	switch {
+	case key.Matches(msg, keys.Select):
 		selected := m.ideList.SelectedItem()
 		if selected != nil {
 			m.ide = selected.(ideItem).name
@@ -289,7 +301,7 @@ func (m *newProjectModel) updateIDE(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.startInstallation()
 		}
 		return m, nil
-	case "esc":
+	case key.Matches(msg, keys.Back):
 		m.currentScreen = screenGitHub
 		return m, nil
 	}
@@ -300,8 +312,7 @@ func (m *newProjectModel) updateIDE(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *newProjectModel) updateSuccess(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter", "q", "ctrl+c":
+	if key.Matches(msg, keymap.Current().Select) {
 		return m, tea.Quit
 	}
 	return m, nil
@@ -344,23 +355,23 @@ func (m *newProjectModel) renderWelcome() string {
 `
 
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#667eea")).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Align(lipgloss.Center).
 		Width(m.width - 4).
 		Render("Welcome to DoPlan!")
 
 	subtitle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#999999")).
+		Foreground(theme.TextDim()).
 		Align(lipgloss.Center).
 		Width(m.width - 4).
 		Render("Let's set up your new project")
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
+		Foreground(theme.Muted()).
 		Align(lipgloss.Center).
 		Width(m.width - 4).
-		Render("Press Enter to continue")
+		Render(navHelp("continue", ""))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -376,19 +387,19 @@ func (m *newProjectModel) renderWelcome() string {
 
 func (m *newProjectModel) renderProjectName() string {
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#667eea")).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Render("Project Name")
 
 	prompt := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(theme.Text()).
 		Render("Enter your project name:")
 
 	input := m.textInput.View()
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
-		Render("Press Enter to continue, Esc to go back")
+		Foreground(theme.Muted()).
+		Render(navHelp("continue", "back"))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -406,30 +417,30 @@ func (m *newProjectModel) renderTemplate() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+			Foreground(theme.Primary()).
 			Bold(true).
 			Render("Select Project Template"),
 		"",
 		m.templateList.View(),
 		"",
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("Press Enter to select, Esc to go back"),
+			Foreground(theme.Muted()).
+			Render(navHelp("select", "back")),
 	)
 }
 
 func (m *newProjectModel) renderGitHub() string {
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#667eea")).
+		Foreground(theme.Primary()).
 		Bold(true).
 		Render("GitHub Repository")
 
 	warning := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#f59e0b")).
+		Foreground(theme.Warning()).
 		Render("⚠️  GitHub repository is required for full functionality")
 
 	prompt := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(theme.Text()).
 		Render("Enter GitHub repository URL (or press Enter to skip):")
 
 	// Reset text input for GitHub screen
@@ -441,8 +452,8 @@ func (m *newProjectModel) renderGitHub() string {
 	input := m.textInput.View()
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
-		Render("Press Enter to continue, Esc to go back")
+		Foreground(theme.Muted()).
+		Render(navHelp("continue", "back"))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -462,15 +473,15 @@ func (m *newProjectModel) renderIDE() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+			Foreground(theme.Primary()).
 			Bold(true).
 			Render("Select Your IDE / AI Tool"),
 		"",
 		m.ideList.View(),
 		"",
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#666666")).
-			Render("Press Enter to select, Esc to go back"),
+			Foreground(theme.Muted()).
+			Render(navHelp("select", "back")),
 	)
 }
 
@@ -489,7 +500,7 @@ func (m *newProjectModel) renderInstall() string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+			Foreground(theme.Primary()).
 			Bold(true).
 			Render("Installing DoPlan..."),
 		"",
@@ -499,20 +510,20 @@ func (m *newProjectModel) renderInstall() string {
 
 func (m *newProjectModel) renderSuccess() string {
 	success := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#10b981")).
+		Foreground(theme.Success()).
 		Bold(true).
 		Render("✅ DoPlan installed successfully!")
 
 	nextSteps := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#ffffff")).
+		Foreground(theme.Text()).
 		Render("Next steps:\n" +
 			"1. Run 'doplan dashboard' to view your project\n" +
 			"2. Use /Discuss in your IDE to start refining your idea\n" +
 			"3. Use /Generate to create project documentation")
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#666666")).
-		Render("Press Enter or Q to exit")
+		Foreground(theme.Muted()).
+		Render(exitHelp())
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -679,6 +690,7 @@ func (m *newProjectModel) saveConfigYAML(projectRoot string, cfg *models.Config,
 		},
 		"tui": map[string]interface{}{
 			"theme":      "default",
+			"colors":     "auto",
 			"animations": true,
 		},
 	}
@@ -2,6 +2,10 @@ package wizard
 
 import (
 	"os"
+
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // RunNewProjectWizard runs the new project creation wizard
@@ -33,3 +37,31 @@ func RunMigrationWizard() error {
 	return nil
 }
 
+// navHelp lists the keys for going on and back from a wizard screen; without a
+// back description that key is left out
+func navHelp(next, back string) string {
+	keys := keymap.Current()
+	items := []keymap.HelpItem{keymap.Item(next, keys.Select)}
+	if back != "" {
+		items = append(items, keymap.Item(back, keys.Back))
+	}
+	return keymap.Help(items...)
+}
+
+// exitHelp lists the keys closing a finished wizard
+func exitHelp() string {
+	keys := keymap.Current()
+	return keymap.Help(keymap.Item("exit", keys.Select, keys.Quit))
+}
+
+// quits reports whether a key quits the wizard. While typing, only non-printable
+// quit keys do, so a project name can contain the quit key.
+func quits(msg tea.KeyMsg, typing bool) bool {
+	if msg.String() == "ctrl+c" {
+		return true
+	}
+	if typing {
+		return keymap.MatchesWhileTyping(msg, keymap.Current().Quit)
+	}
+	return key.Matches(msg, keymap.Current().Quit)
+}
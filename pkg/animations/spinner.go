@@ -1,6 +1,7 @@
 package animations
 
 import (
+	"github.com/DoPlan-dev/CLI/pkg/theme"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,7 +17,7 @@ func NewSpinner() spinner.Model {
 		Frames: SpinnerFrames,
 		FPS:    80, // 80ms per frame
 	}
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#667eea"))
+	s.Style = lipgloss.NewStyle().Foreground(theme.Primary())
 	return s
 }
 
@@ -0,0 +1,62 @@
+package keymap
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// HelpItem is one entry of a help footer, like "[a] add"
+type HelpItem struct {
+	Keys string
+	Desc string
+}
+
+// Item describes what the bindings do together, e.g. Item("move", k.MoveTaskDown,
+// k.MoveTaskUp) for "[J/K] move". Disabled bindings are left out.
+func Item(desc string, bindings ...key.Binding) HelpItem {
+	var labels []string
+	for _, b := range bindings {
+		if b.Enabled() {
+			labels = append(labels, b.Help().Key)
+		}
+	}
+	return HelpItem{Keys: joinLabels(labels), Desc: desc}
+}
+
+// Help renders a help footer from items, skipping those without keys
+func Help(items ...HelpItem) string {
+	var parts []string
+	for _, item := range items {
+		if item.Keys != "" {
+			parts = append(parts, "["+item.Keys+"] "+item.Desc)
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Label returns the key shown for a binding, or "" when it is disabled
+func Label(b key.Binding) string {
+	if !b.Enabled() {
+		return ""
+	}
+	return b.Help().Key
+}
+
+// joinLabels joins key labels with "/", shortening a run of digits such as the
+// view keys to "1-8"
+func joinLabels(labels []string) string {
+	if len(labels) > 2 {
+		run := true
+		for i, label := range labels {
+			if len(label) != 1 || label[0] != labels[0][0]+byte(i) || label[0] < '0' || label[0] > '9' {
+				run = false
+				break
+			}
+		}
+		if run {
+			return labels[0] + "-" + labels[len(labels)-1]
+		}
+	}
+	return strings.Join(labels, "/")
+}
@@ -0,0 +1,404 @@
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// Presets. Default keeps the arrow and hjkl keys; vim adds vim-style keys for
+// adding, renaming and the palette; emacs uses control and alt chords and frees
+// the letter keys, which suits non-QWERTY layouts.
+const (
+	Default = "default"
+	Vim     = "vim"
+	Emacs   = "emacs"
+)
+
+// envKeymap overrides the preset of the keymap files
+const envKeymap = "DOPLAN_KEYMAP"
+
+// Scopes of actions. Global and navigation keys work in every view, so they may
+// not share a key with any other action; view keys only clash within their view.
+const (
+	scopeGlobal   = "global"
+	scopeNav      = "navigation"
+	scopeBoard    = "board"
+	scopeTasks    = "tasks"
+	scopeTimeline = "timeline"
+)
+
+// KeyMap holds the key bindings of the dashboard and wizards
+type KeyMap struct {
+	Preset string
+
+	// Global
+	Quit          key.Binding
+	Refresh       key.Binding
+	Palette       key.Binding
+	ViewDashboard key.Binding
+	ViewPhases    key.Binding
+	ViewFeatures  key.Binding
+	ViewGitHub    key.Binding
+	ViewConfig    key.Binding
+	ViewStats     key.Binding
+	ViewBoard     key.Binding
+	ViewTimeline  key.Binding
+
+	// Navigation
+	Up     key.Binding
+	Down   key.Binding
+	Left   key.Binding
+	Right  key.Binding
+	Select key.Binding
+	Back   key.Binding
+	Reload key.Binding // Rereads a view's files from disk
+
+	// Board
+	MoveCardLeft   key.Binding
+	MoveCardRight  key.Binding
+	FilterPhase    key.Binding
+	FilterAssignee key.Binding
+
+	// Task editor
+	ToggleTask   key.Binding
+	AddTask      key.Binding
+	RenameTask   key.Binding
+	MoveTaskUp   key.Binding
+	MoveTaskDown key.Binding
+	CycleStatus  key.Binding
+	Undo         key.Binding
+
+	// Timeline
+	ZoomIn  key.Binding
+	ZoomOut key.Binding
+	Today   key.Binding
+}
+
+// action describes a binding: its name in keymap files, scope, help text and
+// default keys
+type action struct {
+	name  string
+	scope string
+	desc  string
+	keys  []string
+}
+
+var actions = []action{
+	{"quit", scopeGlobal, "quit", []string{"q", "ctrl+c"}},
+	{"refresh", scopeGlobal, "refresh", []string{"r"}},
+	{"palette", scopeGlobal, "palette", []string{"ctrl+k"}},
+	{"view.dashboard", scopeGlobal, "dashboard", []string{"1"}},
+	{"view.phases", scopeGlobal, "phases", []string{"2"}},
+	{"view.features", scopeGlobal, "features", []string{"3"}},
+	{"view.github", scopeGlobal, "github", []string{"4"}},
+	{"view.config", scopeGlobal, "config", []string{"5"}},
+	{"view.stats", scopeGlobal, "stats", []string{"6"}},
+	{"view.board", scopeGlobal, "board", []string{"7"}},
+	{"view.timeline", scopeGlobal, "timeline", []string{"8"}},
+
+	{"up", scopeNav, "up", []string{"up", "k"}},
+	{"down", scopeNav, "down", []string{"down", "j"}},
+	{"left", scopeNav, "left", []string{"left", "h"}},
+	{"right", scopeNav, "right", []string{"right", "l"}},
+	{"select", scopeNav, "select", []string{"enter"}},
+	{"back", scopeNav, "back", []string{"esc"}},
+	{"reload", scopeNav, "reload", []string{"R"}},
+
+	{"board.moveLeft", scopeBoard, "move card left", []string{"H", "shift+left"}},
+	{"board.moveRight", scopeBoard, "move card right", []string{"L", "shift+right"}},
+	{"board.filterPhase", scopeBoard, "phase", []string{"p"}},
+	{"board.filterAssignee", scopeBoard, "assignee", []string{"a"}},
+
+	{"tasks.toggle", scopeTasks, "toggle", []string{" ", "x"}},
+	{"tasks.add", scopeTasks, "add", []string{"a"}},
+	{"tasks.rename", scopeTasks, "rename", []string{"e"}},
+	{"tasks.moveUp", scopeTasks, "move up", []string{"K", "shift+up"}},
+	{"tasks.moveDown", scopeTasks, "move down", []string{"J", "shift+down"}},
+	{"tasks.status", scopeTasks, "status", []string{"s"}},
+	{"tasks.undo", scopeTasks, "undo", []string{"u", "ctrl+z"}},
+
+	{"timeline.zoomIn", scopeTimeline, "zoom in", []string{"+", "="}},
+	{"timeline.zoomOut", scopeTimeline, "zoom out", []string{"-"}},
+	{"timeline.today", scopeTimeline, "today", []string{"t"}},
+}
+
+// presets change the default keys of some actions
+var presets = map[string]map[string][]string{
+	Default: {},
+	Vim: {
+		"refresh":        {"r", "ctrl+l"},
+		"palette":        {"ctrl+k", ":", "/"},
+		"tasks.add":      {"o", "a"},
+		"tasks.rename":   {"i", "e"},
+		"timeline.today": {"g"},
+	},
+	Emacs: {
+		"refresh":         {"g", "ctrl+r"},
+		"palette":         {"alt+x", "ctrl+k"},
+		"up":              {"up", "ctrl+p"},
+		"down":            {"down", "ctrl+n"},
+		"left":            {"left", "ctrl+b"},
+		"right":           {"right", "ctrl+f"},
+		"back":            {"esc", "ctrl+g"},
+		"reload":          {"alt+r"},
+		"board.moveLeft":  {"alt+b", "shift+left"},
+		"board.moveRight": {"alt+f", "shift+right"},
+		"tasks.toggle":    {" ", "ctrl+t"},
+		"tasks.moveUp":    {"alt+p", "shift+up"},
+		"tasks.moveDown":  {"alt+n", "shift+down"},
+		"tasks.undo":      {"ctrl+_", "ctrl+z"},
+		"timeline.today":  {"ctrl+l"},
+	},
+}
+
+var current = mustBuild(Default)
+
+// Current returns the keymap in use
+func Current() *KeyMap {
+	return current
+}
+
+// Use switches to a keymap
+func Use(k *KeyMap) {
+	current = k
+}
+
+// bindings maps action names to the bindings of k
+func (k *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit": &k.Quit, "refresh": &k.Refresh, "palette": &k.Palette,
+		"view.dashboard": &k.ViewDashboard, "view.phases": &k.ViewPhases, "view.features": &k.ViewFeatures,
+		"view.github": &k.ViewGitHub, "view.config": &k.ViewConfig, "view.stats": &k.ViewStats,
+		"view.board": &k.ViewBoard, "view.timeline": &k.ViewTimeline,
+		"up": &k.Up, "down": &k.Down, "left": &k.Left, "right": &k.Right,
+		"select": &k.Select, "back": &k.Back, "reload": &k.Reload,
+		"board.moveLeft": &k.MoveCardLeft, "board.moveRight": &k.MoveCardRight,
+		"board.filterPhase": &k.FilterPhase, "board.filterAssignee": &k.FilterAssignee,
+		"tasks.toggle": &k.ToggleTask, "tasks.add": &k.AddTask, "tasks.rename": &k.RenameTask,
+		"tasks.moveUp": &k.MoveTaskUp, "tasks.moveDown": &k.MoveTaskDown,
+		"tasks.status": &k.CycleStatus, "tasks.undo": &k.Undo,
+		"timeline.zoomIn": &k.ZoomIn, "timeline.zoomOut": &k.ZoomOut, "timeline.today": &k.Today,
+	}
+}
+
+// Views returns the bindings switching dashboard views, in menu order
+func (k *KeyMap) Views() []key.Binding {
+	return []key.Binding{
+		k.ViewDashboard, k.ViewPhases, k.ViewFeatures, k.ViewGitHub,
+		k.ViewConfig, k.ViewStats, k.ViewBoard, k.ViewTimeline,
+	}
+}
+
+// List returns the bubbles list keys with the keymap's cursor movement
+func (k *KeyMap) List() list.KeyMap {
+	keys := list.DefaultKeyMap()
+	keys.CursorUp = k.Up
+	keys.CursorDown = k.Down
+	keys.PrevPage = k.Left
+	keys.NextPage = k.Right
+	return keys
+}
+
+// File is a keymap file. Bindings map action names to their keys, replacing the
+// preset's; an empty list unbinds the action.
+type File struct {
+	Preset   string              `yaml:"preset"`
+	Bindings map[string][]string `yaml:"bindings"`
+}
+
+// Paths returns the keymap files read, in order: the user's, then the project's
+func Paths(projectRoot string) []string {
+	var paths []string
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "doplan", "keymap.yaml"))
+	}
+	if projectRoot != "" {
+		paths = append(paths, filepath.Join(projectRoot, ".doplan", "keymap.yaml"))
+	}
+	return paths
+}
+
+// Load builds the keymap from the user's and the project's keymap files, the
+// project's settings winning. Keys bound to two actions that can be active at once
+// are a conflict: the keymap is then the preset without the files' bindings, and
+// the error names every conflict.
+func Load(projectRoot string) (*KeyMap, error) {
+	file := File{Bindings: make(map[string][]string)}
+	for _, path := range Paths(projectRoot) {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return mustBuild(Default), fmt.Errorf("failed to read keymap: %w", err)
+		}
+		var f File
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return mustBuild(Default), fmt.Errorf("failed to parse keymap %s: %w", path, err)
+		}
+		if f.Preset != "" {
+			file.Preset = f.Preset
+		}
+		for name, keys := range f.Bindings {
+			file.Bindings[name] = keys
+		}
+	}
+	if env := os.Getenv(envKeymap); env != "" {
+		file.Preset = env
+	}
+	return file.Build()
+}
+
+// Build applies the file's bindings on top of its preset
+func (f File) Build() (*KeyMap, error) {
+	preset := f.Preset
+	if preset == "" {
+		preset = Default
+	}
+	base, err := build(preset, nil)
+	if err != nil {
+		return mustBuild(Default), err
+	}
+
+	var unknown []string
+	for name := range f.Bindings {
+		if _, ok := base.bindings()[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return base, fmt.Errorf("unknown keymap actions: %s", strings.Join(unknown, ", "))
+	}
+
+	k, _ := build(preset, f.Bindings)
+	if conflicts := k.Conflicts(); len(conflicts) > 0 {
+		var errs []error
+		for _, c := range conflicts {
+			errs = append(errs, c)
+		}
+		return base, fmt.Errorf("keymap conflicts, using the %s preset: %w", preset, errors.Join(errs...))
+	}
+	return k, nil
+}
+
+func build(preset string, overrides map[string][]string) (*KeyMap, error) {
+	changes, ok := presets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown keymap preset %q (use %s, %s or %s)", preset, Default, Vim, Emacs)
+	}
+
+	k := &KeyMap{Preset: preset}
+	bindings := k.bindings()
+	for _, a := range actions {
+		keys := a.keys
+		if changed, ok := changes[a.name]; ok {
+			keys = changed
+		}
+		if override, ok := overrides[a.name]; ok {
+			keys = override
+		}
+		*bindings[a.name] = newBinding(keys, a.desc)
+	}
+	return k, nil
+}
+
+func mustBuild(preset string) *KeyMap {
+	k, err := build(preset, nil)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// newBinding makes a binding labeled with its first key; without keys it is
+// disabled
+func newBinding(keys []string, desc string) key.Binding {
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keyLabel(keys[0]), desc))
+}
+
+// keyLabels are shown in help instead of the key names
+var keyLabels = map[string]string{
+	" ":     "space",
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+func keyLabel(k string) string {
+	if label, ok := keyLabels[k]; ok {
+		return label
+	}
+	return k
+}
+
+// Conflict is a key bound to actions that can be active at the same time
+type Conflict struct {
+	Key     string
+	Actions []string
+}
+
+func (c Conflict) Error() string {
+	return fmt.Sprintf("%q is bound to %s", c.Key, strings.Join(c.Actions, " and "))
+}
+
+// Conflicts lists the keys bound to more than one action in the same scope, or
+// to a global or navigation action and any other
+func (k *KeyMap) Conflicts() []Conflict {
+	bindings := k.bindings()
+	var conflicts []Conflict
+	for i, a := range actions {
+		for _, b := range actions[i+1:] {
+			if !overlaps(a.scope, b.scope) {
+				continue
+			}
+			for _, shared := range sharedKeys(bindings[a.name].Keys(), bindings[b.name].Keys()) {
+				conflicts = append(conflicts, Conflict{Key: shared, Actions: []string{a.name, b.name}})
+			}
+		}
+	}
+	return conflicts
+}
+
+func overlaps(a, b string) bool {
+	return a == b || a == scopeGlobal || a == scopeNav || b == scopeGlobal || b == scopeNav
+}
+
+func sharedKeys(a, b []string) []string {
+	var shared []string
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				shared = append(shared, x)
+			}
+		}
+	}
+	return shared
+}
+
+// MatchesWhileTyping reports whether a key matches one of the bindings and is
+// not a printable character, for handling bindings while text is being typed
+func MatchesWhileTyping(msg tea.KeyMsg, bindings ...key.Binding) bool {
+	return msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace && key.Matches(msg, bindings...)
+}
+
+// Setup loads and uses the keymap of the project at projectRoot. A keymap that
+// fails to load or has conflicts leaves a preset in use; the error says why.
+func Setup(projectRoot string) error {
+	k, err := Load(projectRoot)
+	Use(k)
+	return err
+}
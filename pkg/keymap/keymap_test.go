@@ -0,0 +1,86 @@
+package keymap
+
+import (
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresets(t *testing.T) {
+	for name := range presets {
+		k, err := build(name, nil)
+		require.NoError(t, err)
+		assert.Empty(t, k.Conflicts(), name)
+		for action, binding := range k.bindings() {
+			assert.True(t, binding.Enabled(), "%s has no keys in %s", action, name)
+		}
+	}
+
+	_, err := File{Preset: "nano"}.Build()
+	assert.ErrorContains(t, err, `unknown keymap preset "nano"`)
+}
+
+func TestLoad(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv(envKeymap, "")
+	projectRoot := helpers.CreateTempProject(t)
+
+	k, err := Load(projectRoot)
+	require.NoError(t, err)
+	assert.Equal(t, Default, k.Preset, "without keymap files the default preset is used")
+
+	// The project's bindings win over the user's
+	helpers.WriteTestFile(t, configHome, "doplan/keymap.yaml", []byte("preset: emacs\nbindings:\n  quit: [ctrl+q]\n  tasks.add: [n]\n"))
+	helpers.WriteTestFile(t, projectRoot, ".doplan/keymap.yaml", []byte("bindings:\n  tasks.add: [+]\n  tasks.undo: []\n"))
+	k, err = Load(projectRoot)
+	require.NoError(t, err)
+	assert.Equal(t, Emacs, k.Preset)
+	assert.Equal(t, []string{"ctrl+q"}, k.Quit.Keys())
+	assert.Equal(t, []string{"+"}, k.AddTask.Keys())
+	assert.Equal(t, []string{"up", "ctrl+p"}, k.Up.Keys(), "unset actions keep the preset's keys")
+	assert.False(t, k.Undo.Enabled(), "an empty list unbinds the action")
+
+	t.Setenv(envKeymap, Vim)
+	k, err = Load(projectRoot)
+	require.NoError(t, err)
+	assert.Equal(t, Vim, k.Preset, "DOPLAN_KEYMAP overrides the preset")
+}
+
+func TestBuild_Conflicts(t *testing.T) {
+	k, err := File{Preset: Vim, Bindings: map[string][]string{
+		"tasks.add":         {"q"},
+		"board.filterPhase": {"a"},
+		"timeline.today":    {"p"},
+	}}.Build()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"q" is bound to quit and tasks.add`)
+	assert.Contains(t, err.Error(), `"a" is bound to board.filterPhase and board.filterAssignee`)
+	assert.NotContains(t, err.Error(), "timeline.today", "views do not clash with each other")
+	assert.Equal(t, Vim, k.Preset)
+	assert.Equal(t, []string{"o", "a"}, k.AddTask.Keys(), "a conflicting keymap falls back to its preset")
+
+	_, err = File{Bindings: map[string][]string{"tasks.delete": {"d"}}}.Build()
+	assert.ErrorContains(t, err, "unknown keymap actions: tasks.delete")
+}
+
+func TestHelp(t *testing.T) {
+	k := mustBuild(Default)
+	assert.Equal(t, "[1-8] switch views | [↑/↓] select | [space] toggle | [J/K] move",
+		Help(Item("switch views", k.Views()...), Item("select", k.Up, k.Down), Item("toggle", k.ToggleTask), Item("move", k.MoveTaskDown, k.MoveTaskUp)))
+
+	k.Undo = newBinding(nil, "undo")
+	assert.Equal(t, "[q] quit", Help(Item("undo", k.Undo), Item("quit", k.Quit)), "unbound actions are left out")
+	assert.Equal(t, "[1/3] views", Help(Item("views", k.ViewDashboard, k.ViewFeatures)))
+}
+
+func TestMatchesWhileTyping(t *testing.T) {
+	k := mustBuild(Vim)
+	assert.True(t, MatchesWhileTyping(tea.KeyMsg{Type: tea.KeyCtrlK}, k.Palette))
+	assert.False(t, MatchesWhileTyping(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")}, k.Palette), "typed characters are text")
+	assert.True(t, key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")}, k.Palette))
+}
@@ -11,13 +11,40 @@ type Config struct {
 	GitHub      GitHubConfig     `json:"github"`
 	Checkpoint  CheckpointConfig `json:"checkpoint"`
 	State       StateConfig      `json:"state"`
+	Stats       StatsConfig      `json:"stats"`
+	Gates       []GateRule       `json:"gates,omitempty"`
+	TUI         TUIConfig        `json:"tui"`
 }
 
 // GitHubConfig contains GitHub-related settings
 type GitHubConfig struct {
-	Enabled    bool `json:"enabled"`
-	AutoBranch bool `json:"autoBranch"`
-	AutoPR     bool `json:"autoPR"`
+	Enabled         bool     `json:"enabled"`
+	AutoBranch      bool     `json:"autoBranch"`
+	AutoPR          bool     `json:"autoPR"`
+	BaseBranch      string   `json:"baseBranch,omitempty"`      // Branch features merge into (defaults to main/master)
+	StaleBranchDays int      `json:"staleBranchDays,omitempty"` // Days without commits before a branch is stale
+	RequiredChecks  []string `json:"requiredChecks,omitempty"`  // Checks that must pass before a PR is ready for review; empty means all
+	// Branch name globs that are never reported as merged or pruned (default: main, master, develop, release/*)
+	ProtectedBranches []string `json:"protectedBranches,omitempty"`
+	PR                PRConfig `json:"pr"`
+}
+
+// PRConfig controls how pull requests are rendered and who reviews them
+type PRConfig struct {
+	TitleTemplate string   `json:"titleTemplate,omitempty"` // Template in doplan/templates (default: pr-title-template.md)
+	BodyTemplate  string   `json:"bodyTemplate,omitempty"`  // Template in doplan/templates (default: pr-body-template.md)
+	Draft         bool     `json:"draft"`                   // Open draft PRs for features still in progress
+	Reviewers     []string `json:"reviewers,omitempty"`     // Requested on every PR
+	Labels        []string `json:"labels,omitempty"`        // Added to every PR
+	Rules         []PRRule `json:"rules,omitempty"`
+}
+
+// PRRule adds reviewers and labels to PRs of matching features
+type PRRule struct {
+	Phase     string   `json:"phase,omitempty"`   // Phase ID; empty matches any phase
+	Feature   string   `json:"feature,omitempty"` // Glob matched against feature ID and kebab-case name; empty matches any feature
+	Reviewers []string `json:"reviewers,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
 }
 
 // CheckpointConfig contains checkpoint-related settings
@@ -27,6 +54,59 @@ type CheckpointConfig struct {
 	AutoComplete bool `json:"autoComplete"` // Auto-create checkpoint when feature/phase completes
 }
 
+// StatsConfig contains statistics settings
+type StatsConfig struct {
+	Forecast ForecastConfig `json:"forecast"`
+	Coverage CoverageConfig `json:"coverage"`
+	Tests    TestsConfig    `json:"tests"`
+	Hotspots HotspotsConfig `json:"hotspots"`
+}
+
+// GateRule is a quality gate evaluated by 'doplan check'
+type GateRule struct {
+	Rule     string  `json:"rule"`               // min-coverage, no-overdue-features, max-open-prs-per-phase, phase-checkpoints or min-forecast-confidence
+	Value    float64 `json:"value,omitempty"`    // Threshold of the rule, e.g. a coverage percent or PR count
+	Severity string  `json:"severity,omitempty"` // "error" (default) fails the check, "warning" is only reported
+}
+
+// HotspotsConfig tunes the churn and hotspot analysis of the git history. Files are
+// attributed to features through feature branches and the paths in stats.coverage.features.
+type HotspotsConfig struct {
+	WindowDays int      `json:"windowDays,omitempty"` // Days of history analyzed (default 90)
+	Exclude    []string `json:"exclude,omitempty"`    // Extra path globs left out, on top of lockfiles, vendored code and DoPlan's own files
+}
+
+// TestsConfig locates test result reports. Failures are linked to features through
+// the source paths in stats.coverage.features.
+type TestsConfig struct {
+	Paths []string `json:"paths,omitempty"` // go test -json and JUnit XML report globs relative to the project root (default: common locations)
+}
+
+// CoverageConfig locates coverage reports and attributes their files to features
+type CoverageConfig struct {
+	Paths    []string          `json:"paths,omitempty"`    // Report globs relative to the project root; ** matches any directories (default: common Go, lcov, Cobertura and JaCoCo locations)
+	Features []CoverageMapping `json:"features,omitempty"` // Source paths belonging to each feature
+}
+
+// CoverageMapping attributes covered source files to a feature
+type CoverageMapping struct {
+	Feature string   `json:"feature"` // Feature ID
+	Paths   []string `json:"paths"`   // Source globs relative to the project root, e.g. web/src/auth/**
+}
+
+// ForecastConfig tunes the Monte Carlo completion forecast
+type ForecastConfig struct {
+	Confidence  int `json:"confidence,omitempty"`  // Percent chance of meeting a target date below which a phase is at risk (default 85)
+	Simulations int `json:"simulations,omitempty"` // Simulated runs per forecast (default 1000)
+	WindowDays  int `json:"windowDays,omitempty"`  // Days of recent throughput sampled (default 30)
+}
+
+// TUIConfig contains terminal UI settings
+type TUIConfig struct {
+	Theme  string `json:"theme,omitempty"`  // Built-in theme (default, dark, light, high-contrast, colorblind) or one in .doplan/themes
+	Colors string `json:"colors,omitempty"` // Color mode: auto (default), truecolor, 256, 16 or none
+}
+
 // StateConfig contains current workflow state
 type StateConfig struct {
 	CurrentPhase   string `json:"currentPhase"`
@@ -69,26 +149,44 @@ type Phase struct {
 
 // Feature represents a feature within a phase
 type Feature struct {
-	ID             string       `json:"id"`
-	Phase          string       `json:"phase"`
-	Name           string       `json:"name"`
-	Description    string       `json:"description"`
-	Status         string       `json:"status"`
-	Progress       int          `json:"progress"`
-	Branch         string       `json:"branch"`
-	PR             *PullRequest `json:"pr"`
-	CheckpointID   string       `json:"checkpointId"` // Latest checkpoint ID
-	Objectives     []string     `json:"objectives"`
-	Requirements   []string     `json:"requirements"`
-	Dependencies   []string     `json:"dependencies"`
-	DesignOverview string       `json:"designOverview"`
-	Architecture   string       `json:"architecture"`
-	UserFlow       string       `json:"userFlow"`
-	TechnicalSpecs string       `json:"technicalSpecs"`
-	TaskPhases     []TaskPhase  `json:"taskPhases"`
-	StartDate      string       `json:"startDate"`
-	TargetDate     string       `json:"targetDate"`
-	Duration       string       `json:"duration"`
+	ID             string         `json:"id"`
+	Phase          string         `json:"phase"`
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	Status         string         `json:"status"`
+	Progress       int            `json:"progress"`
+	Branch         string         `json:"branch"`
+	BaseBranch     string         `json:"baseBranch,omitempty"` // Branch the feature branch was created from
+	PR             *PullRequest   `json:"pr"`
+	Flags          []FeatureFlag  `json:"flags,omitempty"`         // Problems needing attention, e.g. failed checks
+	CreatedAt      string         `json:"createdAt,omitempty"`     // When DoPlan first saw the feature (RFC3339)
+	StatusHistory  []StatusChange `json:"statusHistory,omitempty"` // Status transitions, oldest first
+	CheckpointID   string         `json:"checkpointId"`            // Latest checkpoint ID
+	Objectives     []string       `json:"objectives"`
+	Requirements   []string       `json:"requirements"`
+	Dependencies   []string       `json:"dependencies"`
+	DesignOverview string         `json:"designOverview"`
+	Architecture   string         `json:"architecture"`
+	UserFlow       string         `json:"userFlow"`
+	TechnicalSpecs string         `json:"technicalSpecs"`
+	TaskPhases     []TaskPhase    `json:"taskPhases"`
+	StartDate      string         `json:"startDate"`
+	TargetDate     string         `json:"targetDate"`
+	Duration       string         `json:"duration"`
+}
+
+// FeatureFlag marks a feature as needing attention
+type FeatureFlag struct {
+	Source string `json:"source"` // What raised the flag, e.g. "check:build"; one flag per source
+	Reason string `json:"reason"`
+	Since  string `json:"since"` // RFC3339
+}
+
+// StatusChange records a feature entering a status. While a feature is flagged its
+// history shows "blocked"; clearing the last flag records its status again.
+type StatusChange struct {
+	Status string `json:"status"`
+	At     string `json:"at"` // RFC3339
 }
 
 // TaskPhase represents a phase of tasks
@@ -101,6 +199,7 @@ type TaskPhase struct {
 type Task struct {
 	Name      string `json:"name"`
 	Completed bool   `json:"completed"`
+	Commit    string `json:"commit,omitempty"` // Commit that completed the task
 }
 
 // Progress tracks project progress
@@ -111,18 +210,35 @@ type Progress struct {
 
 // PullRequest represents a GitHub pull request
 type PullRequest struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
-	URL    string `json:"url"`
-	Status string `json:"status"`
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Status    string `json:"status"`
+	Branch    string `json:"branch,omitempty"` // Head branch
+	Draft     bool   `json:"draft,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"` // RFC3339
+	MergedAt  string `json:"mergedAt,omitempty"`  // RFC3339
 }
 
 // GitHubData contains GitHub activity data
 type GitHubData struct {
-	Branches []Branch      `json:"branches"`
-	Commits  []Commit      `json:"commits"`
-	PRs      []PullRequest `json:"prs"`
-	Pushes   []Push        `json:"pushes"`
+	Branches  []Branch              `json:"branches"`
+	Commits   []Commit              `json:"commits"`
+	PRs       []PullRequest         `json:"prs"`
+	Pushes    []Push                `json:"pushes"`
+	Issues    []Issue               `json:"issues,omitempty"`
+	Checks    []CheckRun            `json:"checks,omitempty"`    // Check run history, newest first
+	Workflows []WorkflowRun         `json:"workflows,omitempty"` // Workflow run history, newest first
+	SyncedAt  string                `json:"syncedAt,omitempty"`  // Last sync attempt (RFC3339)
+	Sources   map[string]SyncSource `json:"sources,omitempty"`   // Freshness of each source, keyed by name
+}
+
+// SyncSource records how fresh one GitHub data source is
+type SyncSource struct {
+	LastSynced  string `json:"lastSynced,omitempty"`  // Last successful fetch (RFC3339)
+	Stale       bool   `json:"stale"`                 // Served from cache because the fetch failed
+	NotModified bool   `json:"notModified,omitempty"` // Server reported no changes since the last fetch
+	Error       string `json:"error,omitempty"`
 }
 
 // Branch represents a Git branch
@@ -135,6 +251,13 @@ type Branch struct {
 	LastCommit  *Commit `json:"lastCommit"`
 	HasPR       bool    `json:"hasPR"`
 	PRURL       string  `json:"prUrl"`
+	Feature     string  `json:"feature,omitempty"` // ID of the feature that owns the branch
+	Merged      bool    `json:"merged"`
+	Stale       bool    `json:"stale"`
+	Orphan      bool    `json:"orphan"`              // Feature branch with no matching feature
+	CreatedAt   string  `json:"createdAt,omitempty"` // First commit beyond the base branch (RFC3339)
+	MergedBy    string  `json:"mergedBy,omitempty"`  // How the merge was detected: pr, squash or ancestry
+	Protected   bool    `json:"protected,omitempty"` // Matches github.protectedBranches; never pruned
 }
 
 // Commit represents a Git commit
@@ -147,6 +270,49 @@ type Commit struct {
 	PRURL   string `json:"prUrl"`
 }
 
+// CheckRun is one CI check run on a branch's head commit
+type CheckRun struct {
+	Name        string `json:"name"`
+	Status      string `json:"status"`               // queued, in_progress or completed
+	Conclusion  string `json:"conclusion,omitempty"` // success, failure, neutral, skipped, ...
+	URL         string `json:"url,omitempty"`
+	Branch      string `json:"branch"`
+	HeadSHA     string `json:"headSha"`
+	CompletedAt string `json:"completedAt,omitempty"`
+}
+
+// WorkflowRun is one GitHub Actions workflow run on a branch
+type WorkflowRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Event      string `json:"event,omitempty"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Branch     string `json:"branch"`
+	HeadSHA    string `json:"headSha"`
+	RunNumber  int    `json:"runNumber"`
+	UpdatedAt  string `json:"updatedAt,omitempty"`
+}
+
+// CIStatus summarizes the checks on a branch's latest head commit
+type CIStatus struct {
+	State     string        `json:"state"` // success, failure, pending or none
+	HeadSHA   string        `json:"headSha,omitempty"`
+	Checks    []CheckRun    `json:"checks,omitempty"`    // Latest run of each check
+	Failing   []string      `json:"failing,omitempty"`   // Names of failed checks
+	Workflows []WorkflowRun `json:"workflows,omitempty"` // Latest run of each workflow
+}
+
+// Issue represents a GitHub issue
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	State   string `json:"state"`             // open or closed
+	Feature string `json:"feature,omitempty"` // ID of the feature the issue is labelled with
+}
+
 // Push represents a Git push
 type Push struct {
 	Branch      string `json:"branch"`
@@ -181,11 +347,13 @@ type ProjectJSON struct {
 
 // GitHubJSON represents GitHub information in dashboard
 type GitHubJSON struct {
-	Repository   string   `json:"repository"`
-	Branch       string   `json:"branch"`
-	Commits      int      `json:"commits"`
-	Contributors []string `json:"contributors"`
-	LastCommit   string   `json:"lastCommit"`
+	Repository   string                `json:"repository"`
+	Branch       string                `json:"branch"`
+	Commits      int                   `json:"commits"`
+	Contributors []string              `json:"contributors"`
+	LastCommit   string                `json:"lastCommit"`
+	SyncedAt     string                `json:"syncedAt,omitempty"`
+	Sources      map[string]SyncSource `json:"sources,omitempty"`
 }
 
 // PhaseJSON represents a phase in dashboard JSON
@@ -199,6 +367,7 @@ type PhaseJSON struct {
 	TargetDate  string        `json:"targetDate"`
 	Features    []FeatureJSON `json:"features"`
 	Stats       PhaseStatsJSON `json:"stats"`
+	Forecast    *ForecastJSON `json:"forecast,omitempty"`
 }
 
 // FeatureJSON represents a feature in dashboard JSON
@@ -208,7 +377,11 @@ type FeatureJSON struct {
 	Status       string    `json:"status"`
 	Progress     int       `json:"progress"`
 	Branch       string    `json:"branch"`
+	BaseBranch   string    `json:"baseBranch,omitempty"`
+	Dependencies []string  `json:"dependencies,omitempty"`
 	PR           *PRJSON   `json:"pr"`
+	Flags        []FeatureFlag `json:"flags,omitempty"`
+	CI           *CIStatus `json:"ci,omitempty"`
 	Commits      int       `json:"commits"`
 	LastActivity string    `json:"lastActivity"`
 	Tasks        []TaskJSON `json:"tasks"`
@@ -281,8 +454,60 @@ type APIKeysJSON struct {
 
 // VelocityJSON represents velocity metrics in dashboard
 type VelocityJSON struct {
-	TasksPerDay         float64 `json:"tasksPerDay"`
-	CommitsPerDay       float64 `json:"commitsPerDay"`
-	EstimatedCompletion string  `json:"estimatedCompletion"`
-	DaysToLaunch        int     `json:"daysToLaunch"`
+	TasksPerDay         float64       `json:"tasksPerDay"`
+	CommitsPerDay       float64       `json:"commitsPerDay"`
+	EstimatedCompletion string        `json:"estimatedCompletion"`
+	DaysToLaunch        int           `json:"daysToLaunch"`
+	Forecast            *ForecastJSON `json:"forecast,omitempty"` // Monte Carlo forecast of the project
+}
+
+// ForecastJSON represents a Monte Carlo completion forecast in dashboard
+type ForecastJSON struct {
+	P50            string  `json:"p50,omitempty"`
+	P85            string  `json:"p85,omitempty"`
+	P95            string  `json:"p95,omitempty"`
+	OnTargetChance float64 `json:"onTargetChance"` // Percent of simulations finishing by the target date
+	AtRisk         bool    `json:"atRisk"`
+	Note           string  `json:"note,omitempty"`           // Why there are no dates, e.g. too little history
+	Calibration    float64 `json:"calibration,omitempty"`    // Actual over estimated feature durations so far
+	CalibratedDate string  `json:"calibratedDate,omitempty"` // Completion if remaining features overrun their estimates like finished ones
+}
+
+// ReleaseNotes represents generated release notes for a phase or tag range
+type ReleaseNotes struct {
+	Version      string           `json:"version"`
+	Date         string           `json:"date"`
+	Phase        string           `json:"phase,omitempty"`
+	Since        string           `json:"since,omitempty"`
+	Features     []ReleaseFeature `json:"features"`
+	Sections     []ReleaseSection `json:"sections"`
+	Breaking     []ReleaseCommit  `json:"breaking"`
+	Contributors []string         `json:"contributors"`
+}
+
+// ReleaseFeature represents a completed feature in release notes
+type ReleaseFeature struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	PRNumber    int    `json:"prNumber,omitempty"`
+	PRURL       string `json:"prUrl,omitempty"`
+}
+
+// ReleaseSection groups release commits by conventional commit type
+type ReleaseSection struct {
+	Type    string          `json:"type"`
+	Title   string          `json:"title"`
+	Commits []ReleaseCommit `json:"commits"`
+}
+
+// ReleaseCommit represents a single commit in release notes
+type ReleaseCommit struct {
+	Hash        string `json:"hash"`
+	ShortHash   string `json:"shortHash"`
+	Type        string `json:"type"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Author      string `json:"author"`
+	Breaking    bool   `json:"breaking"`
 }
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// FeatureStatusBlocked is recorded in a feature's status history while it has flags
+const FeatureStatusBlocked = "blocked"
+
+// SetStatus moves the feature to status and records the transition in its status
+// history, reporting whether the status changed. Transitions of a flagged feature
+// stay hidden behind "blocked" until it completes or its flags clear.
+func (f *Feature) SetStatus(status string, at time.Time) bool {
+	if f.Status == status {
+		return false
+	}
+	f.Status = status
+	if len(f.Flags) == 0 || status == "complete" {
+		f.recordStatus(status, at)
+	}
+	return true
+}
+
+// SetFlag adds or replaces the flag from flag.Source, reporting whether anything changed
+func (f *Feature) SetFlag(flag FeatureFlag) bool {
+	for i := range f.Flags {
+		if f.Flags[i].Source == flag.Source {
+			if f.Flags[i].Reason == flag.Reason {
+				return false
+			}
+			flag.Since = f.Flags[i].Since
+			f.Flags[i] = flag
+			return true
+		}
+	}
+	if len(f.Flags) == 0 && f.Status != "complete" {
+		f.StatusHistory = append(f.StatusHistory, StatusChange{Status: FeatureStatusBlocked, At: flag.Since})
+	}
+	f.Flags = append(f.Flags, flag)
+	return true
+}
+
+// ClearFlag removes the flag raised by source, reporting whether one was set.
+// Clearing the last flag unblocks the feature at the given time.
+func (f *Feature) ClearFlag(source string, at time.Time) bool {
+	for i := range f.Flags {
+		if f.Flags[i].Source == source {
+			f.Flags = append(f.Flags[:i], f.Flags[i+1:]...)
+			if len(f.Flags) == 0 {
+				f.Flags = nil
+				if n := len(f.StatusHistory); n > 0 && f.StatusHistory[n-1].Status == FeatureStatusBlocked {
+					f.recordStatus(f.Status, at)
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Feature) recordStatus(status string, at time.Time) {
+	f.StatusHistory = append(f.StatusHistory, StatusChange{Status: status, At: at.Format(time.RFC3339)})
+}
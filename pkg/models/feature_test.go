@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureStatusHistory(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, 3, 1, hour, 0, 0, 0, time.UTC) }
+	feature := &Feature{ID: "01", Status: "todo"}
+
+	assert.True(t, feature.SetStatus("in-progress", at(9)))
+	assert.False(t, feature.SetStatus("in-progress", at(10)), "an unchanged status records nothing")
+
+	// The first flag blocks the feature and the last one to clear unblocks it
+	assert.True(t, feature.SetFlag(FeatureFlag{Source: "check:build", Reason: "failed", Since: at(11).Format(time.RFC3339)}))
+	assert.True(t, feature.SetFlag(FeatureFlag{Source: "check:lint", Reason: "failed", Since: at(12).Format(time.RFC3339)}))
+	assert.True(t, feature.ClearFlag("check:build", at(13)))
+	assert.True(t, feature.ClearFlag("check:lint", at(14)))
+	assert.False(t, feature.ClearFlag("check:lint", at(15)))
+
+	assert.True(t, feature.SetStatus("complete", at(16)))
+
+	assert.Equal(t, []StatusChange{
+		{Status: "in-progress", At: at(9).Format(time.RFC3339)},
+		{Status: FeatureStatusBlocked, At: at(11).Format(time.RFC3339)},
+		{Status: "in-progress", At: at(14).Format(time.RFC3339)},
+		{Status: "complete", At: at(16).Format(time.RFC3339)},
+	}, feature.StatusHistory)
+}
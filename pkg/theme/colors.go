@@ -2,7 +2,9 @@ package theme
 
 import "github.com/charmbracelet/lipgloss"
 
-// Color constants for the DoPlan theme
+// Color constants of the original DoPlan palette.
+//
+// Deprecated: use the color functions, which follow the theme in use.
 const (
 	ColorPrimary   = "#667eea"
 	ColorSecondary = "#764ba2"
@@ -15,42 +17,56 @@ const (
 )
 
 // Primary returns the primary color
-func Primary() lipgloss.Color {
-	return lipgloss.Color(ColorPrimary)
+func Primary() lipgloss.TerminalColor {
+	return current.Colors.Primary.Terminal()
 }
 
 // Secondary returns the secondary color
-func Secondary() lipgloss.Color {
-	return lipgloss.Color(ColorSecondary)
+func Secondary() lipgloss.TerminalColor {
+	return current.Colors.Secondary.Terminal()
 }
 
 // Success returns the success color
-func Success() lipgloss.Color {
-	return lipgloss.Color(ColorSuccess)
+func Success() lipgloss.TerminalColor {
+	return current.Colors.Success.Terminal()
 }
 
 // Warning returns the warning color
-func Warning() lipgloss.Color {
-	return lipgloss.Color(ColorWarning)
+func Warning() lipgloss.TerminalColor {
+	return current.Colors.Warning.Terminal()
 }
 
 // Error returns the error color
-func Error() lipgloss.Color {
-	return lipgloss.Color(ColorError)
+func Error() lipgloss.TerminalColor {
+	return current.Colors.Error.Terminal()
+}
+
+// Info returns the color of work in progress
+func Info() lipgloss.TerminalColor {
+	return current.Colors.Info.Terminal()
 }
 
 // Text returns the text color
-func Text() lipgloss.Color {
-	return lipgloss.Color(ColorText)
+func Text() lipgloss.TerminalColor {
+	return current.Colors.Text.Terminal()
 }
 
 // TextDim returns the dimmed text color
-func TextDim() lipgloss.Color {
-	return lipgloss.Color(ColorTextDim)
+func TextDim() lipgloss.TerminalColor {
+	return current.Colors.TextDim.Terminal()
+}
+
+// Muted returns the color of help text and work not started
+func Muted() lipgloss.TerminalColor {
+	return current.Colors.Muted.Terminal()
 }
 
 // Border returns the border color
-func Border() lipgloss.Color {
-	return lipgloss.Color(ColorBorder)
+func Border() lipgloss.TerminalColor {
+	return current.Colors.Border.Terminal()
 }
 
+// Surface returns the background color of badges and panels
+func Surface() lipgloss.TerminalColor {
+	return current.Colors.Surface.Terminal()
+}
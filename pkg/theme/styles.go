@@ -1,85 +1,118 @@
 package theme
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 var (
 	// HeaderStyle is used for section headers
-	HeaderStyle = lipgloss.NewStyle().
+	HeaderStyle lipgloss.Style
+
+	// CardStyle is used for card containers
+	CardStyle lipgloss.Style
+
+	// ButtonStyle is used for buttons
+	ButtonStyle lipgloss.Style
+
+	// ButtonActiveStyle is used for active/selected buttons
+	ButtonActiveStyle lipgloss.Style
+
+	// ProgressFilled is used for filled progress bar segments
+	ProgressFilled = "█"
+
+	// ProgressEmpty is used for empty progress bar segments
+	ProgressEmpty = "░"
+
+	// SuccessStyle is used for success messages
+	SuccessStyle lipgloss.Style
+
+	// ErrorStyle is used for error messages
+	ErrorStyle lipgloss.Style
+
+	// WarningStyle is used for warning messages
+	WarningStyle lipgloss.Style
+
+	// HelpStyle is used for help text
+	HelpStyle lipgloss.Style
+
+	// TextStyle is used for normal text
+	TextStyle lipgloss.Style
+
+	// TextDimStyle is used for dimmed text
+	TextDimStyle lipgloss.Style
+)
+
+func init() {
+	OnChange(func() {
+		HeaderStyle = lipgloss.NewStyle().
 			Foreground(Primary()).
 			Bold(true).
 			Padding(0, 1)
 
-	// CardStyle is used for card containers
-	CardStyle = lipgloss.NewStyle().
+		CardStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(Border()).
 			Padding(1, 2).
 			Margin(1, 0)
 
-	// ButtonStyle is used for buttons
-	ButtonStyle = lipgloss.NewStyle().
+		ButtonStyle = lipgloss.NewStyle().
 			Foreground(Text()).
 			Background(Primary()).
 			Padding(0, 2).
 			Margin(1, 0)
 
-	// ButtonActiveStyle is used for active/selected buttons
-	ButtonActiveStyle = lipgloss.NewStyle().
-				Foreground(Text()).
-				Background(Secondary()).
-				Padding(0, 2).
-				Margin(1, 0)
-
-	// ProgressFilled is used for filled progress bar segments
-	ProgressFilled = "█"
-
-	// ProgressEmpty is used for empty progress bar segments
-	ProgressEmpty = "░"
+		ButtonActiveStyle = lipgloss.NewStyle().
+			Foreground(Text()).
+			Background(Secondary()).
+			Padding(0, 2).
+			Margin(1, 0)
 
-	// SuccessStyle is used for success messages
-	SuccessStyle = lipgloss.NewStyle().
+		SuccessStyle = lipgloss.NewStyle().
 			Foreground(Success()).
 			Bold(true)
 
-	// ErrorStyle is used for error messages
-	ErrorStyle = lipgloss.NewStyle().
+		ErrorStyle = lipgloss.NewStyle().
 			Foreground(Error()).
 			Bold(true)
 
-	// WarningStyle is used for warning messages
-	WarningStyle = lipgloss.NewStyle().
+		WarningStyle = lipgloss.NewStyle().
 			Foreground(Warning()).
 			Bold(true)
 
-	// HelpStyle is used for help text
-	HelpStyle = lipgloss.NewStyle().
+		HelpStyle = lipgloss.NewStyle().
 			Foreground(TextDim()).
 			Italic(true)
 
-	// TextStyle is used for normal text
-	TextStyle = lipgloss.NewStyle().
+		TextStyle = lipgloss.NewStyle().
 			Foreground(Text())
 
-	// TextDimStyle is used for dimmed text
-	TextDimStyle = lipgloss.NewStyle().
+		TextDimStyle = lipgloss.NewStyle().
 			Foreground(TextDim())
-)
+	})
+}
 
 // RenderProgressBar renders a progress bar with the given percentage
 func RenderProgressBar(percent int, width int) string {
+	if width <= 0 {
+		return ""
+	}
 	filled := int(float64(width) * float64(percent) / 100.0)
-	empty := width - filled
-	
-	result := ""
-	for i := 0; i < filled; i++ {
-		result += ProgressFilled
+	if filled > width {
+		filled = width
 	}
-	for i := 0; i < empty; i++ {
-		result += ProgressEmpty
+	if filled < 0 {
+		filled = 0
 	}
-	
+	empty := width - filled
+
 	return lipgloss.NewStyle().
 		Foreground(Primary()).
-		Render(result)
+		Render(strings.Repeat(ProgressFilled, filled) + strings.Repeat(ProgressEmpty, empty))
 }
 
+// ProgressGradient returns the ends of the gradient progress bars fill with
+func ProgressGradient() (string, string) {
+	return current.Colors.Primary.Hex(), current.Colors.Secondary.Hex()
+}
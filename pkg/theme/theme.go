@@ -0,0 +1,317 @@
+package theme
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/muesli/termenv"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed themes/*.yaml
+var builtinThemes embed.FS
+
+// Built-in theme names. Default picks dark or light by the terminal background.
+const (
+	Default      = "default"
+	Dark         = "dark"
+	Light        = "light"
+	HighContrast = "high-contrast"
+	Colorblind   = "colorblind"
+)
+
+// Color modes. Auto uses what the terminal supports; the others force a palette
+// size, degrading theme colors to their 256- or 16-color fallbacks.
+const (
+	ModeAuto      = "auto"
+	ModeTrueColor = "truecolor"
+	Mode256       = "256"
+	Mode16        = "16"
+	ModeNone      = "none"
+)
+
+// Environment variables overriding the configured theme and color mode
+const (
+	envTheme   = "DOPLAN_THEME"
+	envColors  = "DOPLAN_COLORS"
+	envNoColor = "NO_COLOR"
+)
+
+// maxExtends bounds chains of themes extending each other
+const maxExtends = 5
+
+// Color is a theme color with optional fallbacks for terminals with fewer
+// colors. In YAML it is either a hex string or a mapping of truecolor, ansi256
+// and ansi; missing fallbacks are approximated from the truecolor value.
+type Color struct {
+	TrueColor string `yaml:"truecolor"`
+	ANSI256   string `yaml:"ansi256"`
+	ANSI      string `yaml:"ansi"`
+}
+
+// UnmarshalYAML reads a color from a hex string or a mapping
+func (c *Color) UnmarshalYAML(node *yaml.Node) error {
+	*c = Color{}
+	if node.Kind == yaml.ScalarNode {
+		c.TrueColor = node.Value
+		return nil
+	}
+	type plain Color
+	return node.Decode((*plain)(c))
+}
+
+// Terminal returns the color for lipgloss styles
+func (c Color) Terminal() lipgloss.TerminalColor {
+	if c.ANSI256 == "" && c.ANSI == "" {
+		return lipgloss.Color(c.TrueColor)
+	}
+	return lipgloss.CompleteColor{
+		TrueColor: c.TrueColor,
+		ANSI256:   firstNonEmpty(c.ANSI256, c.TrueColor),
+		ANSI:      firstNonEmpty(c.ANSI, c.ANSI256, c.TrueColor),
+	}
+}
+
+// Hex returns the truecolor value, for gradients and HTML
+func (c Color) Hex() string {
+	return c.TrueColor
+}
+
+// Colors are the roles a theme fills
+type Colors struct {
+	Primary   Color `yaml:"primary"`   // Titles, selection and accents
+	Secondary Color `yaml:"secondary"` // Gradient ends and active buttons
+	Success   Color `yaml:"success"`   // Done work and passing checks
+	Warning   Color `yaml:"warning"`   // Attention and the today marker
+	Error     Color `yaml:"error"`     // Failures and slipped work
+	Info      Color `yaml:"info"`      // Work in progress
+	Text      Color `yaml:"text"`
+	TextDim   Color `yaml:"textDim"`
+	Muted     Color `yaml:"muted"` // Help text and work not started
+	Border    Color `yaml:"border"`
+	Surface   Color `yaml:"surface"` // Badge and panel backgrounds
+}
+
+func (c *Colors) roles() map[string]*Color {
+	return map[string]*Color{
+		"primary": &c.Primary, "secondary": &c.Secondary,
+		"success": &c.Success, "warning": &c.Warning, "error": &c.Error, "info": &c.Info,
+		"text": &c.Text, "textDim": &c.TextDim, "muted": &c.Muted,
+		"border": &c.Border, "surface": &c.Surface,
+	}
+}
+
+// Theme is a named set of colors. A theme that extends another only needs the
+// colors it changes.
+type Theme struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Extends     string `yaml:"extends"`
+	Colors      Colors `yaml:"colors"`
+}
+
+var (
+	current   = mustLoadBuiltin(Dark)
+	listeners []func()
+)
+
+// Current returns the theme in use
+func Current() *Theme {
+	return current
+}
+
+// Use switches to a theme and restyles everything registered with OnChange
+func Use(t *Theme) {
+	current = t
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// OnChange registers fn to rebuild styles from the current theme. fn runs now and
+// again on every Use, so package-level styles can be set from init.
+func OnChange(fn func()) {
+	listeners = append(listeners, fn)
+	fn()
+}
+
+// Load reads a theme by name from the project's .doplan/themes/<name>.yaml, or
+// else from the built-in themes
+func Load(projectRoot, name string) (*Theme, error) {
+	return load(projectRoot, name, 0)
+}
+
+func load(projectRoot, name string, depth int) (*Theme, error) {
+	if depth > maxExtends {
+		return nil, fmt.Errorf("theme %q extends too many themes", name)
+	}
+	if name == "" || name == Default {
+		name = Dark
+		if !lipgloss.HasDarkBackground() {
+			name = Light
+		}
+	}
+
+	data, err := readTheme(projectRoot, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		Extends string `yaml:"extends"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse theme %q: %w", name, err)
+	}
+	t := &Theme{}
+	if header.Extends != "" {
+		if header.Extends == name {
+			return nil, fmt.Errorf("theme %q extends itself", name)
+		}
+		base, err := load(projectRoot, header.Extends, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		*t = *base
+		t.Description = ""
+	}
+	if err := yaml.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("failed to parse theme %q: %w", name, err)
+	}
+	t.Name = name
+
+	var missing []string
+	for role, c := range t.Colors.roles() {
+		if c.TrueColor == "" {
+			missing = append(missing, role)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("theme %q has no color for %s", name, strings.Join(missing, ", "))
+	}
+	return t, nil
+}
+
+func readTheme(projectRoot, name string) ([]byte, error) {
+	if projectRoot != "" {
+		data, err := os.ReadFile(filepath.Join(projectRoot, ".doplan", "themes", name+".yaml"))
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read theme %q: %w", name, err)
+		}
+	}
+	data, err := builtinThemes.ReadFile("themes/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(Names(projectRoot), ", "))
+	}
+	return data, nil
+}
+
+func mustLoadBuiltin(name string) *Theme {
+	t, err := load("", name, 0)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Names lists the built-in themes and those in the project's .doplan/themes
+func Names(projectRoot string) []string {
+	seen := map[string]bool{Default: true}
+	names := []string{Default}
+	add := func(file string) {
+		if name := strings.TrimSuffix(file, ".yaml"); name != file && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if entries, err := builtinThemes.ReadDir("themes"); err == nil {
+		for _, entry := range entries {
+			add(entry.Name())
+		}
+	}
+	if projectRoot != "" {
+		if entries, err := os.ReadDir(filepath.Join(projectRoot, ".doplan", "themes")); err == nil {
+			for _, entry := range entries {
+				add(entry.Name())
+			}
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// SetColorMode sets how many colors styles use. NO_COLOR turns colors off
+// whatever the mode, for lipgloss styles and fatih/color output alike.
+func SetColorMode(mode string) error {
+	var profile termenv.Profile
+	var err error
+	switch mode {
+	case "", ModeAuto:
+		profile = termenv.NewOutput(os.Stdout).EnvColorProfile()
+	case ModeTrueColor:
+		profile = termenv.TrueColor
+	case Mode256:
+		profile = termenv.ANSI256
+	case Mode16:
+		profile = termenv.ANSI
+	case ModeNone:
+		profile = termenv.Ascii
+	default:
+		err = fmt.Errorf("unknown color mode %q (use %s, %s, %s, %s or %s)", mode, ModeAuto, ModeTrueColor, Mode256, Mode16, ModeNone)
+		profile = termenv.NewOutput(os.Stdout).EnvColorProfile()
+	}
+	if os.Getenv(envNoColor) != "" {
+		profile = termenv.Ascii
+	}
+
+	lipgloss.SetColorProfile(profile)
+	if profile == termenv.Ascii {
+		color.NoColor = true
+	}
+	return err
+}
+
+// ColorProfile returns the color profile styles render with
+func ColorProfile() termenv.Profile {
+	return lipgloss.ColorProfile()
+}
+
+// Setup applies the configured theme and color mode, with DOPLAN_THEME and
+// DOPLAN_COLORS overriding them. A theme that fails to load leaves the default
+// theme in use; the error says why.
+func Setup(projectRoot, name, mode string) error {
+	if env := os.Getenv(envTheme); env != "" {
+		name = env
+	}
+	if env := os.Getenv(envColors); env != "" {
+		mode = env
+	}
+
+	modeErr := SetColorMode(mode)
+	t, themeErr := Load(projectRoot, name)
+	if themeErr != nil {
+		t, _ = Load("", Default)
+	}
+	Use(t)
+	return errors.Join(modeErr, themeErr)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
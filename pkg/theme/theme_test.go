@@ -0,0 +1,104 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/DoPlan-dev/CLI/test/helpers"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreTheme puts back the theme and color settings a test changes
+func restoreTheme(t *testing.T) {
+	t.Helper()
+	theme, profile, noColor := current, lipgloss.ColorProfile(), color.NoColor
+	t.Cleanup(func() {
+		Use(theme)
+		lipgloss.SetColorProfile(profile)
+		color.NoColor = noColor
+	})
+}
+
+func TestLoad_Builtin(t *testing.T) {
+	for _, name := range []string{Dark, Light, HighContrast, Colorblind} {
+		loaded, err := Load("", name)
+		require.NoError(t, err, name)
+		assert.Equal(t, name, loaded.Name)
+		assert.NotEmpty(t, loaded.Description, name)
+	}
+
+	dark, err := Load("", Dark)
+	require.NoError(t, err)
+	assert.Equal(t, "#667eea", dark.Colors.Primary.Hex(), "dark keeps the original colors")
+
+	_, err = Load("", "solarized")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "high-contrast", "the error lists the available themes")
+}
+
+func TestLoad_ProjectTheme(t *testing.T) {
+	projectRoot := helpers.CreateTempProject(t)
+	helpers.WriteTestFile(t, projectRoot, ".doplan/themes/brand.yaml", []byte(`name: brand
+extends: dark
+colors:
+  primary: "#ff8800"
+  success: { truecolor: "#00aa00", ansi256: "34", ansi: "2" }
+`))
+	helpers.WriteTestFile(t, projectRoot, ".doplan/themes/partial.yaml", []byte("colors:\n  primary: \"#ff8800\"\n"))
+	helpers.WriteTestFile(t, projectRoot, ".doplan/themes/loop.yaml", []byte("extends: loop\n"))
+
+	brand, err := Load(projectRoot, "brand")
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#ff8800"), brand.Colors.Primary.Terminal())
+	assert.Equal(t, lipgloss.CompleteColor{TrueColor: "#00aa00", ANSI256: "34", ANSI: "2"}, brand.Colors.Success.Terminal())
+	assert.Equal(t, "#764ba2", brand.Colors.Secondary.Hex(), "unset colors come from the extended theme")
+
+	_, err = Load(projectRoot, "partial")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no color for border, error")
+
+	_, err = Load(projectRoot, "loop")
+	assert.Error(t, err)
+
+	assert.Contains(t, Names(projectRoot), "brand")
+	assert.Equal(t, Default, Names("")[0])
+}
+
+func TestSetColorMode(t *testing.T) {
+	restoreTheme(t)
+	t.Setenv(envNoColor, "")
+
+	require.NoError(t, SetColorMode(Mode16))
+	assert.Equal(t, termenv.ANSI, ColorProfile())
+	require.NoError(t, SetColorMode(Mode256))
+	assert.Equal(t, termenv.ANSI256, ColorProfile())
+	assert.Error(t, SetColorMode("rainbow"))
+
+	t.Setenv(envNoColor, "1")
+	require.NoError(t, SetColorMode(ModeTrueColor))
+	assert.Equal(t, termenv.Ascii, ColorProfile(), "NO_COLOR wins over the configured mode")
+	assert.True(t, color.NoColor)
+}
+
+func TestSetup(t *testing.T) {
+	restoreTheme(t)
+	t.Setenv(envNoColor, "")
+	t.Setenv(envColors, "")
+
+	var restyled []string
+	OnChange(func() { restyled = append(restyled, Current().Name) })
+
+	t.Setenv(envTheme, Colorblind)
+	require.NoError(t, Setup("", HighContrast, Mode256))
+	assert.Equal(t, Colorblind, Current().Name, "DOPLAN_THEME overrides the config")
+	assert.Equal(t, Current().Colors.Success.Terminal(), Success())
+	assert.Equal(t, 2, len(restyled), "OnChange listeners run when registered and on Use")
+
+	t.Setenv(envTheme, "")
+	err := Setup("", "missing", Mode256)
+	require.Error(t, err)
+	assert.Contains(t, []string{Dark, Light}, Current().Name, "an unknown theme falls back to the default")
+}
@@ -4,6 +4,9 @@
 package wizard
 
 import (
+	"github.com/DoPlan-dev/CLI/pkg/keymap"
+	"github.com/DoPlan-dev/CLI/pkg/theme"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -40,29 +43,29 @@ type WizardStyles struct {
 func NewWizardStyles() WizardStyles {
 	return WizardStyles{
 		Header: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#667eea")).
+			Foreground(theme.Primary()).
 			Bold(true).
 			Padding(0, 1),
 		Body: lipgloss.NewStyle().
 			Padding(1, 2),
 		Button: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
-			Background(lipgloss.Color("#667eea")).
+			Foreground(theme.Text()).
+			Background(theme.Primary()).
 			Padding(0, 2).
 			Margin(1, 0),
 		ButtonActive: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
-			Background(lipgloss.Color("#764ba2")).
+			Foreground(theme.Text()).
+			Background(theme.Secondary()).
 			Padding(0, 2).
 			Margin(1, 0),
 		Error: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ef4444")).
+			Foreground(theme.Error()).
 			Bold(true),
 		Success: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#10b981")).
+			Foreground(theme.Success()).
 			Bold(true),
 		Help: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#999999")).
+			Foreground(theme.TextDim()).
 			Italic(true),
 	}
 }
@@ -80,8 +83,8 @@ func (m *WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state.Height = msg.Height
 		return m, nil
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc", "q":
+		keys := keymap.Current()
+		if key.Matches(msg, keys.Back, keys.Quit) {
 			return m, tea.Quit
 		}
 	}
@@ -110,7 +113,8 @@ func (m *WizardModel) renderScreen() string {
 func (m *WizardModel) renderWelcome() string {
 	header := m.styles.Header.Render("Welcome to DoPlan")
 	body := "Welcome message here"
-	footer := m.styles.Help.Render("Press Enter to continue, Esc to exit")
+	keys := keymap.Current()
+	footer := m.styles.Help.Render(keymap.Help(keymap.Item("continue", keys.Select), keymap.Item("exit", keys.Back)))
 	
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -0,0 +1,15 @@
+package helpers
+
+import (
+	"github.com/DoPlan-dev/CLI/pkg/models"
+)
+
+// TaskPhase builds a task phase with one task per flag, named a, b, c and so on,
+// completed where the flag is true
+func TaskPhase(name string, done ...bool) models.TaskPhase {
+	phase := models.TaskPhase{Name: name}
+	for i, completed := range done {
+		phase.Tasks = append(phase.Tasks, models.Task{Name: string(rune('a' + i)), Completed: completed})
+	}
+	return phase
+}
@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// CommitFiles writes files relative to the repository root and commits them
+// as author at when
+func CommitFiles(t *testing.T, repo *git.Repository, author string, when time.Time, files map[string]string) plumbing.Hash {
+	t.Helper()
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to open worktree: %v", err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(wt.Filesystem.Root(), filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			t.Fatalf("Failed to stage %s: %v", name, err)
+		}
+	}
+
+	sig := &object.Signature{Name: author, Email: strings.ToLower(author) + "@example.com", When: when}
+	hash, err := wt.Commit("update", &git.CommitOptions{Author: sig, Committer: sig})
+	if err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	return hash
+}
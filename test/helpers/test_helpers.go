@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/DoPlan-dev/CLI/internal/config"
+	"github.com/DoPlan-dev/CLI/pkg/models"
 )
 
 // CreateTempProject creates a temporary project directory
@@ -42,6 +45,31 @@ func SetupTestProject(t *testing.T) string {
 	return projectRoot
 }
 
+// SetupInstalledProject sets up a test project with DoPlan installed and
+// makes it the working directory until the test ends. A nil config or state
+// is saved as the defaults
+func SetupInstalledProject(t *testing.T, cfg *models.Config, state *models.State) string {
+	t.Helper()
+	projectRoot := SetupTestProject(t)
+
+	if cfg == nil {
+		cfg = config.NewConfig("cursor")
+	}
+	if state == nil {
+		state = &models.State{}
+	}
+	cfgMgr := config.NewManager(projectRoot)
+	if err := cfgMgr.SaveConfig(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	if err := cfgMgr.SaveState(state); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+
+	t.Chdir(projectRoot)
+	return projectRoot
+}
+
 // LoadTestFixture loads a test fixture file
 func LoadTestFixture(t *testing.T, name string) []byte {
 	path := filepath.Join("test", "fixtures", name)